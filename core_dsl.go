@@ -16,6 +16,8 @@ or by running 'ginkgo help'.
 package ginkgo
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -100,6 +102,34 @@ Ginkgo will cancel the SpecContext when a node is interrupted (e.g. by the user
 */
 type SpecContext = internal.SpecContext
 
+/*
+SpanContextFor returns the span of the node running with ctx in the traces exported by --trace-export.  ctx can be the node's SpecContext or any context derived from it.  Code under test can use the span to parent its own spans:
+
+	It("fetches the book", func(ctx SpecContext) {
+		traceParent := SpanContextFor(ctx).TraceParent()
+		...
+	})
+
+The returned SpanContext is not valid when --trace-export isn't set or when ctx does not come from a SpecContext.
+
+You can learn more here: https://onsi.github.io/ginkgo/#exporting-traces
+*/
+func SpanContextFor(ctx context.Context) types.SpanContext {
+	return internal.SpanContextFor(ctx)
+}
+
+/*
+Generators generate the inputs for specs decorated with Property.  Specs receive Generators by accepting them in their body:
+
+	It("round-trips", Property(), func(g Generators) {
+		s := g.String()
+		Expect(Decode(Encode(s))).To(Equal(s))
+	})
+
+You can learn more here: https://onsi.github.io/ginkgo/#property-based-testing
+*/
+type Generators = internal.Generators
+
 /*
 GinkgoWriter implements a GinkgoWriterInterface and io.Writer
 
@@ -293,6 +323,12 @@ func RunSpecs(t GinkgoTestingT, description string, args ...any) bool {
 		defer client.Close()
 	}
 
+	if reporterConfig.EventStream != "" || reporterConfig.EmitStreamEvents {
+		eventStreamReporter, closeEventStream := newEventStreamReporter(client)
+		defer closeEventStream()
+		reporter = reporters.CompositeReporter{reporter, eventStreamReporter}
+	}
+
 	writer := GinkgoWriter.(*internal.Writer)
 	if reporterConfig.Verbosity().GTE(types.VerbosityLevelVerbose) && suiteConfig.ParallelTotal == 1 {
 		writer.SetMode(internal.WriterModeStreamAndBuffer)
@@ -300,8 +336,22 @@ func RunSpecs(t GinkgoTestingT, description string, args ...any) bool {
 		writer.SetMode(internal.WriterModeBufferOnly)
 	}
 
-	if reporterConfig.WillGenerateReport() {
-		registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig)
+	// the ginkgo CLI picks the suite's span when running the suite so that all parallel processes share it
+	if reporterConfig.TraceExport != "" && suiteConfig.TraceSuiteSpan == "" {
+		suiteConfig.TraceSuiteSpan, suiteConfig.TraceParentSpan = types.NewSuiteSpan(os.Getenv("TRACEPARENT"))
+	}
+
+	// the ginkgo CLI hides $GITHUB_STEP_SUMMARY from the suites it runs and writes a single summary for all of them
+	githubStepSummary := reporterConfig.GithubStepSummary()
+	if suiteConfig.DryRun {
+		githubStepSummary = ""
+	}
+	if reporterConfig.WillGenerateReport() || githubStepSummary != "" {
+		registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig, githubStepSummary)
+	}
+
+	if suiteConfig.SpecTimings != "" {
+		registerReportAfterSuiteNodeForSpecTimings(suiteConfig.SpecTimings)
 	}
 
 	err = global.Suite.BuildTree()
@@ -371,6 +421,28 @@ func extractSuiteConfiguration(args []any) (Labels, SemVerConstraints, Component
 	return suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, aroundNodes
 }
 
+// the ginkgo CLI combines the event streams of the suites it runs by routing them through the parallel support server - even when a suite runs serially.
+// when running without the CLI (e.g. with go test) the suite writes to the event stream directly.
+func newEventStreamReporter(client parallel_support.Client) (reporters.Reporter, func()) {
+	if client != nil {
+		return reporters.NewEventStreamReporter(suiteConfig.ParallelProcess, client.PostEmitStreamEvent), func() {}
+	}
+	if suiteConfig.ParallelHost != "" {
+		client = parallel_support.NewClient(suiteConfig.ParallelHost)
+		if !client.Connect() {
+			exitIfErr(types.GinkgoErrors.UnreachableParallelHost(suiteConfig.ParallelHost))
+		}
+		return reporters.NewEventStreamReporter(suiteConfig.ParallelProcess, client.PostEmitStreamEvent), func() { client.Close() }
+	}
+	if reporterConfig.EventStream == "" {
+		// the CLI asked for events (EmitStreamEvents) but didn't give us a server to send them to
+		return reporters.NoopReporter{}, func() {}
+	}
+	stream, err := reporters.OpenEventStream(reporterConfig.EventStream)
+	exitIfErr(err)
+	return reporters.NewEventStreamReporter(suiteConfig.ParallelProcess, stream.Emit), func() { stream.Close() }
+}
+
 func getwd() (string, error) {
 	if !strings.EqualFold(os.Getenv("GINKGO_PRESERVE_CACHE"), "true") {
 		// Getwd calls os.Getenv("PWD"), which breaks test caching if the cache
@@ -752,6 +824,90 @@ func SynchronizedAfterSuite(allProcessBody any, process1Body any, args ...any) b
 	return pushNode(internal.NewNode(internal.TransformNewNodeArgs(exitIfErrors, deprecationTracker, types.NodeTypeSynchronizedAfterSuite, "", combinedArgs...)))
 }
 
+/*
+SharedFixture declares a named, suite-level fixture that is set up lazily - the first time a spec calls Get on the returned *Fixture - and then shared by every spec in the suite:
+
+	var db = SharedFixture("db", func() DBConfig {
+		return StartDatabase()
+	}, func(config DBConfig) {
+		StopDatabase(config)
+	})
+
+	It("stores books", func() {
+		client := NewClient(db.Get())
+		...
+	})
+
+Unlike SynchronizedBeforeSuite, suites that only run a few specs don't pay to set up fixtures that none of those specs use.
+
+When running in parallel, the fixture is set up on whichever process asks for it first and its value is shared with the other processes as JSON - so T must be JSON-encodable.
+
+teardown (which may be nil) runs once, on the process that set the fixture up, with the value returned by setup.  Decorate the specs (or containers) that use the fixture with UsesFixture and the fixture is torn down as soon as the last of them has finished:
+
+	Describe("books", UsesFixture(db), func() {
+		...
+	})
+
+Fixtures that no spec declares it uses are torn down at the end of the suite.
+
+SharedFixture must be called at the top-level or in the body of a container.  Names must be unique within the suite.
+You can learn more, and see some examples, here: https://onsi.github.io/ginkgo/#shared-fixtures
+*/
+func SharedFixture[T any](name string, setup func() T, teardown func(T)) *Fixture[T] {
+	fixture := &internal.SharedFixture{
+		Name:         name,
+		CodeLocation: types.NewCodeLocation(1),
+		Setup:        func() any { return setup() },
+		Encode:       func(value any) ([]byte, error) { return json.Marshal(value) },
+		Decode: func(data []byte) (any, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	}
+	if teardown != nil {
+		fixture.Teardown = func(value any) { teardown(value.(T)) }
+	}
+	exitIfErr(global.Suite.RegisterSharedFixture(fixture))
+	return &Fixture[T]{fixture: fixture}
+}
+
+/*
+Fixture is returned by SharedFixture.  Call Get in any Setup or Subject node closure to access the fixture's value.
+
+You can learn more here: https://onsi.github.io/ginkgo/#shared-fixtures
+*/
+type Fixture[T any] struct {
+	fixture *internal.SharedFixture
+}
+
+/*
+Get returns the fixture's value, setting the fixture up if this is the first time any spec has asked for it.
+
+If the fixture's setup function fails, the node that called Get fails.  Subsequent calls to Get (on any process) fail immediately.  If any spec declares that it uses the fixture with UsesFixture, Get fails in nodes that don't belong to a spec that declares it too - as the fixture may already have been torn down.
+*/
+func (f *Fixture[T]) Get() T {
+	if !global.Suite.InRunPhase() {
+		exitIfErr(types.GinkgoErrors.GetSharedFixtureNotDuringRunPhase(types.NewCodeLocation(1), f.fixture.Name))
+	}
+	value, err := global.Suite.GetSharedFixture(f.fixture)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	return value.(T)
+}
+
+func (f *Fixture[T]) sharedFixture() *internal.SharedFixture {
+	return f.fixture
+}
+
+/*
+AnyFixture is implemented by every *Fixture, whatever its type.  It lets UsesFixture accept fixtures of different types.
+*/
+type AnyFixture interface {
+	sharedFixture() *internal.SharedFixture
+}
+
 /*
 BeforeEach nodes are Setup nodes whose closures run before It node closures.  When multiple BeforeEach nodes
 are defined in nested Container nodes the outermost BeforeEach node closures are run first.
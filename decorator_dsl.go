@@ -188,6 +188,120 @@ if you have a `ReportAfterEach` node that is running for every skipped spec and
 */
 const SuppressProgressReporting = internal.SuppressProgressReporting
 
+/*
+DetectLeaks is a decorator that makes Ginkgo check a spec for leaked goroutines (and, on Linux, leaked file descriptors).  Ginkgo snapshots the running goroutines and open file descriptors before the spec starts and fails the spec if new ones are still around after its AfterEach and DeferCleanup nodes have completed.  The failure includes the stacks of the leaked goroutines.
+
+DetectLeaks can be applied to containers and subject nodes.  In Ordered containers leaks are checked once all of the container's specs and AfterAll nodes have run.  You can enable leak detection for every spec in a suite with --detect-leaks=fail.
+
+You can learn more here: https://onsi.github.io/ginkgo/#detecting-leaked-goroutines-and-file-descriptors
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+const DetectLeaks = internal.DetectLeaks
+
+/*
+WarnOnLeaks is like DetectLeaks but reports leaked goroutines and file descriptors as a "Leaks Detected" report entry instead of failing the spec.  You can enable it for every spec in a suite with --detect-leaks=warn.
+
+You can learn more here: https://onsi.github.io/ginkgo/#detecting-leaked-goroutines-and-file-descriptors
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+const WarnOnLeaks = internal.WarnOnLeaks
+
+/*
+IgnoreLeaks decorates specs with substrings that identify goroutines and file descriptors that should not be reported as leaks.  A goroutine is ignored if any function in its stack contains one of the substrings.  A file descriptor is ignored if its target (e.g. a file path) contains one of the substrings.
+
+IgnoreLeaks can be applied to containers and subject nodes and a spec's ignored leaks are the union of all the IgnoreLeaks in its node hierarchy.
+
+You can learn more here: https://onsi.github.io/ginkgo/#detecting-leaked-goroutines-and-file-descriptors
+*/
+func IgnoreLeaks(substrings ...string) IgnoredLeaks {
+	return IgnoredLeaks(substrings)
+}
+
+/*
+IgnoredLeaks are the type for IgnoreLeaks decorators.  Use IgnoreLeaks(...) to construct IgnoredLeaks.
+*/
+type IgnoredLeaks = internal.IgnoredLeaks
+
+/*
+SpecID is a decorator that names a container or subject node so that other specs can depend on it with DependsOn.  SpecIDs must be unique.  When applied to a container, the SpecID refers to every spec in the container.
+
+SpecID also pins the stable ID that Ginkgo computes for each spec - the ID reported in SpecReport.ID and in the JSON, JUnit, TeamCity, and Go JSON reports, and matched by --focus-id.  A SpecID on an It becomes that spec's ID.  A SpecID on a container replaces the texts of the container and all its ancestors when Ginkgo hashes the IDs of the specs in the container - so renaming the container or its ancestors doesn't change those IDs.
+
+You can learn more here: https://onsi.github.io/ginkgo/#expressing-dependencies-between-specs
+You can learn more about spec IDs here: https://onsi.github.io/ginkgo/#spec-ids
+*/
+type SpecID = internal.SpecID
+
+/*
+DependsOn decorates specs with the SpecIDs of the specs they depend on.  Ginkgo only runs a spec after the specs it depends on have run - including when running in parallel - and skips it if any of them failed or were skipped.
+
+DependsOn can be applied to containers and subject nodes and a spec's prerequisites are the union of all the DependsOn in its node hierarchy.
+
+You can learn more here: https://onsi.github.io/ginkgo/#expressing-dependencies-between-specs
+*/
+func DependsOn(specIDs ...string) Prerequisites {
+	return Prerequisites(specIDs)
+}
+
+/*
+Prerequisites are the type for DependsOn decorators.  Use DependsOn(...) to construct Prerequisites.
+*/
+type Prerequisites = internal.Prerequisites
+
+/*
+UsesFixture declares that specs use the passed-in shared fixtures.  Ginkgo counts the specs that declare they use each fixture and tears the fixture down as soon as the last of them has finished - on the process that set the fixture up - rather than at the end of the suite.
+
+UsesFixture can be applied to containers and subject nodes and a spec's fixtures are the union of all the UsesFixture in its node hierarchy.  Once any spec declares that it uses a fixture only specs that declare it may call Get.
+
+You can learn more here: https://onsi.github.io/ginkgo/#shared-fixtures
+*/
+func UsesFixture(fixtures ...AnyFixture) UsesSharedFixtures {
+	out := UsesSharedFixtures{}
+	for _, fixture := range fixtures {
+		out = append(out, fixture.sharedFixture())
+	}
+	return out
+}
+
+/*
+UsesSharedFixtures are the type for UsesFixture decorators.  Use UsesFixture(...) to construct UsesSharedFixtures.
+*/
+type UsesSharedFixtures = internal.UsesSharedFixtures
+
+/*
+Property is a decorator that turns an It into a property-based test.  The It must be passed a body that accepts Generators - func(g Generators) or func(ctx SpecContext, g Generators).  Ginkgo runs the body many times (100 by default, use NumRuns to change this) with inputs drawn from g.  The inputs are derived from GinkgoRandomSeed() so a failing property can be reproduced with --seed.
+
+When the body fails Ginkgo shrinks the inputs - re-running the body with smaller and smaller inputs (up to 1000 times by default, use MaxShrinks to change this) - and reports the minimal counterexample that still fails, along with the seed, in the spec's failure and in a "Property" report entry.
+
+Each attempt of a spec decorated with MustPassRepeatedly explores new inputs.  Each attempt of a spec decorated with FlakeAttempts retries the same inputs.
+
+You can learn more here: https://onsi.github.io/ginkgo/#property-based-testing
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func Property(options ...PropertyOption) PropertyConfig {
+	return internal.NewPropertyConfig(options...)
+}
+
+/*
+PropertyConfig is the type for Property decorators.  Use Property(...) to construct a PropertyConfig.
+*/
+type PropertyConfig = internal.PropertyConfig
+
+/*
+PropertyOption configures a Property decorator.  NumRuns and MaxShrinks are PropertyOptions.
+*/
+type PropertyOption = internal.PropertyOption
+
+/*
+NumRuns sets the number of times a Property's body is run with freshly generated inputs.  The default is 100.
+*/
+type NumRuns = internal.NumRuns
+
+/*
+MaxShrinks sets the number of times Ginkgo re-runs a failing Property's body while searching for a smaller counterexample.  The default is 1000.  MaxShrinks(0) disables shrinking.
+*/
+type MaxShrinks = internal.MaxShrinks
+
 /*
 AroundNode registers a function that runs before each individual node.  This is considered a more advanced decorator.
 
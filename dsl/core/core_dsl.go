@@ -22,9 +22,13 @@ type GinkgoWriterInterface = ginkgo.GinkgoWriterInterface
 type GinkgoTestingT = ginkgo.GinkgoTestingT
 type GinkgoTInterface = ginkgo.GinkgoTInterface
 type FullGinkgoTInterface = ginkgo.FullGinkgoTInterface
+type GinkgoTRunner = ginkgo.GinkgoTRunner
 type SpecContext = ginkgo.SpecContext
+type Generators = ginkgo.Generators
 type GinkgoTBWrapper = ginkgo.GinkgoTBWrapper
 type NodeArgsTransformer = ginkgo.NodeArgsTransformer
+type Fixture[T any] = ginkgo.Fixture[T]
+type AnyFixture = ginkgo.AnyFixture
 
 var GinkgoWriter = ginkgo.GinkgoWriter
 var GinkgoLogr = ginkgo.GinkgoLogr
@@ -70,3 +74,9 @@ var GinkgoT = ginkgo.GinkgoT
 var GinkgoTB = ginkgo.GinkgoTB
 var AttachProgressReporter = ginkgo.AttachProgressReporter
 var AddTreeConstructionNodeArgsTransformer = ginkgo.AddTreeConstructionNodeArgsTransformer
+var MatchSnapshot = ginkgo.MatchSnapshot
+var SpanContextFor = ginkgo.SpanContextFor
+
+func SharedFixture[T any](name string, setup func() T, teardown func(T)) *Fixture[T] {
+	return ginkgo.SharedFixture(name, setup, teardown)
+}
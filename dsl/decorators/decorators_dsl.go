@@ -29,6 +29,14 @@ type NodeTimeout = ginkgo.NodeTimeout
 type SpecTimeout = ginkgo.SpecTimeout
 type GracePeriod = ginkgo.GracePeriod
 type SpecPriority = ginkgo.SpecPriority
+type IgnoredLeaks = ginkgo.IgnoredLeaks
+type SpecID = ginkgo.SpecID
+type Prerequisites = ginkgo.Prerequisites
+type UsesSharedFixtures = ginkgo.UsesSharedFixtures
+type PropertyConfig = ginkgo.PropertyConfig
+type PropertyOption = ginkgo.PropertyOption
+type NumRuns = ginkgo.NumRuns
+type MaxShrinks = ginkgo.MaxShrinks
 
 const Focus = ginkgo.Focus
 const Pending = ginkgo.Pending
@@ -37,10 +45,16 @@ const Ordered = ginkgo.Ordered
 const ContinueOnFailure = ginkgo.ContinueOnFailure
 const OncePerOrdered = ginkgo.OncePerOrdered
 const SuppressProgressReporting = ginkgo.SuppressProgressReporting
+const DetectLeaks = ginkgo.DetectLeaks
+const WarnOnLeaks = ginkgo.WarnOnLeaks
 
 var Label = ginkgo.Label
 var SemVerConstraint = ginkgo.SemVerConstraint
 var ComponentSemVerConstraint = ginkgo.ComponentSemVerConstraint
+var IgnoreLeaks = ginkgo.IgnoreLeaks
+var DependsOn = ginkgo.DependsOn
+var UsesFixture = ginkgo.UsesFixture
+var Property = ginkgo.Property
 
 func AroundNode[F types.AroundNodeAllowedFuncs](f F) types.AroundNodeDecorator {
 	return types.AroundNode(f, types.NewCodeLocation(1))
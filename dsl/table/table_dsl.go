@@ -34,3 +34,11 @@ var Entry = ginkgo.Entry
 var FEntry = ginkgo.FEntry
 var PEntry = ginkgo.PEntry
 var XEntry = ginkgo.XEntry
+
+var DescribeFuzz = ginkgo.DescribeFuzz
+var FDescribeFuzz = ginkgo.FDescribeFuzz
+var PDescribeFuzz = ginkgo.PDescribeFuzz
+var XDescribeFuzz = ginkgo.XDescribeFuzz
+
+var FuzzEntry = ginkgo.FuzzEntry
+var RunFuzzSpecs = ginkgo.RunFuzzSpecs
@@ -0,0 +1,380 @@
+package ginkgo
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/internal/global"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+DescribeFuzz describes a fuzz target: a table whose body can be driven by Go's fuzzing engine.
+
+For example:
+
+	DescribeFuzz("parsing durations",
+	    func(s string, scale int64) {
+	        d, err := ParseDuration(s)
+	        if err == nil {
+	            Ω(ParseDuration(d.String())).Should(Equal(d))
+	        }
+	    },
+	    FuzzEntry("1h", int64(1)),
+	    FuzzEntry("-3m2s", int64(10)),
+	)
+
+When you run your suite normally DescribeFuzz behaves just like DescribeTable: each seed entry generates an It.  So does every input stored in the target's corpus directory (testdata/fuzz/<full text of the DescribeFuzz>) - this is where the failing inputs found by the fuzzing engine end up.
+
+The body's parameters must all be types supported by Go's fuzzing engine ([]byte, string, bool, byte, rune, float32, float64, and the sized and unsized int and uint types) and it may not accept a SpecContext.  To fuzz the body run:
+
+	ginkgo --fuzz="parsing durations" --fuzztime=30s
+
+This requires a Fuzz function in the suite that calls RunFuzzSpecs.
+
+You can learn more about DescribeFuzz here: https://onsi.github.io/ginkgo/#fuzzing
+*/
+func DescribeFuzz(description string, args ...any) bool {
+	GinkgoHelper()
+	generateFuzzTable(description, args...)
+	return true
+}
+
+/*
+You can focus a fuzz table with `FDescribeFuzz`.  This is equivalent to `FDescribe`.
+*/
+func FDescribeFuzz(description string, args ...any) bool {
+	GinkgoHelper()
+	args = append(args, internal.Focus)
+	generateFuzzTable(description, args...)
+	return true
+}
+
+/*
+You can mark a fuzz table as pending with `PDescribeFuzz`.  This is equivalent to `PDescribe`.
+*/
+func PDescribeFuzz(description string, args ...any) bool {
+	GinkgoHelper()
+	args = append(args, internal.Pending)
+	generateFuzzTable(description, args...)
+	return true
+}
+
+/*
+You can mark a fuzz table as pending with `XDescribeFuzz`.  This is equivalent to `XDescribe`.
+*/
+var XDescribeFuzz = PDescribeFuzz
+
+/*
+FuzzEntry constructs a seed TableEntry for DescribeFuzz.  It is equivalent to Entry(nil, args...).
+
+Seed entries run as specs when the suite is run normally and are added to the fuzzing engine's corpus when fuzzing.
+
+You can learn more about FuzzEntry here: https://onsi.github.io/ginkgo/#fuzzing
+*/
+func FuzzEntry(args ...any) TableEntry {
+	GinkgoHelper()
+	decorations, parameters := internal.PartitionDecorations(args...)
+	return TableEntry{description: nil, decorations: decorations, parameters: parameters, codeLocation: types.NewCodeLocation(0)}
+}
+
+/*
+RunFuzzSpecs is the entry point to Ginkgo's fuzzing support.  Call it from a Fuzz function in your suite:
+
+	func FuzzBooks(f *testing.F) {
+	    RegisterFailHandler(Fail)
+	    RunFuzzSpecs(f)
+	}
+
+When `ginkgo --fuzz=<pattern>` fuzzes the suite RunFuzzSpecs builds the spec tree, seeds the fuzzing engine with the entries and corpus of the DescribeFuzz that matches the pattern, and drives its body with the engine's inputs.  Only the body runs: setup nodes, DeferCleanup, and report entries are not supported while fuzzing.  Failing inputs are moved into the DescribeFuzz's corpus directory so that they run as specs from then on.
+
+Once fuzzing is done RunFuzzSpecs runs the DescribeFuzz's entries - including any failing inputs it just found - as specs, just like RunSpecs would.  Failing inputs are thus reported as spec failures by the run's reporters (e.g. --json-report) and fail the run.
+
+At all other times - e.g. when the suite is run with `ginkgo` or `go test` - RunFuzzSpecs skips.
+
+You can learn more about RunFuzzSpecs here: https://onsi.github.io/ginkgo/#fuzzing
+*/
+func RunFuzzSpecs(f *testing.F) {
+	f.Helper()
+	if !isFuzzing() {
+		f.Skip("RunFuzzSpecs only runs when fuzzing - use ginkgo --fuzz=<pattern> to fuzz a DescribeFuzz")
+	}
+
+	err := global.PushClone()
+	if err != nil {
+		exitIfErr(err)
+	}
+	defer global.PopClone()
+	fuzzTargets = []fuzzTarget{}
+	exitIfErr(global.Suite.BuildTree())
+
+	target, err := selectFuzzTarget(suiteConfig.Fuzz)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, seed := range target.seeds {
+		if err := validateParameters(target.body, seed.parameters, "Table Body function", seed.codeLocation, false); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(target.values(seed.parameters)...)
+	}
+	for _, entry := range target.corpus {
+		f.Add(entry.Values...)
+	}
+
+	GinkgoWriter.(*internal.Writer).SetMode(internal.WriterModeBufferOnly)
+	bodyType := reflect.TypeOf(target.body)
+	in := []reflect.Type{reflect.TypeOf(&testing.T{})}
+	for i := 0; i < bodyType.NumIn(); i++ {
+		in = append(in, bodyType.In(i))
+	}
+	fuzzFunc := reflect.MakeFunc(reflect.FuncOf(in, nil, false), func(args []reflect.Value) []reflect.Value {
+		target.run(args[0].Interface().(*testing.T), args[1:])
+		return nil
+	})
+
+	if isFuzzWorker() {
+		f.Fuzz(fuzzFunc.Interface())
+		return
+	}
+
+	// Go's fuzzing engine writes failing inputs to testdata/fuzz/<name of the Fuzz function>.  We move them to the DescribeFuzz's corpus.
+	crashDir := filepath.Join("testdata", "fuzz", f.Name())
+	existingCrashes := map[string]bool{}
+	for _, file := range readDirNames(crashDir) {
+		existingCrashes[file] = true
+	}
+	f.Fuzz(fuzzFunc.Interface())
+	for _, file := range readDirNames(crashDir) {
+		if existingCrashes[file] {
+			continue
+		}
+		dst := filepath.Join(target.corpusDir, file)
+		err := os.MkdirAll(target.corpusDir, 0755)
+		if err == nil {
+			err = os.Rename(filepath.Join(crashDir, file), dst)
+		}
+		if err != nil {
+			f.Errorf("Ginkgo failed to move the failing input %s into %s:\n%s", filepath.Join(crashDir, file), target.corpusDir, err)
+			continue
+		}
+		fmt.Printf("Ginkgo moved the failing input to %s - it will now run as a spec in \"%s\"\n", dst, target.text)
+	}
+	// only removes the directory if it is now empty
+	os.Remove(crashDir)
+
+	runFuzzTargetSpecs(f, target)
+}
+
+/*
+runFuzzTargetSpecs runs the fuzzed DescribeFuzz's entries - its seeds and its corpus, including any failing inputs the fuzzing engine just found - as specs.  This way the failing inputs are reported as spec failures, with the DescribeFuzz's node hierarchy, by the fuzzing run's reporters.
+*/
+func runFuzzTargetSpecs(f *testing.F, target fuzzTarget) {
+	f.Helper()
+	global.PopClone()
+	// RunSpecs builds the tree again
+	fuzzTargets = []fuzzTarget{}
+	// go test -fuzz also runs the suite's Test function unless -run says otherwise
+	global.SuiteDidRun = false
+
+	description := "Fuzzing " + f.Name()
+	suiteConfig.FocusStrings = []string{"^" + regexp.QuoteMeta(description+" "+target.text+" ")}
+	suiteConfig.FocusFiles, suiteConfig.FocusIDs = nil, nil
+	suiteConfig.SkipStrings, suiteConfig.SkipFiles = nil, nil
+	suiteConfig.LabelFilter, suiteConfig.SemVerFilter = "", ""
+	suiteConfig.RerunFailed, suiteConfig.ImpactFilter, suiteConfig.Shard = "", "", ""
+	RunSpecs(f, description)
+}
+
+type fuzzTarget struct {
+	text      string
+	body      any
+	seeds     []TableEntry
+	corpus    []internal.FuzzCorpusEntry
+	corpusDir string
+}
+
+// fuzzTargets is populated as DescribeFuzz containers are entered while the spec tree is built
+var fuzzTargets = []fuzzTarget{}
+
+func generateFuzzTable(description string, args ...any) {
+	GinkgoHelper()
+	cl := types.NewCodeLocation(0)
+
+	var body any
+	seeds := []TableEntry{}
+	for _, arg := range args {
+		switch t := reflect.TypeOf(arg); {
+		case t == reflect.TypeOf(TableEntry{}):
+			seeds = append(seeds, arg.(TableEntry))
+		case t == reflect.TypeOf([]TableEntry{}):
+			seeds = append(seeds, arg.([]TableEntry)...)
+		case t != nil && t.Kind() == reflect.Func && t.NumOut() == 0:
+			if body != nil {
+				exitIfErr(types.GinkgoErrors.InvalidFuzzBody(cl, "DescribeFuzz was passed more than one body function."))
+			}
+			body = arg
+		}
+	}
+	exitIfErr(validateFuzzBody(body, cl))
+
+	bodyType := reflect.TypeOf(body)
+	parameterTypes := make([]reflect.Type, bodyType.NumIn())
+	for i := range parameterTypes {
+		parameterTypes[i] = bodyType.In(i)
+	}
+
+	// the corpus directory is named after the full text of the DescribeFuzz, which is only known once its container is entered
+	args = append(args, tableConstructionHook(func() []TableEntry {
+		text := CurrentTreeConstructionNodeReport().FullText()
+		corpusDir := internal.FuzzCorpusDirectory(text)
+		for _, target := range fuzzTargets {
+			if target.corpusDir == corpusDir {
+				exitIfErr(types.GinkgoErrors.FuzzCorpusDirectoryCollision(cl, corpusDir, text, target.text))
+			}
+		}
+		corpus, err := internal.ReadFuzzCorpus(corpusDir, parameterTypes)
+		if err != nil {
+			exitIfErr(types.GinkgoErrors.InvalidFuzzCorpus(cl, err))
+		}
+		fuzzTargets = append(fuzzTargets, fuzzTarget{
+			text:      text,
+			body:      body,
+			seeds:     seeds,
+			corpus:    corpus,
+			corpusDir: corpusDir,
+		})
+
+		entries := []TableEntry{}
+		for _, entry := range corpus {
+			path, _ := filepath.Abs(entry.Path)
+			entries = append(entries, TableEntry{
+				description:  "Fuzz Corpus: " + filepath.Base(entry.Path),
+				parameters:   entry.Values,
+				codeLocation: types.CodeLocation{FileName: path, LineNumber: 1},
+			})
+		}
+		return entries
+	}))
+	generateTable(description, false, args...)
+}
+
+func validateFuzzBody(body any, cl types.CodeLocation) error {
+	if body == nil {
+		return types.GinkgoErrors.InvalidFuzzBody(cl, "DescribeFuzz was not passed a body function.")
+	}
+	bodyType := reflect.TypeOf(body)
+	if bodyType.IsVariadic() {
+		return types.GinkgoErrors.InvalidFuzzBody(cl, "The body function may not be variadic.")
+	}
+	if bodyType.NumIn() == 0 {
+		return types.GinkgoErrors.InvalidFuzzBody(cl, "The body function must accept at least one parameter.")
+	}
+	for i := 0; i < bodyType.NumIn(); i++ {
+		if !internal.IsFuzzableType(bodyType.In(i)) {
+			return types.GinkgoErrors.InvalidFuzzBody(cl, fmt.Sprintf("Parameter #%d has type %s which Go's fuzzing engine does not support.", i+1, bodyType.In(i)))
+		}
+	}
+	return nil
+}
+
+func selectFuzzTarget(pattern string) (fuzzTarget, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fuzzTarget{}, types.GinkgoErrors.InvalidFuzzPattern(pattern, err)
+	}
+	matches := []fuzzTarget{}
+	for _, target := range fuzzTargets {
+		if re.MatchString(target.text) {
+			matches = append(matches, target)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return fuzzTarget{}, types.GinkgoErrors.NoMatchingFuzzTarget(pattern)
+	case 1:
+		return matches[0], nil
+	}
+	texts := []string{}
+	for _, match := range matches {
+		texts = append(texts, match.text)
+	}
+	return fuzzTarget{}, types.GinkgoErrors.MultipleMatchingFuzzTargets(pattern, texts)
+}
+
+func (target fuzzTarget) values(parameters []any) []any {
+	bodyType := reflect.TypeOf(target.body)
+	values := make([]any, len(parameters))
+	for i := range parameters {
+		values[i] = computeValue(parameters[i], bodyType.In(i)).Interface()
+	}
+	return values
+}
+
+// run calls the body with a single input from the fuzzing engine and reports any failure the way Ginkgo would
+func (target fuzzTarget) run(t *testing.T, args []reflect.Value) {
+	t.Helper()
+	writer := GinkgoWriter.(*internal.Writer)
+	writer.Truncate()
+	func() {
+		defer func() {
+			if e := recover(); e != nil {
+				global.Failer.Panic(types.NewCodeLocationWithStackTrace(2), e)
+			}
+		}()
+		reflect.ValueOf(target.body).Call(args)
+	}()
+
+	state, failure := global.Failer.Drain()
+	switch state {
+	case types.SpecStatePassed:
+		return
+	case types.SpecStateSkipped:
+		t.Skip(failure.Message)
+		return
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "[%s] %s\n", strings.ToUpper(state.String()), target.text)
+	fmt.Fprintf(out, "%s\n", failure.Message)
+	if failure.ForwardedPanic != "" {
+		fmt.Fprintf(out, "%s\n", failure.ForwardedPanic)
+	}
+	fmt.Fprintf(out, "In [DescribeFuzz] at: %s\n", failure.Location)
+	if state == types.SpecStatePanicked && failure.Location.FullStackTrace != "" {
+		fmt.Fprintf(out, "\nFull Stack Trace\n%s", failure.Location.FullStackTrace)
+	}
+	if output := writer.Bytes(); len(output) > 0 {
+		fmt.Fprintf(out, "\nCaptured GinkgoWriter Output:\n%s", output)
+	}
+	t.Fatal(out.String())
+}
+
+func isFuzzing() bool {
+	fuzz := flag.Lookup("test.fuzz")
+	return fuzz != nil && fuzz.Value.String() != ""
+}
+
+func isFuzzWorker() bool {
+	worker := flag.Lookup("test.fuzzworker")
+	return worker != nil && worker.Value.String() == "true"
+}
+
+func readDirNames(dir string) []string {
+	entries, _ := os.ReadDir(dir)
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,66 @@
+package coverage
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildCoverageCommand() command.Command {
+	var cliConfig = types.NewDefaultCLIConfig()
+
+	flags, err := types.BuildCoverageCommandFlagSet(&cliConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "coverage",
+		Usage:         "ginkgo coverage <FLAGS> who-covers <FILE:LINE>",
+		Flags:         flags,
+		ShortDoc:      "Query the per-spec coverage map generated by 'ginkgo run --spec-coverage'",
+		Documentation: "{{bold}}who-covers{{/}} lists the specs that executed the passed-in line of code.",
+		DocLink:       "per-spec-coverage",
+		Command: func(args []string, _ []string) {
+			if cliConfig.SpecCoverage == "" {
+				cliConfig.SpecCoverage = "spec-coverage.json"
+			}
+			if len(args) != 2 || args[0] != "who-covers" {
+				command.AbortWithUsage("Please pass in who-covers and a FILE:LINE")
+			}
+			WhoCovers(args[1], cliConfig)
+		},
+	}
+}
+
+func WhoCovers(location string, cliConfig types.CLIConfig) {
+	file, lineString, found := strings.Cut(location, ":")
+	line, err := strconv.Atoi(lineString)
+	if !found || err != nil || file == "" {
+		command.AbortWithUsage("Please pass in a location of the form FILE:LINE - got %s", location)
+	}
+
+	coverage, err := internal.LoadSpecCoverage(cliConfig.SpecCoverage)
+	command.AbortIfError("Failed to load the per-spec coverage map:", err)
+
+	covering := []internal.SpecCoverage{}
+	for _, spec := range coverage {
+		if spec.Covers(file, line) {
+			covering = append(covering, spec)
+		}
+	}
+
+	if len(covering) == 0 {
+		fmt.Printf("%s is not covered by any spec\n", location)
+		return
+	}
+	fmt.Printf("%s is covered by %d %s:\n", location, len(covering), internal.PluralizedWord("spec", "specs", len(covering)))
+	for _, spec := range covering {
+		fmt.Printf("  %s %s (%s:%d)\n", spec.SuitePath, spec.FullText(), filepath.Base(spec.LeafNodeLocation.FileName), spec.LeafNodeLocation.LineNumber)
+	}
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// eventStream carries events to the --event-stream destination and the --reporter plugins.  The CLI opens it once and the suites it runs stream their events to it via their parallel support server so that all their events end up in a single stream.
+var eventStream *reporters.EventStream
+var eventStreamDestination io.Closer
+var eventStreamPlugins []*reporterPlugin
+var eventStreamFormatter formatter.Formatter
+
+/*
+OpenEventStream opens the --event-stream destination and launches the --reporter plugins (if any) for the suites the CLI is about to run.  Call CloseEventStream once they have run.
+
+Plugins that fail to launch are reported but don't stop the run.  The returned ReporterConfig has the suites emit their events to the CLI - even when there is no --event-stream destination.
+*/
+func OpenEventStream(reporterConfig types.ReporterConfig, plugins []string) (types.ReporterConfig, error) {
+	eventStreamFormatter = formatter.NewWithNoColorBool(reporterConfig.NoColor)
+	writers := []io.Writer{}
+	if reporterConfig.EventStream != "" {
+		destination, err := reporters.OpenEventStreamDestination(reporterConfig.EventStream)
+		if err != nil {
+			return reporterConfig, err
+		}
+		eventStreamDestination = destination
+		writers = append(writers, &bestEffortWriter{w: destination})
+	}
+	for _, path := range plugins {
+		plugin, err := startReporterPlugin(path)
+		if err != nil {
+			fmt.Fprintln(formatter.ColorableStdOut, eventStreamFormatter.F("{{orange}}Failed to launch reporter %s:{{/}} %s", path, err))
+			continue
+		}
+		eventStreamPlugins = append(eventStreamPlugins, plugin)
+		writers = append(writers, plugin)
+	}
+	if len(writers) == 0 {
+		return reporterConfig, nil
+	}
+
+	eventStream = reporters.NewEventStream(io.MultiWriter(writers...))
+	reporterConfig.EmitStreamEvents = true
+	return reporterConfig, nil
+}
+
+// CloseEventStream closes the --event-stream destination and waits for the --reporter plugins to exit, reporting any that failed.  It is safe to call more than once.
+func CloseEventStream() {
+	if eventStreamDestination != nil {
+		eventStreamDestination.Close()
+		eventStreamDestination = nil
+	}
+	for _, plugin := range eventStreamPlugins {
+		if err := plugin.Close(); err != nil {
+			fmt.Fprintln(formatter.ColorableStdOut, eventStreamFormatter.F("{{orange}}Reporter %s failed:{{/}} %s", plugin.path, err))
+		}
+	}
+	eventStreamPlugins = nil
+	eventStream = nil
+}
+
+// bestEffortWriter stops writing to w once a write fails instead of returning the error - so that a destination that goes away doesn't keep the events from reaching the plugins
+type bestEffortWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (b *bestEffortWriter) Write(p []byte) (int, error) {
+	if b.err == nil {
+		_, b.err = b.w.Write(p)
+	}
+	return len(p), nil
+}
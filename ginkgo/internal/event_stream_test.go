@@ -0,0 +1,38 @@
+package internal_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenEventStream", func() {
+	AfterEach(func() {
+		internal.CloseEventStream()
+	})
+
+	It("has the suites emit their events when there is a destination", func() {
+		destination := filepath.Join(GinkgoT().TempDir(), "events.ndjson")
+		reporterConfig, err := internal.OpenEventStream(types.ReporterConfig{EventStream: destination}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reporterConfig.EmitStreamEvents).Should(BeTrue())
+		Ω(reporterConfig.EventStream).Should(Equal(destination))
+	})
+
+	It("leaves the suites alone when there is nothing to stream to", func() {
+		reporterConfig, err := internal.OpenEventStream(types.ReporterConfig{}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reporterConfig.EmitStreamEvents).Should(BeFalse())
+		Ω(reporterConfig.EventStream).Should(BeEmpty())
+	})
+
+	It("has the suites emit their events for reporter plugins without making up a destination", func() {
+		reporterConfig, err := internal.OpenEventStream(types.ReporterConfig{}, []string{"cat"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reporterConfig.EmitStreamEvents).Should(BeTrue())
+		Ω(reporterConfig.EventStream).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,288 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// FLAKE_HUNT_REPORT_NAME is the name of the JSON artifact written at the end of a --flake-hunt
+const FLAKE_HUNT_REPORT_NAME = "flake-hunt.json"
+
+// the suites' JSON reports are used to track each spec's outcome.  If the user hasn't asked for a JSON report the suites write one with this name and the flake hunt cleans it up.
+const flakeHuntSuiteReportName = "ginkgo-flake-hunt-run.json"
+
+/*
+FlakeHunt aggregates the outcome of every spec across the iterations of a --flake-hunt so that specs that fail intermittently - and the seeds that reproduce their failures - can be identified.
+*/
+type FlakeHunt struct {
+	Iterations int
+	Seeds      []int64
+
+	specs            map[string]*FlakeHuntSpec
+	ownsSuiteReports bool
+}
+
+// FlakeHuntSpec captures the outcomes of a single spec across the iterations of a flake hunt
+type FlakeHuntSpec struct {
+	SuitePath               string
+	SuiteDescription        string
+	ContainerHierarchyTexts []string
+	LeafNodeText            string
+	LeafNodeLocation        types.CodeLocation
+
+	Passed   int
+	Failed   int
+	Failures []FlakeHuntFailure `json:",omitempty"`
+
+	RunTimes      []time.Duration
+	MinRunTime    time.Duration
+	MedianRunTime time.Duration
+	MeanRunTime   time.Duration
+	MaxRunTime    time.Duration
+}
+
+// FlakeHuntFailure captures a single failure of a spec along with the seed that reproduces it
+type FlakeHuntFailure struct {
+	Seed     int64
+	State    types.SpecState
+	Message  string
+	Location types.CodeLocation
+}
+
+// FullText returns the concatenation of the spec's container hierarchy texts and leaf node text
+func (s FlakeHuntSpec) FullText() string {
+	return strings.TrimSpace(strings.Join(append(slices.Clone(s.ContainerHierarchyTexts), s.LeafNodeText), " "))
+}
+
+// FailingSeeds returns the seeds that reproduced the spec's failures
+func (s FlakeHuntSpec) FailingSeeds() []int64 {
+	seeds := []int64{}
+	for _, failure := range s.Failures {
+		if !slices.Contains(seeds, failure.Seed) {
+			seeds = append(seeds, failure.Seed)
+		}
+	}
+	return seeds
+}
+
+// IsFlaky returns true if the spec both passed and failed during the flake hunt
+func (s FlakeHuntSpec) IsFlaky() bool {
+	return s.Passed > 0 && s.Failed > 0
+}
+
+func NewFlakeHunt(iterations int) *FlakeHunt {
+	return &FlakeHunt{
+		Iterations: iterations,
+		specs:      map[string]*FlakeHuntSpec{},
+	}
+}
+
+// ConfigureReporter ensures the suites generate the JSON reports the flake hunt needs
+func (h *FlakeHunt) ConfigureReporter(reporterConfig types.ReporterConfig) types.ReporterConfig {
+	if reporterConfig.JSONReport == "" {
+		reporterConfig.JSONReport = flakeHuntSuiteReportName
+		h.ownsSuiteReports = true
+	}
+	return reporterConfig
+}
+
+// StartIteration must be called before the suites are run for each iteration
+func (h *FlakeHunt) StartIteration(seed int64) {
+	h.Seeds = append(h.Seeds, seed)
+}
+
+// RecordSuite records the outcome of the specs in the suite's JSON report for the current iteration.  Suites that did not run or did not produce a report (e.g. because they failed to compile) are ignored.
+func (h *FlakeHunt) RecordSuite(suite TestSuite, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig) error {
+	if !suite.State.Is(TestSuiteStatePassed, TestSuiteStateFailed) {
+		return nil
+	}
+	path := AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
+	reports, err := LoadJSONReports([]string{path})
+	if h.ownsSuiteReports {
+		os.Remove(path)
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	h.Record(h.Seeds[len(h.Seeds)-1], reports...)
+	return nil
+}
+
+// Record records the outcome of the specs in the passed-in reports, which were run with the passed-in seed
+func (h *FlakeHunt) Record(seed int64, reports ...types.Report) {
+	for _, report := range reports {
+		for _, specReport := range report.SpecReports {
+			if !specReport.LeafNodeType.Is(types.NodeTypeIt) || specReport.State.Is(types.SpecStateSkipped|types.SpecStatePending) {
+				continue
+			}
+			key := strings.Join([]string{report.SuitePath, specReport.LeafNodeLocation.String(), specReport.FullText()}, "\x1f")
+			spec, ok := h.specs[key]
+			if !ok {
+				spec = &FlakeHuntSpec{
+					SuitePath:               report.SuitePath,
+					SuiteDescription:        report.SuiteDescription,
+					ContainerHierarchyTexts: specReport.ContainerHierarchyTexts,
+					LeafNodeText:            specReport.LeafNodeText,
+					LeafNodeLocation:        specReport.LeafNodeLocation,
+				}
+				h.specs[key] = spec
+			}
+			if specReport.State.Is(types.SpecStateFailureStates | types.SpecStateQuarantined) {
+				spec.Failed += 1
+				spec.Failures = append(spec.Failures, FlakeHuntFailure{
+					Seed:     seed,
+					State:    specReport.State,
+					Message:  specReport.Failure.Message,
+					Location: specReport.Failure.Location,
+				})
+			} else {
+				spec.Passed += 1
+			}
+			spec.RunTimes = append(spec.RunTimes, specReport.RunTime)
+		}
+	}
+}
+
+// Specs returns all the specs that ran during the flake hunt with their runtime distributions computed, sorted by suite and location
+func (h *FlakeHunt) Specs() []FlakeHuntSpec {
+	specs := []FlakeHuntSpec{}
+	for _, spec := range h.specs {
+		s := *spec
+		sorted := slices.Clone(s.RunTimes)
+		slices.Sort(sorted)
+		if len(sorted) > 0 {
+			var total time.Duration
+			for _, runTime := range sorted {
+				total += runTime
+			}
+			s.MinRunTime, s.MaxRunTime = sorted[0], sorted[len(sorted)-1]
+			s.MedianRunTime = sorted[len(sorted)/2]
+			s.MeanRunTime = total / time.Duration(len(sorted))
+		}
+		specs = append(specs, s)
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		if specs[i].SuitePath != specs[j].SuitePath {
+			return specs[i].SuitePath < specs[j].SuitePath
+		}
+		if specs[i].LeafNodeLocation.FileName != specs[j].LeafNodeLocation.FileName {
+			return specs[i].LeafNodeLocation.FileName < specs[j].LeafNodeLocation.FileName
+		}
+		if specs[i].LeafNodeLocation.LineNumber != specs[j].LeafNodeLocation.LineNumber {
+			return specs[i].LeafNodeLocation.LineNumber < specs[j].LeafNodeLocation.LineNumber
+		}
+		return specs[i].FullText() < specs[j].FullText()
+	})
+	return specs
+}
+
+// HasFailures returns true if any spec failed during any iteration of the flake hunt
+func (h *FlakeHunt) HasFailures() bool {
+	for _, spec := range h.specs {
+		if spec.Failed > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type flakeHuntReport struct {
+	Iterations   int
+	Seeds        []int64
+	FlakySpecs   []FlakeHuntSpec
+	FailingSpecs []FlakeHuntSpec
+	PassingSpecs []FlakeHuntSpec
+}
+
+func (h *FlakeHunt) report() flakeHuntReport {
+	report := flakeHuntReport{
+		Iterations:   h.Iterations,
+		Seeds:        h.Seeds,
+		FlakySpecs:   []FlakeHuntSpec{},
+		FailingSpecs: []FlakeHuntSpec{},
+		PassingSpecs: []FlakeHuntSpec{},
+	}
+	for _, spec := range h.Specs() {
+		switch {
+		case spec.IsFlaky():
+			report.FlakySpecs = append(report.FlakySpecs, spec)
+		case spec.Failed > 0:
+			report.FailingSpecs = append(report.FailingSpecs, spec)
+		default:
+			report.PassingSpecs = append(report.PassingSpecs, spec)
+		}
+	}
+	sort.SliceStable(report.FlakySpecs, func(i, j int) bool {
+		return report.FlakySpecs[i].Failed > report.FlakySpecs[j].Failed
+	})
+	return report
+}
+
+// WriteReport writes the flake hunt's JSON artifact to path
+func (h *FlakeHunt) WriteReport(path string) error {
+	data, err := json.MarshalIndent(h.report(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0770); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0666)
+}
+
+// Summary renders a table of the specs that failed during the flake hunt
+func (h *FlakeHunt) Summary(f formatter.Formatter) string {
+	report := h.report()
+	seeds := []string{}
+	for _, seed := range report.Seeds {
+		seeds = append(seeds, fmt.Sprintf("%d", seed))
+	}
+
+	out := f.F("{{bold}}Flake Hunt Summary{{/}} - %d %s with seeds %s\n", len(report.Seeds), PluralizedWord("run", "runs", len(report.Seeds)), strings.Join(seeds, ", "))
+	out += f.Fi(1, "{{green}}%d always passed{{/}} | {{orange}}%d failed intermittently{{/}} | {{red}}%d always failed{{/}}\n", len(report.PassingSpecs), len(report.FlakySpecs), len(report.FailingSpecs))
+	if len(report.FlakySpecs)+len(report.FailingSpecs) == 0 {
+		return out
+	}
+
+	out += "\n"
+	out += f.Fi(1, "{{gray}}%6s %6s %10s %10s %10s  %s{{/}}\n", "PASSED", "FAILED", "MIN", "MEDIAN", "MAX", "SPEC")
+	row := func(color string, heading string, spec FlakeHuntSpec) {
+		seeds := []string{}
+		for _, seed := range spec.FailingSeeds() {
+			seeds = append(seeds, fmt.Sprintf("%d", seed))
+		}
+		out += f.Fi(1, "%6d "+color+"%6d{{/}} %10s %10s %10s  "+color+"%s{{/}} %s\n", spec.Passed, spec.Failed, roundedDuration(spec.MinRunTime), roundedDuration(spec.MedianRunTime), roundedDuration(spec.MaxRunTime), heading, spec.FullText())
+		out += f.Fi(1, "%47s{{gray}}%s - failing seeds: %s{{/}}\n", "", spec.LeafNodeLocation, strings.Join(seeds, ", "))
+	}
+	for _, spec := range report.FlakySpecs {
+		row("{{orange}}", "[FLAKY]", spec)
+	}
+	for _, spec := range report.FailingSpecs {
+		row("{{red}}", "[FAILED]", spec)
+	}
+	return out
+}
+
+func roundedDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond * 10).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakeHunt", func() {
+	var hunt *internal.FlakeHunt
+
+	spec := func(text string, state types.SpecState, runTime time.Duration) types.SpecReport {
+		return types.SpecReport{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeText:     text,
+			LeafNodeLocation: types.CodeLocation{FileName: "foo_test.go", LineNumber: len(text)},
+			State:            state,
+			RunTime:          runTime,
+			Failure:          types.Failure{Message: text + " failed"},
+		}
+	}
+
+	run := func(seed int64, specs ...types.SpecReport) {
+		hunt.StartIteration(seed)
+		hunt.Record(seed, types.Report{SuitePath: "/foo", SuiteDescription: "Foo", SpecReports: append(specs, types.SpecReport{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed})})
+	}
+
+	BeforeEach(func() {
+		hunt = internal.NewFlakeHunt(4)
+		run(10, spec("A", types.SpecStatePassed, 1*time.Second), spec("BB", types.SpecStateFailed, time.Second), spec("CCC", types.SpecStatePassed, time.Second), spec("DDDD", types.SpecStateSkipped, 0))
+		run(11, spec("A", types.SpecStatePassed, 4*time.Second), spec("BB", types.SpecStatePanicked, time.Second), spec("CCC", types.SpecStateTimedout, time.Second), spec("DDDD", types.SpecStateSkipped, 0))
+		run(12, spec("A", types.SpecStatePassed, 2*time.Second), spec("BB", types.SpecStateFailed, time.Second), spec("CCC", types.SpecStatePassed, time.Second), spec("DDDD", types.SpecStateSkipped, 0))
+		run(13, spec("A", types.SpecStatePassed, 3*time.Second), spec("BB", types.SpecStateFailed, time.Second), spec("CCC", types.SpecStateQuarantined, time.Second), spec("DDDD", types.SpecStateSkipped, 0))
+	})
+
+	It("tracks the pass/fail counts and runtime distributions of every spec that ran", func() {
+		specs := hunt.Specs()
+		Ω(specs).Should(HaveLen(3))
+
+		Ω(specs[0].LeafNodeText).Should(Equal("A"))
+		Ω(specs[0].Passed).Should(Equal(4))
+		Ω(specs[0].Failed).Should(Equal(0))
+		Ω(specs[0].RunTimes).Should(Equal([]time.Duration{time.Second, 4 * time.Second, 2 * time.Second, 3 * time.Second}))
+		Ω(specs[0].MinRunTime).Should(Equal(time.Second))
+		Ω(specs[0].MedianRunTime).Should(Equal(3 * time.Second))
+		Ω(specs[0].MeanRunTime).Should(Equal(2500 * time.Millisecond))
+		Ω(specs[0].MaxRunTime).Should(Equal(4 * time.Second))
+		Ω(specs[0].IsFlaky()).Should(BeFalse())
+
+		Ω(specs[1].LeafNodeText).Should(Equal("BB"))
+		Ω(specs[1].Failed).Should(Equal(4))
+		Ω(specs[1].IsFlaky()).Should(BeFalse())
+
+		Ω(specs[2].LeafNodeText).Should(Equal("CCC"))
+		Ω(specs[2].Passed).Should(Equal(2))
+		Ω(specs[2].Failed).Should(Equal(2))
+		Ω(specs[2].IsFlaky()).Should(BeTrue())
+		Ω(specs[2].FailingSeeds()).Should(Equal([]int64{11, 13}))
+		Ω(specs[2].Failures[0].State).Should(Equal(types.SpecStateTimedout))
+		Ω(specs[2].Failures[0].Message).Should(Equal("CCC failed"))
+		Ω(specs[2].Failures[1].State).Should(Equal(types.SpecStateQuarantined))
+
+		Ω(hunt.HasFailures()).Should(BeTrue())
+		Ω(hunt.Seeds).Should(Equal([]int64{10, 11, 12, 13}))
+	})
+
+	It("summarizes the failing specs in a table", func() {
+		summary := hunt.Summary(formatter.NewWithNoColorBool(true))
+		Ω(summary).Should(ContainSubstring("Flake Hunt Summary - 4 runs with seeds 10, 11, 12, 13"))
+		Ω(summary).Should(ContainSubstring("1 always passed | 1 failed intermittently | 1 always failed"))
+		Ω(summary).Should(MatchRegexp(`\s+2\s+2\s+1s\s+1s\s+1s\s+\[FLAKY\] CCC`))
+		Ω(summary).Should(ContainSubstring("foo_test.go:3 - failing seeds: 11, 13"))
+		Ω(summary).Should(MatchRegexp(`\s+0\s+4\s+1s\s+1s\s+1s\s+\[FAILED\] BB`))
+		Ω(summary).Should(ContainSubstring("foo_test.go:2 - failing seeds: 10, 11, 12, 13"))
+		Ω(summary).ShouldNot(ContainSubstring("] A"))
+	})
+
+	It("writes a JSON artifact that separates flaky, failing, and passing specs", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "out", internal.FLAKE_HUNT_REPORT_NAME)
+		Ω(hunt.WriteReport(path)).Should(Succeed())
+		data, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var report struct {
+			Iterations   int
+			Seeds        []int64
+			FlakySpecs   []internal.FlakeHuntSpec
+			FailingSpecs []internal.FlakeHuntSpec
+			PassingSpecs []internal.FlakeHuntSpec
+		}
+		Ω(json.Unmarshal(data, &report)).Should(Succeed())
+		Ω(report.Iterations).Should(Equal(4))
+		Ω(report.Seeds).Should(Equal([]int64{10, 11, 12, 13}))
+		Ω(report.FlakySpecs).Should(HaveLen(1))
+		Ω(report.FlakySpecs[0].LeafNodeText).Should(Equal("CCC"))
+		Ω(report.FlakySpecs[0].FailingSeeds()).Should(Equal([]int64{11, 13}))
+		Ω(report.FailingSpecs).Should(HaveLen(1))
+		Ω(report.FailingSpecs[0].LeafNodeText).Should(Equal("BB"))
+		Ω(report.PassingSpecs).Should(HaveLen(1))
+		Ω(report.PassingSpecs[0].MedianRunTime).Should(Equal(3 * time.Second))
+	})
+
+	Describe("ConfigureReporter", func() {
+		It("only asks the suites for a JSON report when the user hasn't", func() {
+			Ω(hunt.ConfigureReporter(types.ReporterConfig{}).JSONReport).ShouldNot(BeEmpty())
+			Ω(hunt.ConfigureReporter(types.ReporterConfig{JSONReport: "out.json"}).JSONReport).Should(Equal("out.json"))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var fuzzFunctionRE = regexp.MustCompile(`(?m)^func (Fuzz\w*)\(\w+ \*testing\.F\)`)
+
+/*
+FindFuzzFunction returns the name of the Fuzz function in the suite's test files.  Ginkgo uses it to hand DescribeFuzz containers to Go's fuzzing engine - so it should call RunFuzzSpecs.
+*/
+func FindFuzzFunction(suite TestSuite) (string, error) {
+	files, err := filepath.Glob(filepath.Join(suite.Path, "*_test.go"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	names := []string{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		for _, match := range fuzzFunctionRE.FindAllSubmatch(data, -1) {
+			names = append(names, string(match[1]))
+		}
+	}
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("%s has no Fuzz function.  Add one that calls RunFuzzSpecs:\n\n  func FuzzSuite(f *testing.F) {\n    RegisterFailHandler(Fail)\n    RunFuzzSpecs(f)\n  }", suite.Path)
+	case 1:
+		return names[0], nil
+	}
+	return "", fmt.Errorf("%s has more than one Fuzz function (%v).  Ginkgo needs exactly one that calls RunFuzzSpecs.", suite.Path, names)
+}
+
+/*
+FuzzSuite uses go test -fuzz to fuzz the DescribeFuzz container that matches suiteConfig.Fuzz, streaming the fuzzing engine's output as it goes.  Once fuzzing is done the suite runs the container's entries as specs (see RunFuzzSpecs) and generates its reports - so a failing input found by the fuzzing engine is reported as a spec failure and fails the suite.
+*/
+func FuzzSuite(suite TestSuite, fuzzFunction string, suiteConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) TestSuite {
+	args := []string{"test", "-run=^$", fmt.Sprintf("-fuzz=^%s$", fuzzFunction)}
+	if cliConfig.FuzzTime > 0 {
+		args = append(args, fmt.Sprintf("-fuzztime=%s", cliConfig.FuzzTime))
+	}
+	if cliConfig.Procs > 0 {
+		args = append(args, fmt.Sprintf("-parallel=%d", cliConfig.Procs))
+	}
+	if goFlagsConfig.Tags != "" {
+		args = append(args, fmt.Sprintf("-tags=%s", goFlagsConfig.Tags))
+	}
+	if goFlagsConfig.Race {
+		args = append(args, "-race")
+	}
+
+	suiteConfig, reporterConfig = absPathsForGeneratedReports(suite, suiteConfig, reporterConfig, cliConfig)
+	suiteConfig = absPathsForSuiteInputs(suiteConfig)
+	if eventStream != nil {
+		server, err := parallel_support.NewServer(1, reporters.NoopReporter{})
+		command.AbortIfError("Failed to start event stream server", err)
+		server.SetEventStream(eventStream)
+		server.Start()
+		defer server.Close()
+		suiteConfig.ParallelHost = server.Address()
+	}
+	ginkgoArgs, err := types.GenerateGinkgoTestRunArgs(suiteConfig, reporterConfig, types.GoFlagsConfig{})
+	command.AbortIfError("Failed to generate test run arguments", err)
+	args = append(args, ".", "-args")
+	args = append(args, ginkgoArgs...)
+	args = append(args, additionalArgs...)
+
+	fmt.Printf("Fuzzing %s with %s...\n", suite.NamespacedName(), fuzzFunction)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = suite.Path
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	suite.State = TestSuiteStatePassed
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("Fuzzing %s failed: %s\n", suite.NamespacedName(), err)
+		suite.State = TestSuiteStateFailed
+	}
+	return suite
+}
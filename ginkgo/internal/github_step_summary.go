@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const githubStepSummaryReportName = "ginkgo-step-summary.md"
+
+// ClaimGithubStepSummary hides $GITHUB_STEP_SUMMARY from the suites the CLI runs so that the CLI can append a single summary that covers all of them.
+// It makes sure the suites generate the Markdown reports that the summary is built from and returns the path to the summary.
+func ClaimGithubStepSummary(reporterConfig types.ReporterConfig) (types.ReporterConfig, string) {
+	githubStepSummary := reporterConfig.GithubStepSummary()
+	if githubStepSummary == "" {
+		return reporterConfig, ""
+	}
+	os.Unsetenv("GITHUB_STEP_SUMMARY")
+	githubStepSummary, _ = filepath.Abs(githubStepSummary)
+	if reporterConfig.MarkdownReport == "" {
+		reporterConfig.MarkdownReport = githubStepSummaryReportName
+	}
+	return reporterConfig, githubStepSummary
+}
+
+// WriteGithubStepSummary appends a summary of the Markdown reports generated for suites to githubStepSummary
+func WriteGithubStepSummary(githubStepSummary string, suites TestSuites, cliConfig types.CLIConfig, reporterConfig types.ReporterConfig) error {
+	paths := []string{}
+	if cliConfig.KeepSeparateReports {
+		for _, suite := range suites.ThatAreGinkgoSuites() {
+			paths = append(paths, AbsPathForGeneratedAsset(reporterConfig.MarkdownReport, suite, cliConfig, 0))
+		}
+	} else if cliConfig.OutputDir != "" {
+		paths = append(paths, filepath.Join(cliConfig.OutputDir, reporterConfig.MarkdownReport))
+	} else {
+		paths = append(paths, reporterConfig.MarkdownReport)
+	}
+
+	allReports := []types.Report{}
+	for _, path := range paths {
+		if !FileExists(path) {
+			continue
+		}
+		reports, err := reporters.LoadMarkdownReport(path)
+		if err != nil {
+			return err
+		}
+		if reporterConfig.MarkdownReport == githubStepSummaryReportName {
+			os.Remove(path)
+		}
+		allReports = append(allReports, reports...)
+	}
+	if len(allReports) == 0 {
+		return nil
+	}
+	return reporters.AppendMarkdownSummary(allReports, githubStepSummary)
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+ListSpecs lists the specs in the compiled suite.
+
+The specs are found with a dry run of the suite that honors the filters in ginkgoConfig: specs that would run are reported as passing by the dry run while specs that are filtered out are reported as skipped.  Specs are listed in the order in which they are declared.
+*/
+func ListSpecs(suite TestSuite, ginkgoConfig types.SuiteConfig) (types.ListedSuite, error) {
+	listed := types.ListedSuite{SuitePath: suite.AbsPath(), Specs: []types.ListedSpec{}}
+
+	tmpDir, err := os.MkdirTemp("", "ginkgo-list")
+	if err != nil {
+		return listed, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ginkgoConfig.DryRun = true
+	// a fixed seed keeps the order of specs declared on the same line (e.g. in a loop) stable from one listing to the next
+	ginkgoConfig.RandomSeed = 1
+	report, err := runSuiteForReport(suite, ginkgoConfig, types.GoFlagsConfig{}, nil, filepath.Join(tmpDir, "list.json"))
+	if err != nil {
+		return listed, err
+	}
+	listed.SuiteDescription = report.SuiteDescription
+
+	specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt)
+	slices.SortStableFunc(specs, func(a, b types.SpecReport) int {
+		if a.LeafNodeLocation.FileName != b.LeafNodeLocation.FileName {
+			return strings.Compare(a.LeafNodeLocation.FileName, b.LeafNodeLocation.FileName)
+		}
+		return a.LeafNodeLocation.LineNumber - b.LeafNodeLocation.LineNumber
+	})
+
+	for _, spec := range specs {
+		listed.Specs = append(listed.Specs, types.ListedSpec{
+			ID:                          spec.ID,
+			FullText:                    spec.FullText(),
+			ContainerHierarchyTexts:     spec.ContainerHierarchyTexts,
+			ContainerHierarchyLocations: spec.ContainerHierarchyLocations,
+			LeafNodeText:                spec.LeafNodeText,
+			LeafNodeLocation:            spec.LeafNodeLocation,
+			Labels:                      spec.Labels(),
+			SemVerConstraints:           spec.SemVerConstraints(),
+			ComponentSemVerConstraints:  spec.ComponentSemVerConstraints(),
+			Serial:                      spec.IsSerial,
+			Ordered:                     spec.IsInOrderedContainer,
+			FlakeAttempts:               spec.MaxFlakeAttempts,
+			MustPassRepeatedly:          spec.MaxMustPassRepeatedly,
+			SpecTimeout:                 spec.SpecTimeout,
+			NodeTimeout:                 spec.NodeTimeout,
+			GracePeriod:                 spec.GracePeriod,
+			Pending:                     spec.State.Is(types.SpecStatePending),
+			WillRun:                     spec.State.Is(types.SpecStatePassed),
+		})
+	}
+	return listed, nil
+}
@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// LoadJSONReports loads the reports stored in the JSON reports (as generated by --json-report) at paths
+func LoadJSONReports(paths []string) ([]types.Report, error) {
+	out := []types.Report{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		reports := []types.Report{}
+		err = json.Unmarshal(data, &reports)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode %s:\n%w", path, err)
+		}
+		out = append(out, reports...)
+	}
+	return out, nil
+}
+
+/*
+MergeReports combines reports for the same suite - for example, the reports generated by each --shard of a suite or by retries of a CI job - into a single report per suite.
+
+Reports are considered to be for the same suite if they share a SuitePath and SuiteDescription.  They are combined with Report.Add and then:
+
+- Specs that appear in more than one report are de-duplicated.  An attempt that actually ran supersedes one that was skipped (e.g. by --rerun-failed) and, otherwise, the attempt that ended last wins.  So a spec that failed and then passed when the job was retried is reported as passing.
+- Reports for the same shard (or several unsharded reports) are retries of the same specs.  Suite-level nodes (e.g. BeforeSuite) and SpecialSuiteFailureReasons (e.g. "Interrupted by User") are taken from the attempt at each shard that ended last - a retry that got past a failing BeforeSuite replaces the failure.
+- PreRunStats are summed across shards.  Retries are not double-counted.
+- SuiteSucceeded is recomputed: the merged suite succeeds if none of its de-duplicated specs and suite-level nodes failed and none of the latest attempts failed for reasons other than their specs (e.g. --fail-on-pending).
+
+Suites are returned in the order they first appear in reports.
+*/
+func MergeReports(reports []types.Report) []types.Report {
+	type suiteKey struct {
+		path        string
+		description string
+	}
+	keys := []suiteKey{}
+	grouped := map[suiteKey][]types.Report{}
+	for _, report := range reports {
+		key := suiteKey{report.SuitePath, report.SuiteDescription}
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], report)
+	}
+
+	out := make([]types.Report, len(keys))
+	for i, key := range keys {
+		out[i] = mergeSuiteReports(grouped[key])
+	}
+	return out
+}
+
+func mergeSuiteReports(reports []types.Report) types.Report {
+	merged := reports[0]
+	for _, report := range reports[1:] {
+		merged = merged.Add(report)
+		merged.SuiteHasProgrammaticFocus = merged.SuiteHasProgrammaticFocus || report.SuiteHasProgrammaticFocus
+	}
+	latestAttempts := latestAttemptAtEachShard(reports)
+	merged.SpecReports = deduplicateSpecReports(reports, latestAttempts)
+
+	shardStats := map[int]types.PreRunStats{}
+	for _, report := range reports {
+		stats := shardStats[report.PreRunStats.Shard]
+		stats.TotalSpecs = max(stats.TotalSpecs, report.PreRunStats.TotalSpecs)
+		stats.SpecsThatWillRun = max(stats.SpecsThatWillRun, report.PreRunStats.SpecsThatWillRun)
+		shardStats[report.PreRunStats.Shard] = stats
+	}
+	merged.PreRunStats = types.PreRunStats{}
+	for _, stats := range shardStats {
+		merged.PreRunStats.TotalSpecs += stats.TotalSpecs
+		merged.PreRunStats.SpecsThatWillRun += stats.SpecsThatWillRun
+	}
+
+	merged.SpecialSuiteFailureReasons = nil
+	merged.SuiteSucceeded = merged.SpecReports.CountWithState(types.SpecStateFailureStates) == 0
+	for i, report := range reports {
+		if !latestAttempts[i] {
+			continue
+		}
+		for _, reason := range report.SpecialSuiteFailureReasons {
+			if !slices.Contains(merged.SpecialSuiteFailureReasons, reason) {
+				merged.SpecialSuiteFailureReasons = append(merged.SpecialSuiteFailureReasons, reason)
+			}
+		}
+		if !report.SuiteSucceeded && report.SpecReports.CountWithState(types.SpecStateFailureStates) == 0 {
+			merged.SuiteSucceeded = false
+		}
+	}
+	return merged
+}
+
+// latestAttemptAtEachShard returns the indices of the reports that ended last at each shard.  Earlier reports for the same shard are superseded retries.
+func latestAttemptAtEachShard(reports []types.Report) map[int]bool {
+	latest := map[int]int{}
+	for i, report := range reports {
+		if j, ok := latest[report.PreRunStats.Shard]; !ok || !report.EndTime.Before(reports[j].EndTime) {
+			latest[report.PreRunStats.Shard] = i
+		}
+	}
+	out := map[int]bool{}
+	for _, i := range latest {
+		out[i] = true
+	}
+	return out
+}
+
+// deduplicateSpecReports returns the spec reports in reports with only the most relevant attempt at each spec.  Suite-level nodes (e.g. BeforeSuite) run once per process and are only taken from the latest attempts.
+func deduplicateSpecReports(reports []types.Report, latestAttempts map[int]bool) types.SpecReports {
+	type specKey struct {
+		location   string
+		hierarchy  string
+		text       string
+		occurrence int
+	}
+	indices := map[specKey]int{}
+	out := types.SpecReports{}
+	for i, report := range reports {
+		// identical specs (e.g. generated in a loop) are told apart by the order in which they appear in each report
+		occurrences := map[specKey]int{}
+		for _, specReport := range report.SpecReports {
+			if !specReport.LeafNodeType.Is(types.NodeTypeIt) {
+				if latestAttempts[i] {
+					out = append(out, specReport)
+				}
+				continue
+			}
+			key := specKey{
+				location:  specReport.LeafNodeLocation.String(),
+				hierarchy: strings.Join(specReport.ContainerHierarchyTexts, "\x1f"),
+				text:      specReport.LeafNodeText,
+			}
+			key.occurrence = occurrences[key]
+			occurrences[key] += 1
+
+			idx, ok := indices[key]
+			if !ok {
+				indices[key] = len(out)
+				out = append(out, specReport)
+			} else if supersedesAttempt(specReport, out[idx]) {
+				out[idx] = specReport
+			}
+		}
+	}
+	return out
+}
+
+func supersedesAttempt(attempt types.SpecReport, previous types.SpecReport) bool {
+	notRun := types.SpecStateSkipped | types.SpecStatePending
+	attemptRan, previousRan := !attempt.State.Is(notRun), !previous.State.Is(notRun)
+	if attemptRan != previousRan {
+		return attemptRan
+	}
+	return !attempt.EndTime.Before(previous.EndTime)
+}
+
+// GenerateMergedReports writes reports out in each of the formats requested in reporterConfig.  Each report is generated separately and then combined using the relevant reporters.MergeAndCleanup* function.
+func GenerateMergedReports(reports []types.Report, reporterConfig types.ReporterConfig) ([]string, error) {
+	type reportFormat struct {
+		ReportName   string
+		GenerateFunc func(types.Report, string) error
+		MergeFunc    func([]string, string) ([]string, error)
+	}
+	reportFormats := []reportFormat{}
+	if reporterConfig.JSONReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JSONReport, GenerateFunc: reporters.GenerateJSONReport, MergeFunc: reporters.MergeAndCleanupJSONReports})
+	}
+	if reporterConfig.GoJSONReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.GoJSONReport, GenerateFunc: reporters.GenerateGoTestJSONReport, MergeFunc: reporters.MergeAndCleanupGoTestJSONReports})
+	}
+	if reporterConfig.JUnitReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JUnitReport, GenerateFunc: reporters.GenerateJUnitReport, MergeFunc: reporters.MergeAndCleanupJUnitReports})
+	}
+	if reporterConfig.TeamcityReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TeamcityReport, GenerateFunc: reporters.GenerateTeamcityReport, MergeFunc: reporters.MergeAndCleanupTeamcityReports})
+	}
+	if reporterConfig.HTMLReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.HTMLReport, GenerateFunc: reporters.GenerateHTMLReport, MergeFunc: reporters.MergeAndCleanupHTMLReports})
+	}
+	if reporterConfig.MarkdownReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.MarkdownReport, GenerateFunc: reporters.GenerateMarkdownReport, MergeFunc: reporters.MergeAndCleanupMarkdownReports})
+	}
+	if reporterConfig.TraceExport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TraceExport, GenerateFunc: reporters.GenerateTraceExport, MergeFunc: reporters.MergeAndCleanupTraceExports})
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ginkgo-merge-reports")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	messages := []string{}
+	for i, format := range reportFormats {
+		sources := []string{}
+		for j, report := range reports {
+			source := fmt.Sprintf("%s/%d_%d", tmpDir, i, j)
+			if err := format.GenerateFunc(report, source); err != nil {
+				return messages, err
+			}
+			sources = append(sources, source)
+		}
+		mergeMessages, err := format.MergeFunc(sources, format.ReportName)
+		messages = append(messages, mergeMessages...)
+		if err != nil {
+			return messages, err
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,127 @@
+package internal_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MergeReports", func() {
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	spec := func(text string, state types.SpecState, end int) types.SpecReport {
+		return types.SpecReport{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeText:     text,
+			LeafNodeLocation: types.CodeLocation{FileName: "foo_test.go", LineNumber: len(text)},
+			State:            state,
+			EndTime:          t.Add(time.Duration(end) * time.Second),
+		}
+	}
+
+	report := func(path string, shard int, start int, specs ...types.SpecReport) types.Report {
+		r := types.Report{
+			SuitePath:        path,
+			SuiteDescription: "Suite " + path,
+			SuiteSucceeded:   true,
+			StartTime:        t.Add(time.Duration(start) * time.Second),
+			EndTime:          t.Add(time.Duration(start+2) * time.Second),
+			PreRunStats:      types.PreRunStats{TotalSpecs: len(specs), SpecsThatWillRun: len(specs), Shard: shard, TotalShards: 2},
+			SpecReports:      specs,
+		}
+		r.SuiteSucceeded = r.SpecReports.CountWithState(types.SpecStateFailureStates) == 0
+		return r
+	}
+
+	It("combines the reports for each suite", func() {
+		a1 := report("a", 1, 1, spec("A1", types.SpecStatePassed, 2), spec("A2", types.SpecStatePassed, 2))
+		a1.SpecialSuiteFailureReasons = []string{"Suite skipped in BeforeSuite"}
+		a2 := report("a", 2, 2, spec("A3", types.SpecStateFailed, 3))
+		a2.SpecialSuiteFailureReasons = []string{"Suite skipped in BeforeSuite", "Interrupted by User"}
+		merged := internal.MergeReports([]types.Report{
+			a1,
+			report("b", 1, 1, spec("B1", types.SpecStatePassed, 2)),
+			a2,
+			report("b", 2, 2, spec("B2", types.SpecStatePassed, 3)),
+		})
+		Ω(merged).Should(HaveLen(2))
+
+		Ω(merged[0].SuitePath).Should(Equal("a"))
+		Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+		Ω(merged[0].SpecialSuiteFailureReasons).Should(Equal([]string{"Suite skipped in BeforeSuite", "Interrupted by User"}))
+		Ω(merged[0].PreRunStats).Should(Equal(types.PreRunStats{TotalSpecs: 3, SpecsThatWillRun: 3}))
+		Ω(merged[0].StartTime).Should(Equal(t.Add(time.Second)))
+		Ω(merged[0].EndTime).Should(Equal(t.Add(4 * time.Second)))
+		Ω(merged[0].SpecReports).Should(HaveLen(3))
+
+		Ω(merged[1].SuitePath).Should(Equal("b"))
+		Ω(merged[1].SuiteSucceeded).Should(BeTrue())
+		Ω(merged[1].PreRunStats).Should(Equal(types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 2}))
+		Ω(merged[1].SpecReports).Should(HaveLen(2))
+	})
+
+	It("de-duplicates retried specs, preferring attempts that ran and then the most recent attempt", func() {
+		merged := internal.MergeReports([]types.Report{
+			report("a", 0, 1, spec("A1", types.SpecStatePassed, 2), spec("A2", types.SpecStateFailed, 2), spec("A3", types.SpecStateFailed, 2)),
+			report("a", 0, 5, spec("A1", types.SpecStateSkipped, 6), spec("A2", types.SpecStatePassed, 6), spec("A3", types.SpecStateSkipped, 6)),
+		})
+		Ω(merged).Should(HaveLen(1))
+		Ω(merged[0].SpecReports).Should(HaveLen(3))
+		Ω(merged[0].SpecReports[0].State).Should(Equal(types.SpecStatePassed))
+		Ω(merged[0].SpecReports[1].State).Should(Equal(types.SpecStatePassed))
+		Ω(merged[0].SpecReports[1].EndTime).Should(Equal(t.Add(6 * time.Second)))
+		Ω(merged[0].SpecReports[2].State).Should(Equal(types.SpecStateFailed))
+		Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+		Ω(merged[0].PreRunStats).Should(Equal(types.PreRunStats{TotalSpecs: 3, SpecsThatWillRun: 3}))
+	})
+
+	It("recomputes SuiteSucceeded once retried specs have passed", func() {
+		merged := internal.MergeReports([]types.Report{
+			report("a", 0, 1, spec("A1", types.SpecStatePassed, 2), spec("A2", types.SpecStateFailed, 2)),
+			report("a", 0, 5, spec("A1", types.SpecStateSkipped, 6), spec("A2", types.SpecStatePassed, 6)),
+		})
+		Ω(merged[0].SuiteSucceeded).Should(BeTrue())
+	})
+
+	It("takes suite-level nodes and special failure reasons from the latest attempt at each shard", func() {
+		beforeSuite := func(state types.SpecState, process int, end int) types.SpecReport {
+			return types.SpecReport{
+				LeafNodeType:     types.NodeTypeBeforeSuite,
+				LeafNodeLocation: types.CodeLocation{FileName: "suite_test.go", LineNumber: 10},
+				State:            state,
+				ParallelProcess:  process,
+				EndTime:          t.Add(time.Duration(end) * time.Second),
+			}
+		}
+		failedAttempt := report("a", 0, 1, beforeSuite(types.SpecStateFailed, 1, 1), beforeSuite(types.SpecStateFailed, 2, 1), spec("A1", types.SpecStateSkipped, 2))
+		failedAttempt.SpecialSuiteFailureReasons = []string{"Interrupted by User"}
+		retry := report("a", 0, 5, beforeSuite(types.SpecStatePassed, 1, 5), spec("A1", types.SpecStatePassed, 6))
+
+		merged := internal.MergeReports([]types.Report{failedAttempt, retry})
+		Ω(merged[0].SuiteSucceeded).Should(BeTrue())
+		Ω(merged[0].SpecialSuiteFailureReasons).Should(BeEmpty())
+		Ω(merged[0].SpecReports).Should(HaveLen(2))
+		Ω(merged[0].SpecReports.CountWithState(types.SpecStateFailureStates)).Should(Equal(0))
+		Ω(merged[0].SpecReports.WithLeafNodeType(types.NodeTypeBeforeSuite)[0].EndTime).Should(Equal(t.Add(5 * time.Second)))
+
+		merged = internal.MergeReports([]types.Report{retry, failedAttempt})
+		Ω(merged[0].SuiteSucceeded).Should(BeTrue(), "the order of the reports doesn't matter, the attempt that ended last wins")
+	})
+
+	It("keeps identical specs distinct", func() {
+		merged := internal.MergeReports([]types.Report{
+			report("a", 1, 1, spec("A", types.SpecStatePassed, 2), spec("A", types.SpecStatePassed, 2)),
+		})
+		Ω(merged[0].SpecReports).Should(HaveLen(2))
+	})
+
+	It("fails suites that failed for reasons other than their specs", func() {
+		failedOnPending := report("a", 0, 1, spec("A1", types.SpecStatePending, 2))
+		failedOnPending.SuiteSucceeded = false
+		merged := internal.MergeReports([]types.Report{failedOnPending})
+		Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+	})
+})
@@ -99,6 +99,15 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 	if reporterConfig.TeamcityReport != "" {
 		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TeamcityReport, GenerateFunc: reporters.GenerateTeamcityReport, MergeFunc: reporters.MergeAndCleanupTeamcityReports})
 	}
+	if reporterConfig.HTMLReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.HTMLReport, GenerateFunc: reporters.GenerateHTMLReport, MergeFunc: reporters.MergeAndCleanupHTMLReports})
+	}
+	if reporterConfig.MarkdownReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.MarkdownReport, GenerateFunc: reporters.GenerateMarkdownReport, MergeFunc: reporters.MergeAndCleanupMarkdownReports})
+	}
+	if reporterConfig.TraceExport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TraceExport, GenerateFunc: reporters.GenerateTraceExport, MergeFunc: reporters.MergeAndCleanupTraceExports})
+	}
 
 	// Generate reports for suites that failed to run
 	reportableSuites := suites.ThatAreGinkgoSuites()
@@ -119,6 +128,9 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 			report.SpecialSuiteFailureReasons = append(report.SpecialSuiteFailureReasons, EMPTY_SKIP_FAILURE_REASON)
 			report.SuiteSucceeded = true
 		}
+		if reporterConfig.TraceExport != "" && report.SuiteConfig.TraceSuiteSpan == "" {
+			report.SuiteConfig.TraceSuiteSpan, report.SuiteConfig.TraceParentSpan = types.NewSuiteSpan(os.Getenv("TRACEPARENT"))
+		}
 
 		for _, format := range reportFormats {
 			format.GenerateFunc(report, AbsPathForGeneratedAsset(format.ReportName, suite, cliConfig, 0))
@@ -144,6 +156,25 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 		}
 	}
 
+	// Merge the per-spec coverage maps unless we've been asked to keep them separate
+	if cliConfig.SpecCoverage != "" && !cliConfig.KeepSeparateReports {
+		coverageMaps := []string{}
+		for _, suite := range suitesWithProfiles {
+			if coverageMap := AbsPathForGeneratedAsset(cliConfig.SpecCoverage, suite, cliConfig, 0); FileExists(coverageMap) {
+				coverageMaps = append(coverageMaps, coverageMap)
+			}
+		}
+		dst := cliConfig.SpecCoverage
+		if cliConfig.OutputDir != "" {
+			dst = filepath.Join(cliConfig.OutputDir, cliConfig.SpecCoverage)
+		}
+		mergeMessages, err := MergeAndCleanupSpecCoverage(coverageMaps, dst)
+		messages = append(messages, mergeMessages...)
+		if err != nil {
+			return messages, err
+		}
+	}
+
 	return messages, nil
 }
 
@@ -0,0 +1,318 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var errCoordinatorGone = errors.New("the coordinator is gone")
+var errTokenRejected = errors.New("the coordinator rejected the worker's token")
+
+type remoteWorker struct {
+	coordinator string
+	token       string
+	host        string
+	dir         string
+	scratch     string
+}
+
+/*
+RunRemoteWorker connects to the coordinator at coordinatorAddress (see RemoteWorkerCoordinator), authenticating with token, and runs the parallel processes it is assigned until the coordinator goes away.
+
+Suite paths are resolved relative to dir - which should be a checkout of the code the coordinator is running - so that specs that rely on files in their package's directory behave as they would locally.
+*/
+func RunRemoteWorker(coordinatorAddress string, token string, dir string) error {
+	if token == "" {
+		return errors.New("remote workers require the coordinator's token")
+	}
+	host, _, err := net.SplitHostPort(coordinatorAddress)
+	if err != nil {
+		return err
+	}
+	scratch, err := os.MkdirTemp("", "ginkgo-worker")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(scratch)
+
+	worker := &remoteWorker{
+		coordinator: "http://" + coordinatorAddress,
+		token:       token,
+		host:        host,
+		dir:         dir,
+		scratch:     scratch,
+	}
+
+	connected := false
+	deadline := time.Now().Add(REMOTE_WORKER_CONNECT_TIMEOUT)
+	lastID := 0
+	for {
+		assignment, err := worker.fetchAssignment(lastID)
+		if err == errCoordinatorGone {
+			fmt.Println("The coordinator has finished, exiting")
+			return nil
+		}
+		if err == errTokenRejected {
+			return err
+		}
+		if err != nil {
+			if connected {
+				fmt.Println("Lost contact with the coordinator, exiting")
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("could not reach the coordinator at %s: %w", coordinatorAddress, err)
+			}
+			time.Sleep(REMOTE_WORKER_POLLING_INTERVAL)
+			continue
+		}
+		if !connected {
+			fmt.Printf("Connected to the coordinator at %s\n", coordinatorAddress)
+			connected = true
+		}
+		if assignment == nil {
+			time.Sleep(REMOTE_WORKER_POLLING_INTERVAL)
+			continue
+		}
+		lastID = assignment.ID
+		err = worker.run(*assignment)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to run %s as parallel process %d:\n%s\n", assignment.PackageName, assignment.Proc, err.Error())
+			worker.reportExit(*assignment, RemoteWorkerExit{ExitStatus: 1, ExitResult: err.Error()})
+		}
+	}
+}
+
+// fetchAssignment returns nil if there is nothing for the worker to do yet
+func (w *remoteWorker) fetchAssignment(after int) (*RemoteWorkerAssignment, error) {
+	resp, err := w.do(http.MethodGet, w.coordinator+"/assignment?after="+strconv.Itoa(after), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, errTokenRejected
+	case http.StatusGone:
+		return nil, errCoordinatorGone
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusOK:
+		assignment := &RemoteWorkerAssignment{}
+		return assignment, json.NewDecoder(resp.Body).Decode(assignment)
+	default:
+		return nil, fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+}
+
+func (w *remoteWorker) suiteURL(assignment RemoteWorkerAssignment, path string) string {
+	return fmt.Sprintf("%s/suites/%d%s", w.coordinator, assignment.ID, path)
+}
+
+func (w *remoteWorker) procURL(assignment RemoteWorkerAssignment, path string) string {
+	return w.suiteURL(assignment, fmt.Sprintf("/procs/%d%s", assignment.Proc, path))
+}
+
+// do sends a request, carrying the worker's token, to the coordinator
+func (w *remoteWorker) do(method string, url string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+w.token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func (w *remoteWorker) post(url string, contentType string, body io.Reader) error {
+	resp, err := w.do(http.MethodPost, url, contentType, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// download returns false if the coordinator does not have the requested file
+func (w *remoteWorker) download(url string, path string, mode os.FileMode) (bool, error) {
+	resp, err := w.do(http.MethodGet, url, "", nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(f, resp.Body)
+	if err != nil {
+		f.Close()
+		return false, err
+	}
+	return true, f.Close()
+}
+
+func (w *remoteWorker) upload(url string, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	resp, err := w.do(http.MethodPut, url, "", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *remoteWorker) reportExit(assignment RemoteWorkerAssignment, exit RemoteWorkerExit) error {
+	data, err := json.Marshal(exit)
+	if err != nil {
+		return err
+	}
+	return w.post(w.procURL(assignment, "/exit"), "application/json", bytes.NewReader(data))
+}
+
+// heartbeat lets the coordinator know the worker is still around.  If the coordinator can't be reached for longer than REMOTE_WORKER_HEARTBEAT_TIMEOUT the running process is killed.
+func (w *remoteWorker) heartbeat(assignment RemoteWorkerAssignment, cmd *exec.Cmd, stop chan any) {
+	ticker := time.NewTicker(REMOTE_WORKER_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+	lastContact := time.Now()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if w.post(w.procURL(assignment, "/heartbeat"), "text/plain", nil) == nil {
+				lastContact = time.Now()
+			} else if time.Since(lastContact) > REMOTE_WORKER_HEARTBEAT_TIMEOUT {
+				fmt.Fprintln(os.Stderr, "Lost contact with the coordinator, stopping the running process")
+				cmd.Process.Kill()
+				return
+			}
+		}
+	}
+}
+
+func (w *remoteWorker) run(assignment RemoteWorkerAssignment) error {
+	if assignment.GOOS != runtime.GOOS || assignment.GOARCH != runtime.GOARCH {
+		return fmt.Errorf("the test binary is compiled for %s/%s but this worker runs on %s/%s - set GOOS and GOARCH when running the coordinator to compile for the workers' platform", assignment.GOOS, assignment.GOARCH, runtime.GOOS, runtime.GOARCH)
+	}
+
+	dir := filepath.Join(w.scratch, strconv.Itoa(assignment.ID))
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	pathToCompiledTest := filepath.Join(dir, "suite.test")
+	if _, err := w.download(w.suiteURL(assignment, "/binary"), pathToCompiledTest, 0777); err != nil {
+		return fmt.Errorf("failed to download the test binary: %w", err)
+	}
+
+	suiteConfig, reporterConfig := assignment.SuiteConfig, assignment.ReporterConfig
+	for name, path := range remoteWorkerInputFiles(&suiteConfig) {
+		if *path == "" {
+			continue
+		}
+		*path = filepath.Join(dir, name)
+		if _, err := w.download(w.suiteURL(assignment, "/files/"+name), *path, 0666); err != nil {
+			return fmt.Errorf("failed to download %s: %w", name, err)
+		}
+	}
+	outputFiles := remoteWorkerOutputFiles(&suiteConfig, &reporterConfig)
+	for name, path := range outputFiles {
+		if *path != "" {
+			*path = filepath.Join(dir, name)
+		}
+	}
+
+	suiteConfig.ParallelProcess, suiteConfig.ParallelTotal = assignment.Proc, assignment.ParallelTotal
+	suiteConfig.ParallelHost = "http://" + net.JoinHostPort(w.host, assignment.ServerPort)
+	args, err := types.GenerateGinkgoTestRunArgs(suiteConfig, reporterConfig, assignment.GoFlagsConfig)
+	if err != nil {
+		return err
+	}
+	args = append([]string{"--test.timeout=0"}, args...)
+	args = append(args, assignment.AdditionalArgs...)
+
+	suitePath := assignment.SuitePath
+	if !filepath.IsAbs(suitePath) {
+		suitePath = filepath.Join(w.dir, suitePath)
+	}
+	if _, err := os.Stat(suitePath); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not find %s - running %s from a scratch directory instead\n", suitePath, assignment.PackageName)
+		suitePath = dir
+	}
+
+	fmt.Printf("Running %s as parallel process %d of %d\n", assignment.PackageName, assignment.Proc, assignment.ParallelTotal)
+	outputReader, outputWriter := io.Pipe()
+	cmd := exec.Command(pathToCompiledTest, args...)
+	cmd.Dir = suitePath
+	cmd.Env = append(os.Environ(), "GINKGO_PARALLEL_PROTOCOL=HTTP", parallel_support.PARALLEL_TOKEN_ENV_VAR+"="+w.token)
+	cmd.Stdout = outputWriter
+	cmd.Stderr = outputWriter
+
+	outputStreamed := make(chan any)
+	go func() {
+		if w.post(w.procURL(assignment, "/output"), "text/plain", outputReader) != nil {
+			io.Copy(io.Discard, outputReader)
+		}
+		close(outputStreamed)
+	}()
+
+	if err := cmd.Start(); err != nil {
+		outputWriter.Close()
+		<-outputStreamed
+		return err
+	}
+	stopHeartbeat := make(chan any)
+	go w.heartbeat(assignment, cmd, stopHeartbeat)
+	cmd.Wait()
+	close(stopHeartbeat)
+	outputWriter.Close()
+	<-outputStreamed
+
+	for name, path := range outputFiles {
+		if *path == "" || !FileExists(*path) {
+			continue
+		}
+		if err := w.upload(w.suiteURL(assignment, "/files/"+name), *path); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to upload %s:\n%s\n", name, err.Error())
+		}
+	}
+
+	fmt.Printf("Parallel process %d of %s finished: %s\n", assignment.Proc, assignment.PackageName, cmd.ProcessState.String())
+	return w.reportExit(assignment, RemoteWorkerExit{
+		ExitStatus: cmd.ProcessState.ExitCode(),
+		ExitResult: cmd.ProcessState.String(),
+	})
+}
@@ -0,0 +1,542 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+Remote workers allow the Ginkgo CLI to spread a suite's parallel processes across multiple machines.
+
+When run with --remote-workers=N the CLI acts as a coordinator.  For each suite it starts a parallel support server (just as it does when running with -procs=N) and publishes an assignment that N workers (started with `ginkgo worker`) claim - one parallel process per worker.  Each worker downloads the compiled test binary and any input files, runs the binary (which talks to the parallel support server directly, over HTTP), and streams the binary's output back to the coordinator.  When the binary exits the worker uploads any reports it generated and then reports the exit status.
+
+Workers send heartbeats while they run so that the coordinator can tell when a worker has disappeared.  Parallel processes that no worker claims within the CLIConfig.RemoteWorkerTimeout fail the suite.
+
+The coordinator hands out the test binary and its input files and accepts the reports workers upload, and the parallel support server steers the suite, so every request to either must carry the coordinator's token (see RemoteWorkerToken).  Workers refuse to run test binaries compiled for a platform other than their own.
+*/
+
+var REMOTE_WORKER_POLLING_INTERVAL = 100 * time.Millisecond
+var REMOTE_WORKER_HEARTBEAT_INTERVAL = time.Second
+var REMOTE_WORKER_HEARTBEAT_TIMEOUT = 10 * time.Second
+var REMOTE_WORKER_CONNECT_TIMEOUT = time.Minute
+
+const REMOTE_WORKER_LOST_CONTACT = "lost contact with remote worker"
+
+// REMOTE_WORKER_TOKEN_ENV_VAR can be used to share the token between the coordinator and its workers without passing it on the command line
+const REMOTE_WORKER_TOKEN_ENV_VAR = "GINKGO_REMOTE_WORKER_TOKEN"
+
+/*
+RemoteWorkerToken returns the token remote workers must present to the coordinator: the token passed in, if any, then $GINKGO_REMOTE_WORKER_TOKEN.  If neither is set a random token is generated and generated is true.
+*/
+func RemoteWorkerToken(token string) (string, bool, error) {
+	if token != "" {
+		return token, false, nil
+	}
+	if token = os.Getenv(REMOTE_WORKER_TOKEN_ENV_VAR); token != "" {
+		return token, false, nil
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", false, err
+	}
+	return hex.EncodeToString(b), true, nil
+}
+
+// RemoteWorkerAssignment is handed to a remote worker and describes the parallel process it should run
+type RemoteWorkerAssignment struct {
+	ID            int
+	Proc          int
+	ParallelTotal int
+
+	// ServerPort is the port of the parallel support server on the coordinator's host.  The server speaks HTTP and requires the coordinator's token.
+	ServerPort string
+
+	// GOOS and GOARCH identify the platform the test binary was compiled for
+	GOOS   string
+	GOARCH string
+
+	SuitePath      string
+	PackageName    string
+	SuiteConfig    types.SuiteConfig
+	ReporterConfig types.ReporterConfig
+	GoFlagsConfig  types.GoFlagsConfig
+	AdditionalArgs []string
+}
+
+// RemoteWorkerExit is sent by a remote worker once its parallel process has exited
+type RemoteWorkerExit struct {
+	ExitStatus int
+	ExitResult string
+}
+
+// remoteWorkerInputFiles returns the configuration that points at files the test binary reads.  These are downloaded from the coordinator.
+func remoteWorkerInputFiles(suiteConfig *types.SuiteConfig) map[string]*string {
+	return map[string]*string{
+		"spec-timings":    &suiteConfig.SpecTimings,
+		"rerun-failed":    &suiteConfig.RerunFailed,
+		"impact-filter":   &suiteConfig.ImpactFilter,
+		"quarantine-file": &suiteConfig.QuarantineFile,
+	}
+}
+
+// remoteWorkerOutputFiles returns the configuration that points at files the test binary writes.  These are uploaded to the coordinator.
+func remoteWorkerOutputFiles(suiteConfig *types.SuiteConfig, reporterConfig *types.ReporterConfig) map[string]*string {
+	return map[string]*string{
+		"json-report":     &reporterConfig.JSONReport,
+		"gojson-report":   &reporterConfig.GoJSONReport,
+		"junit-report":    &reporterConfig.JUnitReport,
+		"teamcity-report": &reporterConfig.TeamcityReport,
+		"html-report":     &reporterConfig.HTMLReport,
+		"markdown-report": &reporterConfig.MarkdownReport,
+		"trace-export":    &reporterConfig.TraceExport,
+		"spec-timings":    &suiteConfig.SpecTimings,
+	}
+}
+
+type remoteProcResult struct {
+	proc                 int
+	exitResult           string
+	passed               bool
+	hasProgrammaticFocus bool
+}
+
+type remoteProc struct {
+	claimed       bool
+	finished      bool
+	lastHeartbeat time.Time
+	output        *bytes.Buffer
+}
+
+type remoteSuite struct {
+	assignment         RemoteWorkerAssignment
+	claimDeadline      time.Time
+	pathToCompiledTest string
+	inputFiles         map[string]string
+	outputFiles        map[string]string
+	procs              []*remoteProc
+	results            chan remoteProcResult
+	stop               chan any
+}
+
+// RemoteWorkerCoordinator hands suites out to remote workers.  It is long-lived and serves all the suites in a ginkgo run.
+type RemoteWorkerCoordinator struct {
+	listener net.Listener
+	host     string
+	token    string
+	goos     string
+	goarch   string
+	lock     *sync.Mutex
+	suite    *remoteSuite
+	nextID   int
+	done     bool
+}
+
+// NewRemoteWorkerCoordinator returns a coordinator listening for remote workers on address.  Workers must present token with every request.
+func NewRemoteWorkerCoordinator(address string, token string) (*RemoteWorkerCoordinator, error) {
+	if token == "" {
+		return nil, errors.New("remote workers require a token")
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	goos, goarch, err := targetPlatform()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	coordinator := &RemoteWorkerCoordinator{
+		listener: listener,
+		host:     host,
+		token:    token,
+		goos:     goos,
+		goarch:   goarch,
+		lock:     &sync.Mutex{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /assignment", coordinator.handleAssignment)
+	mux.HandleFunc("GET /suites/{id}/binary", coordinator.handleBinary)
+	mux.HandleFunc("GET /suites/{id}/files/{name}", coordinator.handleDownloadFile)
+	mux.HandleFunc("PUT /suites/{id}/files/{name}", coordinator.handleUploadFile)
+	mux.HandleFunc("POST /suites/{id}/procs/{proc}/output", coordinator.handleOutput)
+	mux.HandleFunc("POST /suites/{id}/procs/{proc}/heartbeat", coordinator.handleHeartbeat)
+	mux.HandleFunc("POST /suites/{id}/procs/{proc}/exit", coordinator.handleExit)
+	go http.Serve(listener, coordinator.authenticate(mux))
+
+	return coordinator, nil
+}
+
+// targetPlatform returns the GOOS and GOARCH the go toolchain compiles test binaries for
+func targetPlatform() (string, string, error) {
+	output, err := exec.Command("go", "env", "GOOS", "GOARCH").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to determine the platform test binaries are compiled for:\n%w", err)
+	}
+	platform := strings.Fields(string(output))
+	if len(platform) != 2 {
+		return "", "", fmt.Errorf("failed to determine the platform test binaries are compiled for from 'go env GOOS GOARCH':\n%s", output)
+	}
+	return platform[0], platform[1], nil
+}
+
+// authenticate rejects requests that don't carry the coordinator's token
+func (c *RemoteWorkerCoordinator) authenticate(handler http.Handler) http.Handler {
+	expected := []byte("Bearer " + c.token)
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(request.Header.Get("Authorization")), expected) != 1 {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+// Address returns the address the coordinator is listening on
+func (c *RemoteWorkerCoordinator) Address() string {
+	return c.listener.Addr().String()
+}
+
+// Close tells any connected workers that the run is over and stops listening
+func (c *RemoteWorkerCoordinator) Close() {
+	c.lock.Lock()
+	c.done = true
+	c.lock.Unlock()
+	c.listener.Close()
+}
+
+func (c *RemoteWorkerCoordinator) publish(suite TestSuite, serverAddress string, numProcs int, claimTimeout time.Duration, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) *remoteSuite {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.nextID += 1
+	_, serverPort, _ := net.SplitHostPort(strings.TrimPrefix(serverAddress, "http://"))
+	rs := &remoteSuite{
+		assignment: RemoteWorkerAssignment{
+			ID:             c.nextID,
+			ParallelTotal:  numProcs,
+			ServerPort:     serverPort,
+			GOOS:           c.goos,
+			GOARCH:         c.goarch,
+			SuitePath:      suite.Path,
+			PackageName:    suite.PackageName,
+			SuiteConfig:    ginkgoConfig,
+			ReporterConfig: reporterConfig,
+			GoFlagsConfig:  goFlagsConfig,
+			AdditionalArgs: additionalArgs,
+		},
+		claimDeadline:      time.Now().Add(claimTimeout),
+		pathToCompiledTest: suite.PathToCompiledTest,
+		inputFiles:         map[string]string{},
+		outputFiles:        map[string]string{},
+		results:            make(chan remoteProcResult, numProcs),
+		stop:               make(chan any),
+	}
+	for name, path := range remoteWorkerInputFiles(&ginkgoConfig) {
+		if *path != "" {
+			rs.inputFiles[name] = *path
+		}
+	}
+	for name, path := range remoteWorkerOutputFiles(&ginkgoConfig, &reporterConfig) {
+		if *path != "" {
+			rs.outputFiles[name] = *path
+		}
+	}
+	for proc := 1; proc <= numProcs; proc++ {
+		rs.procs = append(rs.procs, &remoteProc{output: &bytes.Buffer{}})
+	}
+	c.suite = rs
+	go c.monitorHeartbeats(rs)
+	return rs
+}
+
+func (c *RemoteWorkerCoordinator) retire(rs *remoteSuite) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	close(rs.stop)
+	if c.suite == rs {
+		c.suite = nil
+	}
+}
+
+// procIsAlive is registered with the parallel support server.  Procs that have yet to be claimed by a worker are considered alive - they simply haven't started yet.
+func (c *RemoteWorkerCoordinator) procIsAlive(rs *remoteSuite, proc int) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	p := rs.procs[proc-1]
+	if p.finished {
+		return false
+	}
+	return !p.claimed || time.Since(p.lastHeartbeat) <= REMOTE_WORKER_HEARTBEAT_TIMEOUT
+}
+
+func (c *RemoteWorkerCoordinator) monitorHeartbeats(rs *remoteSuite) {
+	ticker := time.NewTicker(REMOTE_WORKER_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rs.stop:
+			return
+		case <-ticker.C:
+			c.lock.Lock()
+			for i, p := range rs.procs {
+				if p.claimed && !p.finished && time.Since(p.lastHeartbeat) > REMOTE_WORKER_HEARTBEAT_TIMEOUT {
+					c.finish(rs, i+1, remoteProcResult{exitResult: REMOTE_WORKER_LOST_CONTACT})
+				}
+				if !p.claimed && !p.finished && time.Now().After(rs.claimDeadline) {
+					c.finish(rs, i+1, remoteProcResult{exitResult: "no remote worker picked up this parallel process in time"})
+				}
+			}
+			c.lock.Unlock()
+		}
+	}
+}
+
+// finish must be called with the lock held
+func (c *RemoteWorkerCoordinator) finish(rs *remoteSuite, proc int, result remoteProcResult) {
+	p := rs.procs[proc-1]
+	if p.finished {
+		return
+	}
+	p.finished = true
+	result.proc = proc
+	rs.results <- result
+}
+
+// lookup returns the suite and proc addressed by the request.  Requests for suites that are no longer running are rejected.
+func (c *RemoteWorkerCoordinator) lookup(writer http.ResponseWriter, request *http.Request) (*remoteSuite, *remoteProc, int, bool) {
+	id, _ := strconv.Atoi(request.PathValue("id"))
+	if c.suite == nil || c.suite.assignment.ID != id {
+		writer.WriteHeader(http.StatusGone)
+		return nil, nil, 0, false
+	}
+	if request.PathValue("proc") == "" {
+		return c.suite, nil, 0, true
+	}
+	proc, _ := strconv.Atoi(request.PathValue("proc"))
+	if proc < 1 || proc > len(c.suite.procs) {
+		writer.WriteHeader(http.StatusNotFound)
+		return nil, nil, 0, false
+	}
+	return c.suite, c.suite.procs[proc-1], proc, true
+}
+
+func (c *RemoteWorkerCoordinator) handleAssignment(writer http.ResponseWriter, request *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.done {
+		writer.WriteHeader(http.StatusGone)
+		return
+	}
+	after, _ := strconv.Atoi(request.URL.Query().Get("after"))
+	if c.suite == nil || c.suite.assignment.ID <= after {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	for i, p := range c.suite.procs {
+		if !p.claimed && !p.finished {
+			p.claimed = true
+			p.lastHeartbeat = time.Now()
+			assignment := c.suite.assignment
+			assignment.Proc = i + 1
+			json.NewEncoder(writer).Encode(assignment)
+			return
+		}
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (c *RemoteWorkerCoordinator) handleBinary(writer http.ResponseWriter, request *http.Request) {
+	c.lock.Lock()
+	rs, _, _, ok := c.lookup(writer, request)
+	c.lock.Unlock()
+	if !ok {
+		return
+	}
+	http.ServeFile(writer, request, rs.pathToCompiledTest)
+}
+
+func (c *RemoteWorkerCoordinator) handleDownloadFile(writer http.ResponseWriter, request *http.Request) {
+	c.lock.Lock()
+	rs, _, _, ok := c.lookup(writer, request)
+	c.lock.Unlock()
+	if !ok {
+		return
+	}
+	path, ok := rs.inputFiles[request.PathValue("name")]
+	if !ok || !FileExists(path) {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	http.ServeFile(writer, request, path)
+}
+
+func (c *RemoteWorkerCoordinator) handleUploadFile(writer http.ResponseWriter, request *http.Request) {
+	c.lock.Lock()
+	rs, _, _, ok := c.lookup(writer, request)
+	c.lock.Unlock()
+	if !ok {
+		return
+	}
+	defer request.Body.Close()
+	path, ok := rs.outputFiles[request.PathValue("name")]
+	if !ok {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, err := io.ReadAll(request.Body)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0777)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0666)
+	}
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(os.Stderr, "Failed to save %s uploaded by remote worker:\n%s\n", path, err.Error())
+	}
+}
+
+func (c *RemoteWorkerCoordinator) handleOutput(writer http.ResponseWriter, request *http.Request) {
+	c.lock.Lock()
+	_, p, _, ok := c.lookup(writer, request)
+	c.lock.Unlock()
+	if !ok {
+		return
+	}
+	defer request.Body.Close()
+	buf := make([]byte, 4096)
+	for {
+		n, err := request.Body.Read(buf)
+		if n > 0 {
+			c.lock.Lock()
+			p.output.Write(buf[:n])
+			c.lock.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *RemoteWorkerCoordinator) handleHeartbeat(writer http.ResponseWriter, request *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, p, _, ok := c.lookup(writer, request)
+	if !ok {
+		return
+	}
+	if p.finished {
+		writer.WriteHeader(http.StatusGone)
+		return
+	}
+	p.lastHeartbeat = time.Now()
+}
+
+func (c *RemoteWorkerCoordinator) handleExit(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+	var exit RemoteWorkerExit
+	if json.NewDecoder(request.Body).Decode(&exit) != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	rs, _, proc, ok := c.lookup(writer, request)
+	if !ok {
+		return
+	}
+	c.finish(rs, proc, remoteProcResult{
+		exitResult:           exit.ExitResult,
+		passed:               (exit.ExitStatus == 0) || (exit.ExitStatus == types.GINKGO_FOCUS_EXIT_CODE),
+		hasProgrammaticFocus: exit.ExitStatus == types.GINKGO_FOCUS_EXIT_CODE,
+	})
+}
+
+// RunCompiledSuiteOnRemoteWorkers runs the suite's parallel processes on the remote workers connected to coordinator.  Suites that don't use Ginkgo are run locally.
+func RunCompiledSuiteOnRemoteWorkers(coordinator *RemoteWorkerCoordinator, suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) TestSuite {
+	if !suite.IsGinkgo {
+		return RunCompiledSuite(suite, ginkgoConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
+	}
+
+	suite.State = TestSuiteStateFailed
+	suite.HasProgrammaticFocus = false
+
+	if suite.PathToCompiledTest == "" {
+		return suite
+	}
+
+	suite = runRemote(coordinator, suite, ginkgoConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
+	runAfterRunHook(cliConfig.AfterRunHook, reporterConfig.NoColor, suite)
+	return suite
+}
+
+func runRemote(coordinator *RemoteWorkerCoordinator, suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) TestSuite {
+	numProcs := cliConfig.RemoteWorkers
+
+	server, err := parallel_support.NewServerListeningOn(net.JoinHostPort(coordinator.host, "0"), coordinator.token, numProcs, reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut))
+	command.AbortIfError("Failed to start parallel spec server", err)
+	server.SetEventStream(eventStream)
+	server.Start()
+	defer server.Close()
+
+	ginkgoConfig, reporterConfig = absPathsForGeneratedReports(suite, ginkgoConfig, reporterConfig, cliConfig)
+	ginkgoConfig, reporterConfig, lastRunReport := configureRerunFailed(suite, ginkgoConfig, reporterConfig, cliConfig)
+	ginkgoConfig = absPathsForSuiteInputs(ginkgoConfig)
+
+	rs := coordinator.publish(suite, server.Address(), numProcs, cliConfig.RemoteWorkerTimeout, ginkgoConfig, reporterConfig, goFlagsConfig, additionalArgs)
+	defer coordinator.retire(rs)
+	for proc := 1; proc <= numProcs; proc++ {
+		server.RegisterAlive(proc, func() bool { return coordinator.procIsAlive(rs, proc) })
+	}
+
+	procExitResult := make([]string, numProcs)
+	passed := true
+	for i := 0; i < numProcs; i++ {
+		result := <-rs.results
+		passed = passed && result.passed
+		suite.HasProgrammaticFocus = suite.HasProgrammaticFocus || result.hasProgrammaticFocus
+		procExitResult[result.proc-1] = result.exitResult
+	}
+	if passed {
+		suite.State = TestSuiteStatePassed
+	} else {
+		suite.State = TestSuiteStateFailed
+	}
+
+	procOutput := make([]*bytes.Buffer, numProcs)
+	coordinator.lock.Lock()
+	for i, p := range rs.procs {
+		procOutput[i] = bytes.NewBuffer(bytes.Clone(p.output.Bytes()))
+	}
+	coordinator.lock.Unlock()
+
+	suite = awaitParallelSuiteReports(suite, server, procOutput, procExitResult, cliConfig)
+	cacheLastRunReport(reporterConfig.JSONReport, lastRunReport)
+
+	return suite
+}
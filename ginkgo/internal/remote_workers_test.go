@@ -0,0 +1,114 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteWorkerCoordinator", func() {
+	var coordinator *internal.RemoteWorkerCoordinator
+
+	BeforeEach(func() {
+		var err error
+		coordinator, err = internal.NewRemoteWorkerCoordinator("127.0.0.1:0", "s3cret")
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(coordinator.Close)
+	})
+
+	get := func(path string, token string) int {
+		req, err := http.NewRequest(http.MethodGet, "http://"+coordinator.Address()+path, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Ω(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	It("tells workers to wait when there is nothing to run", func() {
+		Ω(get("/assignment?after=0", "s3cret")).Should(Equal(http.StatusNoContent))
+	})
+
+	It("rejects requests for suites that are not running", func() {
+		Ω(get("/suites/1/binary", "s3cret")).Should(Equal(http.StatusGone))
+	})
+
+	It("rejects requests that don't carry the token", func() {
+		Ω(get("/assignment?after=0", "")).Should(Equal(http.StatusUnauthorized))
+		Ω(get("/assignment?after=0", "guess")).Should(Equal(http.StatusUnauthorized))
+		Ω(get("/suites/1/binary", "")).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("requires a token", func() {
+		_, err := internal.NewRemoteWorkerCoordinator("127.0.0.1:0", "")
+		Ω(err).Should(HaveOccurred())
+		Ω(internal.RunRemoteWorker(coordinator.Address(), "", ".")).Should(HaveOccurred())
+	})
+
+	It("fails workers that present the wrong token", func() {
+		Ω(internal.RunRemoteWorker(coordinator.Address(), "guess", ".")).Should(MatchError(ContainSubstring("the coordinator rejected the worker's token")))
+	})
+
+	It("fails workers given a malformed coordinator address", func() {
+		Ω(internal.RunRemoteWorker("127.0.0.1", "s3cret", ".")).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("RunRemoteWorker", func() {
+	It("refuses to run test binaries compiled for another platform", func() {
+		exits := make(chan internal.RemoteWorkerExit, 1)
+		assigned := false
+		coordinator := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/assignment":
+				if assigned {
+					writer.WriteHeader(http.StatusGone)
+					return
+				}
+				assigned = true
+				json.NewEncoder(writer).Encode(internal.RemoteWorkerAssignment{ID: 1, Proc: 1, ParallelTotal: 1, PackageName: "foo", GOOS: "plan9", GOARCH: "mips"})
+			case "/suites/1/procs/1/exit":
+				var exit internal.RemoteWorkerExit
+				json.NewDecoder(request.Body).Decode(&exit)
+				exits <- exit
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(coordinator.Close)
+
+		Ω(internal.RunRemoteWorker(strings.TrimPrefix(coordinator.URL, "http://"), "s3cret", ".")).Should(Succeed())
+		var exit internal.RemoteWorkerExit
+		Eventually(exits).Should(Receive(&exit))
+		Ω(exit.ExitStatus).Should(Equal(1))
+		Ω(exit.ExitResult).Should(ContainSubstring("the test binary is compiled for plan9/mips but this worker runs on %s/%s", runtime.GOOS, runtime.GOARCH))
+	})
+})
+
+var _ = Describe("RemoteWorkerToken", func() {
+	It("prefers the token passed in, then $GINKGO_REMOTE_WORKER_TOKEN, and otherwise generates one", func() {
+		GinkgoT().Setenv(internal.REMOTE_WORKER_TOKEN_ENV_VAR, "from-env")
+		Ω(internal.RemoteWorkerToken("passed")).Should(Equal("passed"))
+		token, generated, err := internal.RemoteWorkerToken("")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(token).Should(Equal("from-env"))
+		Ω(generated).Should(BeFalse())
+
+		GinkgoT().Setenv(internal.REMOTE_WORKER_TOKEN_ENV_VAR, "")
+		token, generated, err = internal.RemoteWorkerToken("")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(token).Should(HaveLen(32))
+		Ω(generated).Should(BeTrue())
+		other, _, _ := internal.RemoteWorkerToken("")
+		Ω(other).ShouldNot(Equal(token))
+	})
+})
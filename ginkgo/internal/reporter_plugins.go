@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// reporterPluginExitTimeout is how long Ginkgo waits for a reporter plugin to exit once the run is over
+const reporterPluginExitTimeout = time.Minute
+
+/*
+reporterPlugin is an external reporter passed to --reporter.  Ginkgo launches the plugin before running any suites and writes the event stream (see types.StreamEvent) to its stdin.  The plugin's stdout and stderr are forwarded to Ginkgo's.
+
+Plugins can't affect the outcome of the run.  Events are queued and handed to the plugin in the background so that a slow plugin doesn't hold up the suites, and Write never returns an error: if the plugin fails (e.g. it exits early) reporterPlugin records the failure, stops sending it events, and reports the failure when it is closed.
+*/
+type reporterPlugin struct {
+	path  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	done  chan any
+
+	lock   *sync.Mutex
+	cond   *sync.Cond
+	queue  [][]byte
+	closed bool
+	err    error
+}
+
+func startReporterPlugin(path string) (*reporterPlugin, error) {
+	cmd := exec.Command(path)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	plugin := &reporterPlugin{
+		path:  path,
+		cmd:   cmd,
+		stdin: stdin,
+		done:  make(chan any),
+		lock:  &sync.Mutex{},
+	}
+	plugin.cond = sync.NewCond(plugin.lock)
+	go plugin.feed()
+	return plugin, nil
+}
+
+// feed writes the queued events to the plugin's stdin until the plugin is closed or fails
+func (plugin *reporterPlugin) feed() {
+	defer close(plugin.done)
+	defer plugin.stdin.Close()
+	for {
+		plugin.lock.Lock()
+		for len(plugin.queue) == 0 && !plugin.closed && plugin.err == nil {
+			plugin.cond.Wait()
+		}
+		if len(plugin.queue) == 0 || plugin.err != nil {
+			plugin.lock.Unlock()
+			return
+		}
+		event := plugin.queue[0]
+		plugin.queue = plugin.queue[1:]
+		plugin.lock.Unlock()
+
+		if _, err := plugin.stdin.Write(event); err != nil {
+			plugin.fail(fmt.Errorf("failed to send events to the reporter: %w", err))
+		}
+	}
+}
+
+func (plugin *reporterPlugin) Write(p []byte) (int, error) {
+	plugin.lock.Lock()
+	defer plugin.lock.Unlock()
+	if plugin.err == nil && !plugin.closed {
+		plugin.queue = append(plugin.queue, append([]byte{}, p...))
+		plugin.cond.Signal()
+	}
+	return len(p), nil
+}
+
+// Close waits for the plugin to receive the queued events and exit.  It returns the reason the plugin failed, if it did.
+func (plugin *reporterPlugin) Close() error {
+	plugin.lock.Lock()
+	plugin.closed = true
+	plugin.cond.Signal()
+	plugin.lock.Unlock()
+
+	exited := make(chan error, 1)
+	go func() {
+		<-plugin.done
+		exited <- plugin.cmd.Wait()
+	}()
+	select {
+	case err := <-exited:
+		// if the plugin exited with an error, that's what caused any failure to send it events
+		if err != nil {
+			return err
+		}
+	case <-time.After(reporterPluginExitTimeout):
+		plugin.cmd.Process.Kill()
+		return fmt.Errorf("the reporter did not exit within %s of the end of the run", reporterPluginExitTimeout)
+	}
+
+	plugin.lock.Lock()
+	defer plugin.lock.Unlock()
+	return plugin.err
+}
+
+// fail records the first reason the plugin failed
+func (plugin *reporterPlugin) fail(err error) {
+	plugin.lock.Lock()
+	defer plugin.lock.Unlock()
+	if plugin.err == nil {
+		plugin.err = err
+		plugin.queue = nil
+	}
+}
@@ -28,6 +28,11 @@ func RunCompiledSuite(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterC
 		return suite
 	}
 
+	// every run of the suite gets its own span - all of the suite's parallel processes share it
+	if suite.IsGinkgo && reporterConfig.TraceExport != "" && ginkgoConfig.TraceSuiteSpan == "" {
+		ginkgoConfig.TraceSuiteSpan, ginkgoConfig.TraceParentSpan = types.NewSuiteSpan(os.Getenv("TRACEPARENT"))
+	}
+
 	if suite.IsGinkgo && cliConfig.ComputedProcs() > 1 {
 		suite = runParallel(suite, ginkgoConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
 	} else if suite.IsGinkgo {
@@ -105,17 +110,18 @@ func runSerial(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig t
 	if goFlagsConfig.MutexProfile != "" {
 		goFlagsConfig.MutexProfile = AbsPathForGeneratedAsset(goFlagsConfig.MutexProfile, suite, cliConfig, 0)
 	}
-	if reporterConfig.JSONReport != "" {
-		reporterConfig.JSONReport = AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
-	}
-	if reporterConfig.GoJSONReport != "" {
-		reporterConfig.GoJSONReport = AbsPathForGeneratedAsset(reporterConfig.GoJSONReport, suite, cliConfig, 0)
-	}
-	if reporterConfig.JUnitReport != "" {
-		reporterConfig.JUnitReport = AbsPathForGeneratedAsset(reporterConfig.JUnitReport, suite, cliConfig, 0)
-	}
-	if reporterConfig.TeamcityReport != "" {
-		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
+	ginkgoConfig, reporterConfig = absPathsForGeneratedReports(suite, ginkgoConfig, reporterConfig, cliConfig)
+	ginkgoConfig, reporterConfig, lastRunReport := configureRerunFailed(suite, ginkgoConfig, reporterConfig, cliConfig)
+	ginkgoConfig = absPathsForSuiteInputs(ginkgoConfig)
+
+	// serial suites don't otherwise talk to a parallel support server - but they need one to stream their events to the CLI's event stream
+	if eventStream != nil {
+		server, err := parallel_support.NewServer(1, reporters.NoopReporter{})
+		command.AbortIfError("Failed to start event stream server", err)
+		server.SetEventStream(eventStream)
+		server.Start()
+		defer server.Close()
+		ginkgoConfig.ParallelHost = server.Address()
 	}
 
 	args, err := types.GenerateGinkgoTestRunArgs(ginkgoConfig, reporterConfig, goFlagsConfig)
@@ -126,6 +132,7 @@ func runSerial(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig t
 	cmd, buf := buildAndStartCommand(suite, args, true)
 
 	cmd.Wait()
+	cacheLastRunReport(reporterConfig.JSONReport, lastRunReport)
 
 	exitStatus := cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
 	suite.HasProgrammaticFocus = (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
@@ -180,21 +187,13 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 
 	server, err := parallel_support.NewServer(numProcs, reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut))
 	command.AbortIfError("Failed to start parallel spec server", err)
+	server.SetEventStream(eventStream)
 	server.Start()
 	defer server.Close()
 
-	if reporterConfig.JSONReport != "" {
-		reporterConfig.JSONReport = AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
-	}
-	if reporterConfig.GoJSONReport != "" {
-		reporterConfig.GoJSONReport = AbsPathForGeneratedAsset(reporterConfig.GoJSONReport, suite, cliConfig, 0)
-	}
-	if reporterConfig.JUnitReport != "" {
-		reporterConfig.JUnitReport = AbsPathForGeneratedAsset(reporterConfig.JUnitReport, suite, cliConfig, 0)
-	}
-	if reporterConfig.TeamcityReport != "" {
-		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
-	}
+	ginkgoConfig, reporterConfig = absPathsForGeneratedReports(suite, ginkgoConfig, reporterConfig, cliConfig)
+	ginkgoConfig, reporterConfig, lastRunReport := configureRerunFailed(suite, ginkgoConfig, reporterConfig, cliConfig)
+	ginkgoConfig = absPathsForSuiteInputs(ginkgoConfig)
 
 	for proc := 1; proc <= numProcs; proc++ {
 		procGinkgoConfig := ginkgoConfig
@@ -258,33 +257,8 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 		suite.State = TestSuiteStateFailed
 	}
 
-	select {
-	case <-server.GetSuiteDone():
-		fmt.Println("")
-	case <-time.After(time.Second):
-		//one of the nodes never finished reporting to the server.  Something must have gone wrong.
-		fmt.Fprint(formatter.ColorableStdErr, formatter.F("\n{{bold}}{{red}}Ginkgo timed out waiting for all parallel procs to report back{{/}}\n"))
-		fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{gray}}Test suite:{{/}} %s (%s)\n\n", suite.PackageName, suite.Path))
-		fmt.Fprint(formatter.ColorableStdErr, formatter.Fiw(0, formatter.COLS, "This occurs if a parallel process exits before it reports its results to the Ginkgo CLI.  The CLI will now print out all the stdout/stderr output it's collected from the running processes.  However you may not see anything useful in these logs because the individual test processes usually intercept output to stdout/stderr in order to capture it in the spec reports.\n\nYou may want to try rerunning your test suite with {{light-gray}}--output-interceptor-mode=none{{/}} to see additional output here and debug your suite.\n"))
-		fmt.Fprintln(formatter.ColorableStdErr, "  ")
-		for proc := 1; proc <= cliConfig.ComputedProcs(); proc++ {
-			fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{bold}}Output from proc %d:{{/}}\n", proc))
-			fmt.Fprintln(os.Stderr, formatter.Fi(1, "%s", procOutput[proc-1].String()))
-			fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{bold}}Exit result of proc %d:{{/}}\n", proc))
-			fmt.Fprintln(os.Stderr, formatter.Fi(1, "%s\n", procExitResult[proc-1]))
-		}
-		fmt.Fprintf(os.Stderr, "** End **")
-	}
-
-	for proc := 1; proc <= cliConfig.ComputedProcs(); proc++ {
-		output := procOutput[proc-1].String()
-		if proc == 1 && checkForNoTestsWarning(procOutput[0]) && cliConfig.RequireSuite {
-			suite.State = TestSuiteStateFailed
-		}
-		if strings.Contains(output, "deprecated Ginkgo functionality") {
-			fmt.Fprintln(os.Stderr, output)
-		}
-	}
+	suite = awaitParallelSuiteReports(suite, server, procOutput, procExitResult, cliConfig)
+	cacheLastRunReport(reporterConfig.JSONReport, lastRunReport)
 
 	if len(coverProfiles) > 0 {
 		if suite.HasProgrammaticFocus {
@@ -343,6 +317,76 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 	return suite
 }
 
+// absPathsForGeneratedReports points the reports and files generated by the suite at their final destination (see AbsPathForGeneratedAsset)
+func absPathsForGeneratedReports(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig) (types.SuiteConfig, types.ReporterConfig) {
+	if reporterConfig.JSONReport != "" {
+		reporterConfig.JSONReport = AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
+	}
+	if reporterConfig.GoJSONReport != "" {
+		reporterConfig.GoJSONReport = AbsPathForGeneratedAsset(reporterConfig.GoJSONReport, suite, cliConfig, 0)
+	}
+	if reporterConfig.JUnitReport != "" {
+		reporterConfig.JUnitReport = AbsPathForGeneratedAsset(reporterConfig.JUnitReport, suite, cliConfig, 0)
+	}
+	if reporterConfig.TeamcityReport != "" {
+		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
+	}
+	if reporterConfig.HTMLReport != "" {
+		reporterConfig.HTMLReport = AbsPathForGeneratedAsset(reporterConfig.HTMLReport, suite, cliConfig, 0)
+	}
+	if reporterConfig.MarkdownReport != "" {
+		reporterConfig.MarkdownReport = AbsPathForGeneratedAsset(reporterConfig.MarkdownReport, suite, cliConfig, 0)
+	}
+	if reporterConfig.TraceExport != "" {
+		reporterConfig.TraceExport = AbsPathForGeneratedAsset(reporterConfig.TraceExport, suite, cliConfig, 0)
+	}
+	if ginkgoConfig.SpecTimings != "" {
+		ginkgoConfig.SpecTimings = AbsPathForGeneratedAsset(ginkgoConfig.SpecTimings, suite, cliConfig, 0)
+	}
+	return ginkgoConfig, reporterConfig
+}
+
+// absPathsForSuiteInputs resolves the files the suite reads relative to the current directory (the suite runs in its own directory)
+func absPathsForSuiteInputs(ginkgoConfig types.SuiteConfig) types.SuiteConfig {
+	if ginkgoConfig.QuarantineFile != "" {
+		ginkgoConfig.QuarantineFile, _ = filepath.Abs(ginkgoConfig.QuarantineFile)
+	}
+	return ginkgoConfig
+}
+
+// awaitParallelSuiteReports waits for the parallel procs to finish reporting to the server and surfaces their output if something went wrong
+func awaitParallelSuiteReports(suite TestSuite, server parallel_support.Server, procOutput []*bytes.Buffer, procExitResult []string, cliConfig types.CLIConfig) TestSuite {
+	numProcs := len(procOutput)
+	select {
+	case <-server.GetSuiteDone():
+		fmt.Println("")
+	case <-time.After(time.Second):
+		//one of the nodes never finished reporting to the server.  Something must have gone wrong.
+		fmt.Fprint(formatter.ColorableStdErr, formatter.F("\n{{bold}}{{red}}Ginkgo timed out waiting for all parallel procs to report back{{/}}\n"))
+		fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{gray}}Test suite:{{/}} %s (%s)\n\n", suite.PackageName, suite.Path))
+		fmt.Fprint(formatter.ColorableStdErr, formatter.Fiw(0, formatter.COLS, "This occurs if a parallel process exits before it reports its results to the Ginkgo CLI.  The CLI will now print out all the stdout/stderr output it's collected from the running processes.  However you may not see anything useful in these logs because the individual test processes usually intercept output to stdout/stderr in order to capture it in the spec reports.\n\nYou may want to try rerunning your test suite with {{light-gray}}--output-interceptor-mode=none{{/}} to see additional output here and debug your suite.\n"))
+		fmt.Fprintln(formatter.ColorableStdErr, "  ")
+		for proc := 1; proc <= numProcs; proc++ {
+			fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{bold}}Output from proc %d:{{/}}\n", proc))
+			fmt.Fprintln(os.Stderr, formatter.Fi(1, "%s", procOutput[proc-1].String()))
+			fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{bold}}Exit result of proc %d:{{/}}\n", proc))
+			fmt.Fprintln(os.Stderr, formatter.Fi(1, "%s\n", procExitResult[proc-1]))
+		}
+		fmt.Fprintf(os.Stderr, "** End **")
+	}
+
+	for proc := 1; proc <= numProcs; proc++ {
+		output := procOutput[proc-1].String()
+		if proc == 1 && checkForNoTestsWarning(procOutput[0]) && cliConfig.RequireSuite {
+			suite.State = TestSuiteStateFailed
+		}
+		if strings.Contains(output, "deprecated Ginkgo functionality") {
+			fmt.Fprintln(os.Stderr, output)
+		}
+	}
+	return suite
+}
+
 func runAfterRunHook(command string, noColor bool, suite TestSuite) {
 	if command == "" {
 		return
@@ -370,3 +414,39 @@ func runAfterRunHook(command string, noColor bool, suite TestSuite) {
 		fmt.Fprintln(formatter.ColorableStdOut, f.Fi(1, "{{green}}%s{{/}}", output))
 	}
 }
+
+const lastRunReportName = "ginkgo-last-run.json"
+
+/*
+configureRerunFailed resolves --rerun-failed to an absolute path (the suite runs in its own directory) and sets up the report cache used by --last-failed.
+
+With --last-failed the suite's JSON report is cached in the suite's directory (or in --output-dir) and the cache from the prior run, if any, is passed to the suite as --rerun-failed.  If the user has not asked for a JSON report the suite writes its report straight into the cache.  Otherwise the path the user's report must be copied to once the suite has run is returned.
+*/
+func configureRerunFailed(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig) (types.SuiteConfig, types.ReporterConfig, string) {
+	if ginkgoConfig.RerunFailed != "" {
+		ginkgoConfig.RerunFailed, _ = filepath.Abs(ginkgoConfig.RerunFailed)
+	}
+	if !cliConfig.LastFailed {
+		return ginkgoConfig, reporterConfig, ""
+	}
+
+	lastRunReport := AbsPathForGeneratedAsset(lastRunReportName, suite, cliConfig, 0)
+	if ginkgoConfig.RerunFailed == "" && FileExists(lastRunReport) {
+		ginkgoConfig.RerunFailed = lastRunReport
+	}
+	if reporterConfig.JSONReport == "" {
+		reporterConfig.JSONReport = lastRunReport
+		return ginkgoConfig, reporterConfig, ""
+	}
+	return ginkgoConfig, reporterConfig, lastRunReport
+}
+
+func cacheLastRunReport(jsonReport string, lastRunReport string) {
+	if lastRunReport == "" || !FileExists(jsonReport) {
+		return
+	}
+	err := CopyFile(jsonReport, lastRunReport)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to cache report for --last-failed:\n%s\n", err.Error())
+	}
+}
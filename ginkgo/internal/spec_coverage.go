@@ -0,0 +1,267 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"golang.org/x/tools/cover"
+)
+
+/*
+SpecCoverage captures the code covered by a single spec when running with --spec-coverage.
+
+Go only supports snapshotting coverage counters mid-run in regular binaries - not in test binaries (runtime/coverage's WriteCounters and ClearCounters fail in test binaries as they only register their coverage metadata on exit) - so Ginkgo measures each spec by rerunning it on its own, in a separate process, and collecting that process's cover profile.  As a consequence the code run by suite-level setup (e.g. BeforeSuite) is attributed to every spec, as is the code run by the specs it depends on via DependsOn (which --focus-id runs along with it).
+*/
+type SpecCoverage struct {
+	SuitePath               string
+	ContainerHierarchyTexts []string
+	LeafNodeText            string
+	LeafNodeLocation        types.CodeLocation
+	State                   types.SpecState
+
+	// Files maps the files the spec covered to the blocks of code it executed in each file.  Files are identified by their absolute path when Ginkgo can resolve it and by their import path otherwise.
+	Files map[string][]CoveredBlock
+}
+
+// CoveredBlock is a block of code, as identified by go's cover tool, that a spec executed
+type CoveredBlock struct {
+	StartLine int
+	EndLine   int
+}
+
+// FullText returns the concatenation of the spec's container hierarchy texts and leaf node text
+func (s SpecCoverage) FullText() string {
+	return strings.TrimSpace(strings.Join(append(slices.Clone(s.ContainerHierarchyTexts), s.LeafNodeText), " "))
+}
+
+// Covers returns true if the spec executed the code on the passed-in line of file.  file can be an absolute path or a path relative to the current directory.  Files that could not be resolved to an absolute path are matched by suffix.
+func (s SpecCoverage) Covers(file string, line int) bool {
+	absFile, _ := filepath.Abs(file)
+	suffix := "/" + filepath.ToSlash(filepath.Clean(file))
+	for coveredFile, blocks := range s.Files {
+		if coveredFile != absFile && !strings.HasSuffix(filepath.ToSlash(coveredFile), suffix) {
+			continue
+		}
+		for _, block := range blocks {
+			if block.StartLine <= line && line <= block.EndLine {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LoadSpecCoverage loads the per-spec coverage map at path (as generated by --spec-coverage)
+func LoadSpecCoverage(path string) ([]SpecCoverage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	coverage := []SpecCoverage{}
+	err = json.Unmarshal(data, &coverage)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s:\n%w", path, err)
+	}
+	return coverage, nil
+}
+
+func writeSpecCoverage(coverage []SpecCoverage, path string) error {
+	data, err := json.MarshalIndent(coverage, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0770); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0666)
+}
+
+// MergeAndCleanupSpecCoverage merges the per-suite coverage maps in sources into destination and deletes them
+func MergeAndCleanupSpecCoverage(sources []string, destination string) ([]string, error) {
+	messages := []string{}
+	merged := []SpecCoverage{}
+	for _, source := range sources {
+		coverage, err := LoadSpecCoverage(source)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not load %s:\n%s", source, err.Error()))
+			continue
+		}
+		os.Remove(source)
+		merged = append(merged, coverage...)
+	}
+	return messages, writeSpecCoverage(merged, destination)
+}
+
+/*
+MeasureSpecCoverage measures the code covered by each of the suite's specs and writes the suite's coverage map to --spec-coverage (see AbsPathForGeneratedAsset).
+
+The specs to measure are identified with a dry run of the suite that honors the suite's filters.  Each spec is then run on its own - up to --procs at a time - with a cover profile.
+*/
+func MeasureSpecCoverage(suite TestSuite, ginkgoConfig types.SuiteConfig, cliConfig types.CLIConfig, additionalArgs []string) error {
+	if !suite.IsGinkgo || suite.PathToCompiledTest == "" {
+		return nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ginkgo-spec-coverage")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ginkgoConfig = absPathsForSuiteInputs(ginkgoConfig)
+	if ginkgoConfig.RerunFailed != "" {
+		ginkgoConfig.RerunFailed, _ = filepath.Abs(ginkgoConfig.RerunFailed)
+	}
+	ginkgoConfig.ParallelProcess, ginkgoConfig.ParallelTotal, ginkgoConfig.ParallelHost = 1, 1, ""
+	ginkgoConfig.SpecTimings = ""
+	ginkgoConfig.UpdateSnapshots = false
+
+	dryRunConfig := ginkgoConfig
+	dryRunConfig.DryRun = true
+	dryRun, err := runSuiteForReport(suite, dryRunConfig, types.GoFlagsConfig{}, additionalArgs, filepath.Join(tmpDir, "dry-run.json"))
+	if err != nil {
+		return err
+	}
+	specs := types.SpecReports{}
+	for _, spec := range dryRun.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+		if spec.State.Is(types.SpecStatePassed) {
+			specs = append(specs, spec)
+		}
+	}
+	fmt.Printf("Measuring the coverage of %d %s in %s\n", len(specs), PluralizedWord("spec", "specs", len(specs)), suite.Path)
+
+	coverage := make([]SpecCoverage, len(specs))
+	errs := make([]error, len(specs))
+	semaphore := make(chan bool, cliConfig.ComputedProcs())
+	wg := &sync.WaitGroup{}
+	for i, spec := range specs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- true
+			defer func() { <-semaphore }()
+			coverage[i], errs[i] = measureSpec(suite, spec, ginkgoConfig, additionalArgs, filepath.Join(tmpDir, fmt.Sprintf("spec-%d", i)))
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	resolveCoveredFiles(suite, coverage)
+	return writeSpecCoverage(coverage, AbsPathForGeneratedAsset(cliConfig.SpecCoverage, suite, cliConfig, 0))
+}
+
+// measureSpec runs spec on its own and returns the code it covered
+func measureSpec(suite TestSuite, spec types.SpecReport, ginkgoConfig types.SuiteConfig, additionalArgs []string, outputPrefix string) (SpecCoverage, error) {
+	// the spec has already made it through the suite's filters in the dry run, so we replace them with filters that pick out just this spec
+	ginkgoConfig.FocusIDs = []string{spec.ID}
+	ginkgoConfig.FocusStrings, ginkgoConfig.FocusFiles = nil, nil
+	ginkgoConfig.SkipStrings, ginkgoConfig.SkipFiles = nil, nil
+	ginkgoConfig.LabelFilter, ginkgoConfig.SemVerFilter = "", ""
+	ginkgoConfig.RerunFailed, ginkgoConfig.ImpactFilter, ginkgoConfig.Shard = "", "", ""
+
+	coverProfile := outputPrefix + ".coverprofile"
+	report, err := runSuiteForReport(suite, ginkgoConfig, types.GoFlagsConfig{CoverProfile: coverProfile}, additionalArgs, outputPrefix+".json")
+	if err != nil {
+		return SpecCoverage{}, err
+	}
+
+	coverage := SpecCoverage{
+		SuitePath:               suite.AbsPath(),
+		ContainerHierarchyTexts: spec.ContainerHierarchyTexts,
+		LeafNodeText:            spec.LeafNodeText,
+		LeafNodeLocation:        spec.LeafNodeLocation,
+		State:                   types.SpecStateInvalid,
+		Files:                   map[string][]CoveredBlock{},
+	}
+	for _, specReport := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+		if specReport.ID == spec.ID {
+			coverage.State = specReport.State
+		}
+	}
+
+	// the spec's process may not have gotten far enough to write a profile (e.g. if it was aborted) - in which case we report what we know: the spec's state
+	profiles, _ := cover.ParseProfiles(coverProfile)
+	for _, profile := range profiles {
+		for _, block := range profile.Blocks {
+			if block.Count > 0 {
+				coverage.Files[profile.FileName] = append(coverage.Files[profile.FileName], CoveredBlock{StartLine: block.StartLine, EndLine: block.EndLine})
+			}
+		}
+	}
+	return coverage, nil
+}
+
+// runSuiteForReport runs the suite with the passed-in configuration, discarding its output, and returns its report
+func runSuiteForReport(suite TestSuite, ginkgoConfig types.SuiteConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string, jsonReport string) (types.Report, error) {
+	args, err := types.GenerateGinkgoTestRunArgs(ginkgoConfig, types.ReporterConfig{JSONReport: jsonReport, NoColor: true, Succinct: true}, goFlagsConfig)
+	if err != nil {
+		return types.Report{}, err
+	}
+	args = append([]string{"--test.timeout=0"}, args...)
+	args = append(args, additionalArgs...)
+
+	output := &bytes.Buffer{}
+	cmd := exec.Command(suite.PathToCompiledTest, args...)
+	cmd.Dir = suite.Path
+	cmd.Stdout, cmd.Stderr = output, output
+	cmd.Run()
+
+	reports, err := LoadJSONReports([]string{jsonReport})
+	if err != nil || len(reports) == 0 {
+		return types.Report{}, fmt.Errorf("failed to run %s:\n%s", suite.Path, output.String())
+	}
+	return reports[0], nil
+}
+
+// resolveCoveredFiles replaces the import paths that go's cover tool uses to identify files with absolute paths
+func resolveCoveredFiles(suite TestSuite, coverage []SpecCoverage) {
+	packages := []string{}
+	for _, spec := range coverage {
+		for file := range spec.Files {
+			if pkg := path.Dir(file); !slices.Contains(packages, pkg) {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	if len(packages) == 0 {
+		return
+	}
+
+	cmd := exec.Command("go", append([]string{"list", "-e", "-f", "{{.ImportPath}}\t{{.Dir}}"}, packages...)...)
+	cmd.Dir = suite.Path
+	output, err := cmd.Output()
+	if err != nil {
+		return
+	}
+	dirs := map[string]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		importPath, dir, found := strings.Cut(line, "\t")
+		if found && dir != "" {
+			dirs[importPath] = dir
+		}
+	}
+
+	for i := range coverage {
+		files := map[string][]CoveredBlock{}
+		for file, blocks := range coverage[i].Files {
+			if dir, ok := dirs[path.Dir(file)]; ok {
+				file = filepath.Join(dir, path.Base(file))
+			}
+			files[file] = blocks
+		}
+		coverage[i].Files = files
+	}
+}
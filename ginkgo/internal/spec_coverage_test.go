@@ -0,0 +1,53 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecCoverage", func() {
+	var coverage internal.SpecCoverage
+
+	BeforeEach(func() {
+		coverage = internal.SpecCoverage{
+			LeafNodeText: "covers things",
+			Files: map[string][]internal.CoveredBlock{
+				"/root/module/a/a.go":         {{StartLine: 3, EndLine: 5}, {StartLine: 10, EndLine: 10}},
+				"example.com/unresolved/b.go": {{StartLine: 7, EndLine: 9}},
+			},
+		}
+	})
+
+	It("covers the lines in the blocks it executed", func() {
+		Ω(coverage.Covers("/root/module/a/a.go", 3)).Should(BeTrue())
+		Ω(coverage.Covers("/root/module/a/a.go", 10)).Should(BeTrue())
+		Ω(coverage.Covers("/root/module/a/a.go", 6)).Should(BeFalse())
+		Ω(coverage.Covers("/root/module/c/a.go", 3)).Should(BeFalse())
+	})
+
+	It("matches files by suffix", func() {
+		Ω(coverage.Covers("a/a.go", 4)).Should(BeTrue())
+		Ω(coverage.Covers("unresolved/b.go", 8)).Should(BeTrue())
+		Ω(coverage.Covers("b.go", 10)).Should(BeFalse())
+	})
+
+	It("merges coverage maps", func() {
+		dir := GinkgoT().TempDir()
+		Ω(os.WriteFile(filepath.Join(dir, "a.json"), []byte(`[{"LeafNodeText":"a"}]`), 0644)).Should(Succeed())
+		Ω(os.WriteFile(filepath.Join(dir, "b.json"), []byte(`[{"LeafNodeText":"b"},{"LeafNodeText":"c"}]`), 0644)).Should(Succeed())
+
+		messages, err := internal.MergeAndCleanupSpecCoverage([]string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"), filepath.Join(dir, "missing.json")}, filepath.Join(dir, "merged.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(messages).Should(HaveLen(1))
+		Ω(filepath.Join(dir, "a.json")).ShouldNot(BeAnExistingFile())
+
+		merged, err := internal.LoadSpecCoverage(filepath.Join(dir, "merged.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(merged).Should(HaveLen(3))
+		Ω(merged[2].FullText()).Should(Equal("c"))
+	})
+})
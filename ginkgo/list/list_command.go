@@ -0,0 +1,155 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildListCommand() command.Command {
+	var suiteConfig = types.NewDefaultSuiteConfig()
+	var cliConfig = types.NewDefaultCLIConfig()
+	var goFlagsConfig = types.NewDefaultGoFlagsConfig()
+
+	flags, err := types.BuildListCommandFlagSet(&suiteConfig, &cliConfig, &goFlagsConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "list",
+		Usage:         "ginkgo list <FLAGS> <PACKAGES>",
+		Flags:         flags,
+		ShortDoc:      "List the specs in the passed-in packages (or the package in the current directory if left blank).",
+		Documentation: "Compiles each suite and performs a dry run to list its specs - along with their IDs, labels, and decorators - and whether they would run under the passed-in filters.  Use {{bold}}--format=json{{/}} for machine-readable output.",
+		DocLink:       "listing-specs",
+		Command: func(args []string, _ []string) {
+			if cliConfig.ListFormat == "" {
+				cliConfig.ListFormat = "text"
+			}
+			if cliConfig.ListFormat != "text" && cliConfig.ListFormat != "json" {
+				command.AbortWithUsage("--format must be one of json or text - got %s", cliConfig.ListFormat)
+			}
+			var errors []error
+			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
+			command.AbortIfErrors("Ginkgo detected configuration issues:", errors)
+			ListSpecs(args, suiteConfig, cliConfig, goFlagsConfig)
+		},
+	}
+}
+
+func ListSpecs(args []string, suiteConfig types.SuiteConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig) {
+	suites := internal.FindSuites(args, cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter).ThatAreGinkgoSuites()
+	if len(suites) == 0 {
+		command.AbortWith("Found no test suites")
+	}
+
+	internal.VerifyCLIAndFrameworkVersion(suites)
+
+	opc := internal.NewOrderedParallelCompiler(cliConfig.ComputedNumCompilers())
+	opc.StartCompiling(suites, goFlagsConfig, false)
+
+	listedSuites := []types.ListedSuite{}
+	failed := false
+	for {
+		suiteIdx, suite := opc.Next()
+		if suiteIdx >= len(suites) {
+			break
+		}
+		suites[suiteIdx] = suite
+		var listed types.ListedSuite
+		var err error
+		if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
+			listed, err = types.ListedSuite{SuitePath: suite.AbsPath()}, suite.CompilationError
+		} else if suite.State.Is(internal.TestSuiteStateCompiled) {
+			listed, err = internal.ListSpecs(suite, suiteConfig)
+		} else {
+			continue
+		}
+		if err != nil {
+			listed.Error = err.Error()
+			failed = true
+		}
+		listedSuites = append(listedSuites, listed)
+	}
+	internal.Cleanup(goFlagsConfig, suites...)
+
+	if cliConfig.ListFormat == "json" {
+		data, err := json.MarshalIndent(listedSuites, "", "  ")
+		command.AbortIfError("Failed to encode the list of specs:", err)
+		fmt.Println(string(data))
+	} else {
+		for _, listed := range listedSuites {
+			printListedSuite(listed)
+		}
+	}
+
+	if failed {
+		command.AbortWith("Failed to list the specs in all the suites")
+	}
+}
+
+func printListedSuite(listed types.ListedSuite) {
+	if listed.Error != "" {
+		fmt.Printf("%s: %s\n\n", listed.SuitePath, listed.Error)
+		return
+	}
+	numWillRun := 0
+	for _, spec := range listed.Specs {
+		if spec.WillRun {
+			numWillRun += 1
+		}
+	}
+	fmt.Printf("%s (%s): %d %s, %d will run\n", listed.SuiteDescription, listed.SuitePath, len(listed.Specs), internal.PluralizedWord("spec", "specs", len(listed.Specs)), numWillRun)
+	for _, spec := range listed.Specs {
+		line := spec.ID + " " + spec.FullText
+		if len(spec.Labels) > 0 {
+			line += " [" + strings.Join(spec.Labels, ", ") + "]"
+		}
+		if decorators := listedSpecDecorators(spec); len(decorators) > 0 {
+			line += " {" + strings.Join(decorators, ", ") + "}"
+		}
+		line += fmt.Sprintf(" (%s:%d)", filepath.Base(spec.LeafNodeLocation.FileName), spec.LeafNodeLocation.LineNumber)
+		if spec.Pending {
+			line += " - pending"
+		} else if !spec.WillRun {
+			line += " - skipped"
+		}
+		fmt.Println("  " + line)
+	}
+	fmt.Println("")
+}
+
+func listedSpecDecorators(spec types.ListedSpec) []string {
+	decorators := []string{}
+	if spec.Serial {
+		decorators = append(decorators, "Serial")
+	}
+	if spec.Ordered {
+		decorators = append(decorators, "Ordered")
+	}
+	if spec.FlakeAttempts > 0 {
+		decorators = append(decorators, fmt.Sprintf("FlakeAttempts(%d)", spec.FlakeAttempts))
+	}
+	if spec.MustPassRepeatedly > 0 {
+		decorators = append(decorators, fmt.Sprintf("MustPassRepeatedly(%d)", spec.MustPassRepeatedly))
+	}
+	if spec.SpecTimeout > 0 {
+		decorators = append(decorators, fmt.Sprintf("SpecTimeout(%s)", spec.SpecTimeout))
+	}
+	if spec.NodeTimeout > 0 {
+		decorators = append(decorators, fmt.Sprintf("NodeTimeout(%s)", spec.NodeTimeout))
+	}
+	if spec.GracePeriod > 0 {
+		decorators = append(decorators, fmt.Sprintf("GracePeriod(%s)", spec.GracePeriod))
+	}
+	if len(spec.SemVerConstraints) > 0 {
+		decorators = append(decorators, fmt.Sprintf("SemVerConstraint(%s)", strings.Join(spec.SemVerConstraints, ", ")))
+	}
+	return decorators
+}
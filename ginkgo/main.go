@@ -5,12 +5,16 @@ import (
 	"os"
 	"github.com/onsi/ginkgo/v2/ginkgo/build"
 	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/coverage"
 	"github.com/onsi/ginkgo/v2/ginkgo/generators"
 	"github.com/onsi/ginkgo/v2/ginkgo/labels"
+	"github.com/onsi/ginkgo/v2/ginkgo/list"
 	"github.com/onsi/ginkgo/v2/ginkgo/outline"
+	"github.com/onsi/ginkgo/v2/ginkgo/reports"
 	"github.com/onsi/ginkgo/v2/ginkgo/run"
 	"github.com/onsi/ginkgo/v2/ginkgo/unfocus"
 	"github.com/onsi/ginkgo/v2/ginkgo/watch"
+	"github.com/onsi/ginkgo/v2/ginkgo/worker"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -23,8 +27,12 @@ func GenerateCommands() []command.Command {
 		generators.BuildBootstrapCommand(),
 		generators.BuildGenerateCommand(),
 		labels.BuildLabelsCommand(),
+		list.BuildListCommand(),
 		outline.BuildOutlineCommand(),
+		coverage.BuildCoverageCommand(),
+		reports.BuildMergeReportsCommand(),
 		unfocus.BuildUnfocusCommand(),
+		worker.BuildWorkerCommand(),
 		BuildVersionCommand(),
 	}
 }
@@ -0,0 +1,69 @@
+package reports
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildMergeReportsCommand() command.Command {
+	var reporterConfig = types.NewDefaultReporterConfig()
+
+	flags, err := types.BuildMergeReportsCommandFlagSet(&reporterConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "merge-reports",
+		Usage:         "ginkgo merge-reports <FLAGS> <JSON REPORTS>",
+		Flags:         flags,
+		ShortDoc:      "Merge the JSON reports generated by several runs (e.g. the shards of a suite, or retried CI jobs) into a single set of reports",
+		Documentation: "Reports for the same suite are combined into a single report and specs that were retried are de-duplicated.  Use {{bold}}--json-report{{/}}, {{bold}}--gojson-report{{/}}, {{bold}}--junit-report{{/}}, {{bold}}--teamcity-report{{/}}, {{bold}}--html-report{{/}}, {{bold}}--markdown-report{{/}}, and {{bold}}--trace-export{{/}} to pick the reports to generate.  A Markdown summary of the merged reports is appended to $GITHUB_STEP_SUMMARY when it is set, unless {{bold}}--no-github-step-summary{{/}} is set.",
+		DocLink:       "merging-reports",
+		Command: func(args []string, _ []string) {
+			MergeReports(args, reporterConfig)
+		},
+	}
+}
+
+func MergeReports(args []string, reporterConfig types.ReporterConfig) {
+	if len(args) == 0 {
+		command.AbortWithUsage("Please pass in the JSON reports to merge")
+	}
+	githubStepSummary := reporterConfig.GithubStepSummary()
+	if !reporterConfig.WillGenerateReport() && githubStepSummary == "" {
+		command.AbortWithUsage("Please specify at least one report to generate with --json-report, --gojson-report, --junit-report, --teamcity-report, --html-report, --markdown-report, or --trace-export")
+	}
+
+	reports, err := internal.LoadJSONReports(args)
+	command.AbortIfError("Failed to load reports:", err)
+
+	merged := internal.MergeReports(reports)
+	messages, err := internal.GenerateMergedReports(merged, reporterConfig)
+	for _, message := range messages {
+		fmt.Println(message)
+	}
+	command.AbortIfError("Failed to generate merged reports:", err)
+	if githubStepSummary != "" {
+		command.AbortIfError("Failed to write to $GITHUB_STEP_SUMMARY:", reporters.AppendMarkdownSummary(merged, githubStepSummary))
+	}
+
+	succeeded := true
+	for _, report := range merged {
+		status := "Passed"
+		if !report.SuiteSucceeded {
+			status = "Failed"
+			succeeded = false
+		}
+		ran := report.SpecReports.WithLeafNodeType(types.NodeTypeIt).CountWithState(types.SpecStatePassed | types.SpecStateQuarantined | types.SpecStateFailureStates)
+		fmt.Printf("%s: ran %d of %d specs - %s\n", report.SuiteDescription, ran, report.PreRunStats.TotalSpecs, status)
+	}
+
+	if !succeeded {
+		command.Abort(command.AbortDetails{ExitCode: 1})
+	}
+}
@@ -0,0 +1,209 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/ginkgo/watch"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// moduleFiles are the files that can change the build of every package in the module - changing any of them impacts every suite
+var moduleFiles = map[string]bool{"go.mod": true, "go.sum": true, "go.work": true, "go.work.sum": true}
+
+/*
+impactAnalysis selects the suites and specs impacted by the files that changed since a git ref (--changed-since).
+
+A suite is impacted if a changed file lives in the suite's package, in one of the packages the suite depends on (transitively), or in a directory under the suite's package that isn't a Go package (e.g. testdata).  If a baseline for the suite is available (--impact-baseline) the impacted suite is narrowed down to the specs that could be affected by the changes, otherwise all of its specs are impacted.  Impact has to follow the whole dependency tree - a change to any transitive dependency can break a suite - so the packages imported along the way are cached and shared by every suite in the run.  The baseline can be a per-spec coverage map (see --spec-coverage), in which case the specs that executed code in the changed files are impacted (see watch.AffectedSpecsFromCoverage), or a JSON report, in which case the impacted specs are inferred from the specs' source code (see watch.AffectedSpecs).
+*/
+type impactAnalysis struct {
+	ref              string
+	changedFiles     []string
+	baseline         []types.Report
+	baselineCoverage []internal.SpecCoverage
+	packages         *watch.PackageCache
+	tmpDir           string
+}
+
+func newImpactAnalysis(ref string, baselinePath string) (*impactAnalysis, error) {
+	a := &impactAnalysis{ref: ref, packages: watch.NewPackageCache()}
+	var err error
+	a.changedFiles, err = filesChangedSince(ref)
+	if err != nil {
+		return nil, err
+	}
+	if baselinePath != "" {
+		err = a.loadBaseline(baselinePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not load the --impact-baseline: %w", err)
+		}
+	}
+	a.tmpDir, err = os.MkdirTemp("", "ginkgo-impact")
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// loadBaseline loads the baseline at path, which can be a per-spec coverage map (as generated by --spec-coverage) or a JSON report (as generated by --json-report)
+func (a *impactAnalysis) loadBaseline(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	entries := []map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("could not decode %s:\n%w", path, err)
+	}
+	for _, entry := range entries {
+		if _, isReport := entry["SpecReports"]; isReport {
+			a.baseline, err = internal.LoadJSONReports([]string{path})
+			return err
+		}
+	}
+	a.baselineCoverage, err = internal.LoadSpecCoverage(path)
+	return err
+}
+
+// filesChangedSince returns the absolute paths of the files that have changed since ref: committed, staged, and unstaged changes as well as untracked files
+func filesChangedSince(ref string) ([]string, error) {
+	// resolve the root relative to the working directory (rather than with --show-toplevel, which resolves symlinks) so that paths line up with the suites' paths
+	cdup, err := git("rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, err
+	}
+	root, err := filepath.Abs(strings.TrimSpace(cdup))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git("rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("%s is not a valid git ref", ref)
+	}
+	diff, err := git("diff", "--name-only", "-z", "--no-renames", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git("ls-files", "--others", "--exclude-standard", "-z", "--full-name", ":/")
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, file := range strings.Split(diff+untracked, "\x00") {
+		if file != "" {
+			files = append(files, filepath.Join(root, filepath.FromSlash(file)))
+		}
+	}
+	return files, nil
+}
+
+func git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return string(out), nil
+}
+
+/*
+impactFilterFor writes the impact filter for suite and returns its path along with a description of what was selected.  An empty path means that every spec in the suite is impacted.
+*/
+func (a *impactAnalysis) impactFilterFor(suite internal.TestSuite) (string, string, error) {
+	if suite.Precompiled {
+		return "", "precompiled - all specs will run", nil
+	}
+	suiteDir := suite.AbsPath()
+	changed := a.changedFilesImpacting(suiteDir)
+	if changed == nil {
+		return "", "could not determine the suite's dependencies - all specs will run", nil
+	}
+
+	filter := types.ImpactFilter{ChangedSince: a.ref}
+	description := "not impacted - all specs will be skipped"
+	if len(changed) > 0 {
+		var selection watch.SpecSelection
+		if coverage, ok := a.baselineCoverageFor(suiteDir); ok {
+			selection = watch.AffectedSpecsFromCoverage(coverage, suiteDir, changed)
+		} else if report, ok := a.baselineFor(suiteDir); ok {
+			selection = a.packages.AffectedSpecs(report, suiteDir, changed, math.MaxInt)
+		} else {
+			return "", "impacted - all specs will run", nil
+		}
+		if selection.All {
+			return "", "impacted - all specs will run", nil
+		}
+		filter.ImpactedSpecs = selection.FileFilters()
+		description = "impacted, but none of its specs are affected - all specs will be skipped"
+		if !selection.IsEmpty() {
+			description = "impacted - will run " + selection.Description() + " and skip the rest"
+		}
+	}
+
+	path := filepath.Join(a.tmpDir, suite.NamespacedName()+"-impact-filter.json")
+	return path, description, filter.Save(path)
+}
+
+// changedFilesImpacting returns the changed files that impact the suite in suiteDir, or nil if the suite's dependencies can't be determined
+func (a *impactAnalysis) changedFilesImpacting(suiteDir string) []string {
+	dependencies, err := a.packages.Dependencies(suiteDir, math.MaxInt)
+	if err != nil {
+		return nil
+	}
+	dirs := dependencies.Dependencies()
+	changed := []string{}
+	for _, file := range a.changedFiles {
+		dir := filepath.Dir(file)
+		_, isDependency := dirs[dir]
+		switch {
+		case moduleFiles[filepath.Base(file)], dir == suiteDir, isDependency:
+			changed = append(changed, file)
+		case strings.HasPrefix(dir, suiteDir+string(filepath.Separator)) && !isGoPackage(dir):
+			changed = append(changed, file)
+		}
+	}
+	return changed
+}
+
+func (a *impactAnalysis) baselineFor(suiteDir string) (types.Report, bool) {
+	resolvedSuiteDir, _ := filepath.EvalSymlinks(suiteDir)
+	for _, report := range a.baseline {
+		if report.SuitePath == suiteDir {
+			return report, true
+		}
+		if resolved, err := filepath.EvalSymlinks(report.SuitePath); err == nil && resolved == resolvedSuiteDir {
+			return report, true
+		}
+	}
+	return types.Report{}, false
+}
+
+func (a *impactAnalysis) baselineCoverageFor(suiteDir string) ([]internal.SpecCoverage, bool) {
+	resolvedSuiteDir, _ := filepath.EvalSymlinks(suiteDir)
+	coverage := []internal.SpecCoverage{}
+	for _, spec := range a.baselineCoverage {
+		if spec.SuitePath == suiteDir {
+			coverage = append(coverage, spec)
+		} else if resolved, err := filepath.EvalSymlinks(spec.SuitePath); err == nil && resolved == resolvedSuiteDir {
+			coverage = append(coverage, spec)
+		}
+	}
+	return coverage, len(coverage) > 0
+}
+
+func (a *impactAnalysis) cleanup() {
+	os.RemoveAll(a.tmpDir)
+}
+
+func isGoPackage(dir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	return len(matches) > 0
+}
@@ -3,6 +3,7 @@ package run
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -94,16 +95,80 @@ func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
 		r.reporterConfig.Succinct = true
 	}
 
+	var githubStepSummary string
+	if !r.suiteConfig.DryRun {
+		r.reporterConfig, githubStepSummary = internal.ClaimGithubStepSummary(r.reporterConfig)
+	}
+
+	var err error
+	r.reporterConfig, err = internal.OpenEventStream(r.reporterConfig, r.cliConfig.Reporters)
+	command.AbortIfError("Failed to open the event stream:", err)
+	defer internal.CloseEventStream()
+
 	t := time.Now()
 	var endTime time.Time
 	if r.suiteConfig.Timeout > 0 {
 		endTime = t.Add(r.suiteConfig.Timeout)
 	}
 
+	var coordinator *internal.RemoteWorkerCoordinator
+	if r.cliConfig.RemoteWorkers > 0 {
+		token, generated, err := internal.RemoteWorkerToken(r.cliConfig.RemoteWorkerToken)
+		command.AbortIfError("Failed to generate a remote worker token:", err)
+		coordinator, err = internal.NewRemoteWorkerCoordinator(r.cliConfig.CoordinatorAddress, token)
+		command.AbortIfError("Failed to start the remote worker coordinator:", err)
+		fmt.Printf("Waiting for %d remote workers to connect to %s\n", r.cliConfig.RemoteWorkers, coordinator.Address())
+		if generated {
+			fmt.Printf("Start them with: ginkgo worker --coordinator=%s --token=%s\n", coordinator.Address(), token)
+		}
+	}
+
+	impactFilters := map[string]string{}
+	if r.cliConfig.ChangedSince != "" {
+		analysis, err := newImpactAnalysis(r.cliConfig.ChangedSince, r.cliConfig.ImpactBaseline)
+		command.AbortIfError("Ginkgo could not determine the changes since "+r.cliConfig.ChangedSince+":", err)
+		defer analysis.cleanup()
+		fmt.Printf("Found %d %s changed since %s:\n", len(analysis.changedFiles), internal.PluralizedWord("file", "files", len(analysis.changedFiles)), r.cliConfig.ChangedSince)
+		for _, suite := range suites {
+			path, description, err := analysis.impactFilterFor(suite)
+			command.AbortIfError("Ginkgo could not write the impact filter:", err)
+			impactFilters[suite.Path] = path
+			fmt.Printf("  %s: %s\n", suite.Path, description)
+		}
+	}
+
+	if r.suiteConfig.Fuzz != "" {
+		if len(suites) > 1 || suites[0].Precompiled {
+			command.AbortWith("--fuzz can only fuzz a single, uncompiled, test suite")
+		}
+		fuzzFunction, err := internal.FindFuzzFunction(suites[0])
+		command.AbortIfError("Ginkgo could not fuzz the suite:", err)
+		if !r.flags.WasSet("seed") {
+			r.suiteConfig.RandomSeed = time.Now().Unix()
+		}
+		suites[0] = internal.FuzzSuite(suites[0], fuzzFunction, r.suiteConfig, r.reporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
+	}
+
+	var hunt *internal.FlakeHunt
+	var baseSeed int64
+	runReporterConfig := r.reporterConfig
+	if r.cliConfig.FlakeHunt > 0 {
+		hunt = internal.NewFlakeHunt(r.cliConfig.FlakeHunt)
+		runReporterConfig = hunt.ConfigureReporter(r.reporterConfig)
+		baseSeed = r.suiteConfig.RandomSeed
+		if !r.flags.WasSet("seed") {
+			baseSeed = time.Now().Unix()
+		}
+	}
+
 	iteration := 0
+	// when fuzzing, FuzzSuite has already run the suite
 OUTER_LOOP:
-	for {
-		if !r.flags.WasSet("seed") {
+	for r.suiteConfig.Fuzz == "" {
+		if hunt != nil {
+			r.suiteConfig.RandomSeed = baseSeed + int64(iteration)
+			hunt.StartIteration(r.suiteConfig.RandomSeed)
+		} else if !r.flags.WasSet("seed") {
 			r.suiteConfig.RandomSeed = time.Now().Unix()
 		}
 		if r.cliConfig.RandomizeSuites && len(suites) > 1 {
@@ -139,7 +204,7 @@ OUTER_LOOP:
 				continue SUITE_LOOP
 			}
 
-			if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 && !r.cliConfig.KeepGoing {
+			if hunt == nil && suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 && !r.cliConfig.KeepGoing {
 				suites[suiteIdx].State = internal.TestSuiteStateSkippedDueToPriorFailures
 				opc.StopAndDrain()
 				continue SUITE_LOOP
@@ -154,7 +219,34 @@ OUTER_LOOP:
 				}
 			}
 
-			suites[suiteIdx] = internal.RunCompiledSuite(suites[suiteIdx], r.suiteConfig, r.reporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
+			suiteConfig := r.suiteConfig
+			if impactFilter := impactFilters[suite.Path]; impactFilter != "" {
+				suiteConfig.ImpactFilter = impactFilter
+			}
+			if coordinator != nil {
+				suites[suiteIdx] = internal.RunCompiledSuiteOnRemoteWorkers(coordinator, suites[suiteIdx], suiteConfig, runReporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
+			} else {
+				suites[suiteIdx] = internal.RunCompiledSuite(suites[suiteIdx], suiteConfig, runReporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
+			}
+
+			if r.cliConfig.SpecCoverage != "" && !r.interruptHandler.Status().Interrupted() {
+				err := internal.MeasureSpecCoverage(suites[suiteIdx], suiteConfig, r.cliConfig, additionalArgs)
+				command.AbortIfError("Failed to measure the coverage of each spec:", err)
+			}
+
+			if hunt != nil {
+				err := hunt.RecordSuite(suites[suiteIdx], runReporterConfig, r.cliConfig)
+				command.AbortIfError("Failed to record the results of the flake hunt:", err)
+			}
+		}
+
+		if hunt != nil {
+			if suites.CountWithState(internal.TestSuiteStateFailedToCompile) > 0 || iteration+1 >= r.cliConfig.FlakeHunt {
+				break OUTER_LOOP
+			}
+			fmt.Printf("\nFlake hunt: finished run %d of %d (seed %d).\n", iteration+1, r.cliConfig.FlakeHunt, r.suiteConfig.RandomSeed)
+			iteration += 1
+			continue OUTER_LOOP
 		}
 
 		if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
@@ -174,6 +266,10 @@ OUTER_LOOP:
 		iteration += 1
 	}
 
+	if coordinator != nil {
+		coordinator.Close()
+	}
+	internal.CloseEventStream()
 	internal.Cleanup(r.goFlagsConfig, suites...)
 
 	messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, r.cliConfig, r.suiteConfig, r.reporterConfig, r.goFlagsConfig)
@@ -181,10 +277,24 @@ OUTER_LOOP:
 	for _, message := range messages {
 		fmt.Println(message)
 	}
+	if githubStepSummary != "" {
+		command.AbortIfError("Failed to write to $GITHUB_STEP_SUMMARY:", internal.WriteGithubStepSummary(githubStepSummary, suites, r.cliConfig, r.reporterConfig))
+	}
+
+	if hunt != nil {
+		fmt.Fprintln(formatter.ColorableStdOut, "")
+		fmt.Fprint(formatter.ColorableStdOut, hunt.Summary(formatter.NewWithNoColorBool(r.reporterConfig.NoColor)))
+		huntReportPath := internal.FLAKE_HUNT_REPORT_NAME
+		if r.cliConfig.OutputDir != "" {
+			huntReportPath = filepath.Join(r.cliConfig.OutputDir, huntReportPath)
+		}
+		command.AbortIfError("Failed to write the flake hunt report:", hunt.WriteReport(huntReportPath))
+		fmt.Printf("Wrote the flake hunt report to %s\n", huntReportPath)
+	}
 
 	fmt.Printf("\nGinkgo ran %d %s in %s\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), time.Since(t))
 
-	if suites.CountWithState(internal.TestSuiteStateFailureStates...) == 0 {
+	if suites.CountWithState(internal.TestSuiteStateFailureStates...) == 0 && (hunt == nil || !hunt.HasFailures()) {
 		if suites.AnyHaveProgrammaticFocus() && strings.TrimSpace(os.Getenv("GINKGO_EDITOR_INTEGRATION")) == "" {
 			fmt.Printf("Test Suite Passed\n")
 			fmt.Printf("Detected Programmatic Focus - setting exit status to %d\n", types.GINKGO_FOCUS_EXIT_CODE)
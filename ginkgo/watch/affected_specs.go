@@ -0,0 +1,549 @@
+package watch
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// ginkgoSpecAndContainerNames are the Ginkgo DSL functions whose locations appear in a spec's ContainerHierarchyLocations and LeafNodeLocation
+var ginkgoSpecAndContainerNames = map[string]bool{}
+
+func init() {
+	for _, name := range []string{"Describe", "Context", "When", "It", "Specify", "Entry", "DescribeTable", "DescribeTableSubtree", "DescribeFuzz"} {
+		ginkgoSpecAndContainerNames[name] = true
+		ginkgoSpecAndContainerNames["F"+name] = true
+		ginkgoSpecAndContainerNames["P"+name] = true
+		ginkgoSpecAndContainerNames["X"+name] = true
+	}
+	ginkgoSpecAndContainerNames["FuzzEntry"] = true
+}
+
+/*
+SpecSelection captures the specs in a suite that could be affected by a set of changed files.
+
+Specs defined in changed spec files are always affected and are selected by file (their line numbers may well have changed).  Specs in other files are selected by the location of their It.
+*/
+type SpecSelection struct {
+	// All is true if the change could not be narrowed down to a subset of the suite's specs
+	All bool
+	// Files are the changed spec files
+	Files []string
+	// Specs are the affected specs that are not in Files
+	Specs types.SpecReports
+}
+
+// IsEmpty returns true if no specs are affected by the change
+func (s SpecSelection) IsEmpty() bool {
+	return !s.All && len(s.Files) == 0 && len(s.Specs) == 0
+}
+
+// Description summarizes the selected specs for the user, e.g. "the specs in 1 changed spec file and 2 specs affected by the change"
+func (s SpecSelection) Description() string {
+	parts := []string{}
+	if len(s.Files) > 0 {
+		parts = append(parts, fmt.Sprintf("the specs in %d changed spec %s", len(s.Files), internal.PluralizedWord("file", "files", len(s.Files))))
+	}
+	if len(s.Specs) > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s affected by the change", len(s.Specs), internal.PluralizedWord("spec", "specs", len(s.Specs))))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// FileFilters returns the filters (as accepted by --focus-file and --skip-file) that match the selected specs
+func (s SpecSelection) FileFilters() []string {
+	filters := []string{}
+	for _, file := range s.Files {
+		filters = append(filters, "^"+quoteFileName(file)+"$")
+	}
+	lines := map[string][]string{}
+	files := []string{}
+	for _, spec := range s.Specs {
+		file := spec.LeafNodeLocation.FileName
+		if lines[file] == nil {
+			files = append(files, file)
+		}
+		lines[file] = append(lines[file], strconv.Itoa(spec.LeafNodeLocation.LineNumber))
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		filters = append(filters, "^"+quoteFileName(file)+"$:"+strings.Join(lines[file], ","))
+	}
+	return filters
+}
+
+// quoteFileName quotes file for use in a file filter.  Colons (e.g. in Windows drive letters) are escaped as file filters use them to separate the file from the line numbers.
+func quoteFileName(file string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(file), ":", `\x3a`)
+}
+
+/*
+AffectedSpecs determines which of the specs in report - the most recent report for the suite in suiteDir - could be affected by changes to changedFiles.
+
+- Specs whose containers or It live in a changed spec file are affected.
+- If a non-test Go file changes, the specs whose bodies (or the bodies of their containers and of any helpers defined in the suite's test files) refer to that file's package - or to a package that depends on it, down to maxDepth - are affected.
+
+Changes that can't be attributed to individual specs - to a test file that defines no specs (e.g. the suite's bootstrap file or shared helpers), to package-level setup such as BeforeSuite, or to non-Go files - affect every spec.
+*/
+func AffectedSpecs(report types.Report, suiteDir string, changedFiles []string, maxDepth int) SpecSelection {
+	return NewPackageCache().AffectedSpecs(report, suiteDir, changedFiles, maxDepth)
+}
+
+// AffectedSpecs works like the package-level AffectedSpecs but imports packages via the cache so that several suites can share them
+func (c *PackageCache) AffectedSpecs(report types.Report, suiteDir string, changedFiles []string, maxDepth int) SpecSelection {
+	suiteDir, _ = filepath.Abs(suiteDir)
+	specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt)
+	if len(specs) == 0 {
+		return SpecSelection{All: true}
+	}
+
+	specFiles := map[string]bool{}
+	for _, spec := range specs {
+		for _, location := range specLocations(spec) {
+			specFiles[location.FileName] = true
+		}
+	}
+
+	selection := SpecSelection{}
+	changedFile := map[string]bool{}
+	changedPackages := map[string]bool{}
+	for _, file := range changedFiles {
+		switch {
+		case !strings.HasSuffix(file, ".go"):
+			return SpecSelection{All: true}
+		case goTestRegExp.MatchString(file) && filepath.Dir(file) == suiteDir:
+			if !specFiles[file] {
+				return SpecSelection{All: true}
+			}
+			selection.Files = append(selection.Files, file)
+			changedFile[file] = true
+		case goTestRegExp.MatchString(file):
+			// test files in other packages aren't compiled into this suite
+		default:
+			changedPackages[filepath.Dir(file)] = true
+		}
+	}
+	sort.Strings(selection.Files)
+	if len(changedPackages) == 0 {
+		return selection
+	}
+
+	analyzer := newReferenceAnalyzer(suiteDir, maxDepth, c)
+	global, ok := analyzer.globalReferences()
+	if !ok || analyzer.affectedBy(global, changedPackages) {
+		return SpecSelection{All: true}
+	}
+	for _, spec := range specs {
+		inChangedFile := false
+		for _, location := range specLocations(spec) {
+			inChangedFile = inChangedFile || changedFile[location.FileName]
+		}
+		if inChangedFile {
+			continue
+		}
+		references, ok := analyzer.specReferences(spec)
+		if !ok {
+			return SpecSelection{All: true}
+		}
+		if analyzer.affectedBy(references, changedPackages) {
+			selection.Specs = append(selection.Specs, spec)
+		}
+	}
+	return selection
+}
+
+/*
+AffectedSpecsFromCoverage determines which of the specs in coverage - the per-spec coverage map (as generated by --spec-coverage) for the suite in suiteDir - could be affected by changes to changedFiles.
+
+- Specs whose It lives in a changed spec file are affected.
+- If a non-test Go file changes, the specs that executed code in that file are affected.
+
+Changes that the coverage map can't attribute to individual specs - to a test file that defines no specs, to a Go file that no spec executed (e.g. because its package wasn't instrumented, see --coverpkg), or to non-Go files - affect every spec.
+*/
+func AffectedSpecsFromCoverage(coverage []internal.SpecCoverage, suiteDir string, changedFiles []string) SpecSelection {
+	suiteDir, _ = filepath.Abs(suiteDir)
+	if len(coverage) == 0 {
+		return SpecSelection{All: true}
+	}
+
+	specFiles := map[string]bool{}
+	coveredBy := map[string][]int{}
+	for i, spec := range coverage {
+		specFiles[resolvedPath(spec.LeafNodeLocation.FileName)] = true
+		for file := range spec.Files {
+			file = resolvedPath(file)
+			coveredBy[file] = append(coveredBy[file], i)
+		}
+	}
+
+	selection := SpecSelection{}
+	changedFile := map[string]bool{}
+	affected := map[int]bool{}
+	for _, file := range changedFiles {
+		resolved := resolvedPath(file)
+		switch {
+		case !strings.HasSuffix(file, ".go"):
+			return SpecSelection{All: true}
+		case goTestRegExp.MatchString(file) && filepath.Dir(file) == suiteDir:
+			if !specFiles[resolved] {
+				return SpecSelection{All: true}
+			}
+			selection.Files = append(selection.Files, file)
+			changedFile[resolved] = true
+		case goTestRegExp.MatchString(file):
+			// test files in other packages aren't compiled into this suite
+		case len(coveredBy[resolved]) == 0:
+			return SpecSelection{All: true}
+		default:
+			for _, i := range coveredBy[resolved] {
+				affected[i] = true
+			}
+		}
+	}
+	sort.Strings(selection.Files)
+
+	for i, spec := range coverage {
+		if affected[i] && !changedFile[resolvedPath(spec.LeafNodeLocation.FileName)] {
+			selection.Specs = append(selection.Specs, types.SpecReport{
+				ContainerHierarchyTexts: spec.ContainerHierarchyTexts,
+				LeafNodeType:            types.NodeTypeIt,
+				LeafNodeText:            spec.LeafNodeText,
+				LeafNodeLocation:        spec.LeafNodeLocation,
+			})
+		}
+	}
+	return selection
+}
+
+// resolvedPath resolves any symlinks in path so that paths reported by git, go list, and the suite can be compared
+func resolvedPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+func specLocations(spec types.SpecReport) []types.CodeLocation {
+	return append(append([]types.CodeLocation{}, spec.ContainerHierarchyLocations...), spec.LeafNodeLocation)
+}
+
+// references are the packages (by directory) and package-level names that a piece of code refers to
+type references struct {
+	packages map[string]bool
+	names    map[string]bool
+}
+
+func newReferences() references {
+	return references{packages: map[string]bool{}, names: map[string]bool{}}
+}
+
+func (r references) merge(other references) {
+	for pkg := range other.packages {
+		r.packages[pkg] = true
+	}
+	for name := range other.names {
+		r.names[name] = true
+	}
+}
+
+type analyzedFile struct {
+	file       *ast.File
+	inPackage  bool
+	imports    map[string]string
+	dotImports []string
+}
+
+// referenceAnalyzer works out which packages the specs in a suite refer to by inspecting the AST of the suite's test files
+type referenceAnalyzer struct {
+	suiteDir string
+	maxDepth int
+	packages *PackageCache
+	fset     *token.FileSet
+
+	files        map[string]*analyzedFile
+	declarations map[string]map[string][]ast.Node
+	dependencies map[string]map[string]int
+}
+
+func newReferenceAnalyzer(suiteDir string, maxDepth int, packages *PackageCache) *referenceAnalyzer {
+	return &referenceAnalyzer{
+		suiteDir:     suiteDir,
+		maxDepth:     maxDepth,
+		packages:     packages,
+		fset:         token.NewFileSet(),
+		files:        map[string]*analyzedFile{},
+		dependencies: map[string]map[string]int{},
+	}
+}
+
+func (a *referenceAnalyzer) parse(path string) (*analyzedFile, error) {
+	if f, ok := a.files[path]; ok {
+		return f, nil
+	}
+	file, err := parser.ParseFile(a.fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	f := &analyzedFile{
+		file:      file,
+		inPackage: !strings.HasSuffix(file.Name.Name, "_test"),
+		imports:   map[string]string{},
+	}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		pkg, err := build.Import(importPath, filepath.Dir(path), build.FindOnly)
+		if err != nil || pkg.Goroot || matchesGinkgoOrGomega(pkg.Dir) && !matchesGinkgoIntegration(pkg.Dir) {
+			continue
+		}
+		name := filepath.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if bp, err := a.packages.importDir(pkg.Dir); err == nil {
+			name = bp.Name
+		}
+		switch name {
+		case ".":
+			f.dotImports = append(f.dotImports, pkg.Dir)
+		case "_":
+		default:
+			f.imports[name] = pkg.Dir
+		}
+	}
+	a.files[path] = f
+	return f, nil
+}
+
+// testFiles parses the suite's test files
+func (a *referenceAnalyzer) testFiles() (map[string]*analyzedFile, error) {
+	paths, err := filepath.Glob(filepath.Join(a.suiteDir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]*analyzedFile{}
+	for _, path := range paths {
+		if strings.HasPrefix(filepath.Base(path), ".") || strings.HasPrefix(filepath.Base(path), "_") {
+			continue
+		}
+		f, err := a.parse(path)
+		if err != nil {
+			return nil, err
+		}
+		out[path] = f
+	}
+	return out, nil
+}
+
+/*
+globalReferences returns the packages referred to by the suite's package-level code that runs regardless of which specs are selected: package-level variables and init functions (which is where BeforeSuite, top-level BeforeEach, and friends live).
+
+It returns false if the suite's test files can't be parsed.
+*/
+func (a *referenceAnalyzer) globalReferences() (references, bool) {
+	files, err := a.testFiles()
+	if err != nil {
+		return references{}, false
+	}
+	a.declarations = map[string]map[string][]ast.Node{}
+	global := newReferences()
+	for path, f := range files {
+		pkg := f.file.Name.Name
+		if a.declarations[pkg] == nil {
+			a.declarations[pkg] = map[string][]ast.Node{}
+		}
+		for _, decl := range f.file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Name.Name == "init" {
+					global.merge(a.references(path, decl, nil))
+					continue
+				}
+				// methods are indexed by name alongside functions: we don't know the type of the value a method is called on so h.Do() pulls in every method named Do
+				a.declarations[pkg][decl.Name.Name] = append(a.declarations[pkg][decl.Name.Name], decl)
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							if name.Name != "_" {
+								a.declarations[pkg][name.Name] = append(a.declarations[pkg][name.Name], spec)
+							}
+						}
+						for _, value := range spec.Values {
+							if !isSpecOrContainer(value) {
+								global.merge(a.references(path, value, nil))
+							}
+						}
+					case *ast.TypeSpec:
+						a.declarations[pkg][spec.Name.Name] = append(a.declarations[pkg][spec.Name.Name], spec)
+					}
+				}
+			}
+		}
+	}
+	return a.resolveNames(global, files), true
+}
+
+/*
+specReferences returns the packages referred to by spec: by its It, by the bodies of its containers (excluding the other specs and containers nested within them), and - transitively - by any package-level helpers (and any methods with the names they call) in the suite's test files that they refer to.
+
+It returns false if the spec's code can't be found.
+*/
+func (a *referenceAnalyzer) specReferences(spec types.SpecReport) (references, bool) {
+	files, err := a.testFiles()
+	if err != nil {
+		return references{}, false
+	}
+	out := newReferences()
+	locations := specLocations(spec)
+	for idx, location := range locations {
+		if !goTestRegExp.MatchString(location.FileName) {
+			// the spec is defined by a helper in a regular package (e.g. shared behaviors) - so it refers to that package
+			out.packages[filepath.Dir(location.FileName)] = true
+			continue
+		}
+		f, ok := files[location.FileName]
+		if !ok {
+			return references{}, false
+		}
+		call := a.callAtLine(f.file, location.LineNumber)
+		if call == nil {
+			return references{}, false
+		}
+		var exclude func(*ast.CallExpr) bool
+		if idx < len(locations)-1 {
+			exclude = func(nested *ast.CallExpr) bool { return nested != call && isSpecOrContainer(nested) }
+		}
+		out.merge(a.references(location.FileName, call, exclude))
+	}
+	return a.resolveNames(out, files), true
+}
+
+// callAtLine returns the outermost call expression that starts on line
+func (a *referenceAnalyzer) callAtLine(file *ast.File, line int) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(file, func(node ast.Node) bool {
+		if found != nil || node == nil {
+			return false
+		}
+		if a.fset.Position(node.Pos()).Line > line || a.fset.Position(node.End()).Line < line {
+			return false
+		}
+		if call, ok := node.(*ast.CallExpr); ok && isSpecOrContainer(call) && (a.fset.Position(call.Pos()).Line == line || a.fset.Position(call.Lparen).Line == line) {
+			found = call
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// references returns the packages and names referred to by node, skipping over any nested calls for which exclude returns true
+func (a *referenceAnalyzer) references(path string, node ast.Node, exclude func(*ast.CallExpr) bool) references {
+	out := newReferences()
+	f := a.files[path]
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			if exclude != nil && exclude(node) {
+				return false
+			}
+		case *ast.SelectorExpr:
+			if ident, ok := node.X.(*ast.Ident); ok {
+				if dir, ok := f.imports[ident.Name]; ok {
+					out.packages[dir] = true
+					return false
+				}
+			}
+		case *ast.Ident:
+			out.names[node.Name] = true
+		}
+		return true
+	})
+	for _, dir := range f.dotImports {
+		out.packages[dir] = true
+	}
+	if f.inPackage {
+		// code in the package under test can refer to anything in the package without qualifying it
+		out.packages[a.suiteDir] = true
+	}
+	return out
+}
+
+// resolveNames adds the references made by the package-level declarations in the suite's test files that r refers to, transitively
+func (a *referenceAnalyzer) resolveNames(r references, files map[string]*analyzedFile) references {
+	visited := map[string]bool{}
+	pending := []string{}
+	for name := range r.names {
+		pending = append(pending, name)
+	}
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+		for path, f := range files {
+			for _, decl := range a.declarations[f.file.Name.Name][name] {
+				if decl.Pos() < f.file.FileStart || decl.End() > f.file.FileEnd {
+					continue
+				}
+				declReferences := a.references(path, decl, nil)
+				r.merge(declReferences)
+				for other := range declReferences.names {
+					if !visited[other] {
+						pending = append(pending, other)
+					}
+				}
+			}
+		}
+	}
+	return r
+}
+
+// affectedBy returns true if any of the packages in r - or any of their dependencies - are among the changed packages
+func (a *referenceAnalyzer) affectedBy(r references, changedPackages map[string]bool) bool {
+	for pkg := range r.packages {
+		if changedPackages[pkg] {
+			return true
+		}
+		if _, ok := a.dependencies[pkg]; !ok {
+			deps, err := a.packages.Dependencies(pkg, a.maxDepth)
+			if err != nil {
+				// we can't tell what the package depends on so we assume the worst
+				return true
+			}
+			a.dependencies[pkg] = deps.Dependencies()
+		}
+		for dep := range a.dependencies[pkg] {
+			if changedPackages[dep] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isSpecOrContainer(node ast.Node) bool {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return ginkgoSpecAndContainerNames[fun.Name]
+	case *ast.SelectorExpr:
+		return ginkgoSpecAndContainerNames[fun.Sel.Name]
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Affected specs", func() {
+	spec := func(text string, file string, line int, containers ...types.CodeLocation) types.SpecReport {
+		return types.SpecReport{
+			LeafNodeType:                types.NodeTypeIt,
+			LeafNodeText:                text,
+			LeafNodeLocation:            types.CodeLocation{FileName: file, LineNumber: line},
+			ContainerHierarchyLocations: containers,
+		}
+	}
+
+	Describe("SpecSelection", func() {
+		It("builds file filters that match the selected specs", func() {
+			selection := SpecSelection{
+				Files: []string{"/suite/a_test.go"},
+				Specs: types.SpecReports{spec("c1", "/suite/c_test.go", 10), spec("b", "/suite/b_test.go", 3), spec("c2", "/suite/c_test.go", 12)},
+			}
+			Ω(selection.IsEmpty()).Should(BeFalse())
+			Ω(selection.FileFilters()).Should(Equal([]string{`^/suite/a_test\.go$`, `^/suite/b_test\.go$:3`, `^/suite/c_test\.go$:10,12`}))
+
+			filters, err := types.ParseFileFilters(selection.FileFilters())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(filters.Matches([]types.CodeLocation{{FileName: "/suite/c_test.go", LineNumber: 12}})).Should(BeTrue())
+			Ω(filters.Matches([]types.CodeLocation{{FileName: "/suite/c_test.go", LineNumber: 11}})).Should(BeFalse())
+			Ω(filters.Matches([]types.CodeLocation{{FileName: "/suite/a_test.go", LineNumber: 1}})).Should(BeTrue())
+		})
+
+		It("is empty when nothing is selected", func() {
+			Ω(SpecSelection{}.IsEmpty()).Should(BeTrue())
+			Ω(SpecSelection{All: true}.IsEmpty()).Should(BeFalse())
+		})
+	})
+
+	Describe("AffectedSpecs", func() {
+		var dir string
+		var report types.Report
+
+		write := func(name string, content string) string {
+			path := filepath.Join(dir, name)
+			Ω(os.WriteFile(path, []byte(content), 0644)).Should(Succeed())
+			return path
+		}
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			write("suite_test.go", "package suite_test\n\nimport \"testing\"\n\nfunc TestSuite(t *testing.T) {}\n")
+			write("a_test.go", `package suite_test
+
+var _ = Describe("A", func() {
+	It("uses a helper", func() {
+		helper()
+	})
+
+	It("doesn't", func() {
+	})
+})
+`)
+			write("helpers_test.go", "package suite_test\n\nfunc helper() {}\n")
+			describe := types.CodeLocation{FileName: filepath.Join(dir, "a_test.go"), LineNumber: 3}
+			report = types.Report{SpecReports: types.SpecReports{
+				spec("uses a helper", filepath.Join(dir, "a_test.go"), 4, describe),
+				spec("doesn't", filepath.Join(dir, "a_test.go"), 8, describe),
+			}}
+		})
+
+		It("selects the specs in changed spec files by file", func() {
+			selection := AffectedSpecs(report, dir, []string{filepath.Join(dir, "a_test.go")}, 1)
+			Ω(selection.All).Should(BeFalse())
+			Ω(selection.Files).Should(Equal([]string{filepath.Join(dir, "a_test.go")}))
+			Ω(selection.Specs).Should(BeEmpty())
+		})
+
+		It("selects every spec when a test file that defines no specs changes", func() {
+			Ω(AffectedSpecs(report, dir, []string{filepath.Join(dir, "helpers_test.go")}, 1).All).Should(BeTrue())
+		})
+
+		It("selects every spec when a file that isn't Go code changes", func() {
+			Ω(AffectedSpecs(report, dir, []string{filepath.Join(dir, "fixture.json")}, 1).All).Should(BeTrue())
+		})
+
+		Context("when specs call helper methods", func() {
+			BeforeEach(func() {
+				Ω(os.Mkdir(filepath.Join(dir, "lib"), 0755)).Should(Succeed())
+				write(filepath.Join("lib", "lib.go"), "package lib\n\nfunc Work() {}\n")
+				write("a_test.go", `package suite_test
+
+var _ = Describe("A", func() {
+	It("uses a helper method", func() {
+		h := &harness{}
+		h.Do()
+	})
+
+	It("doesn't", func() {
+	})
+})
+`)
+				write("helpers_test.go", "package suite_test\n\nimport \"./lib\"\n\ntype harness struct{}\n\nfunc (h *harness) Do() {\n\tlib.Work()\n}\n")
+				describe := types.CodeLocation{FileName: filepath.Join(dir, "a_test.go"), LineNumber: 3}
+				report = types.Report{SpecReports: types.SpecReports{
+					spec("uses a helper method", filepath.Join(dir, "a_test.go"), 4, describe),
+					spec("doesn't", filepath.Join(dir, "a_test.go"), 9, describe),
+				}}
+			})
+
+			It("selects the specs that call methods that refer to the changed package", func() {
+				selection := AffectedSpecs(report, dir, []string{filepath.Join(dir, "lib", "lib.go")}, 1)
+				Ω(selection.All).Should(BeFalse())
+				Ω(selection.Specs).Should(HaveLen(1))
+				Ω(selection.Specs[0].LeafNodeText).Should(Equal("uses a helper method"))
+			})
+		})
+
+		It("selects nothing when the change can't affect the suite", func() {
+			selection := AffectedSpecs(report, dir, []string{filepath.Join(dir, "other", "other_test.go")}, 1)
+			Ω(selection.IsEmpty()).Should(BeTrue())
+		})
+	})
+
+	Describe("AffectedSpecsFromCoverage", func() {
+		var coverage []internal.SpecCoverage
+
+		covering := func(text string, line int, files ...string) internal.SpecCoverage {
+			spec := internal.SpecCoverage{
+				LeafNodeText:     text,
+				LeafNodeLocation: types.CodeLocation{FileName: "/suite/a_test.go", LineNumber: line},
+				Files:            map[string][]internal.CoveredBlock{},
+			}
+			for _, file := range files {
+				spec.Files[file] = []internal.CoveredBlock{{StartLine: 1, EndLine: 3}}
+			}
+			return spec
+		}
+
+		BeforeEach(func() {
+			coverage = []internal.SpecCoverage{
+				covering("uses a", 3, "/lib/a.go", "/lib/shared.go"),
+				covering("uses b", 7, "/lib/b.go", "/lib/shared.go"),
+			}
+		})
+
+		It("selects the specs that executed code in the changed files", func() {
+			selection := AffectedSpecsFromCoverage(coverage, "/suite", []string{"/lib/b.go"})
+			Ω(selection.All).Should(BeFalse())
+			Ω(selection.Specs).Should(HaveLen(1))
+			Ω(selection.Specs[0].LeafNodeText).Should(Equal("uses b"))
+			Ω(selection.FileFilters()).Should(Equal([]string{`^/suite/a_test\.go$:7`}))
+
+			selection = AffectedSpecsFromCoverage(coverage, "/suite", []string{"/lib/shared.go"})
+			Ω(selection.Specs).Should(HaveLen(2))
+		})
+
+		It("selects the specs in changed spec files by file", func() {
+			selection := AffectedSpecsFromCoverage(coverage, "/suite", []string{"/suite/a_test.go", "/lib/a.go"})
+			Ω(selection.Files).Should(Equal([]string{"/suite/a_test.go"}))
+			Ω(selection.Specs).Should(BeEmpty())
+		})
+
+		It("selects every spec when a changed Go file wasn't executed by any spec", func() {
+			Ω(AffectedSpecsFromCoverage(coverage, "/suite", []string{"/other/other.go"}).All).Should(BeTrue())
+		})
+
+		It("selects every spec when a test file that defines no specs or a file that isn't Go code changes", func() {
+			Ω(AffectedSpecsFromCoverage(coverage, "/suite", []string{"/suite/suite_test.go"}).All).Should(BeTrue())
+			Ω(AffectedSpecsFromCoverage(coverage, "/suite", []string{"/lib/fixture.json"}).All).Should(BeTrue())
+		})
+
+		It("selects nothing when the change can't affect the suite", func() {
+			Ω(AffectedSpecsFromCoverage(coverage, "/suite", []string{"/other/other_test.go"}).IsEmpty()).Should(BeTrue())
+		})
+	})
+})
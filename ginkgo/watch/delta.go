@@ -4,6 +4,8 @@ import "sort"
 
 type Delta struct {
 	ModifiedPackages []string
+	// ModifiedFiles are the watched files, in any of the ModifiedPackages, that were added, modified, or removed
+	ModifiedFiles []string
 
 	NewSuites      []*Suite
 	RemovedSuites  []*Suite
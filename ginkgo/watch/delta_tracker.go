@@ -29,6 +29,9 @@ func NewDeltaTracker(maxDepth int, watchRegExp *regexp.Regexp) *DeltaTracker {
 func (d *DeltaTracker) Delta(suites internal.TestSuites) (delta Delta, errors SuiteErrors) {
 	errors = SuiteErrors{}
 	delta.ModifiedPackages = d.packageHashes.CheckForChanges()
+	for _, pkg := range delta.ModifiedPackages {
+		delta.ModifiedFiles = append(delta.ModifiedFiles, d.packageHashes.Get(pkg).ModifiedFiles...)
+	}
 
 	providedSuitePaths := map[string]bool{}
 	for _, suite := range suites {
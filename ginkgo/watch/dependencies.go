@@ -5,13 +5,58 @@ import (
 	"strings"
 )
 
+/*
+PackageCache remembers the packages that have been imported while computing dependencies.  Share a PackageCache when computing the dependencies of many packages - e.g. of every suite in a run - so that the packages they have in common are only imported once.  A PackageCache is not safe for concurrent use.
+*/
+type PackageCache struct {
+	dirs    map[string]importedPackage
+	imports map[string]importedPackage
+}
+
+type importedPackage struct {
+	pkg *build.Package
+	err error
+}
+
+func NewPackageCache() *PackageCache {
+	return &PackageCache{
+		dirs:    map[string]importedPackage{},
+		imports: map[string]importedPackage{},
+	}
+}
+
+func (c *PackageCache) importDir(dir string) (*build.Package, error) {
+	imported, ok := c.dirs[dir]
+	if !ok {
+		imported.pkg, imported.err = build.ImportDir(dir, 0)
+		c.dirs[dir] = imported
+	}
+	return imported.pkg, imported.err
+}
+
+func (c *PackageCache) importPath(path string) (*build.Package, error) {
+	imported, ok := c.imports[path]
+	if !ok {
+		imported.pkg, imported.err = build.Import(path, ".", 0)
+		c.imports[path] = imported
+	}
+	return imported.pkg, imported.err
+}
+
 type Dependencies struct {
-	deps map[string]int
+	deps     map[string]int
+	packages *PackageCache
 }
 
 func NewDependencies(path string, maxDepth int) (Dependencies, error) {
+	return NewPackageCache().Dependencies(path, maxDepth)
+}
+
+// Dependencies computes the dependencies of the package at path, importing packages via the cache
+func (c *PackageCache) Dependencies(path string, maxDepth int) (Dependencies, error) {
 	d := Dependencies{
-		deps: map[string]int{},
+		deps:     map[string]int{},
+		packages: c,
 	}
 
 	if maxDepth == 0 {
@@ -39,7 +84,7 @@ func (d Dependencies) Dependencies() map[string]int {
 }
 
 func (d Dependencies) seedWithDepsForPackageAtPath(path string) error {
-	pkg, err := build.ImportDir(path, 0)
+	pkg, err := d.packages.importDir(path)
 	if err != nil {
 		return err
 	}
@@ -61,7 +106,7 @@ func (d Dependencies) addDepsForDepth(depth int) {
 }
 
 func (d Dependencies) addDepsForDep(dep string, depth int) {
-	pkg, err := build.ImportDir(dep, 0)
+	pkg, err := d.packages.importDir(dep)
 	if err != nil {
 		println(err.Error())
 		return
@@ -71,7 +116,7 @@ func (d Dependencies) addDepsForDep(dep string, depth int) {
 
 func (d Dependencies) resolveAndAdd(deps []string, depth int) {
 	for _, dep := range deps {
-		pkg, err := build.Import(dep, ".", 0)
+		pkg, err := d.packages.importPath(dep)
 		if err != nil {
 			continue
 		}
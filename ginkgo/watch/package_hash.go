@@ -3,7 +3,9 @@ package watch
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -15,9 +17,13 @@ type PackageHash struct {
 	TestModifiedTime time.Time
 	Deleted          bool
 
+	// ModifiedFiles are the files that were added, modified, or removed when CheckForChanges last detected a change
+	ModifiedFiles []string
+
 	path        string
 	codeHash    string
 	testHash    string
+	fileHashes  map[string]string
 	watchRegExp *regexp.Regexp
 }
 
@@ -27,21 +33,23 @@ func NewPackageHash(path string, watchRegExp *regexp.Regexp) *PackageHash {
 		watchRegExp: watchRegExp,
 	}
 
-	p.codeHash, _, p.testHash, _, p.Deleted = p.computeHashes()
+	p.codeHash, _, p.testHash, _, p.fileHashes, p.Deleted = p.computeHashes()
 
 	return p
 }
 
 func (p *PackageHash) CheckForChanges() bool {
-	codeHash, codeModifiedTime, testHash, testModifiedTime, deleted := p.computeHashes()
+	codeHash, codeModifiedTime, testHash, testModifiedTime, fileHashes, deleted := p.computeHashes()
 
 	if deleted {
 		if !p.Deleted {
 			t := time.Now()
 			p.CodeModifiedTime = t
 			p.TestModifiedTime = t
+			p.ModifiedFiles = p.modifiedFiles(fileHashes)
 		}
 		p.Deleted = true
+		p.fileHashes = fileHashes
 		return true
 	}
 
@@ -57,12 +65,35 @@ func (p *PackageHash) CheckForChanges() bool {
 		modified = true
 	}
 
+	if modified {
+		p.ModifiedFiles = p.modifiedFiles(fileHashes)
+	}
+
 	p.codeHash = codeHash
 	p.testHash = testHash
+	p.fileHashes = fileHashes
+	return modified
+}
+
+// modifiedFiles returns the paths of the files whose hashes differ between p.fileHashes and fileHashes
+func (p *PackageHash) modifiedFiles(fileHashes map[string]string) []string {
+	modified := []string{}
+	for name, hash := range fileHashes {
+		if p.fileHashes[name] != hash {
+			modified = append(modified, filepath.Join(p.path, name))
+		}
+	}
+	for name := range p.fileHashes {
+		if _, ok := fileHashes[name]; !ok {
+			modified = append(modified, filepath.Join(p.path, name))
+		}
+	}
+	sort.Strings(modified)
 	return modified
 }
 
-func (p *PackageHash) computeHashes() (codeHash string, codeModifiedTime time.Time, testHash string, testModifiedTime time.Time, deleted bool) {
+func (p *PackageHash) computeHashes() (codeHash string, codeModifiedTime time.Time, testHash string, testModifiedTime time.Time, fileHashes map[string]string, deleted bool) {
+	fileHashes = map[string]string{}
 	entries, err := os.ReadDir(p.path)
 
 	if err != nil {
@@ -85,6 +116,7 @@ func (p *PackageHash) computeHashes() (codeHash string, codeModifiedTime time.Ti
 		}
 
 		if goTestRegExp.MatchString(info.Name()) {
+			fileHashes[info.Name()] = p.hashForFileInfo(info)
 			testHash += p.hashForFileInfo(info)
 			if info.ModTime().After(testModifiedTime) {
 				testModifiedTime = info.ModTime()
@@ -93,6 +125,7 @@ func (p *PackageHash) computeHashes() (codeHash string, codeModifiedTime time.Ti
 		}
 
 		if p.watchRegExp.MatchString(info.Name()) {
+			fileHashes[info.Name()] = p.hashForFileInfo(info)
 			codeHash += p.hashForFileInfo(info)
 			if info.ModTime().After(codeModifiedTime) {
 				codeModifiedTime = info.ModTime()
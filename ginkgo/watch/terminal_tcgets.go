@@ -0,0 +1,9 @@
+//go:build linux || solaris
+// +build linux solaris
+
+package watch
+
+import "golang.org/x/sys/unix"
+
+const ioctlGetTermios = unix.TCGETS
+const ioctlSetTermios = unix.TCSETS
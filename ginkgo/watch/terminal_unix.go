@@ -0,0 +1,35 @@
+//go:build freebsd || openbsd || netbsd || dragonfly || darwin || linux || solaris
+// +build freebsd openbsd netbsd dragonfly darwin linux solaris
+
+package watch
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// makeRaw turns off line buffering and echoing for the terminal at fd so that key presses can be read as they happen.  Signals (e.g. ctrl-c) are left alone.  It returns a function that restores the terminal's original state.
+func makeRaw(fd int) (func() error, error) {
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	original := *termios
+	termios.Lflag &^= unix.ECHO | unix.ICANON
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, termios); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return unix.IoctlSetTermios(fd, ioctlSetTermios, &original)
+	}, nil
+}
+
+// terminalSize returns the width and height of the terminal at fd
+func terminalSize(fd int) (int, int, error) {
+	ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
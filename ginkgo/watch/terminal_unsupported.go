@@ -0,0 +1,17 @@
+//go:build !(freebsd || openbsd || netbsd || dragonfly || darwin || linux || solaris)
+// +build !freebsd,!openbsd,!netbsd,!dragonfly,!darwin,!linux,!solaris
+
+package watch
+
+import (
+	"errors"
+	"runtime"
+)
+
+func makeRaw(fd int) (func() error, error) {
+	return nil, errors.New("the interactive terminal UI is not supported on " + runtime.GOOS)
+}
+
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errors.New("the interactive terminal UI is not supported on " + runtime.GOOS)
+}
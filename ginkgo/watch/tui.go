@@ -0,0 +1,573 @@
+package watch
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type tuiSuiteState uint
+
+const (
+	tuiSuiteStateIdle tuiSuiteState = iota
+	tuiSuiteStateQueued
+	tuiSuiteStateRunning
+	tuiSuiteStatePassed
+	tuiSuiteStateFailed
+	tuiSuiteStateFailedToCompile
+)
+
+// tuiSuite tracks the most recent run of a watched suite
+type tuiSuite struct {
+	suite            internal.TestSuite
+	state            tuiSuiteState
+	report           types.Report
+	hasReport        bool
+	compilationError string
+}
+
+// tuiResult is the outcome of running a single suite
+type tuiResult struct {
+	suite            internal.TestSuite
+	report           types.Report
+	hasReport        bool
+	compilationError string
+	// merge is set when only some of the suite's specs ran - the report is merged into the suite's previous report
+	merge bool
+	// more is set when the suite is about to run again (e.g. to run the remaining specs after the affected specs passed)
+	more bool
+}
+
+// tuiAction tells the watcher what to do in response to a key press
+type tuiAction uint
+
+const (
+	tuiActionNone tuiAction = iota
+	tuiActionQuit
+	tuiActionRunAll
+	tuiActionRunFailed
+)
+
+type tuiMode uint
+
+const (
+	tuiModeList tuiMode = iota
+	tuiModeFilter
+)
+
+// tuiRow is a line in the list of suites and specs.  spec is -1 for the row representing the suite itself.
+type tuiRow struct {
+	suite int
+	spec  int
+}
+
+/*
+tui is the model behind `ginkgo watch --tui`.  It tracks the state of each watched suite and the specs in their most recent reports, turns key presses into actions, and renders the screen.
+
+It does not touch the terminal or run anything itself - that's the watcher's job - which keeps it straightforward to test.
+*/
+type tui struct {
+	suites  []*tuiSuite
+	verbose bool
+	noColor bool
+	f       formatter.Formatter
+
+	mode        tuiMode
+	focus       string
+	filterInput string
+	running     bool
+	status      string
+
+	selected   int
+	listOffset int
+	showPane   bool
+	paneOffset int
+	paneHeight int
+}
+
+func newTUI(verbose bool, noColor bool) *tui {
+	return &tui{
+		verbose: verbose,
+		noColor: noColor,
+		f:       formatter.NewWithNoColorBool(noColor),
+	}
+}
+
+// addSuite starts tracking suite and returns its index.  Suites are identified by their path so adding the same suite twice is a no-op.
+func (t *tui) addSuite(suite internal.TestSuite) int {
+	if idx := t.indexOf(suite.Path); idx != -1 {
+		return idx
+	}
+	t.suites = append(t.suites, &tuiSuite{suite: suite})
+	return len(t.suites) - 1
+}
+
+func (t *tui) indexOf(path string) int {
+	for idx, suite := range t.suites {
+		if suite.suite.Path == path {
+			return idx
+		}
+	}
+	return -1
+}
+
+// failedSuites returns the indices of the suites whose most recent run failed
+func (t *tui) failedSuites() []int {
+	out := []int{}
+	for idx, suite := range t.suites {
+		if suite.state == tuiSuiteStateFailed || suite.state == tuiSuiteStateFailedToCompile {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
+
+func (t *tui) allSuites() []int {
+	out := []int{}
+	for idx := range t.suites {
+		out = append(out, idx)
+	}
+	return out
+}
+
+// willRun marks the suites at indices as queued - the first of them is about to run
+func (t *tui) willRun(indices []int, description string) {
+	t.running = true
+	for _, idx := range indices {
+		t.suites[idx].state = tuiSuiteStateQueued
+	}
+	if len(indices) > 0 {
+		t.suites[indices[0]].state = tuiSuiteStateRunning
+	}
+	t.status = fmt.Sprintf("Running %d %s%s...", len(indices), internal.PluralizedWord("suite", "suites", len(indices)), description)
+}
+
+/*
+didRun records the result of running the suite at idx and, unless the suite is about to run again, marks the next queued suite as running.
+
+When only some of the specs ran (e.g. when only the failed specs were rerun) the new report is merged into the previous one so that the specs that had already passed continue to be listed as passing.
+*/
+func (t *tui) didRun(idx int, result tuiResult) {
+	suite := t.suites[idx]
+	suite.compilationError = result.compilationError
+	switch {
+	case result.compilationError != "":
+		suite.state = tuiSuiteStateFailedToCompile
+	case result.suite.State.Is(internal.TestSuiteStatePassed):
+		suite.state = tuiSuiteStatePassed
+	default:
+		suite.state = tuiSuiteStateFailed
+	}
+	if result.hasReport {
+		if result.merge && suite.hasReport {
+			suite.report = internal.MergeReports([]types.Report{suite.report, result.report})[0]
+			// the merged report spans both runs - we want to show how long this run took
+			suite.report.StartTime, suite.report.EndTime, suite.report.RunTime = result.report.StartTime, result.report.EndTime, result.report.RunTime
+		} else {
+			suite.report = result.report
+		}
+		suite.hasReport = true
+	}
+	if result.more && suite.state == tuiSuiteStatePassed {
+		suite.state = tuiSuiteStateRunning
+		return
+	}
+	for _, other := range t.suites {
+		if other.state == tuiSuiteStateQueued {
+			other.state = tuiSuiteStateRunning
+			break
+		}
+	}
+}
+
+// runDidEnd is called once every suite in the run has run.  messages are shown in the status line.
+func (t *tui) runDidEnd(messages []string) {
+	t.running = false
+	for _, suite := range t.suites {
+		if suite.state == tuiSuiteStateQueued || suite.state == tuiSuiteStateRunning {
+			suite.state = tuiSuiteStateIdle
+		}
+	}
+	t.status = "Done.  Resuming watch..."
+	if len(messages) > 0 {
+		t.status = strings.Join(messages, "  ")
+	}
+}
+
+// rows returns the rows in the list.  Every suite gets a row followed by rows for the failed specs in its most recent report - or for all of its specs if the TUI is verbose.
+func (t *tui) rows() []tuiRow {
+	rows := []tuiRow{}
+	for suiteIdx, suite := range t.suites {
+		rows = append(rows, tuiRow{suite: suiteIdx, spec: -1})
+		if !suite.hasReport {
+			continue
+		}
+		for specIdx, spec := range suite.report.SpecReports {
+			if spec.State.Is(types.SpecStateFailureStates) || (t.verbose && spec.LeafNodeType.Is(types.NodeTypeIt)) {
+				rows = append(rows, tuiRow{suite: suiteIdx, spec: specIdx})
+			}
+		}
+	}
+	return rows
+}
+
+// handleKey updates the TUI in response to a key press (as returned by parseKeys) and returns the action the watcher should take
+func (t *tui) handleKey(key string) tuiAction {
+	if t.mode == tuiModeFilter {
+		switch key {
+		case "enter":
+			t.mode = tuiModeList
+			t.focus = t.filterInput
+			return t.runUnlessRunning(tuiActionRunAll)
+		case "esc":
+			t.mode = tuiModeList
+		case "backspace":
+			if t.filterInput != "" {
+				_, size := utf8.DecodeLastRuneInString(t.filterInput)
+				t.filterInput = t.filterInput[:len(t.filterInput)-size]
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				t.filterInput += key
+			}
+		}
+		return tuiActionNone
+	}
+
+	rows := t.rows()
+	switch key {
+	case "q", "ctrl-c":
+		if t.running {
+			t.status = "Waiting for the current run to finish - press ctrl-c to abort it"
+			return tuiActionNone
+		}
+		return tuiActionQuit
+	case "a":
+		return t.runUnlessRunning(tuiActionRunAll)
+	case "f":
+		if len(t.failedSuites()) == 0 {
+			t.status = "There are no failed suites to rerun"
+			return tuiActionNone
+		}
+		return t.runUnlessRunning(tuiActionRunFailed)
+	case "/":
+		t.mode = tuiModeFilter
+		t.filterInput = t.focus
+	case "v":
+		t.verbose = !t.verbose
+		t.paneOffset = 0
+	case "j", "down":
+		t.selected = min(t.selected+1, len(rows)-1)
+		t.paneOffset = 0
+	case "k", "up":
+		t.selected = max(t.selected-1, 0)
+		t.paneOffset = 0
+	case "enter":
+		t.showPane = !t.showPane
+		t.paneOffset = 0
+	case "esc":
+		t.showPane = false
+	case "J", "pgdown":
+		if t.showPane {
+			t.paneOffset += max(t.paneHeight-1, 1)
+		}
+	case "K", "pgup":
+		if t.showPane {
+			t.paneOffset = max(t.paneOffset-max(t.paneHeight-1, 1), 0)
+		}
+	}
+	return tuiActionNone
+}
+
+func (t *tui) runUnlessRunning(action tuiAction) tuiAction {
+	if t.running {
+		t.status = "Wait for the current run to finish before starting another"
+		return tuiActionNone
+	}
+	return action
+}
+
+// render renders the screen as height lines that are each no wider than width
+func (t *tui) render(width int, height int) []string {
+	rows := t.rows()
+	t.selected = max(min(t.selected, len(rows)-1), 0)
+
+	// header, list, (separator, pane), status, help
+	listHeight := max(height-3, 1)
+	t.paneHeight = 0
+	if t.showPane {
+		listHeight = max((height-3)/3, 1)
+		t.paneHeight = max(height-4-listHeight, 1)
+	}
+	if t.selected < t.listOffset {
+		t.listOffset = t.selected
+	}
+	if t.selected >= t.listOffset+listHeight {
+		t.listOffset = t.selected - listHeight + 1
+	}
+	t.listOffset = max(min(t.listOffset, len(rows)-listHeight), 0)
+
+	lines := []string{t.renderHeader(width)}
+	for idx := t.listOffset; idx < t.listOffset+listHeight; idx++ {
+		if idx >= len(rows) {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, t.renderRow(rows[idx], idx == t.selected, width))
+	}
+
+	if t.showPane && len(rows) > 0 {
+		title, content := t.pane(rows[t.selected])
+		lines = append(lines, t.f.F("{{gray}}%s{{/}}", tuiTruncate("── "+title+" "+strings.Repeat("─", width), width)))
+		wrapped := []string{}
+		for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+			wrapped = append(wrapped, tuiWrap(line, width)...)
+		}
+		t.paneOffset = max(min(t.paneOffset, len(wrapped)-t.paneHeight), 0)
+		for idx := t.paneOffset; idx < t.paneOffset+t.paneHeight; idx++ {
+			if idx < len(wrapped) {
+				lines = append(lines, wrapped[idx])
+			} else {
+				lines = append(lines, "")
+			}
+		}
+	}
+
+	lines = append(lines, t.f.F("{{gray}}%s{{/}}", tuiTruncate(t.status, width)))
+	lines = append(lines, t.renderHelp(width))
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+	return lines
+}
+
+func (t *tui) renderHeader(width int) string {
+	verbosity := "normal"
+	if t.verbose {
+		verbosity = "verbose"
+	}
+	header := fmt.Sprintf("Ginkgo Watch - %d %s - verbosity: %s", len(t.suites), internal.PluralizedWord("suite", "suites", len(t.suites)), verbosity)
+	if t.focus != "" {
+		header += fmt.Sprintf(" - focus: %s", t.focus)
+	}
+	return t.f.F("{{bold}}%s{{/}}", tuiTruncate(header, width))
+}
+
+func (t *tui) renderHelp(width int) string {
+	if t.mode == tuiModeFilter {
+		return t.f.F("{{bold}}%s{{/}}", tuiTruncate("focus: "+t.filterInput+"_  (enter to run, esc to cancel, leave empty to run everything)", width))
+	}
+	help := "a run all · f run failed · / focus · v verbosity · j/k select · enter output"
+	if t.showPane {
+		help += " · J/K scroll output"
+	}
+	help += " · q quit"
+	return t.f.F("{{gray}}%s{{/}}", tuiTruncate(help, width))
+}
+
+func (t *tui) renderRow(row tuiRow, selected bool, width int) string {
+	cursor := "  "
+	if selected {
+		cursor = "> "
+	}
+	suite := t.suites[row.suite]
+	if row.spec == -1 {
+		glyph, color, summary := t.suiteSummary(suite)
+		line := tuiTruncate(fmt.Sprintf("%s%s %s  %s", cursor, glyph, suite.suite.PackageName, summary), width)
+		return t.f.F(color+"%s{{/}}", line)
+	}
+	spec := suite.report.SpecReports[row.spec]
+	text := spec.FullText()
+	if spec.LeafNodeType.Is(types.NodeTypesForSuiteLevelNodes) {
+		text = fmt.Sprintf("[%s]", spec.LeafNodeType)
+	}
+	line := tuiTruncate(fmt.Sprintf("%s    [%s] %s", cursor, strings.ToUpper(spec.State.String()), text), width)
+	return t.f.F(tuiColorForState(spec.State)+"%s{{/}}", line)
+}
+
+func (t *tui) suiteSummary(suite *tuiSuite) (string, string, string) {
+	switch suite.state {
+	case tuiSuiteStateQueued:
+		return "-", "{{gray}}", "queued"
+	case tuiSuiteStateRunning:
+		return "*", "{{yellow}}", "running..."
+	case tuiSuiteStateFailedToCompile:
+		return "✗", "{{red}}", "failed to compile"
+	case tuiSuiteStateIdle:
+		if !suite.hasReport {
+			return "-", "{{gray}}", "waiting for changes"
+		}
+	}
+	glyph, color := "✓", "{{green}}"
+	if suite.state == tuiSuiteStateFailed {
+		glyph, color = "✗", "{{red}}"
+	}
+	if !suite.hasReport {
+		return glyph, color, "no report was generated"
+	}
+	specs := suite.report.SpecReports
+	counts := []string{}
+	for _, c := range []struct {
+		state types.SpecState
+		name  string
+	}{
+		{types.SpecStateFailureStates, "failed"},
+		{types.SpecStatePassed, "passed"},
+		{types.SpecStatePending, "pending"},
+		{types.SpecStateSkipped, "skipped"},
+	} {
+		if n := specs.WithLeafNodeType(types.NodeTypeIt).CountWithState(c.state); n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, c.name))
+		}
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "no specs ran")
+	}
+	return glyph, color, fmt.Sprintf("%s in %.3fs", strings.Join(counts, ", "), suite.report.RunTime.Seconds())
+}
+
+// pane returns the title and content of the pane for row.  Specs are rendered by the default reporter, suites show their compilation errors or the reasons they failed.
+func (t *tui) pane(row tuiRow) (string, string) {
+	suite := t.suites[row.suite]
+	if row.spec == -1 {
+		switch {
+		case suite.compilationError != "":
+			return suite.suite.PackageName, suite.compilationError
+		case suite.hasReport && len(suite.report.SpecialSuiteFailureReasons) > 0:
+			return suite.suite.PackageName, strings.Join(suite.report.SpecialSuiteFailureReasons, "\n")
+		}
+		return suite.suite.PackageName, "Select a spec to see its output and timeline"
+	}
+
+	spec := suite.report.SpecReports[row.spec]
+	conf := types.ReporterConfig{NoColor: t.noColor, Verbose: !t.verbose, VeryVerbose: t.verbose}
+	// the timeline has not been streamed to the TUI so we render the spec the way we would if it had run in parallel
+	spec.RunningInParallel = true
+	buf := &strings.Builder{}
+	reporters.NewDefaultReporter(conf, buf).DidRun(spec)
+	return spec.FullText(), strings.ReplaceAll(buf.String(), "\t", "    ")
+}
+
+func tuiColorForState(state types.SpecState) string {
+	switch {
+	case state.Is(types.SpecStatePassed):
+		return "{{green}}"
+	case state.Is(types.SpecStatePending):
+		return "{{yellow}}"
+	case state.Is(types.SpecStateSkipped):
+		return "{{cyan}}"
+	case state.Is(types.SpecStateFailed):
+		return "{{red}}"
+	case state.Is(types.SpecStateTimedout):
+		return "{{orange}}"
+	case state.Is(types.SpecStatePanicked):
+		return "{{magenta}}"
+	case state.Is(types.SpecStateInterrupted | types.SpecStateAborted):
+		return "{{coral}}"
+	}
+	return "{{/}}"
+}
+
+// tuiTruncate truncates s to width runes
+func tuiTruncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:max(width, 0)])
+}
+
+/*
+tuiWrap wraps line - which may contain ANSI escape sequences - into lines that are no wider than width.
+
+Escape sequences don't count towards the width.  Colors that are in effect when a line is wrapped are carried over to the next line.
+*/
+func tuiWrap(line string, width int) []string {
+	out := []string{}
+	current, active := &strings.Builder{}, ""
+	n := 0
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\x1b' && i+1 < len(runes) && runes[i+1] == '[' {
+			j := i + 2
+			for j < len(runes) && (runes[j] < '@' || runes[j] > '~') {
+				j++
+			}
+			sequence := string(runes[i:min(j+1, len(runes))])
+			current.WriteString(sequence)
+			if sequence == "\x1b[0m" {
+				active = ""
+			} else {
+				active += sequence
+			}
+			i = j
+			continue
+		}
+		if n == width {
+			if active != "" {
+				current.WriteString("\x1b[0m")
+			}
+			out = append(out, current.String())
+			current.Reset()
+			current.WriteString(active)
+			n = 0
+		}
+		current.WriteRune(runes[i])
+		n++
+	}
+	if active != "" {
+		current.WriteString("\x1b[0m")
+	}
+	return append(out, current.String())
+}
+
+// parseKeys turns the bytes read from a terminal in raw mode into key names: printable characters are returned as-is, special keys as "enter", "esc", "backspace", "up", "down", "pgup", "pgdown", and "ctrl-c"
+func parseKeys(data []byte) []string {
+	keys := []string{}
+	for len(data) > 0 {
+		switch {
+		case data[0] == '\x1b' && len(data) >= 3 && data[1] == '[':
+			sequences := map[string]string{"\x1b[A": "up", "\x1b[B": "down", "\x1b[5~": "pgup", "\x1b[6~": "pgdown"}
+			matched := false
+			for sequence, key := range sequences {
+				if strings.HasPrefix(string(data), sequence) {
+					keys = append(keys, key)
+					data = data[len(sequence):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// skip unsupported escape sequences
+				j := 2
+				for j < len(data) && (data[j] < '@' || data[j] > '~') {
+					j++
+				}
+				data = data[min(j+1, len(data)):]
+			}
+		case data[0] == '\x1b':
+			keys = append(keys, "esc")
+			data = data[1:]
+		case data[0] == '\r' || data[0] == '\n':
+			keys = append(keys, "enter")
+			data = data[1:]
+		case data[0] == 127 || data[0] == '\b':
+			keys = append(keys, "backspace")
+			data = data[1:]
+		case data[0] == 3:
+			keys = append(keys, "ctrl-c")
+			data = data[1:]
+		case data[0] < 32:
+			data = data[1:]
+		default:
+			r, size := utf8.DecodeRune(data)
+			keys = append(keys, string(r))
+			data = data[size:]
+		}
+	}
+	return keys
+}
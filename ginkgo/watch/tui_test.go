@@ -0,0 +1,195 @@
+package watch
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("The watch TUI", func() {
+	var t *tui
+	var a, b internal.TestSuite
+
+	spec := func(text string, state types.SpecState) types.SpecReport {
+		return types.SpecReport{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeText:     text,
+			LeafNodeLocation: types.NewCustomCodeLocation("a_test.go:17"),
+			State:            state,
+		}
+	}
+
+	report := func(specs ...types.SpecReport) types.Report {
+		return types.Report{SuitePath: "/a", SuiteDescription: "A", RunTime: time.Second, SpecReports: specs}
+	}
+
+	BeforeEach(func() {
+		t = newTUI(false, true)
+		a = internal.TestSuite{Path: "/a", PackageName: "a"}
+		b = internal.TestSuite{Path: "/b", PackageName: "b"}
+		Ω(t.addSuite(a)).Should(Equal(0))
+		Ω(t.addSuite(b)).Should(Equal(1))
+		Ω(t.addSuite(a)).Should(Equal(0))
+	})
+
+	runA := func(r types.Report, state internal.TestSuiteState, merge bool) {
+		t.willRun([]int{0}, "")
+		a.State = state
+		t.didRun(0, tuiResult{suite: a, report: r, hasReport: true, merge: merge})
+		t.runDidEnd(nil)
+	}
+
+	Describe("running suites", func() {
+		It("tracks the state of each suite as it runs", func() {
+			t.willRun([]int{0, 1}, "")
+			Ω(t.running).Should(BeTrue())
+			Ω(t.suites[0].state).Should(Equal(tuiSuiteStateRunning))
+			Ω(t.suites[1].state).Should(Equal(tuiSuiteStateQueued))
+
+			a.State = internal.TestSuiteStateFailed
+			t.didRun(0, tuiResult{suite: a, report: report(spec("fails", types.SpecStateFailed)), hasReport: true})
+			Ω(t.suites[0].state).Should(Equal(tuiSuiteStateFailed))
+			Ω(t.suites[1].state).Should(Equal(tuiSuiteStateRunning))
+
+			t.didRun(1, tuiResult{suite: b, compilationError: "boom"})
+			Ω(t.suites[1].state).Should(Equal(tuiSuiteStateFailedToCompile))
+
+			t.runDidEnd([]string{"composite coverage: 100.0% of statements"})
+			Ω(t.running).Should(BeFalse())
+			Ω(t.status).Should(Equal("composite coverage: 100.0% of statements"))
+			Ω(t.failedSuites()).Should(Equal([]int{0, 1}))
+		})
+
+		It("merges the report when only the failed specs were rerun", func() {
+			runA(report(spec("passes", types.SpecStatePassed), spec("fails", types.SpecStateFailed)), internal.TestSuiteStateFailed, false)
+			rerun := report(spec("passes", types.SpecStateSkipped), spec("fails", types.SpecStatePassed))
+			rerun.RunTime = 200 * time.Millisecond
+			runA(rerun, internal.TestSuiteStatePassed, true)
+			Ω(t.suites[0].state).Should(Equal(tuiSuiteStatePassed))
+			Ω(t.suites[0].report.SpecReports.CountWithState(types.SpecStatePassed)).Should(Equal(2))
+			Ω(t.suites[0].report.RunTime).Should(Equal(200 * time.Millisecond))
+
+			runA(report(spec("passes", types.SpecStateSkipped)), internal.TestSuiteStatePassed, false)
+			Ω(t.suites[0].report.SpecReports).Should(HaveLen(1))
+		})
+
+		It("keeps the suite running when it is about to run again", func() {
+			t.willRun([]int{0, 1}, "")
+			a.State = internal.TestSuiteStatePassed
+			t.didRun(0, tuiResult{suite: a, report: report(spec("affected", types.SpecStatePassed)), hasReport: true, more: true})
+			Ω(t.suites[0].state).Should(Equal(tuiSuiteStateRunning))
+			Ω(t.suites[1].state).Should(Equal(tuiSuiteStateQueued))
+
+			t.didRun(0, tuiResult{suite: a, report: report(spec("affected", types.SpecStateSkipped), spec("remaining", types.SpecStatePassed)), hasReport: true, merge: true})
+			Ω(t.suites[0].state).Should(Equal(tuiSuiteStatePassed))
+			Ω(t.suites[0].report.SpecReports.CountWithState(types.SpecStatePassed)).Should(Equal(2))
+			Ω(t.suites[1].state).Should(Equal(tuiSuiteStateRunning))
+		})
+	})
+
+	Describe("the list", func() {
+		BeforeEach(func() {
+			runA(report(spec("passes", types.SpecStatePassed), spec("fails", types.SpecStateFailed)), internal.TestSuiteStateFailed, false)
+		})
+
+		It("lists each suite followed by its failed specs", func() {
+			Ω(t.rows()).Should(Equal([]tuiRow{{0, -1}, {0, 1}, {1, -1}}))
+			lines := t.render(80, 10)
+			Ω(lines).Should(HaveLen(10))
+			Ω(lines[0]).Should(Equal("Ginkgo Watch - 2 suites - verbosity: normal"))
+			Ω(lines[1]).Should(Equal("> ✗ a  1 failed, 1 passed in 1.000s"))
+			Ω(lines[2]).Should(Equal("      [FAILED] fails"))
+			Ω(lines[3]).Should(Equal("  - b  waiting for changes"))
+		})
+
+		It("lists every spec when verbose", func() {
+			Ω(t.handleKey("v")).Should(Equal(tuiActionNone))
+			Ω(t.rows()).Should(Equal([]tuiRow{{0, -1}, {0, 0}, {0, 1}, {1, -1}}))
+			Ω(t.render(80, 10)[2]).Should(Equal("      [PASSED] passes"))
+		})
+
+		It("keeps every line within the terminal", func() {
+			for _, line := range t.render(12, 4) {
+				Ω(utf8.RuneCountInString(line)).Should(BeNumerically("<=", 12))
+			}
+		})
+
+		It("shows the selected spec's output and timeline in a pane", func() {
+			t.suites[0].report.SpecReports[1].CapturedGinkgoWriterOutput = "hello from the GinkgoWriter\n"
+			t.suites[0].report.SpecReports[1].Failure = types.Failure{Message: "expected a bear", Location: types.NewCustomCodeLocation("a_test.go:18"), FailureNodeType: types.NodeTypeIt}
+			t.handleKey("j")
+			t.handleKey("enter")
+			screen := strings.Join(t.render(80, 30), "\n")
+			Ω(screen).Should(ContainSubstring("── fails ──"))
+			Ω(screen).Should(ContainSubstring("Timeline >>"))
+			Ω(screen).Should(ContainSubstring("hello from the GinkgoWriter"))
+			Ω(screen).Should(ContainSubstring("expected a bear"))
+
+			t.handleKey("esc")
+			Ω(strings.Join(t.render(80, 30), "\n")).ShouldNot(ContainSubstring("expected a bear"))
+		})
+
+		It("shows compilation errors in the pane", func() {
+			t.didRun(1, tuiResult{suite: b, compilationError: "undefined: bear"})
+			t.handleKey("j")
+			t.handleKey("j")
+			t.handleKey("enter")
+			Ω(strings.Join(t.render(80, 30), "\n")).Should(ContainSubstring("undefined: bear"))
+		})
+	})
+
+	Describe("key presses", func() {
+		It("reruns everything or only the failed suites", func() {
+			Ω(t.handleKey("a")).Should(Equal(tuiActionRunAll))
+			Ω(t.handleKey("f")).Should(Equal(tuiActionNone))
+			Ω(t.status).Should(Equal("There are no failed suites to rerun"))
+
+			runA(report(spec("fails", types.SpecStateFailed)), internal.TestSuiteStateFailed, false)
+			Ω(t.handleKey("f")).Should(Equal(tuiActionRunFailed))
+		})
+
+		It("focuses on the specs matching a filter", func() {
+			Ω(t.handleKey("/")).Should(Equal(tuiActionNone))
+			for _, key := range []string{"b", "e", "x", "backspace", "a", "r"} {
+				Ω(t.handleKey(key)).Should(Equal(tuiActionNone))
+			}
+			Ω(t.render(80, 10)[9]).Should(HavePrefix("focus: bear_"))
+			Ω(t.handleKey("enter")).Should(Equal(tuiActionRunAll))
+			Ω(t.focus).Should(Equal("bear"))
+			Ω(t.render(80, 10)[0]).Should(HaveSuffix("focus: bear"))
+
+			t.handleKey("/")
+			t.handleKey("x")
+			t.handleKey("esc")
+			Ω(t.focus).Should(Equal("bear"))
+		})
+
+		It("does not start a run or quit while a run is in progress", func() {
+			t.willRun([]int{0}, "")
+			Ω(t.handleKey("a")).Should(Equal(tuiActionNone))
+			Ω(t.handleKey("q")).Should(Equal(tuiActionNone))
+			t.runDidEnd(nil)
+			Ω(t.handleKey("q")).Should(Equal(tuiActionQuit))
+		})
+	})
+
+	Describe("parseKeys", func() {
+		It("parses the bytes read from the terminal", func() {
+			Ω(parseKeys([]byte("aJ\x1b[A\x1b[B\x1b[5~\x1b[6~\x1b[C\r\x7f\x03\x1bé"))).Should(Equal([]string{"a", "J", "up", "down", "pgup", "pgdown", "enter", "backspace", "ctrl-c", "esc", "é"}))
+		})
+	})
+
+	Describe("tuiWrap", func() {
+		It("wraps lines without counting escape sequences and carries colors over", func() {
+			Ω(tuiWrap("abcdef", 4)).Should(Equal([]string{"abcd", "ef"}))
+			Ω(tuiWrap("\x1b[1mabc\x1b[0mdef", 4)).Should(Equal([]string{"\x1b[1mabc\x1b[0md", "ef"}))
+			Ω(tuiWrap("\x1b[31mabcdef", 4)).Should(Equal([]string{"\x1b[31mabcd\x1b[0m", "\x1b[31mef\x1b[0m"}))
+		})
+	})
+})
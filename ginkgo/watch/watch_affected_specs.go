@@ -0,0 +1,104 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// watchReportName is the name of the JSON report suites generate when ginkgo watch needs their reports (i.e. with --tui or --affected-specs) and the user has not asked for a JSON report
+const watchReportName = "ginkgo-watch-report.json"
+
+// affectedSpecsRun is one of the runs ginkgo watch uses to run the specs selected by --affected-specs
+type affectedSpecsRun struct {
+	description string
+	suiteConfig types.SuiteConfig
+	// partial is set when the run only runs some of the suite's specs - its report should be merged into the suite's previous report
+	partial bool
+}
+
+/*
+affectedSpecs returns the specs in suite that could be affected by changedFiles.  Every spec is selected if --affected-specs is not set or if there is no report from a previous run of the suite to work from.
+*/
+func (w *SpecWatcher) affectedSpecs(suite internal.TestSuite, report types.Report, hasReport bool, changedFiles []string) SpecSelection {
+	if !w.cliConfig.AffectedSpecs || !hasReport || len(changedFiles) == 0 {
+		return SpecSelection{All: true}
+	}
+	return AffectedSpecs(report, suite.AbsPath(), changedFiles, w.cliConfig.Depth)
+}
+
+/*
+affectedSpecsRuns returns the runs needed to run the specs in selection: first the selected specs and then, with --then-remaining-specs, the rest of the suite's specs.  Each run after the first should only happen if the previous run passed.
+
+The selected specs are focused with --focus-file filters, which take the place of any --focus-file passed to ginkgo watch.  The remaining specs are run by skipping the selected specs with --skip-file filters.
+*/
+func (w *SpecWatcher) affectedSpecsRuns(selection SpecSelection, suiteConfig types.SuiteConfig) []affectedSpecsRun {
+	if selection.All {
+		return []affectedSpecsRun{{suiteConfig: suiteConfig}}
+	}
+	runs := []affectedSpecsRun{}
+	filters := selection.FileFilters()
+	if !selection.IsEmpty() {
+		run := affectedSpecsRun{description: selection.Description(), suiteConfig: suiteConfig, partial: true}
+		run.suiteConfig.FocusFiles = filters
+		runs = append(runs, run)
+	}
+	if w.cliConfig.ThenRemainingSpecs {
+		run := affectedSpecsRun{description: "the remaining specs", suiteConfig: suiteConfig, partial: true}
+		run.suiteConfig.SkipFiles = append(append([]string{}, suiteConfig.SkipFiles...), filters...)
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// reporterConfigWithJSONReport returns the reporter configuration to run suites with when ginkgo watch needs their reports
+func (w *SpecWatcher) reporterConfigWithJSONReport() types.ReporterConfig {
+	reporterConfig := w.reporterConfig
+	if reporterConfig.JSONReport == "" {
+		reporterConfig.JSONReport = watchReportName
+	}
+	return reporterConfig
+}
+
+// loadReport loads the JSON report generated by suite.  The report is removed if the user did not ask for it.
+func (w *SpecWatcher) loadReport(suite internal.TestSuite, reporterConfig types.ReporterConfig) (types.Report, bool) {
+	path := internal.AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, w.cliConfig, 0)
+	reports, err := internal.LoadJSONReports([]string{path})
+	if w.reporterConfig.JSONReport == "" {
+		os.Remove(path)
+	}
+	if err != nil || len(reports) == 0 {
+		return types.Report{}, false
+	}
+	return reports[0], true
+}
+
+/*
+runAffectedSpecs is the --affected-specs variant of running a compiled suite.  It performs runs (see affectedSpecsRuns) one after the other, stopping at the first run that fails.
+
+The suite's report is recorded so that the next run can work out which specs are affected.
+*/
+func (w *SpecWatcher) runAffectedSpecs(suite internal.TestSuite, runs []affectedSpecsRun, additionalArgs []string) internal.TestSuite {
+	reporterConfig := w.reporterConfigWithJSONReport()
+	for _, run := range runs {
+		if w.interruptHandler.Status().Interrupted() {
+			break
+		}
+		if run.description != "" {
+			fmt.Printf("Running %s in %s\n", run.description, suite.PackageName)
+		}
+		suite = internal.RunCompiledSuite(suite, run.suiteConfig, reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
+		if report, ok := w.loadReport(suite, reporterConfig); ok {
+			if previous, ok := w.reports[suite.Path]; ok && run.partial {
+				report = internal.MergeReports([]types.Report{previous, report})[0]
+			}
+			w.reports[suite.Path] = report
+		}
+		if !suite.State.Is(internal.TestSuiteStatePassed) {
+			break
+		}
+	}
+	return suite
+}
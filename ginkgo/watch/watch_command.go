@@ -49,6 +49,7 @@ func BuildWatchCommand() command.Command {
 				flags:          flags,
 
 				interruptHandler: interruptHandler,
+				reports:          map[string]types.Report{},
 			}
 
 			watcher.WatchSpecs(args, additionalArgs)
@@ -64,6 +65,9 @@ type SpecWatcher struct {
 	flags          types.GinkgoFlagSet
 
 	interruptHandler *interrupt_handler.InterruptHandler
+
+	// reports holds the most recent report of each suite (keyed by path) so that --affected-specs can work out which specs a change affects
+	reports map[string]types.Report
 }
 
 func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
@@ -75,6 +79,16 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 		command.AbortWith("Found no test suites")
 	}
 
+	var err error
+	w.reporterConfig, err = internal.OpenEventStream(w.reporterConfig, nil)
+	command.AbortIfError("Failed to open the event stream:", err)
+	defer internal.CloseEventStream()
+
+	if w.cliConfig.TUI {
+		w.watchSpecsInTUI(args, suites, additionalArgs)
+		return
+	}
+
 	fmt.Printf("Identified %d test %s.  Locating dependencies to a depth of %d (this may take a while)...\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), w.cliConfig.Depth)
 	deltaTracker := NewDeltaTracker(w.cliConfig.Depth, regexp.MustCompile(w.cliConfig.WatchRegExp))
 	delta, errors := deltaTracker.Delta(suites)
@@ -90,7 +104,7 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 
 	if len(suites) == 1 {
 		w.updateSeed()
-		w.compileAndRun(suites[0], additionalArgs)
+		w.compileAndRun(suites[0], nil, additionalArgs)
 	}
 
 	ticker := time.NewTicker(time.Second)
@@ -137,7 +151,7 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 					return
 				}
 				deltaTracker.WillRun(suites[idx])
-				suites[idx] = w.compileAndRun(suites[idx], additionalArgs)
+				suites[idx] = w.compileAndRun(suites[idx], delta.ModifiedFiles, additionalArgs)
 			}
 			color := "{{green}}"
 			if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
@@ -156,7 +170,16 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 	}
 }
 
-func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, additionalArgs []string) internal.TestSuite {
+func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, changedFiles []string, additionalArgs []string) internal.TestSuite {
+	var runs []affectedSpecsRun
+	if w.cliConfig.AffectedSpecs {
+		report, hasReport := w.reports[suite.Path]
+		runs = w.affectedSpecsRuns(w.affectedSpecs(suite, report, hasReport, changedFiles), w.suiteConfig)
+		if len(runs) == 0 {
+			fmt.Printf("No specs in %s are affected by the change\n", suite.PackageName)
+			return suite
+		}
+	}
 	suite = internal.CompileSuite(suite, w.goFlagsConfig, false)
 	if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
 		fmt.Println(suite.CompilationError.Error())
@@ -165,7 +188,11 @@ func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, additionalArgs []s
 	if w.interruptHandler.Status().Interrupted() {
 		return suite
 	}
-	suite = internal.RunCompiledSuite(suite, w.suiteConfig, w.reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
+	if w.cliConfig.AffectedSpecs {
+		suite = w.runAffectedSpecs(suite, runs, additionalArgs)
+	} else {
+		suite = internal.RunCompiledSuite(suite, w.suiteConfig, w.reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
+	}
 	internal.Cleanup(w.goFlagsConfig, suite)
 	return suite
 }
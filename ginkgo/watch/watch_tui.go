@@ -0,0 +1,227 @@
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+watchSpecsInTUI is the --tui variant of WatchSpecs.  Instead of streaming each run's output it takes over the terminal and shows the state of each watched suite and its specs.
+
+Each suite generates a JSON report which the TUI loads once the suite has run.  While the TUI is running anything written to stdout or stderr (including the output of the suites themselves) is redirected to a log file so that it doesn't clobber the screen.
+*/
+func (w *SpecWatcher) watchSpecsInTUI(args []string, suites internal.TestSuites, additionalArgs []string) {
+	in, out := os.Stdin, os.Stdout
+	restoreTerminal, err := makeRaw(int(in.Fd()))
+	command.AbortIfError("--tui requires an interactive terminal:", err)
+	defer restoreTerminal()
+
+	tmpDir, err := os.MkdirTemp("", "ginkgo-watch-tui")
+	command.AbortIfError("Failed to create temporary directory:", err)
+	defer os.RemoveAll(tmpDir)
+	log, err := os.Create(filepath.Join(tmpDir, "output.log"))
+	command.AbortIfError("Failed to create log file:", err)
+	defer log.Close()
+
+	fmt.Fprintf(out, "Identified %d test %s.  Locating dependencies to a depth of %d (this may take a while)...\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), w.cliConfig.Depth)
+	deltaTracker := NewDeltaTracker(w.cliConfig.Depth, regexp.MustCompile(w.cliConfig.WatchRegExp))
+	delta, errors := deltaTracker.Delta(suites)
+
+	model := newTUI(w.reporterConfig.Verbosity().GTE(types.VerbosityLevelVerbose), w.reporterConfig.NoColor)
+	for _, suite := range delta.NewSuites {
+		model.addSuite(suite.Suite)
+	}
+	for suite, err := range errors {
+		model.status = fmt.Sprintf("Failed to watch %s: %s", suite.PackageName, err)
+	}
+
+	stdout, stderr, colorableStdOut, colorableStdErr := os.Stdout, os.Stderr, formatter.ColorableStdOut, formatter.ColorableStdErr
+	os.Stdout, os.Stderr, formatter.ColorableStdOut, formatter.ColorableStdErr = log, log, log, log
+	defer func() {
+		os.Stdout, os.Stderr, formatter.ColorableStdOut, formatter.ColorableStdErr = stdout, stderr, colorableStdOut, colorableStdErr
+	}()
+
+	// switch to the alternate screen and hide the cursor
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan string)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, key := range parseKeys(buf[:n]) {
+				keys <- key
+			}
+		}
+	}()
+
+	var results chan tuiResult
+	var ran internal.TestSuites
+	startRun := func(indices []int, onlyFailed bool, description string, selections map[int]SpecSelection) {
+		w.updateSeed()
+		ran = internal.TestSuites{}
+		model.willRun(indices, description)
+		results = w.runSuitesForTUI(model, indices, onlyFailed, selections, tmpDir, additionalArgs)
+	}
+
+	if len(model.suites) == 1 {
+		deltaTracker.WillRun(model.suites[0].suite)
+		startRun(model.allSuites(), false, "", nil)
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		w.renderTUI(model, out)
+		select {
+		case key := <-keys:
+			switch model.handleKey(key) {
+			case tuiActionQuit:
+				return
+			case tuiActionRunAll:
+				for _, idx := range model.allSuites() {
+					deltaTracker.WillRun(model.suites[idx].suite)
+				}
+				startRun(model.allSuites(), false, "", nil)
+			case tuiActionRunFailed:
+				startRun(model.failedSuites(), true, " with failures", nil)
+			}
+		case result, ok := <-results:
+			if !ok {
+				results = nil
+				messages, err := internal.FinalizeProfilesAndReportsForSuites(ran, w.cliConfig, w.suiteConfig, w.reporterConfig, w.goFlagsConfig)
+				if err != nil {
+					messages = append(messages, "Could not finalize profiles: "+err.Error())
+				}
+				model.runDidEnd(messages)
+				break
+			}
+			if !result.more {
+				ran = append(ran, result.suite)
+			}
+			model.didRun(model.indexOf(result.suite.Path), result)
+		case <-ticker.C:
+			if results != nil {
+				break
+			}
+			suites := internal.FindSuites(args, w.cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
+			delta, _ = deltaTracker.Delta(suites)
+			indices, selections, unaffected := []int{}, map[int]SpecSelection{}, []string{}
+			for _, suite := range delta.NewSuites {
+				deltaTracker.WillRun(suite.Suite)
+				indices = append(indices, model.addSuite(suite.Suite))
+			}
+			for _, suite := range delta.ModifiedSuites() {
+				deltaTracker.WillRun(suite.Suite)
+				idx := model.addSuite(suite.Suite)
+				selection := w.affectedSpecs(suite.Suite, model.suites[idx].report, model.suites[idx].hasReport, delta.ModifiedFiles)
+				if len(w.affectedSpecsRuns(selection, w.suiteConfig)) == 0 {
+					unaffected = append(unaffected, suite.Suite.PackageName)
+					continue
+				}
+				indices, selections[idx] = append(indices, idx), selection
+			}
+			if len(indices) > 0 {
+				startRun(indices, false, " after detecting changes in "+strings.Join(delta.ModifiedPackages, ", "), selections)
+			} else if len(unaffected) > 0 {
+				model.status = "No specs in " + strings.Join(unaffected, ", ") + " are affected by the change"
+			}
+		case <-w.interruptHandler.Status().Channel:
+			return
+		}
+	}
+}
+
+/*
+runSuitesForTUI compiles and runs the suites at indices, one after the other, in the background.  The result of each suite is sent on the returned channel, which is closed once all the suites have run.
+
+With onlyFailed each suite is run with --rerun-failed pointed at its most recent report.  Suites with an entry in selections only run the selected specs (see affectedSpecsRuns).  A focus set in the TUI replaces any --focus passed to ginkgo watch.
+*/
+func (w *SpecWatcher) runSuitesForTUI(model *tui, indices []int, onlyFailed bool, selections map[int]SpecSelection, tmpDir string, additionalArgs []string) chan tuiResult {
+	suiteConfig := w.suiteConfig
+	if model.focus != "" {
+		suiteConfig.FocusStrings = []string{model.focus}
+	}
+	reporterConfig := w.reporterConfigWithJSONReport()
+
+	type job struct {
+		suite internal.TestSuite
+		runs  []affectedSpecsRun
+	}
+	jobs := []job{}
+	for _, idx := range indices {
+		selection, ok := selections[idx]
+		if !ok {
+			selection = SpecSelection{All: true}
+		}
+		j := job{suite: model.suites[idx].suite, runs: w.affectedSpecsRuns(selection, suiteConfig)}
+		if onlyFailed && model.suites[idx].hasReport {
+			rerunFailed := filepath.Join(tmpDir, fmt.Sprintf("rerun-failed-%d.json", idx))
+			data, _ := json.Marshal([]types.Report{model.suites[idx].report})
+			if os.WriteFile(rerunFailed, data, 0644) == nil {
+				j.runs[0].suiteConfig.RerunFailed, j.runs[0].partial = rerunFailed, true
+			}
+		}
+		jobs = append(jobs, j)
+	}
+
+	results := make(chan tuiResult)
+	go func() {
+		defer close(results)
+		for _, j := range jobs {
+			if w.interruptHandler.Status().Interrupted() {
+				return
+			}
+			suite := internal.CompileSuite(j.suite, w.goFlagsConfig, false)
+			if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
+				results <- tuiResult{suite: suite, compilationError: suite.CompilationError.Error()}
+				continue
+			}
+			for idx, run := range j.runs {
+				suite = internal.RunCompiledSuite(suite, run.suiteConfig, reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
+				result := tuiResult{suite: suite, merge: run.partial}
+				result.report, result.hasReport = w.loadReport(suite, reporterConfig)
+				result.more = idx < len(j.runs)-1 && suite.State.Is(internal.TestSuiteStatePassed) && !w.interruptHandler.Status().Interrupted()
+				results <- result
+				if !result.more {
+					break
+				}
+			}
+			internal.Cleanup(w.goFlagsConfig, suite)
+		}
+	}()
+	return results
+}
+
+func (w *SpecWatcher) renderTUI(model *tui, out io.Writer) {
+	width, height, err := terminalSize(int(os.Stdin.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+	screen := &strings.Builder{}
+	// move to the top left and redraw every line, clearing whatever is left over from the previous render
+	screen.WriteString("\x1b[H")
+	for idx, line := range model.render(width, height) {
+		if idx > 0 {
+			screen.WriteString("\r\n")
+		}
+		screen.WriteString(line + "\x1b[K")
+	}
+	screen.WriteString("\x1b[J")
+	fmt.Fprint(out, screen.String())
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"os"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type workerConfig struct {
+	Coordinator string
+	Token       string
+	Dir         string
+}
+
+func BuildWorkerCommand() command.Command {
+	conf := workerConfig{
+		Dir: ".",
+	}
+	flags, err := types.NewGinkgoFlagSet(
+		types.GinkgoFlags{
+			{Name: "coordinator", KeyPath: "Coordinator",
+				Usage:         "The address of the ginkgo run coordinating the remote workers.  Required.",
+				UsageArgument: "host:port",
+			},
+			{Name: "token", KeyPath: "Token",
+				Usage:             "The token printed by, or passed to, the coordinating ginkgo run with --remote-worker-token.  Required.",
+				UsageArgument:     "token",
+				UsageDefaultValue: "$GINKGO_REMOTE_WORKER_TOKEN",
+			},
+			{Name: "dir", KeyPath: "Dir",
+				Usage:             "The root of the checkout of the code under test.  Suites are run from their package directory beneath this root.",
+				UsageArgument:     "path",
+				UsageDefaultValue: conf.Dir,
+			},
+		},
+		&conf,
+		types.GinkgoFlagSections{},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "worker",
+		Usage:         "ginkgo worker --coordinator=host:port --token=token",
+		ShortDoc:      "Run parallel processes handed out by a ginkgo run started with --remote-workers",
+		Documentation: "The worker exits when the coordinating ginkgo run completes.",
+		DocLink:       "distributing-specs-across-machines",
+		Flags:         flags,
+		Command: func(args []string, _ []string) {
+			if conf.Coordinator == "" {
+				command.AbortWithUsage("worker requires a --coordinator")
+			}
+			if conf.Token == "" {
+				conf.Token = os.Getenv(internal.REMOTE_WORKER_TOKEN_ENV_VAR)
+			}
+			if conf.Token == "" {
+				command.AbortWithUsage("worker requires a --token")
+			}
+			err := internal.RunRemoteWorker(conf.Coordinator, conf.Token, conf.Dir)
+			command.AbortIfError("Remote worker failed:", err)
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/onsi/ginkgo/v2/internal/global"
 	"github.com/onsi/ginkgo/v2/internal/testingtproxy"
 	"github.com/onsi/ginkgo/v2/types"
 )
@@ -21,6 +22,7 @@ GinkgoT() attempts to mimic the behavior of `testing.T` with the exception of th
 
 - Error/Errorf: failures in Ginkgo always immediately stop execution and there is no mechanism to log a failure without aborting the test.  As such Error/Errorf are equivalent to Fatal/Fatalf.
 - Parallel() is a no-op as Ginkgo's multi-process parallelism model is substantially different from go test's in-process model.
+- Subtests are supported via GinkgoTRunner: GinkgoT().(GinkgoTRunner).Run(name, f) runs f as a subtest but f receives a GinkgoTRunner instead of a *testing.T.  Subtests are recorded in the spec's SpecReport.Subtests.
 
 You can learn more here: https://onsi.github.io/ginkgo/#using-third-party-libraries
 */
@@ -36,6 +38,7 @@ func GinkgoT(optionalOffset ...int) FullGinkgoTInterface {
 		DeferCleanup,
 		CurrentSpecReport,
 		AddReportEntry,
+		global.Suite.AddSubtestReport,
 		GinkgoRecover,
 		AttachProgressReporter,
 		suiteConfig.RandomSeed,
@@ -48,65 +51,19 @@ func GinkgoT(optionalOffset ...int) FullGinkgoTInterface {
 /*
 The portion of the interface returned by GinkgoT() that maps onto methods in the testing package's T.
 */
-type GinkgoTInterface interface {
-	Cleanup(func())
-	Chdir(dir string)
-	Context() context.Context
-	Setenv(kev, value string)
-	Error(args ...any)
-	Errorf(format string, args ...any)
-	Fail()
-	FailNow()
-	Failed() bool
-	Fatal(args ...any)
-	Fatalf(format string, args ...any)
-	Helper()
-	Log(args ...any)
-	Logf(format string, args ...any)
-	Name() string
-	Parallel()
-	Skip(args ...any)
-	SkipNow()
-	Skipf(format string, args ...any)
-	Skipped() bool
-	TempDir() string
-	Attr(key, value string)
-	Output() io.Writer
-	ArtifactDir() string
-}
+type GinkgoTInterface = testingtproxy.GinkgoTInterface
 
 /*
 Additional methods returned by GinkgoT() that provide deeper integration points into Ginkgo
 */
-type FullGinkgoTInterface interface {
-	GinkgoTInterface
-
-	AddReportEntryVisibilityAlways(name string, args ...any)
-	AddReportEntryVisibilityFailureOrVerbose(name string, args ...any)
-	AddReportEntryVisibilityNever(name string, args ...any)
-
-	//Prints to the GinkgoWriter
-	Print(a ...any)
-	Printf(format string, a ...any)
-	Println(a ...any)
-
-	//Provides access to Ginkgo's color formatting, correctly configured to match the color settings specified in the invocation of ginkgo
-	F(format string, args ...any) string
-	Fi(indentation uint, format string, args ...any) string
-	Fiw(indentation uint, maxWidth uint, format string, args ...any) string
-
-	//Generates a formatted string version of the current spec's timeline
-	RenderTimeline() string
+type FullGinkgoTInterface = testingtproxy.FullGinkgoTInterface
 
-	GinkgoRecover()
-	DeferCleanup(args ...any)
-
-	RandomSeed() int64
-	ParallelProcess() int
-	ParallelTotal() int
+/*
+GinkgoTRunner is implemented by the value returned by GinkgoT() and by the t passed to its subtests.  Use GinkgoT().(GinkgoTRunner).Run(name, f) to run f as a subtest.
 
-	AttachProgressReporter(func() string) func()
-}
+You can learn more here: https://onsi.github.io/ginkgo/#subtests
+*/
+type GinkgoTRunner = testingtproxy.GinkgoTRunner
 
 /*
 GinkgoTB() implements a wrapper that exactly matches the testing.TB interface.
@@ -0,0 +1,5 @@
+package a
+
+func A() string {
+	return "a"
+}
@@ -0,0 +1,5 @@
+package b
+
+func B() string {
+	return "b"
+}
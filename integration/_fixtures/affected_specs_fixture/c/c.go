@@ -0,0 +1,5 @@
+package c
+
+func C() string {
+	return "c"
+}
@@ -0,0 +1,7 @@
+package specs_test
+
+import "github.com/onsi/ginkgo/v2/integration/_fixtures/affected_specs_fixture/c"
+
+func useC() string {
+	return c.C()
+}
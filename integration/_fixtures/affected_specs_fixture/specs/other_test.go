@@ -0,0 +1,12 @@
+package specs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Other", func() {
+	It("lives in another file", func() {
+		Ω(2).Should(Equal(2))
+	})
+})
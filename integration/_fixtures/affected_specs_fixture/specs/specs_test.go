@@ -0,0 +1,27 @@
+package specs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/integration/_fixtures/affected_specs_fixture/a"
+	"github.com/onsi/ginkgo/v2/integration/_fixtures/affected_specs_fixture/b"
+)
+
+var _ = Describe("Specs", func() {
+	It("uses a", func() {
+		Ω(a.A()).Should(Equal("a"))
+	})
+
+	It("uses b", func() {
+		Ω(b.B()).Should(Equal("b"))
+	})
+
+	It("uses c through a helper", func() {
+		Ω(useC()).Should(Equal("c"))
+	})
+
+	It("uses nothing", func() {
+		Ω(1).Should(Equal(1))
+	})
+})
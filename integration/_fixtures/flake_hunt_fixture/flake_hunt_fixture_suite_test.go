@@ -0,0 +1,13 @@
+package flake_hunt_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFlakeHuntFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FlakeHunt Fixture Suite")
+}
@@ -0,0 +1,16 @@
+package flake_hunt_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("flake hunt", func() {
+	It("passes", func() {
+		Ω(true).Should(BeTrue())
+	})
+
+	It("flakes", func() {
+		Ω(GinkgoRandomSeed()%2).Should(BeZero(), "fails with odd seeds")
+	})
+})
@@ -0,0 +1,18 @@
+package fuzz_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFuzzFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fuzz Fixture Suite")
+}
+
+func FuzzFixture(f *testing.F) {
+	RegisterFailHandler(Fail)
+	RunFuzzSpecs(f)
+}
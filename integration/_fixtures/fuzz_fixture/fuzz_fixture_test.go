@@ -0,0 +1,24 @@
+package fuzz_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("names", func() {
+	DescribeFuzz("are short",
+		func(name string) {
+			GinkgoWriter.Printf("checking %q\n", name)
+			Ω(len(name)).Should(BeNumerically("<", 4), "name is too long")
+		},
+		FuzzEntry("abc"),
+		FuzzEntry(""),
+	)
+
+	DescribeFuzz("are never nil",
+		func(name []byte, n int) {
+			Ω(len(name)).Should(BeNumerically(">=", 0))
+		},
+		FuzzEntry([]byte("abc"), 3),
+	)
+})
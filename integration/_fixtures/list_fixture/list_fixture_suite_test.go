@@ -0,0 +1,13 @@
+package list_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestListFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ListFixture Suite")
+}
@@ -0,0 +1,20 @@
+package list_fixture_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Cats", Label("cat"), func() {
+	It("purrs", Label("happy"), func() {})
+	It("hisses", Serial, FlakeAttempts(3), func() {})
+	PIt("naps", func() {})
+	It("hisses", func() {})
+})
+
+var _ = Describe("Dogs", Ordered, func() {
+	It("barks", SpecTimeout(time.Second), func(ctx context.Context) {})
+	It("wags", SemVerConstraint(">= 2.0.0"), func() {})
+})
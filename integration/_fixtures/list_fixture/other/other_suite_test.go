@@ -0,0 +1,15 @@
+package other_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOther(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Other Suite")
+}
+
+var _ = It("is another spec", func() {})
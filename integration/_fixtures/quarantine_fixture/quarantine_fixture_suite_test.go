@@ -0,0 +1,13 @@
+package quarantine_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestQuarantineFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Quarantine Fixture Suite")
+}
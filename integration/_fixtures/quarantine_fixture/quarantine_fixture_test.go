@@ -0,0 +1,20 @@
+package quarantine_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("quarantine", func() {
+	It("passes", func() {
+		Ω(true).Should(BeTrue())
+	})
+
+	It("flakes", func() {
+		Ω(true).Should(BeFalse(), "a known flake")
+	})
+
+	It("fails", func() {
+		Ω(true).Should(BeFalse(), "a real failure")
+	})
+})
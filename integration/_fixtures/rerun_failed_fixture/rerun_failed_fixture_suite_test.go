@@ -0,0 +1,13 @@
+package rerun_failed_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRerunFailedFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RerunFailedFixture Suite")
+}
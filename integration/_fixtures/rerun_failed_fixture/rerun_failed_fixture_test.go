@@ -0,0 +1,35 @@
+package rerun_failed_fixture_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// specs that "fail" only do so until the fixed file is written to the suite's directory
+func failUnlessFixed() {
+	_, err := os.Stat("fixed")
+	Ω(err).ShouldNot(HaveOccurred(), "not fixed yet")
+}
+
+var _ = Describe("widgets", func() {
+	It("passes", func() {})
+	It("fails", failUnlessFixed)
+
+	Context("nested", func() {
+		It("fails", failUnlessFixed)
+		It("passes", func() {})
+	})
+})
+
+var _ = DescribeTable("a table with duplicate entry descriptions",
+	func(fail bool) {
+		if fail {
+			failUnlessFixed()
+		}
+	},
+	Entry("an entry", false),
+	Entry("an entry", true),
+	Entry("an entry", false),
+)
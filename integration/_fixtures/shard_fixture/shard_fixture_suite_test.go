@@ -0,0 +1,13 @@
+package shard_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestShardFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ShardFixture Suite")
+}
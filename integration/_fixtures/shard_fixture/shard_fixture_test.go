@@ -0,0 +1,24 @@
+package shard_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("sharding", func() {
+	It("A", func() {})
+	It("B", func() {})
+	It("C", func() {})
+	It("D", func() {})
+	It("E", Label("skip-me"), func() {})
+
+	Describe("ordered", Ordered, func() {
+		It("O1", func() {})
+		It("O2", func() {})
+		It("O3", func() {})
+	})
+
+	Describe("serial", Serial, func() {
+		It("S1", func() {})
+		It("S2", func() {})
+	})
+})
@@ -0,0 +1,13 @@
+package shared_fixture_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSharedFixtureFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SharedFixture Fixture Suite")
+}
@@ -0,0 +1,67 @@
+package shared_fixture_fixture_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type DB struct {
+	URL string
+}
+
+var db = SharedFixture("db", func() DB {
+	GinkgoWriter.Printf("SETTING UP db ON PROC %d\n", GinkgoParallelProcess())
+	time.Sleep(200 * time.Millisecond)
+	return DB{URL: "postgres://localhost"}
+}, func(db DB) {
+	GinkgoWriter.Printf("TEARING DOWN db %s ON PROC %d\n", db.URL, GinkgoParallelProcess())
+})
+
+var _ = SharedFixture("unused", func() string {
+	GinkgoWriter.Println("SETTING UP unused")
+	return "unused"
+}, func(string) {
+	GinkgoWriter.Println("TEARING DOWN unused")
+})
+
+var cacheTornDown bool
+
+var cache = SharedFixture("cache", func() string {
+	GinkgoWriter.Printf("SETTING UP cache ON PROC %d\n", GinkgoParallelProcess())
+	return "redis://localhost"
+}, func(url string) {
+	cacheTornDown = true
+	GinkgoWriter.Printf("TEARING DOWN cache %s ON PROC %d\n", url, GinkgoParallelProcess())
+})
+
+var _ = Describe("specs that declare the fixtures they use", func() {
+	Describe("cache users", UsesFixture(cache), func() {
+		for i := range 4 {
+			It(fmt.Sprintf("uses the cache %d", i), func() {
+				Ω(cache.Get()).Should(Equal("redis://localhost"))
+				time.Sleep(50 * time.Millisecond)
+			})
+		}
+		It("uses the cache serially", Serial, func() {
+			Ω(cache.Get()).Should(Equal("redis://localhost"))
+		})
+	})
+
+	It("runs after the cache users when running in series", func() {
+		if suiteConfig, _ := GinkgoConfiguration(); suiteConfig.ParallelTotal == 1 {
+			Ω(cacheTornDown).Should(BeTrue())
+		}
+	})
+})
+
+var _ = Describe("specs that share a fixture", func() {
+	for i := range 6 {
+		It(fmt.Sprintf("uses the db %d", i), func() {
+			Ω(db.Get().URL).Should(Equal("postgres://localhost"))
+			time.Sleep(50 * time.Millisecond)
+		})
+	}
+})
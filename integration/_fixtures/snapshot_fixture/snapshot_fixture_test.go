@@ -0,0 +1,21 @@
+package snapshot_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("rendering", func() {
+	for i := 0; i < 10; i++ {
+		It(fmt.Sprintf("renders book %d", i), func() {
+			MatchSnapshot("title", fmt.Sprintf("Book %d\nEdition %s", i, os.Getenv("EDITION")))
+			MatchSnapshot("metadata", map[string]int{"book": i})
+		})
+	}
+
+	It(fmt.Sprintf("renders the %s index", os.Getenv("INDEX_NAME")), func() {
+		MatchSnapshot("index", "the index")
+	})
+})
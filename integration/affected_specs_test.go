@@ -0,0 +1,69 @@
+package integration_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Watch with --affected-specs", MarkSlow, func() {
+	var session *gexec.Session
+
+	BeforeEach(func() {
+		fm.MountFixture("affected_specs")
+	})
+
+	AfterEach(func() {
+		if session != nil {
+			session.Kill().Wait()
+		}
+	})
+
+	modifyFile := func(path ...string) {
+		time.Sleep(time.Second)
+		content, err := os.ReadFile(fm.PathTo("affected_specs", path...))
+		Ω(err).ShouldNot(HaveOccurred())
+		content = append(content, []byte("//")...)
+		Ω(os.WriteFile(fm.PathTo("affected_specs", path...), content, 0666)).Should(Succeed())
+	}
+
+	It("only reruns the specs affected by the change", func() {
+		session = startGinkgo(fm.PathTo("affected_specs"), "watch", "--affected-specs", "-v", "-depth=1", "specs")
+		Eventually(session).Should(gbytes.Say(`Ran 5 of 5 Specs`))
+
+		modifyFile("a", "a.go")
+		Eventually(session).Should(gbytes.Say("Running 1 spec affected by the change in specs"))
+		Eventually(session).Should(gbytes.Say("uses a"))
+		Eventually(session).Should(gbytes.Say(`Ran 1 of 5 Specs`))
+
+		modifyFile("c", "c.go")
+		Eventually(session).Should(gbytes.Say("Running 1 spec affected by the change in specs"))
+		Eventually(session).Should(gbytes.Say("uses c through a helper"))
+		Eventually(session).Should(gbytes.Say(`Ran 1 of 5 Specs`))
+
+		modifyFile("specs", "other_test.go")
+		Eventually(session).Should(gbytes.Say("Running the specs in 1 changed spec file in specs"))
+		Eventually(session).Should(gbytes.Say("lives in another file"))
+		Eventually(session).Should(gbytes.Say(`Ran 1 of 5 Specs`))
+
+		modifyFile("specs", "helpers_test.go")
+		Eventually(session).Should(gbytes.Say(`Ran 5 of 5 Specs`))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("ginkgo-watch-report.json"))
+		Ω(fm.PathTo("affected_specs", "specs", "ginkgo-watch-report.json")).ShouldNot(BeAnExistingFile())
+	})
+
+	It("runs the remaining specs once the affected specs pass with --then-remaining-specs", func() {
+		session = startGinkgo(fm.PathTo("affected_specs"), "watch", "--affected-specs", "--then-remaining-specs", "-depth=1", "specs")
+		Eventually(session).Should(gbytes.Say(`Ran 5 of 5 Specs`))
+
+		modifyFile("b", "b.go")
+		Eventually(session).Should(gbytes.Say("Running 1 spec affected by the change in specs"))
+		Eventually(session).Should(gbytes.Say(`Ran 1 of 5 Specs`))
+		Eventually(session).Should(gbytes.Say("Running the remaining specs in specs"))
+		Eventually(session).Should(gbytes.Say(`Ran 4 of 5 Specs`))
+	})
+})
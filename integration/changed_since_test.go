@@ -0,0 +1,107 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("--changed-since", func() {
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Ginkgo", "-c", "user.email=ginkgo@example.com"}, args...)...)
+		cmd.Dir = fm.PathTo("affected_specs")
+		output, err := cmd.CombinedOutput()
+		Ω(err).ShouldNot(HaveOccurred(), string(output))
+	}
+
+	BeforeEach(func() {
+		fm.MountFixture("affected_specs")
+		fm.WriteFile("affected_specs", ".gitignore", "*.json\n")
+		git("init", "-q")
+		git("add", ".")
+		git("commit", "-q", "-m", "baseline")
+
+		session := startGinkgo(fm.PathTo("affected_specs"), "--json-report=baseline.json", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+	})
+
+	specStates := func() map[string]types.SpecReport {
+		states := map[string]types.SpecReport{}
+		for _, spec := range fm.LoadJSONReports("affected_specs", "report.json")[0].SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+			states[spec.LeafNodeText] = spec
+		}
+		return states
+	}
+
+	It("skips every spec in suites that aren't impacted by the changes", func() {
+		fm.WriteFile("affected_specs", "README.md", "nothing to see here")
+		session := startGinkgo(fm.PathTo("affected_specs"), "--changed-since=HEAD", "--json-report=report.json", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("Found 1 file changed since HEAD"))
+		Ω(session).Should(gbytes.Say("specs: not impacted - all specs will be skipped"))
+		Ω(session).Should(gbytes.Say("Ran 0 of 5 Specs"))
+
+		specs := specStates()
+		Ω(specs).Should(HaveLen(5))
+		for _, spec := range specs {
+			Ω(spec.State).Should(Equal(types.SpecStateSkipped))
+			Ω(spec.Failure.Message).Should(Equal("not impacted by the changes since HEAD"))
+		}
+	})
+
+	It("runs every spec in impacted suites when there is no baseline", func() {
+		fm.AppendToFile("affected_specs", "a/a.go", "//")
+		session := startGinkgo(fm.PathTo("affected_specs"), "--changed-since=HEAD", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("specs: impacted - all specs will run"))
+		Ω(session).Should(gbytes.Say("Ran 5 of 5 Specs"))
+	})
+
+	It("narrows impacted suites down to the impacted specs with a baseline", MarkSlow, func() {
+		fm.AppendToFile("affected_specs", "a/a.go", "//")
+		git("add", ".")
+		git("commit", "-q", "-m", "change a")
+		session := startGinkgo(fm.PathTo("affected_specs"), "--changed-since=HEAD~1", "--impact-baseline=baseline.json", "--json-report=report.json", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("specs: impacted - will run 1 spec affected by the change and skip the rest"))
+		Ω(session).Should(gbytes.Say("Ran 1 of 5 Specs"))
+
+		specs := specStates()
+		Ω(specs["uses a"].State).Should(Equal(types.SpecStatePassed))
+		Ω(specs["uses b"].State).Should(Equal(types.SpecStateSkipped))
+		Ω(specs["uses b"].Failure.Message).Should(Equal("not impacted by the changes since HEAD~1"))
+	})
+
+	It("narrows impacted suites down to the specs that executed the changed code with a per-spec coverage baseline", MarkSlow, func() {
+		session := startGinkgo(fm.PathTo("affected_specs"), "--spec-coverage=coverage.json", "--coverpkg=./...", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+
+		fm.AppendToFile("affected_specs", "c/c.go", "//")
+		session = startGinkgo(fm.PathTo("affected_specs"), "--changed-since=HEAD", "--impact-baseline=coverage.json", "--json-report=report.json", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("specs: impacted - will run 1 spec affected by the change and skip the rest"))
+		Ω(session).Should(gbytes.Say("Ran 1 of 5 Specs"))
+
+		specs := specStates()
+		Ω(specs["uses c through a helper"].State).Should(Equal(types.SpecStatePassed))
+		Ω(specs["uses a"].State).Should(Equal(types.SpecStateSkipped))
+		Ω(specs["uses a"].Failure.Message).Should(Equal("not impacted by the changes since HEAD"))
+	})
+
+	It("complains about refs that don't exist", func() {
+		session := startGinkgo(fm.PathTo("affected_specs"), "--changed-since=nope", "-r")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err).Should(gbytes.Say("nope is not a valid git ref"))
+	})
+
+	It("requires --changed-since with --impact-baseline", func() {
+		session := startGinkgo(fm.PathTo("affected_specs"), "--impact-baseline=baseline.json", "-r")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err).Should(gbytes.Say("--impact-baseline requires --changed-since"))
+	})
+})
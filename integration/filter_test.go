@@ -9,6 +9,7 @@ import (
 	"github.com/onsi/gomega/gexec"
 
 	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 var _ = Describe("Filter", func() {
@@ -182,4 +183,118 @@ var _ = Describe("Filter", func() {
 			}
 		})
 	})
+
+	Describe("Sharding specs", func() {
+		BeforeEach(func() {
+			fm.MountFixture("shard")
+		})
+
+		It("runs disjoint shards that together cover the suite and can merge their reports", func() {
+			names := []string{}
+			for _, shard := range []string{"1/2", "2/2"} {
+				session := startGinkgo(fm.PathTo("shard"), "--no-color", "--label-filter=!skip-me", "--shard="+shard, "--json-report=shard-"+shard[:1]+".json")
+				Eventually(session).Should(gexec.Exit(0))
+				Ω(session).Should(gbytes.Say("Running shard " + shard[:1] + " of 2"))
+
+				report := fm.LoadJSONReports("shard", "shard-"+shard[:1]+".json")[0]
+				Ω(report.PreRunStats.Shard).Should(Equal(int(shard[0] - '0')))
+				Ω(report.PreRunStats.TotalShards).Should(Equal(2))
+				specs := Reports(report.SpecReports).WithLeafNodeType(types.NodeTypeIt)
+				Ω(report.PreRunStats.TotalSpecs).Should(Equal(len(specs)))
+				Ω(report.PreRunStats.SpecsThatWillRun).Should(Equal(len(specs.WithState(types.SpecStatePassed))))
+				names = append(names, specs.Names()...)
+
+				ran := specs.WithState(types.SpecStatePassed).Names()
+				Ω(ran).Should(Or(ContainElements("O1", "O2", "O3"), Not(ContainElement(BeElementOf("O1", "O2", "O3")))))
+				Ω(ran).Should(Or(ContainElements("S1", "S2"), Not(ContainElement(BeElementOf("S1", "S2")))))
+				Ω(len(ran)).Should(BeNumerically(">=", 4))
+			}
+			Ω(names).Should(ConsistOf("A", "B", "C", "D", "E", "O1", "O2", "O3", "S1", "S2"))
+
+			session := startGinkgo(fm.PathTo("shard"), "merge-reports", "--json-report=merged.json", "--junit-report=merged.xml", "shard-1.json", "shard-2.json")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say("ShardFixture Suite: ran 9 of 10 specs - Passed"))
+
+			reports := fm.LoadJSONReports("shard", "merged.json")
+			Ω(reports).Should(HaveLen(1))
+			Ω(reports[0].SuiteSucceeded).Should(BeTrue())
+			Ω(reports[0].PreRunStats).Should(Equal(types.PreRunStats{TotalSpecs: 10, SpecsThatWillRun: 9}))
+			Ω(Reports(reports[0].SpecReports).WithLeafNodeType(types.NodeTypeIt).Names()).Should(ConsistOf("A", "B", "C", "D", "E", "O1", "O2", "O3", "S1", "S2"))
+
+			junit := fm.LoadJUnitReport("shard", "merged.xml")
+			Ω(junit.TestSuites).Should(HaveLen(1))
+			Ω(junit.TestSuites[0].Tests).Should(BeNumerically(">=", 10))
+		})
+
+		It("errors if the shard is malformed", func() {
+			session := startGinkgo(fm.PathTo("shard"), "--shard=3/2")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say("Invalid --shard '3/2'"))
+		})
+	})
+
+	Describe("Rerunning failed specs", func() {
+		BeforeEach(func() {
+			fm.MountFixture("rerun_failed")
+		})
+
+		It("only reruns the specs that failed in the passed-in report", func() {
+			session := startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--json-report=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Ran 7 of 7 Specs`))
+
+			session = startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--rerun-failed=report.json", "--json-report=rerun.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Ran 3 of 7 Specs`))
+			specs := Reports(fm.LoadJSONReports("rerun_failed", "rerun.json")[0].SpecReports)
+			Ω(specs.WithState(types.SpecStateFailed).Names()).Should(ConsistOf("fails", "fails", "an entry"))
+			Ω(specs.WithState(types.SpecStateSkipped)).Should(HaveLen(4))
+
+			fm.WriteFile("rerun_failed", "fixed", "")
+			session = startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--rerun-failed=report.json", "--json-report=rerun.json")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say(`Ran 3 of 7 Specs`))
+			specs = Reports(fm.LoadJSONReports("rerun_failed", "rerun.json")[0].SpecReports)
+			Ω(specs.WithState(types.SpecStatePassed).Names()).Should(ConsistOf("fails", "fails", "an entry"))
+			Ω(specs.WithState(types.SpecStatePassed).Find("an entry").LeafNodeLocation.LineNumber).Should(Equal(33))
+		})
+
+		It("errors if the report can't be loaded", func() {
+			session := startGinkgo(fm.PathTo("rerun_failed"), "--rerun-failed=nope.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say("Could not load the report passed to --rerun-failed"))
+		})
+
+		It("can track the failures from the last run with --last-failed", func() {
+			session := startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--last-failed")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Ran 7 of 7 Specs`))
+			Ω(fm.PathTo("rerun_failed", "ginkgo-last-run.json")).Should(BeARegularFile())
+
+			session = startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--last-failed")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Ran 3 of 7 Specs`))
+
+			fm.WriteFile("rerun_failed", "fixed", "")
+			session = startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--last-failed", "--json-report=report.json")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say(`Ran 3 of 7 Specs`))
+			Ω(fm.ContentOf("rerun_failed", "ginkgo-last-run.json")).Should(Equal(fm.ContentOf("rerun_failed", "report.json")))
+
+			session = startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--last-failed")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say(`Ran 7 of 7 Specs`))
+		})
+
+		It("keeps the --last-failed report in --output-dir when set", func() {
+			session := startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--last-failed", "--output-dir=./out", "--procs=2")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(fm.PathTo("rerun_failed", "ginkgo-last-run.json")).ShouldNot(BeAnExistingFile())
+			Ω(fm.ListDir("rerun_failed", "out")).Should(ConsistOf(HaveSuffix("ginkgo-last-run.json")))
+
+			session = startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--last-failed", "--output-dir=./out", "--procs=2")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Ran 3 of 7 Specs`))
+		})
+	})
 })
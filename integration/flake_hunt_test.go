@@ -0,0 +1,101 @@
+package integration_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("--flake-hunt", func() {
+	type flakeHuntSpec struct {
+		LeafNodeText string
+		Passed       int
+		Failed       int
+		RunTimes     []int64
+		Failures     []struct {
+			Seed    int64
+			State   string
+			Message string
+		}
+	}
+	type flakeHuntReport struct {
+		Iterations   int
+		Seeds        []int64
+		FlakySpecs   []flakeHuntSpec
+		FailingSpecs []flakeHuntSpec
+		PassingSpecs []flakeHuntSpec
+	}
+
+	loadFlakeHuntReport := func(target string) flakeHuntReport {
+		report := flakeHuntReport{}
+		Ω(json.Unmarshal([]byte(fm.ContentOf("flake_hunt", target)), &report)).Should(Succeed())
+		return report
+	}
+
+	BeforeEach(func() {
+		fm.MountFixture("flake_hunt")
+	})
+
+	DescribeTable("runs the suite with successive seeds and reports the specs that fail intermittently", func(args ...string) {
+		args = append([]string{"--no-color", "--flake-hunt=4", "--seed=3"}, args...)
+		session := startGinkgo(fm.PathTo("flake_hunt"), args...)
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Flake hunt: finished run 1 of 4 \(seed 3\)`))
+		Ω(session).Should(gbytes.Say(`Flake hunt: finished run 3 of 4 \(seed 5\)`))
+		Ω(session).Should(gbytes.Say(`Flake Hunt Summary - 4 runs with seeds 3, 4, 5, 6`))
+		Ω(session).Should(gbytes.Say(`1 always passed \| 1 failed intermittently \| 0 always failed`))
+		Ω(session).Should(gbytes.Say(`\s+2\s+2\s+.*\[FLAKY\] flake hunt flakes`))
+		Ω(session).Should(gbytes.Say(`flake_hunt_fixture_test.go:\d+ - failing seeds: 3, 5`))
+		Ω(session).Should(gbytes.Say(`Wrote the flake hunt report to flake-hunt.json`))
+		Ω(session).Should(gbytes.Say(`Test Suite Failed`))
+
+		report := loadFlakeHuntReport("flake-hunt.json")
+		Ω(report.Iterations).Should(Equal(4))
+		Ω(report.Seeds).Should(Equal([]int64{3, 4, 5, 6}))
+		Ω(report.FailingSpecs).Should(BeEmpty())
+		Ω(report.PassingSpecs).Should(HaveLen(1))
+		Ω(report.PassingSpecs[0].LeafNodeText).Should(Equal("passes"))
+		Ω(report.PassingSpecs[0].Passed).Should(Equal(4))
+		Ω(report.FlakySpecs).Should(HaveLen(1))
+		flaky := report.FlakySpecs[0]
+		Ω(flaky.LeafNodeText).Should(Equal("flakes"))
+		Ω(flaky.Passed).Should(Equal(2))
+		Ω(flaky.Failed).Should(Equal(2))
+		Ω(flaky.RunTimes).Should(HaveLen(4))
+		Ω(flaky.Failures).Should(HaveLen(2))
+		Ω(flaky.Failures[0].Seed).Should(Equal(int64(3)))
+		Ω(flaky.Failures[0].State).Should(Equal("failed"))
+		Ω(flaky.Failures[0].Message).Should(ContainSubstring("fails with odd seeds"))
+		Ω(flaky.Failures[1].Seed).Should(Equal(int64(5)))
+
+		Ω(fm.ListDir("flake_hunt")).ShouldNot(ContainElement(ContainSubstring("ginkgo-flake-hunt-run")))
+	},
+		Entry("in series"),
+		Entry("in parallel", "--procs=2"),
+	)
+
+	It("writes the report to --output-dir and leaves the user's JSON report in place", func() {
+		session := startGinkgo(fm.PathTo("flake_hunt"), "--no-color", "--flake-hunt=2", "--seed=2", "--json-report=out.json", "--output-dir=./reports")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(fm.PathTo("flake_hunt", "reports/out.json")).Should(BeAnExistingFile())
+		report := loadFlakeHuntReport("reports/flake-hunt.json")
+		Ω(report.Seeds).Should(Equal([]int64{2, 3}))
+		Ω(report.FlakySpecs).Should(HaveLen(1))
+	})
+
+	It("passes when no specs fail", func() {
+		session := startGinkgo(fm.PathTo("flake_hunt"), "--no-color", "--flake-hunt=3", "--focus=passes")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`1 always passed \| 0 failed intermittently \| 0 always failed`))
+		Ω(session).Should(gbytes.Say(`Test Suite Passed`))
+	})
+
+	It("cannot be combined with --repeat or --until-it-fails", func() {
+		session := startGinkgo(fm.PathTo("flake_hunt"), "--no-color", "--flake-hunt=3", "--repeat=2")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err).Should(gbytes.Say(`--flake-hunt can.t be combined`))
+	})
+})
@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("--fuzz", func() {
+	BeforeEach(func() {
+		fm.MountFixture("fuzz")
+	})
+
+	It("runs DescribeFuzz seed entries as specs when not fuzzing", func() {
+		session := startGinkgo(fm.PathTo("fuzz"), "--no-color", "-v")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`names are short Entry: abc`))
+		Ω(session).Should(gbytes.Say(`names are never nil`))
+		Ω(session).Should(gbytes.Say(`Ran 3 of 3 Specs`))
+	})
+
+	It("fuzzes the matching DescribeFuzz, stores the failing input in its corpus, and reports it as a spec failure", func() {
+		session := startGinkgo(fm.PathTo("fuzz"), "--no-color", "--fuzz=are short", "--fuzztime=60s", "--json-report=report.json")
+		Eventually(session, "2m").Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Fuzzing fuzz with FuzzFixture`))
+		Ω(session).Should(gbytes.Say(`Ginkgo moved the failing input to testdata/fuzz/names_are_short/\w+ - it will now run as a spec in "names are short"`))
+		Ω(session).Should(gbytes.Say(`Running Suite: Fuzzing FuzzFixture`))
+		Ω(session).Should(gbytes.Say(`Will run 3 of 4 specs`))
+		Ω(session).Should(gbytes.Say(`names are short \[It\] Fuzz Corpus: \w+`))
+		Ω(session).Should(gbytes.Say(`name is too long`))
+		Ω(session).Should(gbytes.Say(`Ran 3 of 4 Specs`))
+		Ω(session).Should(gbytes.Say(`\[FAILED\] names are short\s+name is too long`))
+		Ω(session).Should(gbytes.Say(`Test Suite Failed`))
+
+		By("reporting the failing input as a failed spec in the run's reports")
+		report := fm.LoadJSONReports("fuzz", "report.json")[0]
+		Ω(report.SuiteSucceeded).Should(BeFalse())
+		failures := report.SpecReports.WithState(types.SpecStateFailed)
+		Ω(failures).Should(HaveLen(1))
+		Ω(failures[0].ContainerHierarchyTexts).Should(Equal([]string{"names", "are short"}))
+		Ω(failures[0].LeafNodeText).Should(HavePrefix("Fuzz Corpus: "))
+		Ω(failures[0].Failure.Message).Should(HavePrefix("name is too long"))
+
+		Ω(fm.ListDir("fuzz", "testdata/fuzz/names_are_short")).Should(HaveLen(1))
+		Ω(fm.PathTo("fuzz", "testdata/fuzz/FuzzFixture")).ShouldNot(BeADirectory())
+
+		By("running the failing input as a spec from then on")
+		session = startGinkgo(fm.PathTo("fuzz"), "--no-color")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`\[FAIL\] names are short \[It\] Fuzz Corpus: \w+`))
+		Ω(session).Should(gbytes.Say(`Ran 4 of 4 Specs`))
+	})
+
+	It("fails when two DescribeFuzz containers would share a corpus directory", func() {
+		fm.WriteFile("fuzz", "collision_test.go", `package fuzz_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("names/are", func() {
+	DescribeFuzz("short", func(name string) {}, FuzzEntry("abc"))
+})
+`)
+		session := startGinkgo(fm.PathTo("fuzz"), "--no-color")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Fuzz corpus directory collision`))
+		Ω(session).Should(gbytes.Say(`testdata/fuzz/names_are_short`))
+	})
+
+	It("fails when no DescribeFuzz matches", func() {
+		session := startGinkgo(fm.PathTo("fuzz"), "--no-color", "--fuzz=are long", "--fuzztime=1s")
+		Eventually(session, "2m").Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`No DescribeFuzz matches --fuzz`))
+		Ω(session).Should(gbytes.Say(`Test Suite Failed`))
+	})
+
+	It("aborts when the suite has no Fuzz function", func() {
+		fm.MountFixture("passing_ginkgo_tests")
+		session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "--no-color", "--fuzz=foo")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err).Should(gbytes.Say(`has no Fuzz function`))
+	})
+})
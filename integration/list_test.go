@@ -0,0 +1,116 @@
+package integration_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("ginkgo list", func() {
+	BeforeEach(func() {
+		fm.MountFixture("list")
+	})
+
+	listSpecs := func(args ...string) []types.ListedSuite {
+		session := startGinkgo(fm.PathTo("list"), append([]string{"list", "--format=json"}, args...)...)
+		Eventually(session).Should(gexec.Exit(0))
+		listed := []types.ListedSuite{}
+		Ω(json.Unmarshal(session.Out.Contents(), &listed)).Should(Succeed())
+		return listed
+	}
+
+	It("lists each spec along with its decorators and whether it will run", func() {
+		listed := listSpecs()
+		Ω(listed).Should(HaveLen(1))
+		Ω(listed[0].SuiteDescription).Should(Equal("ListFixture Suite"))
+		Ω(listed[0].SuitePath).Should(Equal(fm.AbsPathTo("list")))
+
+		specs := listed[0].Specs
+		texts := []string{}
+		for _, spec := range specs {
+			texts = append(texts, spec.FullText)
+		}
+		Ω(texts).Should(Equal([]string{"Cats purrs", "Cats hisses", "Cats naps", "Cats hisses", "Dogs barks", "Dogs wags"}))
+
+		Ω(specs[0].Labels).Should(Equal([]string{"cat", "happy"}))
+		Ω(specs[0].LeafNodeLocation.LineNumber).Should(Equal(11))
+		Ω(specs[0].ContainerHierarchyTexts).Should(Equal([]string{"Cats"}))
+		Ω(specs[0].WillRun).Should(BeTrue())
+		Ω(specs[1].Serial).Should(BeTrue())
+		Ω(specs[1].FlakeAttempts).Should(Equal(3))
+		Ω(specs[2].Pending).Should(BeTrue())
+		Ω(specs[2].WillRun).Should(BeFalse())
+		Ω(specs[4].Ordered).Should(BeTrue())
+		Ω(specs[4].SpecTimeout.Seconds()).Should(Equal(1.0))
+		Ω(specs[5].SemVerConstraints).Should(Equal([]string{">= 2.0.0"}))
+	})
+
+	It("gives each spec a stable ID - even specs with the same text", func() {
+		ids := map[string]string{}
+		for _, spec := range listSpecs()[0].Specs {
+			Ω(spec.ID).Should(HaveLen(16))
+			ids[spec.ID] = spec.FullText
+		}
+		Ω(ids).Should(HaveLen(6))
+
+		for _, spec := range listSpecs("--label-filter=happy")[0].Specs {
+			Ω(ids).Should(HaveKeyWithValue(spec.ID, spec.FullText))
+		}
+	})
+
+	It("lists the IDs that appear in Ginkgo's reports and that --focus-id accepts", func() {
+		specs := listSpecs()[0].Specs
+		Ω(specs[3].FullText).Should(Equal("Cats hisses"))
+
+		session := startGinkgo(fm.PathTo("list"), "--no-color", "--focus-id="+specs[3].ID, "--focus-id="+specs[4].ID, "--json-report=report.json", "--junit-report=report.xml")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("Ran 2 of 6 Specs"))
+
+		ran := map[string]string{}
+		for _, spec := range fm.LoadJSONReports("list", "report.json")[0].SpecReports {
+			if spec.State.Is(types.SpecStatePassed) {
+				ran[spec.ID] = spec.FullText()
+			}
+		}
+		Ω(ran).Should(Equal(map[string]string{specs[3].ID: "Cats hisses", specs[4].ID: "Dogs barks"}))
+
+		ids := []string{}
+		for _, testCase := range fm.LoadJUnitReport("list", "report.xml").TestSuites[0].TestCases {
+			ids = append(ids, testCase.ID)
+		}
+		Ω(ids).Should(ContainElements(specs[0].ID, specs[3].ID, specs[5].ID))
+	})
+
+	It("reports whether each spec would run under the filters", func() {
+		willRun := []string{}
+		for _, spec := range listSpecs("--label-filter=cat", "--skip=purrs", "--sem-ver-filter=1.0.0")[0].Specs {
+			if spec.WillRun {
+				willRun = append(willRun, spec.FullText)
+			}
+		}
+		Ω(willRun).Should(Equal([]string{"Cats hisses", "Cats hisses"}))
+	})
+
+	It("lists multiple suites in text", func() {
+		session := startGinkgo(fm.PathTo("list"), "list", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`ListFixture Suite \(.*\): 6 specs, 5 will run`))
+		Ω(session).Should(gbytes.Say(`[0-9a-f]{16} Cats purrs \[cat, happy\] \(list_fixture_test.go:11\)`))
+		Ω(session).Should(gbytes.Say(`[0-9a-f]{16} Cats hisses \[cat, Serial\] {Serial, FlakeAttempts\(3\)} \(list_fixture_test.go:12\)`))
+		Ω(session).Should(gbytes.Say(`[0-9a-f]{16} Cats naps \[cat\] \(list_fixture_test.go:13\) - pending`))
+		Ω(session).Should(gbytes.Say(`[0-9a-f]{16} Dogs barks {Ordered, SpecTimeout\(1s\)}`))
+		Ω(session).Should(gbytes.Say(`Other Suite \(.*\): 1 spec, 1 will run`))
+		Ω(session).Should(gbytes.Say(`[0-9a-f]{16} is another spec \(other_suite_test.go:15\)`))
+	})
+
+	It("complains about unknown formats", func() {
+		session := startGinkgo(fm.PathTo("list"), "list", "--format=yaml")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err).Should(gbytes.Say("--format must be one of json or text - got yaml"))
+	})
+})
@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+)
+
+var _ = Describe("--quarantine-file", func() {
+	BeforeEach(func() {
+		fm.MountFixture("quarantine")
+	})
+
+	Context("when only quarantined specs fail", func() {
+		BeforeEach(func() {
+			fm.WriteFile("quarantine", "flaky.yaml", `
+- text: "quarantine flakes"
+  reason: "tracked in #17"
+- text: "quarantine fails"
+`)
+		})
+
+		DescribeTable("reports the quarantined failures without failing the suite", func(args ...string) {
+			args = append([]string{"--no-color", "--quarantine-file=flaky.yaml", "--json-report=out.json", "--junit-report=out.xml"}, args...)
+			session := startGinkgo(fm.PathTo("quarantine"), args...)
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say(`Summarizing 2 Quarantined Failures:`))
+			Ω(session).Should(gbytes.Say(`1 Passed \| 0 Failed \| 2 Quarantined`))
+			Ω(string(session.Out.Contents())).Should(ContainSubstring("This spec is quarantined so its failure does not fail the suite: tracked in #17"))
+
+			report := fm.LoadJSONReports("quarantine", "out.json")[0]
+			Ω(report.SuiteSucceeded).Should(BeTrue())
+			specs := Reports(report.SpecReports)
+			Ω(specs.Find("flakes")).Should(HaveBeenQuarantined("a known flake"))
+			Ω(specs.Find("flakes").QuarantineReason).Should(Equal("tracked in #17"))
+			Ω(specs.Find("fails")).Should(HaveBeenQuarantined("a real failure"))
+			Ω(specs.Find("passes")).Should(HavePassed())
+			Ω(specs.WithState(types.SpecStateQuarantined)).Should(HaveLen(2))
+
+			junit := fm.LoadJUnitReport("quarantine", "out.xml")
+			Ω(junit.Failures).Should(Equal(0))
+			Ω(junit.TestSuites[0].TestCases).Should(ContainElement(And(
+				HaveField("Name", "[It] quarantine flakes"),
+				HaveField("Status", "quarantined"),
+				HaveField("Skipped.Message", ContainSubstring("quarantined (tracked in #17) - a known flake")),
+			)))
+		},
+			Entry("in series"),
+			Entry("in parallel", "--procs=2"),
+		)
+	})
+
+	Context("when a spec that is not quarantined fails", func() {
+		BeforeEach(func() {
+			fm.WriteFile("quarantine", "flaky.yaml", `- text: "quarantine flakes"`)
+		})
+
+		It("fails the suite", func() {
+			session := startGinkgo(fm.PathTo("quarantine"), "--no-color", "--quarantine-file=flaky.yaml")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Summarizing 1 Failure:`))
+			Ω(session).Should(gbytes.Say(`Summarizing 1 Quarantined Failure:`))
+			Ω(session).Should(gbytes.Say(`1 Passed \| 1 Failed \| 1 Quarantined`))
+		})
+	})
+
+	Context("when the quarantine file is invalid", func() {
+		BeforeEach(func() {
+			fm.WriteFile("quarantine", "flaky.yaml", `- reason: "no text or location"`)
+		})
+
+		It("errors", func() {
+			session := startGinkgo(fm.PathTo("quarantine"), "--no-color", "--quarantine-file=flaky.yaml")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`Could not load the file passed to --quarantine-file`))
+		})
+	})
+})
@@ -1,8 +1,11 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -343,9 +346,16 @@ var _ = Describe("Reporting", func() {
 			Ω(lines).Should(ContainElement("##teamcity[testSuiteFinished name='']"))
 		}
 
+		checkHTMLReport := func(data string, reports ...types.Report) {
+			Ω(data).Should(HavePrefix("<!DOCTYPE html>"))
+			for _, report := range reports {
+				Ω(data).Should(ContainSubstring("<h2>" + report.SuiteDescription + "</h2>"))
+			}
+		}
+
 		Context("the default behavior", func() {
 			BeforeEach(func() {
-				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--gojson-report=out.go.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "-seed=17")
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--gojson-report=out.go.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "--html-report=out.html", "--markdown-report=out.md", "-seed=17")
 				Eventually(session).Should(gexec.Exit(1))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
@@ -363,6 +373,186 @@ var _ = Describe("Reporting", func() {
 				checkTeamcityReport(fm.ContentOf("reporting", "out.tc"))
 				checkTeamcitySubpackageReport(fm.ContentOf("reporting", "out.tc"))
 				checkTeamcityFailedCompilationReport(fm.ContentOf("reporting", "out.tc"))
+
+				checkHTMLReport(fm.ContentOf("reporting", "out.html"), reports[0], reports[2])
+
+				markdownReports, err := reporters.LoadMarkdownReport(fm.PathTo("reporting", "out.md"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(markdownReports).Should(HaveLen(3))
+				Ω(fm.ContentOf("reporting", "out.md")).Should(HavePrefix("## Ginkgo Report: ❌ Failed\n"))
+			})
+		})
+
+		Context("when $GITHUB_STEP_SUMMARY is set", func() {
+			BeforeEach(func() {
+				Ω(os.WriteFile(fm.PathTo("reporting", "step-summary.md"), []byte("# Earlier Step\n"), 0644)).Should(Succeed())
+				stepSummary, err := filepath.Abs(fm.PathTo("reporting", "step-summary.md"))
+				Ω(err).ShouldNot(HaveOccurred())
+				GinkgoT().Setenv("GITHUB_STEP_SUMMARY", stepSummary)
+			})
+
+			It("appends a single summary of all the suites to $GITHUB_STEP_SUMMARY", func() {
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "-seed=17")
+				Eventually(session).Should(gexec.Exit(1))
+
+				summary := fm.ContentOf("reporting", "step-summary.md")
+				Ω(summary).Should(HavePrefix("# Earlier Step\n## Ginkgo Report: ❌ Failed\n"))
+				Ω(strings.Count(summary, "\n## ")).Should(Equal(1))
+				Ω(summary).Should(ContainSubstring("| **Total** | ❌ Failed |"))
+				Ω(summary).Should(ContainSubstring("- **[FAILED]** ReportingFixture Suite: "))
+				Ω(summary).Should(MatchRegexp(`- \*\*/.*malformed\\_sub\\_package\*\* failed:`))
+				Ω(summary).ShouldNot(ContainSubstring("ginkgo-reports"))
+				Ω(fm.PathTo("reporting", "ginkgo-step-summary.md")).ShouldNot(BeAnExistingFile())
+			})
+
+			It("leaves $GITHUB_STEP_SUMMARY alone with --no-github-step-summary", func() {
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "-seed=17", "--no-github-step-summary")
+				Eventually(session).Should(gexec.Exit(1))
+
+				Ω(fm.ContentOf("reporting", "step-summary.md")).Should(Equal("# Earlier Step\n"))
+				Ω(fm.PathTo("reporting", "ginkgo-step-summary.md")).ShouldNot(BeAnExistingFile())
+			})
+		})
+
+		Context("with --trace-export", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--trace-export=traces.json", "-seed=17")
+				Eventually(session).Should(gexec.Exit(1))
+				Ω(session).ShouldNot(gbytes.Say("Could not open"))
+			})
+
+			It("exports a single trace, parented by $TRACEPARENT, that covers all the suites and all the parallel processes", func() {
+				type span struct {
+					TraceID      string
+					SpanID       string
+					ParentSpanID string
+					Name         string
+				}
+				traces := struct {
+					ResourceSpans []struct {
+						ScopeSpans []struct {
+							Spans []span
+						}
+					}
+				}{}
+				Ω(json.Unmarshal([]byte(fm.ContentOf("reporting", "traces.json")), &traces)).Should(Succeed())
+				Ω(traces.ResourceSpans).Should(HaveLen(3))
+
+				suiteSpans := map[string]span{}
+				spans := []span{}
+				for _, resourceSpans := range traces.ResourceSpans {
+					suiteSpan := resourceSpans.ScopeSpans[0].Spans[0]
+					suiteSpans[suiteSpan.Name] = suiteSpan
+					spans = append(spans, resourceSpans.ScopeSpans[0].Spans...)
+				}
+				for _, span := range spans {
+					Ω(span.TraceID).Should(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+				}
+				Ω(suiteSpans).Should(HaveKey("ReportingFixture Suite"))
+				Ω(suiteSpans["ReportingFixture Suite"].ParentSpanID).Should(Equal("00f067aa0ba902b7"))
+
+				By("giving the specs from both parallel processes the same parent")
+				specSpans := 0
+				for _, span := range spans {
+					if strings.HasPrefix(span.Name, "reporting test ") {
+						Ω(span.ParentSpanID).Should(Equal(suiteSpans["ReportingFixture Suite"].SpanID))
+						specSpans += 1
+					}
+				}
+				Ω(specSpans).Should(BeNumerically(">", 1))
+			})
+		})
+
+		Describe("--event-stream", func() {
+			loadEvents := func() []types.StreamEvent {
+				events := []types.StreamEvent{}
+				for _, line := range strings.Split(strings.TrimSpace(fm.ContentOf("reporting", "events.ndjson")), "\n") {
+					var event types.StreamEvent
+					Ω(json.Unmarshal([]byte(line), &event)).Should(Succeed())
+					events = append(events, event)
+				}
+				return events
+			}
+
+			checkEvents := func(events []types.StreamEvent) map[int]bool {
+				processes := map[int]bool{}
+				suiteEvents := map[string][]types.StreamEventType{}
+				running := map[int]string{}
+				for _, event := range events {
+					Ω(event.SuitePath).ShouldNot(BeEmpty())
+					switch event.Type {
+					case types.StreamEventSuiteWillBegin, types.StreamEventSuiteDidEnd:
+						suiteEvents[event.SuiteDescription] = append(suiteEvents[event.SuiteDescription], event.Type)
+						Ω(event.Report.SpecReports).Should(BeEmpty())
+					case types.StreamEventWillRun:
+						Ω(running).ShouldNot(HaveKey(event.ParallelProcess))
+						running[event.ParallelProcess] = event.SpecReport.FullText()
+						processes[event.ParallelProcess] = true
+					case types.StreamEventDidRun:
+						Ω(running).Should(HaveKeyWithValue(event.ParallelProcess, event.SpecReport.FullText()))
+						delete(running, event.ParallelProcess)
+					case types.StreamEventFailure:
+						Ω(running).Should(HaveKey(event.ParallelProcess))
+					}
+				}
+				Ω(suiteEvents).Should(Equal(map[string][]types.StreamEventType{
+					"ReportingFixture Suite":     {types.StreamEventSuiteWillBegin, types.StreamEventSuiteDidEnd},
+					"Reporting SubPackage Suite": {types.StreamEventSuiteWillBegin, types.StreamEventSuiteDidEnd},
+				}))
+				Ω(events).Should(ContainElement(And(HaveField("Type", types.StreamEventFailure), HaveField("Failure.Message", "fail!"))))
+				Ω(events).Should(ContainElement(And(HaveField("Type", types.StreamEventSpecEvent), HaveField("SpecEvent.SpecEventType", types.SpecEventNodeStart))))
+				return processes
+			}
+
+			It("streams the events of all the suites to a single stream", func() {
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--event-stream=events.ndjson", "-seed=17")
+				Eventually(session).Should(gexec.Exit(1))
+				Ω(checkEvents(loadEvents())).Should(Equal(map[int]bool{1: true}))
+			})
+
+			It("combines the events of all the parallel processes", func() {
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--event-stream=events.ndjson", "-seed=17")
+				Eventually(session).Should(gexec.Exit(1))
+				events := loadEvents()
+				Ω(checkEvents(events)).Should(Equal(map[int]bool{1: true, 2: true}))
+				Ω(events[len(events)-1].Type).Should(Equal(types.StreamEventSuiteDidEnd))
+				Ω(events[len(events)-1].ParallelProcess).Should(Equal(0))
+				Ω(events[len(events)-1].Report.SuiteSucceeded).Should(BeFalse())
+			})
+
+			Describe("--reporter", func() {
+				writeReporter := func(pkg string, name string, script string) {
+					fm.WriteFile(pkg, name, "#!/bin/sh\n"+script+"\n")
+					Ω(os.Chmod(fm.PathTo(pkg, name), 0755)).Should(Succeed())
+				}
+
+				BeforeEach(func() {
+					if runtime.GOOS == "windows" {
+						Skip("these reporters are shell scripts")
+					}
+				})
+
+				It("streams the events of all the suites and parallel processes to the reporter's stdin", func() {
+					writeReporter("reporting", "reporter.sh", "cat > events.ndjson")
+					session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--reporter=./reporter.sh", "-seed=17")
+					Eventually(session).Should(gexec.Exit(1))
+					events := loadEvents()
+					Ω(checkEvents(events)).Should(Equal(map[int]bool{1: true, 2: true}))
+					Ω(events[len(events)-1].Type).Should(Equal(types.StreamEventSuiteDidEnd))
+				})
+
+				It("reports reporters that fail without affecting the outcome of the run", func() {
+					fm.MountFixture("passing_ginkgo_tests")
+					writeReporter("passing_ginkgo_tests", "reporter.sh", "cat > events.ndjson")
+					writeReporter("passing_ginkgo_tests", "failing-reporter.sh", "exit 3")
+					session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "--no-color", "--reporter=./failing-reporter.sh", "--reporter=./missing-reporter", "--reporter=./reporter.sh")
+					Eventually(session).Should(gexec.Exit(0))
+					Ω(session).Should(gbytes.Say(`Failed to launch reporter ./missing-reporter`))
+					Ω(session).Should(gbytes.Say(`Reporter ./failing-reporter.sh failed: exit status 3`))
+					Ω(session).Should(gbytes.Say("Test Suite Passed"))
+					Ω(fm.ContentOf("passing_ginkgo_tests", "events.ndjson")).Should(ContainSubstring(`"Type":"SuiteDidEnd"`))
+				})
 			})
 		})
 
@@ -391,7 +581,7 @@ var _ = Describe("Reporting", func() {
 
 		Context("with -keep-separate-reports", func() {
 			BeforeEach(func() {
-				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--gojson-report=out.go.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "--keep-separate-reports", "-seed=17")
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--gojson-report=out.go.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "--html-report=out.html", "--keep-separate-reports", "-seed=17")
 				Eventually(session).Should(gexec.Exit(1))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
@@ -402,10 +592,12 @@ var _ = Describe("Reporting", func() {
 				checkJSONReport(reports[0])
 				checkJUnitReport(fm.LoadJUnitReport("reporting", "out.xml").TestSuites[0])
 				checkTeamcityReport(fm.ContentOf("reporting", "out.tc"))
+				checkHTMLReport(fm.ContentOf("reporting", "out.html"), reports[0])
 
 				reports = fm.LoadJSONReports("reporting", "reporting_sub_package/out.json")
 				Ω(reports).Should(HaveLen(1))
 				checkJSONSubpackageReport(reports[0])
+				checkHTMLReport(fm.ContentOf("reporting", "reporting_sub_package/out.html"), reports[0])
 				checkJUnitSubpackageReport(fm.LoadJUnitReport("reporting", "reporting_sub_package/out.xml").TestSuites[0])
 				checkTeamcitySubpackageReport(fm.ContentOf("reporting", "reporting_sub_package/out.tc"))
 
@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"regexp"
 	"runtime"
@@ -290,6 +291,77 @@ var _ = Describe("Running Specs", func() {
 				Ω(output).Should(ContainSubstring("Test Suite Passed"))
 			})
 		})
+
+		Context("with --spec-timings", func() {
+			It("records the spec timings in the package directory and uses them on subsequent runs", func() {
+				session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "--no-color", "--procs=2", "--spec-timings=timings.json")
+				Eventually(session).Should(gexec.Exit(0))
+
+				timings, err := types.LoadSpecTimings(fm.PathTo("passing_ginkgo_tests", "timings.json"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(timings).Should(HaveLen(5))
+				Ω(timings).Should(HaveKey(MatchRegexp(`^passing_ginkgo_tests_test\.go:\d+ `)))
+
+				session = startGinkgo(fm.PathTo("passing_ginkgo_tests"), "--no-color", "--procs=2", "--spec-timings=timings.json")
+				Eventually(session).Should(gexec.Exit(0))
+				Ω(session).Should(gbytes.Say("Test Suite Passed"))
+			})
+		})
+	})
+
+	Context("when running on remote workers", func() {
+		var coordinatorAddress string
+
+		BeforeEach(func() {
+			fm.MountFixture("synchronized_setup_tests")
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Ω(err).ShouldNot(HaveOccurred())
+			coordinatorAddress = listener.Addr().String()
+			listener.Close()
+		})
+
+		It("hands the parallel processes out to the workers and collects their output and reports", func() {
+			session := startGinkgo(fm.PathTo("synchronized_setup_tests"), "--no-color", "--remote-workers=2", "--coordinator-address="+coordinatorAddress, "--json-report=out.json")
+			Eventually(session).Should(gbytes.Say("Waiting for 2 remote workers to connect to " + regexp.QuoteMeta(coordinatorAddress)))
+			Eventually(session).Should(gbytes.Say(`Start them with: ginkgo worker --coordinator=` + regexp.QuoteMeta(coordinatorAddress) + ` --token=([0-9a-f]{32})\n`))
+			token := regexp.MustCompile(`--token=([0-9a-f]{32})`).FindStringSubmatch(string(session.Out.Contents()))[1]
+
+			intruder := startGinkgo(fm.PathTo("synchronized_setup_tests"), "worker", "--coordinator="+coordinatorAddress, "--token=guess")
+			Eventually(intruder).Should(gexec.Exit(1))
+			Ω(intruder.Err).Should(gbytes.Say("the coordinator rejected the worker's token"))
+
+			workers := []*gexec.Session{}
+			for range 2 {
+				workers = append(workers, startGinkgo(fm.PathTo("synchronized_setup_tests"), "worker", "--coordinator="+coordinatorAddress, "--token="+token))
+			}
+
+			Eventually(session).Should(gexec.Exit(0))
+			for _, worker := range workers {
+				Eventually(worker).Should(gexec.Exit(0))
+				Ω(worker).Should(gbytes.Say(`Running synchronized_setup_tests as parallel process \d of 2`))
+			}
+
+			output := string(session.Out.Contents())
+			Ω(output).Should(ContainSubstring("Ran 2 of 2 Specs"))
+			Ω(output).Should(ContainSubstring("BEFORE_A_1"))
+			Ω(output).Should(ContainSubstring("AFTER_A_1"))
+			Ω(output).Should(ContainSubstring("Test Suite Passed"))
+
+			report := fm.LoadJSONReports("synchronized_setup_tests", "out.json")[0]
+			Ω(Reports(report.SpecReports).WithLeafNodeType(types.NodeTypeIt)).Should(HaveLen(2))
+			procs := map[int]bool{}
+			for _, specReport := range report.SpecReports {
+				procs[specReport.ParallelProcess] = true
+			}
+			Ω(procs).Should(Equal(map[int]bool{1: true, 2: true}))
+		})
+
+		It("fails the suite when workers don't pick up its parallel processes in time", func() {
+			session := startGinkgo(fm.PathTo("synchronized_setup_tests"), "--no-color", "--remote-workers=1", "--coordinator-address="+coordinatorAddress, "--remote-worker-token=s3cret", "--remote-worker-timeout=1s")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session.Out).ShouldNot(gbytes.Say("Start them with"))
+			Ω(session.Err).Should(gbytes.Say("no remote worker picked up this parallel process in time"))
+		})
 	})
 
 	Context("when running in parallel and there are specs marked Serial", Label("slow"), func() {
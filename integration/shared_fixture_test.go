@@ -0,0 +1,60 @@
+package integration_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("SharedFixture", func() {
+	BeforeEach(func() {
+		fm.MountFixture("shared_fixture")
+	})
+
+	DescribeTable("sets fixtures up once, when first used, and tears them down on the process that set them up", func(args ...string) {
+		args = append([]string{"--no-color", "--json-report=out.json"}, args...)
+		session := startGinkgo(fm.PathTo("shared_fixture"), args...)
+		Eventually(session).Should(gexec.Exit(0))
+
+		report := fm.LoadJSONReports("shared_fixture", "out.json")[0]
+		Ω(report.SuiteSucceeded).Should(BeTrue())
+
+		setUpOn := map[string][]int{}
+		teardowns := map[string]types.SpecReport{}
+		lastCacheUserEndTime := time.Time{}
+		for _, specReport := range report.SpecReports {
+			Ω(specReport.CapturedGinkgoWriterOutput).ShouldNot(ContainSubstring("unused"))
+			for _, name := range []string{"db", "cache"} {
+				if strings.Contains(specReport.CapturedGinkgoWriterOutput, "SETTING UP "+name) {
+					setUpOn[name] = append(setUpOn[name], specReport.ParallelProcess)
+				}
+			}
+			if specReport.LeafNodeType == types.NodeTypeCleanupAfterSuite {
+				teardowns[specReport.LeafNodeText] = specReport
+			}
+			if strings.HasPrefix(specReport.LeafNodeText, "uses the cache") && specReport.EndTime.After(lastCacheUserEndTime) {
+				lastCacheUserEndTime = specReport.EndTime
+			}
+		}
+		Ω(setUpOn["db"]).Should(HaveLen(1))
+		Ω(setUpOn["cache"]).Should(HaveLen(1))
+		Ω(teardowns).Should(HaveLen(2))
+
+		db := teardowns[`SharedFixture "db"`]
+		Ω(db.ParallelProcess).Should(Equal(setUpOn["db"][0]))
+		Ω(db.CapturedGinkgoWriterOutput).Should(Equal(fmt.Sprintf("TEARING DOWN db postgres://localhost ON PROC %d\n", setUpOn["db"][0])))
+
+		cache := teardowns[`SharedFixture "cache"`]
+		Ω(cache.ParallelProcess).Should(Equal(setUpOn["cache"][0]))
+		Ω(cache.CapturedGinkgoWriterOutput).Should(Equal(fmt.Sprintf("TEARING DOWN cache redis://localhost ON PROC %d\n", setUpOn["cache"][0])))
+		Ω(cache.StartTime).ShouldNot(BeTemporally("<", lastCacheUserEndTime))
+	},
+		Entry("when running in series"),
+		Entry("when running in parallel", "--procs=3"),
+	)
+})
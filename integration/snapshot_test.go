@@ -0,0 +1,71 @@
+package integration_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("MatchSnapshot", func() {
+	BeforeEach(func() {
+		fm.MountFixture("snapshot")
+		os.Setenv("EDITION", "1")
+		os.Setenv("INDEX_NAME", "alphabetical")
+		DeferCleanup(os.Unsetenv, "EDITION")
+		DeferCleanup(os.Unsetenv, "INDEX_NAME")
+	})
+
+	loadSnapshots := func() types.Snapshots {
+		GinkgoHelper()
+		snapshots, err := types.LoadSnapshots(fm.PathTo("snapshot", "__snapshots__", "snapshot_fixture_test.ginkgo.snap"))
+		Ω(err).ShouldNot(HaveOccurred())
+		return snapshots
+	}
+
+	DescribeTable("writes, checks, and updates snapshots", func(args ...string) {
+		args = append([]string{"--no-color"}, args...)
+
+		By("writing the snapshots on the first run")
+		session := startGinkgo(fm.PathTo("snapshot"), args...)
+		Eventually(session).Should(gexec.Exit(0))
+		snapshots := loadSnapshots()
+		Ω(snapshots).Should(HaveLen(21))
+		Ω(snapshots).Should(HaveKeyWithValue("rendering renders book 7 - title", "Book 7\nEdition 1"))
+		Ω(snapshots).Should(HaveKeyWithValue("rendering renders book 7 - metadata", "{\n  \"book\": 7\n}"))
+
+		By("failing when the values change")
+		os.Setenv("EDITION", "2")
+		session = startGinkgo(fm.PathTo("snapshot"), args...)
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Snapshot "title" does not match`))
+		Ω(session).Should(gbytes.Say(`- Edition 1\n\s+\+ Edition 2`))
+		Ω(loadSnapshots()).Should(Equal(snapshots))
+
+		By("reporting obsolete snapshots")
+		os.Setenv("EDITION", "1")
+		os.Setenv("INDEX_NAME", "chronological")
+		session = startGinkgo(fm.PathTo("snapshot"), args...)
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`Found 1 Obsolete Snapshot:`))
+		Ω(session).Should(gbytes.Say(`\[OBSOLETE\] rendering renders the alphabetical index - index`))
+		Ω(session).Should(gbytes.Say(`Run with --update-snapshots to remove them`))
+
+		By("updating the snapshots and removing obsolete ones with --update-snapshots")
+		os.Setenv("EDITION", "2")
+		session = startGinkgo(fm.PathTo("snapshot"), append(args, "--update-snapshots")...)
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`Removed 1 Obsolete Snapshot:`))
+		snapshots = loadSnapshots()
+		Ω(snapshots).Should(HaveLen(21))
+		Ω(snapshots).Should(HaveKeyWithValue("rendering renders book 7 - title", "Book 7\nEdition 2"))
+		Ω(snapshots).Should(HaveKeyWithValue("rendering renders the chronological index - index", "the index"))
+		Ω(snapshots).ShouldNot(HaveKey("rendering renders the alphabetical index - index"))
+	},
+		Entry("when running in series"),
+		Entry("when running in parallel", "--procs=3"),
+	)
+})
@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("--spec-coverage", MarkSlow, func() {
+	BeforeEach(func() {
+		fm.MountFixture("affected_specs")
+		session := startGinkgo(fm.PathTo("affected_specs"), "--spec-coverage=spec-coverage.json", "--coverpkg=./...", "--procs=2", "-r")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("Measuring the coverage of 5 specs in ./specs"))
+		Ω(fm.PathTo("affected_specs", "spec-coverage.json")).Should(BeAnExistingFile())
+		Ω(fm.PathTo("affected_specs", "specs", "spec-coverage.json")).ShouldNot(BeAnExistingFile())
+	})
+
+	whoCovers := func(location string) *gexec.Session {
+		session := startGinkgo(fm.PathTo("affected_specs"), "coverage", "who-covers", location)
+		Eventually(session).Should(gexec.Exit(0))
+		return session
+	}
+
+	It("attributes the code each spec covers to the spec", func() {
+		session := whoCovers("a/a.go:4")
+		Ω(session).Should(gbytes.Say(`a/a.go:4 is covered by 1 spec:`))
+		Ω(session).Should(gbytes.Say(`Specs uses a \(specs_test.go:12\)`))
+
+		session = whoCovers("c/c.go:4")
+		Ω(session).Should(gbytes.Say(`c/c.go:4 is covered by 1 spec:`))
+		Ω(session).Should(gbytes.Say(`Specs uses c through a helper \(specs_test.go:20\)`))
+	})
+
+	It("reports lines that no spec covers", func() {
+		session := whoCovers("a/a.go:1")
+		Ω(session).Should(gbytes.Say("a/a.go:1 is not covered by any spec"))
+	})
+
+	It("complains about malformed locations", func() {
+		session := startGinkgo(fm.PathTo("affected_specs"), "coverage", "who-covers", "a/a.go")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err).Should(gbytes.Say("Please pass in a location of the form FILE:LINE"))
+	})
+})
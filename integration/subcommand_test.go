@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/types"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 )
 
@@ -631,6 +632,41 @@ var _ = Describe("Subcommand", func() {
 		})
 	})
 
+	Describe("ginkgo merge-reports", func() {
+		BeforeEach(func() {
+			fm.MountFixture("rerun_failed")
+			session := startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--json-report=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+		})
+
+		It("fails if the merged suite failed", func() {
+			session := startGinkgo(fm.PathTo("rerun_failed"), "merge-reports", "--json-report=merged.json", "report.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say("RerunFailedFixture Suite: ran 7 of 7 specs - Failed"))
+		})
+
+		It("de-duplicates retried specs and generates the requested reports", func() {
+			fm.WriteFile("rerun_failed", "fixed", "")
+			session := startGinkgo(fm.PathTo("rerun_failed"), "--no-color", "--rerun-failed=report.json", "--json-report=rerun.json")
+			Eventually(session).Should(gexec.Exit(0))
+
+			session = startGinkgo(fm.PathTo("rerun_failed"), "merge-reports", "--json-report=merged.json", "--gojson-report=merged.go.json", "--junit-report=merged.xml", "--teamcity-report=merged.teamcity", "report.json", "rerun.json")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say("RerunFailedFixture Suite: ran 7 of 7 specs - Passed"))
+
+			reports := fm.LoadJSONReports("rerun_failed", "merged.json")
+			Ω(reports).Should(HaveLen(1))
+			Ω(reports[0].SuiteSucceeded).Should(BeTrue())
+			specs := reports[0].SpecReports.WithLeafNodeType(types.NodeTypeIt)
+			Ω(specs).Should(HaveLen(7))
+			Ω(specs.CountWithState(types.SpecStatePassed)).Should(Equal(7))
+
+			Ω(fm.LoadJUnitReport("rerun_failed", "merged.xml").Failures).Should(Equal(0))
+			Ω(fm.ContentOf("rerun_failed", "merged.teamcity")).Should(ContainSubstring("##teamcity[testSuiteStarted name='RerunFailedFixture Suite']"))
+			Ω(fm.ContentOf("rerun_failed", "merged.go.json")).Should(ContainSubstring(`"Action":"pass"`))
+		})
+	})
+
 	Describe("ginkgo version", func() {
 		It("should print out the version info", func() {
 			session := startGinkgo("", "version")
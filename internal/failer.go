@@ -85,6 +85,17 @@ func (f *Failer) AbortSuite(message string, location types.CodeLocation) {
 	}
 }
 
+// Record records an outcome and failure captured by a previous Drain
+func (f *Failer) Record(state types.SpecState, failure types.Failure) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.state == types.SpecStatePassed {
+		f.state = state
+		f.failure = failure
+	}
+}
+
 func (f *Failer) Drain() (types.SpecState, types.Failure) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -46,6 +47,9 @@ func ApplyNestedFocusPolicyToTree(tree *TreeNode) {
 /*
 Ginkgo supports focussing specs using `FIt`, `FDescribe`, etc. - this is called "programmatic focus"
 It also supports focussing specs using regular expressions on the command line (`-focus=`, `-skip=`) that match against spec text and file filters (`-focus-files=`, `-skip-files=`) that match against code locations for nodes in specs.
+Finally, `-rerun-failed=` focuses on the specs that failed in a prior run as recorded in a JSON report.  Only the report for the suite at suitePath is consulted as the JSON report may cover several suites.
+
+`-impact-filter=` (set by `ginkgo run --changed-since`) is applied last: specs that would otherwise run but are not impacted by the changes are skipped with a reason (see types.ImpactFilter).
 
 When both programmatic and file filters are provided their results are ANDed together.  If multiple kinds of filters are provided, the file filters run first followed by the regex filters.
 
@@ -53,10 +57,11 @@ This function sets the `Skip` property on specs by applying Ginkgo's focus polic
 - If there are no CLI arguments and no programmatic focus, do nothing.
 - If a spec somewhere has programmatic focus skip any specs that have no programmatic focus.
 - If there are CLI arguments parse them and skip any specs that either don't match the focus filters or do match the skip filters.
+- Finally, un-skip any (non-pending) specs that the remaining specs depend on via DependsOn so that the remaining specs can actually run.
 
 *Note:* specs with pending nodes are Skipped when created by NewSpec.
 */
-func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suiteSemVerConstraints SemVerConstraints, suiteComponentSemVerConstraints ComponentSemVerConstraints, suiteConfig types.SuiteConfig) (Specs, bool) {
+func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suiteSemVerConstraints SemVerConstraints, suiteComponentSemVerConstraints ComponentSemVerConstraints, suitePath string, suiteConfig types.SuiteConfig) (Specs, bool) {
 	focusString := strings.Join(suiteConfig.FocusStrings, "|")
 	skipString := strings.Join(suiteConfig.SkipStrings, "|")
 
@@ -118,6 +123,19 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 		skipChecks = append(skipChecks, func(spec Spec) bool { return skipFilters.Matches(spec.Nodes.CodeLocations()) })
 	}
 
+	if len(suiteConfig.FocusIDs) > 0 {
+		skipChecks = append(skipChecks, func(spec Spec) bool { return !slices.Contains(suiteConfig.FocusIDs, spec.ID) })
+	}
+
+	if suiteConfig.RerunFailed != "" {
+		// if nothing failed in the prior run there is nothing to focus on, so we run everything.  but if only specs in other suites failed there is nothing to rerun here.
+		if priorFailures, _ := types.LoadRerunFilter(suiteConfig.RerunFailed, ""); len(priorFailures) > 0 {
+			rerunFilter, _ := types.LoadRerunFilter(suiteConfig.RerunFailed, suitePath)
+			specsToRerun := specsMatchingRerunFilter(specs, rerunFilter)
+			skipChecks = append(skipChecks, func(spec Spec) bool { return !specsToRerun[spec.SubjectID()] })
+		}
+	}
+
 	if focusString != "" {
 		// skip specs that don't match the focus string
 		re := regexp.MustCompile(focusString)
@@ -130,6 +148,13 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 		skipChecks = append(skipChecks, func(spec Spec) bool { return re.MatchString(description + " " + spec.Text()) })
 	}
 
+	var impactFilter types.ImpactFilter
+	var impactedSpecs types.FileFilters
+	if suiteConfig.ImpactFilter != "" {
+		impactFilter, _ = types.LoadImpactFilter(suiteConfig.ImpactFilter)
+		impactedSpecs, _ = types.ParseFileFilters(impactFilter.ImpactedSpecs)
+	}
+
 	// skip specs if shouldSkip() is true.  note that we do nothing if shouldSkip() is false to avoid overwriting skip status established by the node's pending status
 	processedSpecs := Specs{}
 	for _, spec := range specs {
@@ -139,8 +164,89 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 				break
 			}
 		}
+		if !spec.Skip && suiteConfig.ImpactFilter != "" && !impactedSpecs.Matches(spec.Nodes.CodeLocations()) {
+			spec.Skip, spec.SkipReason = true, impactFilter.SkipReason()
+		}
 		processedSpecs = append(processedSpecs, spec)
 	}
 
-	return processedSpecs, hasProgrammaticFocus
+	return includePrerequisitesOfSelectedSpecs(processedSpecs), hasProgrammaticFocus
+}
+
+/*
+specsMatchingRerunFilter returns the SubjectIDs of the specs matched by the RerunFilter.
+
+Specs are matched by file name, container hierarchy, and leaf node text.  When multiple specs share all three (e.g. table entries with identical descriptions) the line numbers of the failed specs are used to pick out the ones that actually failed.  If none of those line numbers match (presumably because the file has since been edited) all the ambiguous specs are rerun - it's better to run a few specs too many than to miss the one that failed.
+*/
+func specsMatchingRerunFilter(specs Specs, filter types.RerunFilter) map[uint]bool {
+	candidates := map[string]Specs{}
+	keys := []string{}
+	for _, spec := range specs {
+		leaf := spec.FirstNodeWithType(types.NodeTypeIt)
+		key := types.RerunFilterKey(leaf.CodeLocation, spec.Nodes.WithType(types.NodeTypeContainer).Texts(), leaf.Text)
+		if _, ok := filter[key]; !ok {
+			continue
+		}
+		if _, ok := candidates[key]; !ok {
+			keys = append(keys, key)
+		}
+		candidates[key] = append(candidates[key], spec)
+	}
+
+	matches := map[uint]bool{}
+	for _, key := range keys {
+		matchedByLine := Specs{}
+		if len(candidates[key]) > 1 {
+			for _, spec := range candidates[key] {
+				if slices.Contains(filter[key], spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation.LineNumber) {
+					matchedByLine = append(matchedByLine, spec)
+				}
+			}
+		}
+		if len(matchedByLine) == 0 {
+			matchedByLine = candidates[key]
+		}
+		for _, spec := range matchedByLine {
+			matches[spec.SubjectID()] = true
+		}
+	}
+	return matches
+}
+
+/*
+includePrerequisitesOfSelectedSpecs ensures that the specs the filters have selected can actually run by un-skipping the specs they depend on (transitively) via DependsOn.  Without their prerequisites the selected specs would simply be skipped.
+
+Pending prerequisites remain skipped - there's no running those.
+*/
+func includePrerequisitesOfSelectedSpecs(specs Specs) Specs {
+	dependencies := newSpecDependencies(specs)
+	if dependencies.IsZero() {
+		return specs
+	}
+	indexBySubjectID := map[uint]int{}
+	pending := []uint{}
+	for idx, spec := range specs {
+		indexBySubjectID[spec.SubjectID()] = idx
+		if !spec.Skip {
+			pending = append(pending, spec.SubjectID())
+		}
+	}
+	visited := map[uint]bool{}
+	for len(pending) > 0 {
+		subjectID := pending[0]
+		pending = pending[1:]
+		if visited[subjectID] {
+			continue
+		}
+		visited[subjectID] = true
+		for _, prerequisite := range dependencies.prerequisiteSpecsFor(specs[indexBySubjectID[subjectID]]) {
+			idx := indexBySubjectID[prerequisite]
+			if specs[idx].Nodes.HasNodeMarkedPending() {
+				continue
+			}
+			specs[idx].Skip, specs[idx].SkipReason = false, ""
+			pending = append(pending, prerequisite)
+		}
+	}
+	return specs
 }
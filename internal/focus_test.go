@@ -1,6 +1,10 @@
 package internal_test
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -81,6 +85,7 @@ var _ = Describe("Focus", func() {
 		var suiteLabels Labels
 		var suiteSemVerConstraints SemVerConstraints
 		var suiteComponentSemVerConstraints ComponentSemVerConstraints
+		var suitePath string
 		var conf types.SuiteConfig
 
 		harvestSkips := func(specs Specs) []bool {
@@ -93,6 +98,7 @@ var _ = Describe("Focus", func() {
 
 		BeforeEach(func() {
 			description = "Silmarillion Suite"
+			suitePath = "/path/to/silmarillion"
 			suiteLabels = Labels{"SuiteLabel", "TopLevelLabel"}
 			conf = types.SuiteConfig{}
 		})
@@ -109,7 +115,7 @@ var _ = Describe("Focus", func() {
 			})
 
 			It("skips those specs", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, true, false, true}))
 				Ω(hasProgrammaticFocus).Should(BeFalse())
 			})
@@ -126,7 +132,7 @@ var _ = Describe("Focus", func() {
 				}
 			})
 			It("skips any other specs and notes that it has programmatic focus", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{true, true, false, true, false}))
 				Ω(hasProgrammaticFocus).Should(BeTrue())
 			})
@@ -141,7 +147,7 @@ var _ = Describe("Focus", func() {
 					}
 				})
 				It("does not skip any other specs and notes that it does not have programmatic focus", func() {
-					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 					Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, true, false}))
 					Ω(hasProgrammaticFocus).Should(BeFalse())
 				})
@@ -167,14 +173,14 @@ var _ = Describe("Focus", func() {
 				})
 
 				It("overrides any programmatic focus, runs only specs that match the focus string, and continues to skip specs with nodes marked pending", func() {
-					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 					Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, false, false, true, true, true}))
 					Ω(hasProgrammaticFocus).Should(BeFalse())
 				})
 
 				It("includes the description string in the search", func() {
 					conf.FocusStrings = []string{"Silmaril"}
-					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 					Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, false, false, true, false, false}))
 					Ω(hasProgrammaticFocus).Should(BeFalse())
 				})
@@ -186,14 +192,14 @@ var _ = Describe("Focus", func() {
 				})
 
 				It("overrides any programmatic focus, and runs specs that don't match the skip strings, and continues to skip specs with nodes marked pending", func() {
-					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 					Ω(harvestSkips(specs)).Should(Equal([]bool{true, true, true, false, true, false, false}))
 					Ω(hasProgrammaticFocus).Should(BeFalse())
 				})
 
 				It("includes the description string in the search", func() {
 					conf.SkipStrings = []string{"Silmaril"}
-					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 					Ω(harvestSkips(specs)).Should(Equal([]bool{true, true, true, true, true, true, true}))
 					Ω(hasProgrammaticFocus).Should(BeFalse())
 				})
@@ -206,7 +212,7 @@ var _ = Describe("Focus", func() {
 				})
 
 				It("ORs both together", func() {
-					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 					Ω(harvestSkips(specs)).Should(Equal([]bool{false, true, true, false, true, true, true}))
 					Ω(hasProgrammaticFocus).Should(BeFalse())
 				})
@@ -229,12 +235,184 @@ var _ = Describe("Focus", func() {
 			})
 
 			It("applies a file-based focus and skip filter", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, true, true, true, false}))
 				Ω(hasProgrammaticFocus).Should(BeFalse())
 			})
 		})
 
+		Context("when configured to focus on spec IDs", func() {
+			BeforeEach(func() {
+				specs = Specs{
+					S(N()),        //skip because "A" is not in FocusIDs
+					S(N()),        //include because "B" is in FocusIDs
+					S(N(Pending)), //skip because spec is flagged pending
+					S(N()),        //include because "D" is in FocusIDs
+					S(N()),        //skip because "ED" is not in FocusIDs - IDs must match exactly
+				}
+				for i, id := range []string{"A", "B", "C", "D", "ED"} {
+					specs[i].ID = id
+				}
+
+				conf.FocusIDs = []string{"B", "C", "D"}
+			})
+
+			It("only includes the specs with matching IDs", func() {
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{true, false, true, false, true}))
+				Ω(hasProgrammaticFocus).Should(BeFalse())
+			})
+
+			It("also includes the specs the matching specs depend on, transitively, so that they can run", func() {
+				specs = Specs{
+					S(N(internal.SpecID("setup"))),                                    //include because "B" depends on it, via "migrate"
+					S(N(internal.SpecID("migrate"), internal.Prerequisites{"setup"})), //include because "B" depends on it
+					S(N(internal.Prerequisites{"migrate"})),                           //include because "B" is in FocusIDs
+					S(N(internal.Prerequisites{"setup"})),                             //skip because "C" is not in FocusIDs
+				}
+				for i, id := range []string{"A", "M", "B", "C"} {
+					specs[i].ID = id
+				}
+				conf.FocusIDs = []string{"B"}
+				specs, _ = internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, false, true}))
+			})
+		})
+
+		Context("when configured with an impact filter", func() {
+			BeforeEach(func() {
+				specs = Specs{
+					S(N(CL("file_a", 1))),              //include because "file_a" is impacted
+					S(N(CL("file_b", 3), CL("c", 15))), //include because "c:15" is impacted
+					S(N(CL("file_b", 17))),             //skip because it is not impacted
+					S(N(CL("file_a", 20), Pending)),    //skip because spec is flagged pending
+					S(N(CL("d", 17))),                  //skip because it is skipped by --skip-file
+				}
+
+				conf.SkipFiles = []string{"d"}
+				conf.ImpactFilter = filepath.Join(GinkgoT().TempDir(), "impact-filter.json")
+				Ω(types.ImpactFilter{ChangedSince: "main", ImpactedSpecs: []string{"file_a", "c:15"}}.Save(conf.ImpactFilter)).Should(Succeed())
+			})
+
+			It("skips the specs that aren't impacted, with a reason", func() {
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, true, true, true}))
+				Ω(specs[2].SkipReason).Should(Equal("not impacted by the changes since main"))
+				Ω(specs[3].SkipReason).Should(BeEmpty())
+				Ω(specs[4].SkipReason).Should(BeEmpty())
+				Ω(hasProgrammaticFocus).Should(BeFalse())
+			})
+		})
+
+		Context("when configured to rerun failed specs", func() {
+			var failedSpecs types.SpecReports
+
+			failed := func(file string, line int, containers []string, text string) types.SpecReport {
+				return types.SpecReport{
+					LeafNodeType:            types.NodeTypeIt,
+					LeafNodeLocation:        CL(file, line),
+					LeafNodeText:            text,
+					ContainerHierarchyTexts: containers,
+					State:                   types.SpecStateFailed,
+				}
+			}
+
+			JustBeforeEach(func() {
+				conf.RerunFailed = filepath.Join(GinkgoT().TempDir(), "report.json")
+				data, err := json.Marshal([]types.Report{{SpecReports: failedSpecs}})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(os.WriteFile(conf.RerunFailed, data, 0666)).Should(Succeed())
+			})
+
+			Context("when the report has failures", func() {
+				BeforeEach(func() {
+					failedSpecs = types.SpecReports{
+						failed("/old/path/a_test.go", 3, []string{"outer", "inner"}, "A"),
+						failed("/old/path/a_test.go", 10, []string{"table"}, "entry"),
+						{LeafNodeType: types.NodeTypeIt, LeafNodeLocation: CL("a_test.go", 20), LeafNodeText: "C", State: types.SpecStatePassed},
+						{LeafNodeType: types.NodeTypeIt, LeafNodeLocation: CL("a_test.go", 30), LeafNodeText: "D", State: types.SpecStatePanicked},
+						{LeafNodeType: types.NodeTypeBeforeSuite, LeafNodeLocation: CL("a_test.go", 40), State: types.SpecStateFailed},
+					}
+					specs = Specs{
+						S(N(ntCon, "outer", CL("a_test.go", 1)), N(ntCon, "inner", CL("a_test.go", 2)), N(ntIt, "A", CL("a_test.go", 7))), //include: hierarchy and text match even though the line moved
+						S(N(ntCon, "outer", CL("a_test.go", 1)), N(ntIt, "A", CL("a_test.go", 3))),                                        //skip: hierarchy differs
+						S(N(ntCon, "inner", CL("b_test.go", 2)), N(ntCon, "outer", CL("a_test.go", 2)), N(ntIt, "A", CL("b_test.go", 3))), //skip: file and hierarchy differ
+						S(N(ntCon, "table", CL("a_test.go", 8)), N(ntIt, "entry", CL("a_test.go", 9))),                                    //skip: duplicate entry text, different line
+						S(N(ntCon, "table", CL("a_test.go", 8)), N(ntIt, "entry", CL("a_test.go", 10))),                                   //include: duplicate entry text, matching line
+						S(N(ntIt, "C", CL("a_test.go", 20))), //skip: passed
+						S(N(ntIt, "D", CL("a_test.go", 31))), //include: panicked
+						S(N(ntIt, "E", CL("a_test.go", 40))), //skip: not in report
+					}
+				})
+
+				It("only runs the failed specs", func() {
+					specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+					Ω(harvestSkips(specs)).Should(Equal([]bool{false, true, true, true, false, true, false, true}))
+					Ω(hasProgrammaticFocus).Should(BeFalse())
+				})
+			})
+
+			Context("when specs with duplicate text have all moved", func() {
+				BeforeEach(func() {
+					failedSpecs = types.SpecReports{failed("a_test.go", 10, []string{"table"}, "entry")}
+					specs = Specs{
+						S(N(ntCon, "table", CL("a_test.go", 8)), N(ntIt, "entry", CL("a_test.go", 12))),
+						S(N(ntCon, "table", CL("a_test.go", 8)), N(ntIt, "entry", CL("a_test.go", 13))),
+						S(N(ntCon, "table", CL("a_test.go", 8)), N(ntIt, "other", CL("a_test.go", 14))),
+					}
+				})
+
+				It("runs all the ambiguous specs", func() {
+					specs, _ := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+					Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, true}))
+				})
+			})
+
+			Context("when the report covers several suites", func() {
+				BeforeEach(func() {
+					specs = Specs{
+						S(N(ntCon, "container", CL("a_test.go", 1)), N(ntIt, "A", CL("a_test.go", 3))),
+						S(N(ntCon, "container", CL("a_test.go", 1)), N(ntIt, "B", CL("a_test.go", 5))),
+					}
+				})
+
+				JustBeforeEach(func() {
+					data, err := json.Marshal([]types.Report{
+						{SuitePath: "/path/to/silmarillion", SpecReports: types.SpecReports{failed("/path/to/silmarillion/a_test.go", 3, []string{"container"}, "A")}},
+						{SuitePath: "/path/to/hobbit", SpecReports: types.SpecReports{failed("/path/to/hobbit/a_test.go", 5, []string{"container"}, "B")}},
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(os.WriteFile(conf.RerunFailed, data, 0666)).Should(Succeed())
+				})
+
+				It("only reruns the specs that failed in the running suite", func() {
+					specs, _ := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+					Ω(harvestSkips(specs)).Should(Equal([]bool{false, true}))
+				})
+
+				It("skips every spec in suites with no failures of their own", func() {
+					specs, _ := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, "/path/to/unfinished-tales", conf)
+					Ω(harvestSkips(specs)).Should(Equal([]bool{true, true}))
+				})
+			})
+
+			Context("when the report has no failures", func() {
+				BeforeEach(func() {
+					failedSpecs = types.SpecReports{{LeafNodeType: types.NodeTypeIt, LeafNodeLocation: CL("a_test.go", 20), LeafNodeText: "C", State: types.SpecStatePassed}}
+					specs = Specs{
+						S(N(ntIt, "C", CL("a_test.go", 20))),
+						S(N(ntIt, "D", CL("a_test.go", 30))),
+						S(N(ntIt, "E", CL("a_test.go", 40), Pending)),
+					}
+				})
+
+				It("runs everything", func() {
+					specs, _ := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+					Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, true}))
+				})
+			})
+		})
+
 		Context("when configured with a label filter", func() {
 			BeforeEach(func() {
 				conf.LabelFilter = "(cat || cow) && !fish"
@@ -249,7 +427,7 @@ var _ = Describe("Focus", func() {
 			})
 
 			It("applies the label filters", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{true, false, true, true, false, true}))
 				Ω(hasProgrammaticFocus).Should(BeFalse())
 
@@ -270,7 +448,7 @@ var _ = Describe("Focus", func() {
 			})
 
 			It("applies the label filters", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{true, false, true, false, true, true}))
 				Ω(hasProgrammaticFocus).Should(BeFalse())
 
@@ -286,7 +464,7 @@ var _ = Describe("Focus", func() {
 				}
 			})
 			It("honors the suite level label", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{false, true}))
 				Ω(hasProgrammaticFocus).Should(BeFalse())
 			})
@@ -312,7 +490,7 @@ var _ = Describe("Focus", func() {
 			})
 
 			It("applies all filters", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{false, true, true, true, true, true, true}))
 				Ω(hasProgrammaticFocus).Should(BeFalse())
 			})
@@ -339,10 +517,35 @@ var _ = Describe("Focus", func() {
 			})
 
 			It("applies all filters", func() {
-				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, conf)
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
 				Ω(harvestSkips(specs)).Should(Equal([]bool{true, false, true, true, true, true, true, true}))
 				Ω(hasProgrammaticFocus).Should(BeTrue())
 			})
 		})
+
+		Context("when the specs selected by the filters depend on other specs", func() {
+			BeforeEach(func() {
+				specs = Specs{
+					S(N(ntCon, "Database", internal.SpecID("setup")), N("creates the schema")),                          //include because "migrates" depends on it, via "migrate"
+					S(N(ntCon, "Database"), N("migrates", internal.SpecID("migrate"), internal.Prerequisites{"setup"})), //include because "reports" depends on it
+					S(N(ntCon, "Reporting"), N("reports", internal.Prerequisites{"migrate"}, Label("reporting"))),       //include because it matches the filter
+					S(N(ntCon, "Reporting"), N("exports", internal.Prerequisites{"setup"})),                             //skip because it doesn't match the filter
+					S(N(ntCon, "Cache", internal.SpecID("warm"), Pending), N("warms up")),                               //skip because spec is flagged pending
+					S(N(ntCon, "Cache"), N("reads from the cache", internal.Prerequisites{"warm"}, Label("reporting"))), //include because it matches the filter
+				}
+			})
+
+			It("also includes the prerequisites of the specs matching --focus, transitively, so that they can run", func() {
+				conf.FocusStrings = []string{"reports|reads from"}
+				specs, _ = internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, false, true, true, false}))
+			})
+
+			It("also includes the prerequisites of the specs matching a label filter", func() {
+				conf.LabelFilter = "reporting"
+				specs, _ = internal.ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{false, false, false, true, true, false}))
+			})
+		})
 	})
 })
@@ -0,0 +1,298 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// FuzzCorpusHeader is the first line of every file in a Go fuzz corpus
+const FuzzCorpusHeader = "go test fuzz v1"
+
+// fuzzableTypes are the parameter types supported by Go's fuzzing engine
+var fuzzableTypes = map[reflect.Type]bool{
+	reflect.TypeFor[[]byte]():  true,
+	reflect.TypeFor[string]():  true,
+	reflect.TypeFor[bool]():    true,
+	reflect.TypeFor[byte]():    true,
+	reflect.TypeFor[rune]():    true,
+	reflect.TypeFor[float32](): true,
+	reflect.TypeFor[float64](): true,
+	reflect.TypeFor[int]():     true,
+	reflect.TypeFor[int8]():    true,
+	reflect.TypeFor[int16]():   true,
+	reflect.TypeFor[int64]():   true,
+	reflect.TypeFor[uint]():    true,
+	reflect.TypeFor[uint16]():  true,
+	reflect.TypeFor[uint32]():  true,
+	reflect.TypeFor[uint64]():  true,
+}
+
+// IsFuzzableType returns true if Go's fuzzing engine can generate values of type t
+func IsFuzzableType(t reflect.Type) bool {
+	return fuzzableTypes[t]
+}
+
+var fuzzCorpusDirectoryRE = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// FuzzCorpusDirectory returns the directory, relative to the suite, in which the corpus for the DescribeFuzz with the passed-in full text is stored
+func FuzzCorpusDirectory(text string) string {
+	return filepath.Join("testdata", "fuzz", fuzzCorpusDirectoryRE.ReplaceAllString(text, "_"))
+}
+
+// FuzzCorpusEntry is an input read from a fuzz corpus directory
+type FuzzCorpusEntry struct {
+	Path   string
+	Values []any
+}
+
+/*
+ReadFuzzCorpus reads the inputs stored in dir - typically these are failing inputs found by Go's fuzzing engine.  The values in each file must have the passed-in types.
+
+A missing directory is not an error: it simply means that no inputs have been found yet.
+*/
+func ReadFuzzCorpus(dir string, types []reflect.Type) ([]FuzzCorpusEntry, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+
+	entries := []FuzzCorpusEntry{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values, err := ParseFuzzCorpusFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(values) != len(types) {
+			return nil, fmt.Errorf("%s: expected %d values but found %d", path, len(types), len(values))
+		}
+		for i := range values {
+			if reflect.TypeOf(values[i]) != types[i] {
+				return nil, fmt.Errorf("%s: value #%d has type %T but the fuzz body expects %s", path, i+1, values[i], types[i])
+			}
+		}
+		entries = append(entries, FuzzCorpusEntry{Path: path, Values: values})
+	}
+	return entries, nil
+}
+
+// ParseFuzzCorpusFile parses a file written in Go's fuzz corpus encoding
+func ParseFuzzCorpusFile(data []byte) ([]any, error) {
+	lines := bytes.Split(data, []byte("\n"))
+	if len(lines) == 0 || string(bytes.TrimSpace(lines[0])) != FuzzCorpusHeader {
+		return nil, fmt.Errorf("missing \"%s\" header", FuzzCorpusHeader)
+	}
+	values := []any{}
+	for _, line := range lines[1:] {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		value, err := parseFuzzCorpusValue(string(line))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values found")
+	}
+	return values, nil
+}
+
+func parseFuzzCorpusValue(line string) (any, error) {
+	expr, err := parser.ParseExpr(line)
+	if err != nil {
+		return nil, fmt.Errorf("malformed value %s: %w", line, err)
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, fmt.Errorf("malformed value %s", line)
+	}
+
+	if array, ok := call.Fun.(*ast.ArrayType); ok {
+		if elt, ok := array.Elt.(*ast.Ident); !ok || array.Len != nil || elt.Name != "byte" {
+			return nil, fmt.Errorf("unsupported type in %s", line)
+		}
+		s, err := parseFuzzCorpusLiteral(call.Args[0], token.STRING)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value %s: %w", line, err)
+		}
+		return []byte(s), nil
+	}
+
+	if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+		pkg, ok := selector.X.(*ast.Ident)
+		if !ok || pkg.Name != "math" {
+			return nil, fmt.Errorf("unsupported type in %s", line)
+		}
+		bits, err := parseFuzzCorpusLiteral(call.Args[0], token.INT)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value %s: %w", line, err)
+		}
+		switch selector.Sel.Name {
+		case "Float64frombits":
+			u, err := strconv.ParseUint(bits, 0, 64)
+			return math.Float64frombits(u), err
+		case "Float32frombits":
+			u, err := strconv.ParseUint(bits, 0, 32)
+			return math.Float32frombits(uint32(u)), err
+		}
+		return nil, fmt.Errorf("unsupported type in %s", line)
+	}
+
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type in %s", line)
+	}
+	arg := call.Args[0]
+	switch ident.Name {
+	case "string":
+		s, err := parseFuzzCorpusLiteral(arg, token.STRING)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value %s: %w", line, err)
+		}
+		return s, nil
+	case "bool":
+		if value, ok := arg.(*ast.Ident); ok && (value.Name == "true" || value.Name == "false") {
+			return value.Name == "true", nil
+		}
+		return nil, fmt.Errorf("malformed value %s", line)
+	case "byte", "rune":
+		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.CHAR {
+			r, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+			if err != nil {
+				return nil, err
+			}
+			if ident.Name == "byte" {
+				return byte(r), nil
+			}
+			return r, nil
+		}
+	}
+
+	if f, ok := parseFuzzCorpusSpecialFloat(arg); ok {
+		switch ident.Name {
+		case "float32":
+			return float32(f), nil
+		case "float64":
+			return f, nil
+		}
+	}
+
+	s, err := parseFuzzCorpusLiteral(arg, token.INT, token.FLOAT)
+	if err != nil {
+		return nil, fmt.Errorf("malformed value %s: %w", line, err)
+	}
+	var value any
+	switch ident.Name {
+	case "int":
+		value, err = strconv.Atoi(s)
+	case "int8":
+		value, err = parseFuzzCorpusInt[int8](s, 8)
+	case "int16":
+		value, err = parseFuzzCorpusInt[int16](s, 16)
+	case "int32", "rune":
+		value, err = parseFuzzCorpusInt[int32](s, 32)
+	case "int64":
+		value, err = parseFuzzCorpusInt[int64](s, 64)
+	case "uint":
+		value, err = parseFuzzCorpusUint[uint](s, 64)
+	case "uint8", "byte":
+		value, err = parseFuzzCorpusUint[uint8](s, 8)
+	case "uint16":
+		value, err = parseFuzzCorpusUint[uint16](s, 16)
+	case "uint32":
+		value, err = parseFuzzCorpusUint[uint32](s, 32)
+	case "uint64":
+		value, err = parseFuzzCorpusUint[uint64](s, 64)
+	case "float32":
+		var f float64
+		f, err = strconv.ParseFloat(s, 32)
+		value = float32(f)
+	case "float64":
+		value, err = strconv.ParseFloat(s, 64)
+	default:
+		return nil, fmt.Errorf("unsupported type in %s", line)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed value %s: %w", line, err)
+	}
+	return value, nil
+}
+
+// parseFuzzCorpusLiteral returns the (unquoted) string representation of a literal of one of the passed-in kinds.  Numbers may be negated.
+func parseFuzzCorpusLiteral(expr ast.Expr, kinds ...token.Token) (string, error) {
+	sign := ""
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+		sign, expr = "-", unary.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return "", fmt.Errorf("expected a literal")
+	}
+	for _, kind := range kinds {
+		if lit.Kind != kind {
+			continue
+		}
+		if kind == token.STRING {
+			if sign != "" {
+				return "", fmt.Errorf("strings can't be negated")
+			}
+			return strconv.Unquote(lit.Value)
+		}
+		return sign + lit.Value, nil
+	}
+	return "", fmt.Errorf("unexpected %s literal", lit.Kind)
+}
+
+// parseFuzzCorpusSpecialFloat parses the +Inf, -Inf, and NaN values that Go's fuzzing engine writes for floats
+func parseFuzzCorpusSpecialFloat(expr ast.Expr) (float64, bool) {
+	sign := 1
+	if unary, ok := expr.(*ast.UnaryExpr); ok && (unary.Op == token.ADD || unary.Op == token.SUB) {
+		if unary.Op == token.SUB {
+			sign = -1
+		}
+		expr = unary.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	switch {
+	case !ok:
+		return 0, false
+	case ident.Name == "Inf":
+		return math.Inf(sign), true
+	case ident.Name == "NaN":
+		return math.NaN(), true
+	}
+	return 0, false
+}
+
+func parseFuzzCorpusInt[T int8 | int16 | int32 | int64](s string, bitSize int) (T, error) {
+	i, err := strconv.ParseInt(s, 0, bitSize)
+	return T(i), err
+}
+
+func parseFuzzCorpusUint[T uint | uint8 | uint16 | uint32 | uint64](s string, bitSize int) (T, error) {
+	u, err := strconv.ParseUint(s, 0, bitSize)
+	return T(u), err
+}
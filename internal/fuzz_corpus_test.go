@@ -0,0 +1,109 @@
+package internal_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+)
+
+var _ = Describe("Fuzz corpora", func() {
+	Describe("FuzzCorpusDirectory", func() {
+		It("sanitizes the description", func() {
+			Ω(internal.FuzzCorpusDirectory("parses durations")).Should(Equal(filepath.Join("testdata", "fuzz", "parses_durations")))
+			Ω(internal.FuzzCorpusDirectory("a/b: c-d.e")).Should(Equal(filepath.Join("testdata", "fuzz", "a_b_c-d.e")))
+		})
+	})
+
+	Describe("ParseFuzzCorpusFile", func() {
+		It("parses the values written by Go's fuzzing engine", func() {
+			values, err := internal.ParseFuzzCorpusFile([]byte(`go test fuzz v1
+[]byte("\x00abc")
+string("héllo\n")
+bool(true)
+byte('\x01')
+rune('é')
+int(-3)
+int8(12)
+int16(-0x10)
+int64(99)
+uint(7)
+uint16(8)
+uint32(9)
+uint64(10)
+float32(1.5)
+float64(-2.25)
+float64(+Inf)
+math.Float64frombits(0x7ff8000000000001)
+`))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(values).Should(HaveLen(17))
+			Ω(values[:15]).Should(Equal([]any{[]byte("\x00abc"), "héllo\n", true, byte(1), 'é', -3, int8(12), int16(-16), int64(99), uint(7), uint16(8), uint32(9), uint64(10), float32(1.5), -2.25}))
+			Ω(math.IsInf(values[15].(float64), 1)).Should(BeTrue())
+			Ω(math.IsNaN(values[16].(float64))).Should(BeTrue())
+		})
+
+		It("errors when the header is missing", func() {
+			_, err := internal.ParseFuzzCorpusFile([]byte("string(\"a\")\n"))
+			Ω(err).Should(MatchError(ContainSubstring("missing \"go test fuzz v1\" header")))
+		})
+
+		It("errors when a value is malformed or has an unsupported type", func() {
+			_, err := internal.ParseFuzzCorpusFile([]byte("go test fuzz v1\nstring(3)\n"))
+			Ω(err).Should(MatchError(ContainSubstring("malformed value string(3)")))
+
+			_, err = internal.ParseFuzzCorpusFile([]byte("go test fuzz v1\ncomplex64(3)\n"))
+			Ω(err).Should(MatchError(ContainSubstring("unsupported type in complex64(3)")))
+
+			_, err = internal.ParseFuzzCorpusFile([]byte("go test fuzz v1\nint8(300)\n"))
+			Ω(err).Should(MatchError(ContainSubstring("malformed value int8(300)")))
+		})
+	})
+
+	Describe("ReadFuzzCorpus", func() {
+		var dir string
+		types := []reflect.Type{reflect.TypeFor[string](), reflect.TypeFor[int]()}
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		It("returns nothing when the directory does not exist", func() {
+			Ω(internal.ReadFuzzCorpus(filepath.Join(dir, "missing"), types)).Should(BeEmpty())
+		})
+
+		It("reads each file, in order", func() {
+			Ω(os.WriteFile(filepath.Join(dir, "b"), []byte("go test fuzz v1\nstring(\"b\")\nint(2)\n"), 0644)).Should(Succeed())
+			Ω(os.WriteFile(filepath.Join(dir, "a"), []byte("go test fuzz v1\nstring(\"a\")\nint(1)\n"), 0644)).Should(Succeed())
+			Ω(internal.ReadFuzzCorpus(dir, types)).Should(Equal([]internal.FuzzCorpusEntry{
+				{Path: filepath.Join(dir, "a"), Values: []any{"a", 1}},
+				{Path: filepath.Join(dir, "b"), Values: []any{"b", 2}},
+			}))
+		})
+
+		It("errors when the values don't match the expected types", func() {
+			Ω(os.WriteFile(filepath.Join(dir, "a"), []byte("go test fuzz v1\nstring(\"a\")\n"), 0644)).Should(Succeed())
+			_, err := internal.ReadFuzzCorpus(dir, types)
+			Ω(err).Should(MatchError(ContainSubstring("expected 2 values but found 1")))
+
+			Ω(os.WriteFile(filepath.Join(dir, "a"), []byte("go test fuzz v1\nstring(\"a\")\nint64(1)\n"), 0644)).Should(Succeed())
+			_, err = internal.ReadFuzzCorpus(dir, types)
+			Ω(err).Should(MatchError(ContainSubstring("value #2 has type int64 but the fuzz body expects int")))
+		})
+	})
+
+	Describe("IsFuzzableType", func() {
+		It("only allows the types supported by Go's fuzzing engine", func() {
+			Ω(internal.IsFuzzableType(reflect.TypeFor[[]byte]())).Should(BeTrue())
+			Ω(internal.IsFuzzableType(reflect.TypeFor[int32]())).Should(BeTrue())
+			Ω(internal.IsFuzzableType(reflect.TypeFor[uint8]())).Should(BeTrue())
+			Ω(internal.IsFuzzableType(reflect.TypeFor[[]string]())).Should(BeFalse())
+			Ω(internal.IsFuzzableType(reflect.TypeFor[struct{}]())).Should(BeFalse())
+		})
+	})
+})
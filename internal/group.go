@@ -93,6 +93,8 @@ type group struct {
 	specs          Specs
 	runOncePairs   map[uint]runOncePairs
 	runOnceTracker map[runOncePair]types.SpecState
+	// skip messages for specs whose prerequisites did not pass, keyed by SubjectID
+	unmetPrerequisites map[uint]string
 
 	succeeded              bool
 	failedInARunOnceBefore bool
@@ -104,6 +106,7 @@ func newGroup(suite *Suite) *group {
 		suite:                  suite,
 		runOncePairs:           map[uint]runOncePairs{},
 		runOnceTracker:         map[runOncePair]types.SpecState{},
+		unmetPrerequisites:     map[uint]string{},
 		succeeded:              true,
 		failedInARunOnceBefore: false,
 		continueOnFailure:      false,
@@ -112,6 +115,11 @@ func newGroup(suite *Suite) *group {
 
 // initialReportForSpec constructs a new SpecReport right before running the spec.
 func (g *group) initialReportForSpec(spec Spec) types.SpecReport {
+	quarantinedSpec, isQuarantined := g.suite.quarantine.Match(spec.Text(), spec.Nodes.WithType(types.NodeTypeContainer|types.NodeTypeIt).CodeLocations())
+	gracePeriod := spec.FirstNodeWithType(types.NodeTypeIt).GracePeriod
+	if gracePeriod < 0 {
+		gracePeriod = 0
+	}
 	return types.SpecReport{
 		ContainerHierarchyTexts:                      spec.Nodes.WithType(types.NodeTypeContainer).Texts(),
 		ContainerHierarchyLocations:                  spec.Nodes.WithType(types.NodeTypeContainer).CodeLocations(),
@@ -128,9 +136,15 @@ func (g *group) initialReportForSpec(spec Spec) types.SpecReport {
 		RunningInParallel:                            g.suite.isRunningInParallel(),
 		IsSerial:                                     spec.Nodes.HasNodeMarkedSerial(),
 		IsInOrderedContainer:                         !spec.Nodes.FirstNodeMarkedOrdered().IsZero(),
+		IsQuarantined:                                isQuarantined,
+		QuarantineReason:                             quarantinedSpec.Reason,
 		MaxFlakeAttempts:                             spec.Nodes.GetMaxFlakeAttempts(),
 		MaxMustPassRepeatedly:                        spec.Nodes.GetMaxMustPassRepeatedly(),
+		SpecTimeout:                                  spec.FirstNodeWithType(types.NodeTypeIt).SpecTimeout,
+		NodeTimeout:                                  spec.FirstNodeWithType(types.NodeTypeIt).NodeTimeout,
+		GracePeriod:                                  gracePeriod,
 		SpecPriority:                                 spec.Nodes.GetSpecPriority(),
+		ID:                                           spec.ID,
 	}
 }
 
@@ -140,6 +154,7 @@ func constructionNodeReportForTreeNode(node *TreeNode) *types.ConstructionNodeRe
 	var report types.ConstructionNodeReport
 	// Walk up the tree and set attributes accordingly.
 	addNodeToReportForNode(&report, node)
+	report.ID = containerSpecID(node.AncestorNodeChain().WithType(types.NodeTypeContainer))
 	return &report
 }
 
@@ -167,6 +182,12 @@ func (g *group) evaluateSkipStatus(spec Spec) (types.SpecState, types.Failure) {
 	if spec.Nodes.HasNodeMarkedPending() {
 		return types.SpecStatePending, types.Failure{}
 	}
+	if message, ok := g.unmetPrerequisites[spec.SubjectID()]; ok {
+		return types.SpecStateSkipped, g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt), message)
+	}
+	if spec.Skip && spec.SkipReason != "" {
+		return types.SpecStateSkipped, g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt), spec.SkipReason)
+	}
 	if spec.Skip {
 		return types.SpecStateSkipped, types.Failure{}
 	}
@@ -211,6 +232,62 @@ func (g *group) isLastSpecWithPair(specID uint, pair runOncePair) bool {
 	return lastSpecID == specID
 }
 
+// isLastSpecToRun returns true if no spec after the spec at idx will run - at which point all of the group's AfterAll nodes have run
+func (g *group) isLastSpecToRun(idx int) bool {
+	if g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates) && !g.continueOnFailure {
+		return true
+	}
+	for _, spec := range g.specs[idx+1:] {
+		if !spec.Skip && !spec.Nodes.HasNodeMarkedPending() {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+skipSpecsWithUnmetPrerequisites marks the specs from idx onwards whose prerequisites did not pass as skipped.  This happens before a spec runs - rather than when each spec is evaluated - so that isLastSpecWithPair knows which spec will actually be the last to run and the group's AfterAll nodes still run.  It is called again after each spec runs as specs in an Ordered container can depend on earlier specs in the same container.
+*/
+func (g *group) skipSpecsWithUnmetPrerequisites(idx int) {
+	if g.suite.specDependencies.IsZero() || g.suite.interruptHandler.Status().Interrupted() || g.suite.skipAll {
+		return
+	}
+	yetToRun := map[uint]bool{}
+	for _, spec := range g.specs[idx:] {
+		yetToRun[spec.SubjectID()] = true
+	}
+	for i := idx; i < len(g.specs); i++ {
+		spec := g.specs[i]
+		if spec.Skip || spec.Nodes.HasNodeMarkedPending() {
+			continue
+		}
+		if id, outcome := g.suite.unmetPrerequisite(spec, yetToRun); id != "" {
+			g.unmetPrerequisites[spec.SubjectID()] = fmt.Sprintf("Spec skipped because its prerequisite \"%s\" %s", id, outcome)
+			g.specs[i].Skip = true
+		}
+	}
+}
+
+func (g *group) reportLeaks(spec Spec, leakDetection LeakDetection, leaks leaks) {
+	if leaks.IsZero() {
+		return
+	}
+	node := spec.FirstNodeWithType(types.NodeTypeIt)
+	switch leakDetection {
+	case LeakDetectionFail:
+		failure := g.suite.failureForLeafNodeWithMessage(node, leaks.String())
+		if g.suite.currentSpecReport.State == types.SpecStatePassed {
+			g.suite.currentSpecReport.State = types.SpecStateFailed
+			g.suite.currentSpecReport.Failure = failure
+		} else {
+			g.suite.currentSpecReport.AdditionalFailures = append(g.suite.currentSpecReport.AdditionalFailures, types.AdditionalFailure{State: types.SpecStateFailed, Failure: failure})
+		}
+	case LeakDetectionWarn:
+		entry, _ := NewReportEntry("Leaks Detected", node.CodeLocation, leaks.String())
+		g.suite.AddReportEntry(entry)
+	}
+}
+
 func (g *group) attemptSpec(isFinalAttempt bool, spec Spec) bool {
 	failedInARunOnceBefore := false
 	pairs := g.runOncePairs[spec.SubjectID()]
@@ -340,9 +417,17 @@ func (g *group) run(specs Specs) {
 		g.runOncePairs[spec.SubjectID()] = runOncePairsForSpec(spec)
 	}
 
-	for _, spec := range g.specs {
+	g.skipSpecsWithUnmetPrerequisites(0)
+
+	// in Ordered containers goroutines and file descriptors can be shared between specs until the AfterAll nodes run
+	// so the baseline is taken before the first spec and leaks are only checked after the last spec runs
+	var leakBaseline leakSnapshot
+	leakDetection := LeakDetectionNone
+
+	for idx, spec := range g.specs {
 		g.suite.selectiveLock.Lock()
 		g.suite.currentSpecReport = g.initialReportForSpec(spec)
+		g.suite.currentSpecSharedFixtures = spec.Nodes.UnionOfSharedFixtures()
 		g.suite.selectiveLock.Unlock()
 
 		g.suite.currentSpecReport.State, g.suite.currentSpecReport.Failure = g.evaluateSkipStatus(spec)
@@ -351,6 +436,16 @@ func (g *group) run(specs Specs) {
 
 		skip := g.suite.config.DryRun || g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates|types.SpecStateSkipped|types.SpecStatePending)
 
+		if !skip && leakDetection == LeakDetectionNone {
+			leakDetection = spec.Nodes.GetLeakDetection()
+			if leakDetection == LeakDetectionNone {
+				leakDetection = LeakDetectionFromConfig(g.suite.config.DetectLeaks)
+			}
+			if leakDetection != LeakDetectionNone {
+				leakBaseline = takeLeakSnapshot()
+			}
+		}
+
 		g.suite.currentSpecReport.StartTime = time.Now()
 		failedInARunOnceBefore := false
 		if !skip {
@@ -370,6 +465,8 @@ func (g *group) run(specs Specs) {
 
 			for attempt := 0; attempt < maxAttempts; attempt++ {
 				g.suite.currentSpecReport.NumAttempts = attempt + 1
+				// only the subtests of the final attempt are reported
+				g.suite.currentSpecReport.Subtests = nil
 				g.suite.writer.Truncate()
 				g.suite.outputInterceptor.StartInterceptingOutput()
 				if attempt > 0 {
@@ -403,16 +500,32 @@ func (g *group) run(specs Specs) {
 					}
 				}
 			}
+
+			if leakDetection != LeakDetectionNone && g.isLastSpecToRun(idx) {
+				if !g.suite.currentSpecReport.State.Is(types.SpecStateInterrupted | types.SpecStateAborted) {
+					g.reportLeaks(spec, leakDetection, findLeaks(leakBaseline, spec.Nodes.UnionOfIgnoredLeaks()))
+				}
+				leakDetection = LeakDetectionNone
+			}
+		}
+
+		if g.suite.currentSpecReport.IsQuarantined && g.suite.currentSpecReport.State.Is(types.SpecStateFailed|types.SpecStatePanicked|types.SpecStateTimedout) {
+			g.suite.currentSpecReport.State = types.SpecStateQuarantined
 		}
 
 		g.suite.reportEach(spec, types.NodeTypeReportAfterEach)
 		g.suite.processCurrentSpecReport()
-		if g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates) {
+		g.suite.recordSpecState(spec, g.suite.currentSpecReport.State)
+		g.suite.sharedFixtureUserFinished(spec)
+		g.skipSpecsWithUnmetPrerequisites(idx + 1)
+		// a quarantined failure doesn't fail the suite but subsequent specs in an Ordered container still can't rely on it having succeeded
+		if g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates | types.SpecStateQuarantined) {
 			g.succeeded = false
 			g.failedInARunOnceBefore = g.failedInARunOnceBefore || failedInARunOnceBefore
 		}
 		g.suite.selectiveLock.Lock()
 		g.suite.currentSpecReport = types.SpecReport{}
+		g.suite.currentSpecSharedFixtures = nil
 		g.suite.selectiveLock.Unlock()
 	}
 }
@@ -0,0 +1,94 @@
+package internal_integration_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.QuarantineFile is set", func() {
+	var clB, clOrdered types.CodeLocation
+
+	BeforeEach(func() {
+		clB = types.NewCodeLocation(0)
+		clOrdered = types.NewCodeLocation(0)
+		quarantine := fmt.Sprintf(`
+- text: "container A"
+  reason: "flakes under load"
+- location: %s:%d
+- text: "container D"
+- location: %s:%d
+`, filepath.Base(clB.FileName), clB.LineNumber, filepath.Base(clOrdered.FileName), clOrdered.LineNumber)
+		conf.QuarantineFile = filepath.Join(GinkgoT().TempDir(), "flaky.yaml")
+		Ω(os.WriteFile(conf.QuarantineFile, []byte(quarantine), 0666)).Should(Succeed())
+	})
+
+	Context("when only quarantined specs fail", func() {
+		var success bool
+		BeforeEach(func() {
+			success, _ = RunFixture("quarantined failures", func() {
+				Context("container", func() {
+					It("A", rt.T("A", func() { F("fail A", cl) }))
+					It("B", clB, rt.T("B"))
+					It("C", rt.T("C"))
+					It("D", rt.T("D", func() { panic("boom") }))
+				})
+				Context("ordered", Ordered, clOrdered, func() {
+					It("E", rt.T("E", func() { F("fail E") }))
+					It("F", rt.T("F"))
+				})
+			})
+		})
+
+		It("runs the quarantined specs", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("A", "B", "C", "D", "E"))
+		})
+
+		It("reports the failures of quarantined specs as quarantined and does not fail the suite", func() {
+			Ω(success).Should(BeTrue())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(6), NPassed(2), NFailed(0), NSkipped(1)))
+			Ω(reporter.Did.WithState(types.SpecStateQuarantined).Names()).Should(ConsistOf("A", "D", "E"))
+		})
+
+		It("retains the failure and the reason for the quarantine", func() {
+			Ω(reporter.Did.Find("A")).Should(HaveBeenQuarantined("fail A", cl))
+			Ω(reporter.Did.Find("A").QuarantineReason).Should(Equal("flakes under load"))
+			Ω(reporter.Did.Find("D").Failure.ForwardedPanic).Should(Equal("boom"))
+		})
+
+		It("marks quarantined specs that pass as quarantined without changing their state", func() {
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+			Ω(reporter.Did.Find("B").IsQuarantined).Should(BeTrue())
+			Ω(reporter.Did.Find("C").IsQuarantined).Should(BeFalse())
+		})
+
+		It("quarantines every spec in a quarantined container, and still skips the rest of an Ordered container after a quarantined failure", func() {
+			Ω(reporter.Did.Find("E").IsQuarantined).Should(BeTrue())
+			Ω(reporter.Did.Find("F").IsQuarantined).Should(BeTrue())
+			Ω(reporter.Did.Find("F")).Should(HaveBeenSkippedWithMessage("Spec skipped because an earlier spec in an ordered container failed"))
+		})
+	})
+
+	Context("when a spec that is not quarantined fails", func() {
+		var success bool
+		BeforeEach(func() {
+			success, _ = RunFixture("quarantined and real failures", func() {
+				Context("container", func() {
+					It("A", rt.T("A", func() { F("fail A") }))
+					It("C", rt.T("C", func() { F("fail C") }))
+				})
+			})
+		})
+
+		It("fails the suite", func() {
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("A")).Should(HaveBeenQuarantined("fail A"))
+			Ω(reporter.Did.Find("C")).Should(HaveFailed("fail C"))
+		})
+	})
+})
@@ -52,6 +52,12 @@ var _ = Describe("CurrentSpecReport", func() {
 			Context("an serial spec", func() {
 				It("D", Serial, logCurrentSpecReport("D"), SpecPriority(10))
 			})
+
+			Context("a spec with timeouts", func() {
+				It("E", SpecTimeout(time.Minute), NodeTimeout(time.Second), GracePeriod(time.Millisecond), func(_ SpecContext) {
+					specs["E"] = CurrentSpecReport()
+				})
+			})
 			AfterSuite(logCurrentSpecReport("after-suite"))
 		})
 	})
@@ -100,6 +106,15 @@ var _ = Describe("CurrentSpecReport", func() {
 		Ω(specs["D"].SpecPriority).Should(Equal(10))
 	})
 
+	It("captures timeouts correctly", func() {
+		Ω(specs["it-A"].SpecTimeout).Should(BeZero())
+		Ω(specs["it-A"].NodeTimeout).Should(BeZero())
+		Ω(specs["it-A"].GracePeriod).Should(BeZero())
+		Ω(specs["E"].SpecTimeout).Should(Equal(time.Minute))
+		Ω(specs["E"].NodeTimeout).Should(Equal(time.Second))
+		Ω(specs["E"].GracePeriod).Should(Equal(time.Millisecond))
+	})
+
 	It("captures test details correctly", func() {
 		spec := specs["aft-A"]
 		Ω(spec.ContainerHierarchyTexts).Should(Equal([]string{"a passing test"}))
@@ -0,0 +1,174 @@
+package internal_integration_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+func leakyWorker(stop chan any) {
+	<-stop
+}
+
+var _ = Describe("Leak detection", func() {
+	var stop chan any
+
+	BeforeEach(func() {
+		stop = make(chan any)
+		DeferCleanup(func() { close(stop) })
+		originalTimeout := internal.LeakDetectionTimeout
+		internal.LeakDetectionTimeout = 100 * time.Millisecond
+		DeferCleanup(func() { internal.LeakDetectionTimeout = originalTimeout })
+	})
+
+	Context("with the DetectLeaks decorator", func() {
+		var success bool
+		BeforeEach(func() {
+			success, _ = RunFixture("detect leaks", func() {
+				Describe("container", DetectLeaks, func() {
+					It("leaks", rt.T("leaks", func() { go leakyWorker(stop) }))
+					It("cleans up", rt.T("cleans up", func() {
+						done := make(chan any)
+						go leakyWorker(done)
+						DeferCleanup(func() { close(done) })
+					}))
+					It("fails and leaks", rt.T("fails and leaks", func() {
+						go leakyWorker(stop)
+						F("boom")
+					}))
+					It("ignores", IgnoreLeaks("leakyWorker"), rt.T("ignores", func() { go leakyWorker(stop) }))
+				})
+				It("is not decorated", rt.T("is not decorated", func() { go leakyWorker(stop) }))
+			})
+		})
+
+		It("fails specs that leak goroutines and reports the goroutine's stack", func() {
+			Ω(success).Should(BeFalse())
+			Ω(rt).Should(HaveTracked("leaks", "cleans up", "fails and leaks", "ignores", "is not decorated"))
+			Ω(reporter.Did.Find("leaks")).Should(HaveFailed("Spec leaked 1 goroutine that outlived its AfterEach and DeferCleanup nodes", FailureNodeType(types.NodeTypeIt)))
+			Ω(reporter.Did.Find("leaks").Failure.Message).Should(ContainSubstring("internal_integration_test.leakyWorker"))
+			Ω(reporter.Did.Find("leaks").Failure.Message).Should(ContainSubstring("leak_detection_test.go"))
+		})
+
+		It("does not fail specs that clean up after themselves", func() {
+			Ω(reporter.Did.Find("cleans up")).Should(HavePassed())
+		})
+
+		It("reports leaks in specs that have already failed as additional failures", func() {
+			Ω(reporter.Did.Find("fails and leaks")).Should(HaveFailed("boom"))
+			Ω(reporter.Did.Find("fails and leaks").AdditionalFailures).Should(HaveLen(1))
+			Ω(reporter.Did.Find("fails and leaks").AdditionalFailures[0].Failure.Message).Should(ContainSubstring("Spec leaked 1 goroutine"))
+		})
+
+		It("honors IgnoreLeaks", func() {
+			Ω(reporter.Did.Find("ignores")).Should(HavePassed())
+		})
+
+		It("only checks decorated specs", func() {
+			Ω(reporter.Did.Find("is not decorated")).Should(HavePassed())
+		})
+	})
+
+	Context("with the WarnOnLeaks decorator", func() {
+		var success bool
+		BeforeEach(func() {
+			success, _ = RunFixture("warn on leaks", func() {
+				It("leaks", WarnOnLeaks, rt.T("leaks", func() { go leakyWorker(stop) }))
+			})
+		})
+
+		It("reports the leak as a report entry without failing the spec", func() {
+			Ω(success).Should(BeTrue())
+			Ω(reporter.Did.Find("leaks")).Should(HavePassed())
+			entries := reporter.Did.Find("leaks").ReportEntries
+			Ω(entries).Should(HaveLen(1))
+			Ω(entries[0].Name).Should(Equal("Leaks Detected"))
+			Ω(entries[0].StringRepresentation()).Should(ContainSubstring("internal_integration_test.leakyWorker"))
+		})
+	})
+
+	Context("when config.DetectLeaks is set", func() {
+		var success bool
+		BeforeEach(func() {
+			conf.DetectLeaks = "fail"
+			success, _ = RunFixture("detect leaks in every spec", func() {
+				It("leaks", rt.T("leaks", func() { go leakyWorker(stop) }))
+				It("overrides", WarnOnLeaks, rt.T("overrides", func() { go leakyWorker(stop) }))
+				It("passes", rt.T("passes"))
+			})
+		})
+
+		It("checks every spec, letting decorators override the configured behavior", func() {
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("leaks")).Should(HaveFailed("Spec leaked 1 goroutine"))
+			Ω(reporter.Did.Find("overrides")).Should(HavePassed())
+			Ω(reporter.Did.Find("overrides").ReportEntries).Should(HaveLen(1))
+			Ω(reporter.Did.Find("passes")).Should(HavePassed())
+		})
+	})
+
+	Context("in an Ordered container", func() {
+		var success bool
+		BeforeEach(func() {
+			success, _ = RunFixture("ordered leaks", func() {
+				Describe("shares a goroutine between specs", Ordered, DetectLeaks, func() {
+					done := make(chan any)
+					BeforeAll(func() { go leakyWorker(done) })
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+					AfterAll(func() { close(done) })
+				})
+				Describe("never stops its goroutine", Ordered, DetectLeaks, func() {
+					BeforeAll(func() { go leakyWorker(stop) })
+					It("C", rt.T("C"))
+					It("D", rt.T("D"))
+				})
+			})
+		})
+
+		It("only checks for leaks once the AfterAll nodes have run, attributing them to the last spec", func() {
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+			Ω(reporter.Did.Find("D")).Should(HaveFailed("Spec leaked 1 goroutine"))
+		})
+	})
+
+	Context("when a spec leaks a file descriptor", func() {
+		var path string
+		var success bool
+		BeforeEach(func() {
+			if runtime.GOOS != "linux" {
+				Skip("file descriptor leak detection is only supported on Linux")
+			}
+			path = filepath.Join(GinkgoT().TempDir(), "leaked.txt")
+			Ω(os.WriteFile(path, []byte("hi"), 0666)).Should(Succeed())
+			var leaked *os.File
+			DeferCleanup(func() { leaked.Close() })
+			success, _ = RunFixture("file descriptor leaks", func() {
+				It("leaks", DetectLeaks, func() {
+					leaked, _ = os.Open(path)
+				})
+				It("closes", DetectLeaks, func() {
+					f, _ := os.Open(path)
+					DeferCleanup(f.Close)
+				})
+			})
+		})
+
+		It("fails the spec and reports the leaked file descriptor", func() {
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("leaks")).Should(HaveFailed("Spec leaked 1 file descriptor"))
+			Ω(reporter.Did.Find("leaks").Failure.Message).Should(ContainSubstring(path))
+			Ω(reporter.Did.Find("closes")).Should(HavePassed())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Properties", func() {
+	Context("when properties pass and fail", func() {
+		var success bool
+		var otherCL types.CodeLocation
+		BeforeEach(func() {
+			otherCL = types.NewCustomCodeLocation("elsewhere")
+			success, _ = RunFixture("properties", func() {
+				It("passes", Property(NumRuns(20)), func(g Generators) {
+					rt.Run("passes")
+					n := g.IntBetween(-5, 5)
+					if n < -5 || n > 5 {
+						F("out of range")
+					}
+				})
+				It("fails on large ints", Property(), func(g Generators) {
+					rt.Run("fails on large ints")
+					if g.Int() > 10 {
+						F("too big")
+					}
+				})
+				It("fails on long strings", Property(), func(ctx SpecContext, g Generators) {
+					b, s := g.Bool(), g.String()
+					if len(s) >= 3 {
+						F("too long")
+					}
+					if b && len(s) == 2 {
+						F("elsewhere", otherCL)
+					}
+				})
+				It("panics", Property(NumRuns(10), MaxShrinks(0)), func(g Generators) {
+					rt.Run("panics")
+					panic("boom")
+				})
+				It("skips", Property(), func(g Generators) {
+					rt.Run("skips")
+					FixtureSkip("not today")
+				})
+			})
+		})
+
+		It("runs passing properties NumRuns times and reports the number of runs", func() {
+			Ω(success).Should(BeFalse())
+			count := 0
+			for _, run := range rt.TrackedRuns() {
+				if run == "passes" {
+					count += 1
+				}
+			}
+			Ω(count).Should(Equal(20))
+			report := reporter.Did.Find("passes")
+			Ω(report).Should(HavePassed())
+			Ω(report.ReportEntries).Should(HaveLen(1))
+			Ω(report.ReportEntries[0].Name).Should(Equal("Property"))
+			Ω(report.ReportEntries[0].Value.String()).Should(Equal("Passed 20 runs"))
+			Ω(report.ReportEntries[0].Visibility).Should(Equal(types.ReportEntryVisibilityFailureOrVerbose))
+		})
+
+		It("shrinks failing properties to a minimal counterexample and reports it along with the seed", func() {
+			report := reporter.Did.Find("fails on large ints")
+			Ω(report).Should(HaveFailed(ContainSubstring("too big")))
+			Ω(report.Failure.Message).Should(MatchRegexp(`too big\n\nProperty Failed on run \d+ of 100 \(--seed=17\)\.  Shrunk \d+ times to this minimal counterexample:\n  Int\(\) = 11$`))
+			Ω(report.ReportEntries).Should(HaveLen(1))
+			Ω(report.ReportEntries[0].Name).Should(Equal("Property"))
+			Ω(report.ReportEntries[0].Value.String()).Should(HaveSuffix("Int() = 11"))
+			Ω(report.ReportEntries[0].Visibility).Should(Equal(types.ReportEntryVisibilityAlways))
+		})
+
+		It("only shrinks towards counterexamples that fail in the same way", func() {
+			report := reporter.Did.Find("fails on long strings")
+			Ω(report).Should(HaveFailed(ContainSubstring("too long"), cl))
+			Ω(report.Failure.Message).Should(HaveSuffix("minimal counterexample:\n  Bool() = false\n  String() = \"aaa\""))
+		})
+
+		It("reports panics", func() {
+			report := reporter.Did.Find("panics")
+			Ω(report).Should(HavePanicked("boom"))
+			Ω(report.Failure.Message).Should(ContainSubstring("Property Failed on run 1 of 10 (--seed=17).  Counterexample:\n  (no values were generated)"))
+			Ω(rt).Should(HaveRun("panics"))
+		})
+
+		It("stops running a property when it is skipped", func() {
+			Ω(reporter.Did.Find("skips")).Should(HaveBeenSkippedWithMessage("not today"))
+			Ω(reporter.Did.Find("skips").ReportEntries).Should(BeEmpty())
+		})
+	})
+
+	Context("with FlakeAttempts and MustPassRepeatedly", func() {
+		var values map[string][][]int
+		BeforeEach(func() {
+			values = map[string][][]int{}
+			track := func(text string, g Generators) {
+				attempt := CurrentSpecReport().NumAttempts - 1
+				if len(values[text]) <= attempt {
+					values[text] = append(values[text], []int{})
+				}
+				values[text][attempt] = append(values[text][attempt], g.Int())
+			}
+			RunFixture("property attempts", func() {
+				It("flakes", FlakeAttempts(2), Property(NumRuns(5), MaxShrinks(0)), func(g Generators) {
+					track("flakes", g)
+					if CurrentSpecReport().NumAttempts == 1 && len(values["flakes"][0]) == 3 {
+						F("flake")
+					}
+				})
+				It("repeats", MustPassRepeatedly(2), Property(NumRuns(5)), func(g Generators) {
+					track("repeats", g)
+				})
+			})
+		})
+
+		It("retries the same inputs with FlakeAttempts", func() {
+			Ω(reporter.Did.Find("flakes")).Should(HavePassed(NumAttempts(2)))
+			Ω(values["flakes"]).Should(HaveLen(2))
+			Ω(values["flakes"][0]).Should(HaveLen(3))
+			Ω(values["flakes"][1]).Should(HaveLen(5))
+			Ω(values["flakes"][1][:3]).Should(Equal(values["flakes"][0]))
+		})
+
+		It("explores new inputs with MustPassRepeatedly", func() {
+			Ω(reporter.Did.Find("repeats")).Should(HavePassed(NumAttempts(2)))
+			Ω(values["repeats"]).Should(HaveLen(2))
+			Ω(values["repeats"][0]).Should(HaveLen(5))
+			Ω(values["repeats"][1]).ShouldNot(Equal(values["repeats"][0]))
+		})
+	})
+})
@@ -0,0 +1,150 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SharedFixture", func() {
+	var setups int
+
+	BeforeEach(func() {
+		setups = 0
+	})
+
+	Context("when specs use a fixture", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("shared fixtures", func() {
+				BeforeSuite(func() {
+					rt.Run("before-suite")
+					DeferCleanup(rt.T("cleanup"))
+				})
+				db := SharedFixture("db", func() string {
+					setups += 1
+					rt.Run("setup")
+					return "postgres://"
+				}, func(url string) {
+					rt.RunWithData("teardown", "url", url)
+				})
+				SharedFixture("unused", func() string {
+					rt.Run("setup-unused")
+					return ""
+				}, func(string) {
+					rt.Run("teardown-unused")
+				})
+				Describe("container", func() {
+					It("A", func() {
+						rt.RunWithData("A", "url", db.Get())
+					})
+					It("B", func() {
+						rt.RunWithData("B", "url", db.Get())
+					})
+					It("C", rt.T("C"))
+				})
+				AfterSuite(rt.T("after-suite"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("sets the fixture up the first time a spec asks for it and tears it down after the AfterSuite", func() {
+			Ω(rt).Should(HaveTracked("before-suite", "setup", "A", "B", "C", "after-suite", "teardown", "cleanup"))
+			Ω(setups).Should(Equal(1))
+			Ω(rt).Should(HaveRunWithData("A", "url", "postgres://"))
+			Ω(rt).Should(HaveRunWithData("B", "url", "postgres://"))
+			Ω(rt).Should(HaveRunWithData("teardown", "url", "postgres://"))
+		})
+
+		It("reports the teardown", func() {
+			teardown := reporter.Did.FindByLeafNodeType(types.NodeTypeCleanupAfterSuite)
+			Ω(teardown.LeafNodeText).Should(Equal(`SharedFixture "db"`))
+			Ω(teardown).Should(HavePassed())
+		})
+	})
+
+	Context("when specs declare that they use a fixture", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("declared shared fixtures", func() {
+				BeforeSuite(func() {
+					rt.Run("before-suite")
+					DeferCleanup(rt.T("cleanup"))
+				})
+				db := SharedFixture("db", func() string {
+					setups += 1
+					rt.Run("setup")
+					return "postgres://"
+				}, func(url string) {
+					rt.RunWithData("teardown", "url", url)
+				})
+				Describe("container", func() {
+					Describe("users", UsesFixture(db), func() {
+						It("A", func() {
+							rt.RunWithData("A", "url", db.Get())
+						})
+						It("B", rt.T("B"))
+					})
+					It("C", UsesFixture(db), func() {
+						rt.RunWithData("C", "url", db.Get())
+					})
+					It("D", rt.T("D"))
+					It("E", func() {
+						db.Get()
+						rt.Run("E")
+					})
+				})
+				AfterSuite(rt.T("after-suite"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("tears the fixture down as soon as the last spec that declared it has finished", func() {
+			Ω(rt).Should(HaveTracked("before-suite", "setup", "A", "B", "C", "teardown", "D", "after-suite", "cleanup"))
+			Ω(setups).Should(Equal(1))
+			Ω(rt).Should(HaveRunWithData("C", "url", "postgres://"))
+			Ω(rt).Should(HaveRunWithData("teardown", "url", "postgres://"))
+
+			teardown := reporter.Did.FindByLeafNodeType(types.NodeTypeCleanupAfterSuite)
+			Ω(teardown.LeafNodeText).Should(Equal(`SharedFixture "db"`))
+			Ω(teardown).Should(HavePassed())
+			Ω(reporter.Did.Names()).Should(Equal([]string{"A", "B", "C", `SharedFixture "db"`, "D", "E"}))
+		})
+
+		It("fails specs that ask for the fixture without declaring it", func() {
+			Ω(reporter.Did.Find("E")).Should(HaveFailed(ContainSubstring(`SharedFixture "db" was not declared`)))
+		})
+	})
+
+	Context("when the fixture fails to set up", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing shared fixtures", func() {
+				db := SharedFixture("db", func() string {
+					setups += 1
+					F("boom")
+					return ""
+				}, func(string) {
+					rt.Run("teardown")
+				})
+				Describe("container", func() {
+					It("A", func() {
+						db.Get()
+						rt.Run("A")
+					})
+					It("B", func() {
+						db.Get()
+						rt.Run("B")
+					})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the spec that asked for it and every spec that asks for it afterwards, without setting it up again", func() {
+			Ω(setups).Should(Equal(1))
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("boom"))
+			Ω(reporter.Did.Find("B")).Should(HaveFailed(ContainSubstring(`SharedFixture "db" failed to set up`)))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeCleanupAfterSuite)).Should(BeZero())
+		})
+	})
+})
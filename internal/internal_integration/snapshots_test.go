@@ -0,0 +1,185 @@
+package internal_integration_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshots", func() {
+	var specFile, snapshotPath string
+	var value string
+	var success bool
+
+	// specs are given a custom location so that their snapshots are stored in a temporary directory
+	at := func(line int) types.CodeLocation {
+		return types.CodeLocation{FileName: specFile, LineNumber: line}
+	}
+	loadSnapshots := func() types.Snapshots {
+		GinkgoHelper()
+		snapshots, err := types.LoadSnapshots(snapshotPath)
+		Ω(err).ShouldNot(HaveOccurred())
+		return snapshots
+	}
+
+	BeforeEach(func() {
+		specFile = filepath.Join(GinkgoT().TempDir(), "catalog_test.go")
+		snapshotPath = filepath.Join(filepath.Dir(specFile), "__snapshots__", "catalog_test.ginkgo.snap")
+		value = "line 1\nline 2\nline 3"
+	})
+
+	Describe("matching snapshots", func() {
+		JustBeforeEach(func() {
+			success, _ = RunFixture("snapshots", func() {
+				Describe("catalog", func() {
+					BeforeEach(func() {
+						MatchSnapshot("setup", "set up")
+					})
+					It("renders", at(1), rt.T("renders", func() {
+						MatchSnapshot("text", value)
+						MatchSnapshot("json", map[string]int{"books": 3})
+					}))
+				})
+			})
+		})
+
+		Context("when the snapshots don't exist yet", func() {
+			It("writes them and passes", func() {
+				Ω(success).Should(BeTrue())
+				Ω(reporter.Did.Find("renders")).Should(HavePassed())
+				Ω(loadSnapshots()).Should(Equal(types.Snapshots{
+					"catalog renders - setup": "set up",
+					"catalog renders - text":  "line 1\nline 2\nline 3",
+					"catalog renders - json":  "{\n  \"books\": 3\n}",
+				}))
+			})
+
+			It("records that it wrote them", func() {
+				entries := reporter.Did.Find("renders").ReportEntries
+				Ω(entries).Should(HaveLen(3))
+				Ω(entries[1].Name).Should(Equal("Snapshot"))
+				Ω(entries[1].Value.String()).Should(Equal(`Wrote new snapshot "text" in ` + snapshotPath))
+				Ω(entries[1].Visibility).Should(Equal(types.ReportEntryVisibilityFailureOrVerbose))
+			})
+		})
+
+		Context("when the snapshots exist", func() {
+			BeforeEach(func() {
+				Ω(types.Snapshots{
+					"catalog renders - setup": "set up",
+					"catalog renders - text":  "line 1\nline 2\nline 3",
+					"catalog renders - json":  "{\n  \"books\": 3\n}",
+				}.Save(snapshotPath)).Should(Succeed())
+			})
+
+			Context("and they match", func() {
+				It("passes without touching them", func() {
+					Ω(success).Should(BeTrue())
+					Ω(reporter.Did.Find("renders").ReportEntries).Should(BeEmpty())
+					Ω(reporter.End.ObsoleteSnapshots).Should(BeEmpty())
+				})
+			})
+
+			Context("and they don't match", func() {
+				BeforeEach(func() {
+					value = "line 1\nline two\nline 3"
+				})
+
+				It("fails with a diff", func() {
+					Ω(success).Should(BeFalse())
+					Ω(reporter.Did.Find("renders")).Should(HaveFailed("Snapshot \"text\" does not match (- snapshot, + received):\n    line 1\n  - line 2\n  + line two\n    line 3\nRun with --update-snapshots to update the snapshot stored in " + snapshotPath))
+					Ω(loadSnapshots()).Should(HaveKeyWithValue("catalog renders - text", "line 1\nline 2\nline 3"))
+				})
+
+				Context("with --update-snapshots", func() {
+					BeforeEach(func() {
+						conf.UpdateSnapshots = true
+					})
+
+					It("updates them and passes", func() {
+						Ω(success).Should(BeTrue())
+						Ω(loadSnapshots()).Should(HaveKeyWithValue("catalog renders - text", "line 1\nline two\nline 3"))
+						Ω(reporter.Did.Find("renders").ReportEntries[0].Value.String()).Should(Equal(`Updated snapshot "text" in ` + snapshotPath))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("obsolete snapshots", func() {
+		BeforeEach(func() {
+			Ω(types.Snapshots{
+				"A passes - used":       "used",
+				"A passes - unused":     "unused",
+				"A fails - unused":      "unused",
+				"A is skipped - unused": "unused",
+				"A was renamed - text":  "gone",
+			}.Save(snapshotPath)).Should(Succeed())
+			Ω(types.Snapshots{"B deleted - text": "gone"}.Save(filepath.Join(filepath.Dir(snapshotPath), "deleted_test.ginkgo.snap"))).Should(Succeed())
+			Ω(os.WriteFile(filepath.Join(filepath.Dir(snapshotPath), "unrelated.snap"), []byte("[B - 1]\nnot ours\n---\n\n"), 0644)).Should(Succeed())
+		})
+
+		runFixture := func() {
+			success, _ = RunFixture("obsolete snapshots", func() {
+				Describe("A", func() {
+					It("passes", at(1), func() { MatchSnapshot("used", "used") })
+					It("fails", at(2), func() { F("boom") })
+					It("is skipped", at(3), func() { FixtureSkip() })
+				})
+			})
+		}
+
+		It("reports the snapshots that belong to specs that are gone or that passed without using them", func() {
+			runFixture()
+			Ω(reporter.End.ObsoleteSnapshots).Should(Equal([]types.ObsoleteSnapshot{
+				{Path: filepath.Join(filepath.Dir(snapshotPath), "catalog_test.ginkgo.snap"), Key: "A passes - unused"},
+				{Path: filepath.Join(filepath.Dir(snapshotPath), "catalog_test.ginkgo.snap"), Key: "A was renamed - text"},
+				{Path: filepath.Join(filepath.Dir(snapshotPath), "deleted_test.ginkgo.snap"), Key: "B deleted - text"},
+			}))
+			Ω(loadSnapshots()).Should(HaveLen(5))
+		})
+
+		It("removes them with --update-snapshots", func() {
+			conf.UpdateSnapshots = true
+			runFixture()
+			Ω(reporter.End.ObsoleteSnapshots).Should(HaveLen(3))
+			Ω(loadSnapshots()).Should(Equal(types.Snapshots{
+				"A passes - used":       "used",
+				"A fails - unused":      "unused",
+				"A is skipped - unused": "unused",
+			}))
+			Ω(filepath.Join(filepath.Dir(snapshotPath), "deleted_test.ginkgo.snap")).ShouldNot(BeAnExistingFile())
+			Ω(filepath.Join(filepath.Dir(snapshotPath), "unrelated.snap")).Should(BeAnExistingFile())
+		})
+
+		It("does nothing during a dry run", func() {
+			conf.DryRun = true
+			runFixture()
+			Ω(reporter.End.ObsoleteSnapshots).Should(BeEmpty())
+		})
+	})
+
+	Describe("misuse", func() {
+		It("fails when MatchSnapshot is called outside of a spec", func() {
+			success, _ = RunFixture("misuse", func() {
+				BeforeSuite(func() { MatchSnapshot("suite", "value") })
+				It("A", at(1), func() {})
+			})
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveFailed(ContainSubstring("outside of a running spec")))
+		})
+
+		It("rejects empty names", func() {
+			success, _ = RunFixture("misuse", func() {
+				It("has an empty name", at(1), func() { MatchSnapshot("", "value") })
+			})
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("has an empty name")).Should(HaveFailed(ContainSubstring("non-empty name")))
+			Ω(snapshotPath).ShouldNot(BeAnExistingFile())
+		})
+	})
+})
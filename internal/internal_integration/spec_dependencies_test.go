@@ -0,0 +1,158 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spec dependencies", func() {
+	Context("when the prerequisites pass", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("passing prerequisites", func() {
+				Describe("container", func() {
+					It("A", DependsOn("migrations"), rt.T("A"))
+					It("B", DependsOn("C"), rt.T("B"))
+					Describe("migrations", SpecID("migrations"), func() {
+						It("C", SpecID("C"), rt.T("C"))
+						It("D", rt.T("D"))
+					})
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the dependent specs after their prerequisites", func() {
+			Ω(rt).Should(HaveTracked("C", "B", "D", "A"))
+			Ω(reporter.Did.WithState(types.SpecStatePassed)).Should(HaveLen(4))
+		})
+	})
+
+	Context("when a prerequisite fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing prerequisites", func() {
+				Describe("container", func() {
+					It("A", SpecID("A"), rt.T("A", func() { F("boom") }))
+					It("B", SpecID("B"), DependsOn("A"), rt.T("B"))
+					It("C", DependsOn("B"), rt.T("C"))
+					It("D", rt.T("D"))
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("skips the dependent specs - and their dependents - with a clear reason", func() {
+			Ω(rt).Should(HaveTracked("A", "D"))
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("boom"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkippedWithMessage(`Spec skipped because its prerequisite "A" failed`))
+			Ω(reporter.Did.Find("C")).Should(HaveBeenSkippedWithMessage(`Spec skipped because its prerequisite "B" was skipped`))
+			Ω(reporter.Did.Find("D")).Should(HavePassed())
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(4), NPassed(1), NFailed(1), NSkipped(2)))
+		})
+	})
+
+	Context("when the filters select a dependent spec but not its prerequisites", func() {
+		BeforeEach(func() {
+			conf.FocusStrings = []string{"B"}
+			success, _ := RunFixture("filtered prerequisites", func() {
+				It("A", SpecID("A"), rt.T("A"))
+				It("B", DependsOn("A"), rt.T("B"))
+				It("C", rt.T("C"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the prerequisites too", func() {
+			Ω(rt).Should(HaveTracked("A", "B"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+			Ω(reporter.Did.Find("C")).Should(HaveBeenSkipped())
+		})
+	})
+
+	Context("when a prerequisite is pending", func() {
+		BeforeEach(func() {
+			conf.FocusStrings = []string{"B"}
+			success, _ := RunFixture("pending prerequisites", func() {
+				It("A", SpecID("A"), Pending, rt.T("A"))
+				It("B", DependsOn("A"), rt.T("B"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("skips the dependent specs", func() {
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkippedWithMessage(`Spec skipped because its prerequisite "A" was skipped`))
+		})
+	})
+
+	Context("when a spec in an Ordered container depends on a spec that fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("ordered dependents", func() {
+				Describe("container", func() {
+					It("A", SpecID("A"), rt.T("A", func() { F("boom") }))
+					Describe("ordered", Ordered, ContinueOnFailure, func() {
+						BeforeAll(rt.T("before-all"))
+						It("B", SpecID("B"), rt.T("B", func() { F("bam") }))
+						It("C", rt.T("C"))
+						It("D", DependsOn("A"), rt.T("D"))
+						It("E", DependsOn("B"), rt.T("E"))
+						AfterAll(rt.T("after-all"))
+					})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("skips the dependent specs but still runs the AfterAll nodes", func() {
+			Ω(rt).Should(HaveTracked("A", "before-all", "B", "C", "after-all"))
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+			Ω(reporter.Did.Find("D")).Should(HaveBeenSkippedWithMessage(`Spec skipped because its prerequisite "A" failed`))
+			Ω(reporter.Did.Find("E")).Should(HaveBeenSkippedWithMessage(`Spec skipped because its prerequisite "B" failed`))
+		})
+	})
+
+	Context("when running in parallel", func() {
+		BeforeEach(func() {
+			SetUpForParallel(2)
+		})
+
+		It("waits for prerequisites running on other processes", func() {
+			success := RunFixtureInParallel("parallel prerequisites", func(_ int) {
+				It("A", SpecID("A"), func() {
+					time.Sleep(100 * time.Millisecond)
+					rt.Run("A")
+				})
+				It("B", DependsOn("A"), rt.T("B"))
+			})
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A", "B"))
+			Ω(reporter.Did.Find("A").ParallelProcess).ShouldNot(Equal(reporter.Did.Find("B").ParallelProcess))
+		})
+
+		It("skips dependents when a prerequisite running on another process fails", func() {
+			success := RunFixtureInParallel("failing parallel prerequisites", func(_ int) {
+				It("A", SpecID("A"), func() {
+					time.Sleep(100 * time.Millisecond)
+					F("boom")
+				})
+				It("B", DependsOn("A"), rt.T("B"))
+			})
+			Ω(success).Should(BeFalse())
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkippedWithMessage(`Spec skipped because its prerequisite "A" failed`))
+		})
+
+		It("lets Serial specs depend on specs that aren't Serial", func() {
+			success := RunFixtureInParallel("serial dependents", func(_ int) {
+				It("A", SpecID("A"), rt.T("A"))
+				It("B", Serial, DependsOn("A"), rt.T("B"))
+			})
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A", "B"))
+		})
+	})
+})
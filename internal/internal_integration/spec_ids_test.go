@@ -0,0 +1,79 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+)
+
+var _ = Describe("Spec IDs", func() {
+	var containerReports map[string]types.ConstructionNodeReport
+
+	fixture := func() {
+		Describe("container", func() {
+			containerReports["container"] = CurrentTreeConstructionNodeReport()
+			It("A", rt.T("A"))
+			DescribeTable("table", func(name string) { rt.Run(name) },
+				Entry("same", "B"),
+				Entry("same", "C"),
+			)
+			Describe("pinned", SpecID("pinned"), func() {
+				containerReports["pinned"] = CurrentTreeConstructionNodeReport()
+				It("D", SpecID("D"), rt.T("D"))
+			})
+		})
+	}
+
+	BeforeEach(func() {
+		containerReports = map[string]types.ConstructionNodeReport{}
+	})
+
+	It("gives every spec a distinct ID that is the same from one run to the next", func() {
+		Ω(RunFixture("spec ids", fixture)).Should(BeTrue())
+		ids := map[string]string{}
+		for _, report := range reporter.Did {
+			Ω(report.ID).ShouldNot(BeEmpty())
+			ids[report.LeafNodeText+"/"+report.ID] = report.ID
+		}
+		Ω(ids).Should(HaveLen(4))
+		Ω(reporter.Did.Find("D").ID).Should(Equal("D"))
+
+		firstRun := reporter.Did
+		reporter = NewFakeReporter()
+		Ω(RunFixture("spec ids", fixture)).Should(BeTrue())
+		for i := range firstRun {
+			Ω(reporter.Did[i].ID).Should(Equal(firstRun[i].ID))
+		}
+	})
+
+	It("gives containers an ID too", func() {
+		Ω(RunFixture("spec ids", fixture)).Should(BeTrue())
+		Ω(containerReports["container"].ID).ShouldNot(BeEmpty())
+		Ω(containerReports["pinned"].ID).Should(Equal("pinned"))
+	})
+
+	It("does not give suite-level nodes an ID", func() {
+		Ω(RunFixture("spec ids", func() {
+			BeforeSuite(rt.T("before-suite"))
+			It("A", rt.T("A"))
+		})).Should(BeTrue())
+		Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite).ID).Should(BeEmpty())
+		Ω(reporter.Did.Find("A").ID).ShouldNot(BeEmpty())
+	})
+
+	Context("with config.FocusIDs", func() {
+		It("only runs the specs with those IDs", func() {
+			Ω(RunFixture("spec ids", fixture)).Should(BeTrue())
+			entryID := reporter.Did.Find("same").ID
+
+			rt.Reset()
+			reporter = NewFakeReporter()
+			conf.FocusIDs = []string{entryID, "D"}
+			Ω(RunFixture("spec ids", fixture)).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("B", "D"))
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(4), NPassed(2), NSkipped(2)))
+		})
+	})
+})
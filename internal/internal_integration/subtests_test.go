@@ -0,0 +1,82 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Subtests run with GinkgoTRunner.Run", func() {
+	var failureLine int
+	BeforeEach(func() {
+		success, _ := RunFixture("subtests", func() {
+			Describe("container", func() {
+				It("A", func() {
+					rt.Run("A")
+					GinkgoT().(GinkgoTRunner).Run("passes", func(t GinkgoTRunner) {
+						rt.Run("A/passes")
+						t.Log("hello")
+					})
+					GinkgoT().(GinkgoTRunner).Run("fails", func(t GinkgoTRunner) {
+						rt.Run("A/fails")
+						failureLine = types.NewCodeLocation(0).LineNumber + 1
+						t.Fatal("boom")
+						rt.Run("unreachable")
+					})
+					GinkgoT().(GinkgoTRunner).Run("nested", func(t GinkgoTRunner) {
+						t.Run("inner", func(t GinkgoTRunner) { rt.Run("A/nested/inner") })
+					})
+					rt.Run("A - after subtests")
+				})
+				It("B", func() {
+					GinkgoT().(GinkgoTRunner).Run("skips", func(t GinkgoTRunner) { t.Skip("not now") })
+					rt.Run("B")
+				})
+				It("C", FlakeAttempts(2), func() {
+					attempt := CurrentSpecReport().NumAttempts
+					GinkgoT().(GinkgoTRunner).Run("flakes", func(t GinkgoTRunner) {
+						if attempt == 1 {
+							t.Fail()
+						}
+					})
+				})
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("runs every subtest, continuing after failing subtests", func() {
+		Ω(rt).Should(HaveTracked("A", "A/passes", "A/fails", "A/nested/inner", "A - after subtests", "B"))
+	})
+
+	It("fails the spec when a subtest fails", func() {
+		Ω(reporter.Did.Find("A")).Should(HaveFailed("Subtest \"fails\" failed:\nboom\n"))
+		Ω(reporter.Did.Find("A").Failure.Location.LineNumber).Should(Equal(failureLine))
+		Ω(reporter.Did.Find("B")).Should(HavePassed())
+	})
+
+	It("records the subtests in the spec report", func() {
+		subtests := reporter.Did.Find("A").Subtests
+		Ω(subtests).Should(HaveLen(3))
+		Ω(subtests[0].Name).Should(Equal("passes"))
+		Ω(subtests[0].State).Should(Equal(types.SpecStatePassed))
+		Ω(subtests[0].CapturedGinkgoWriterOutput).Should(Equal("hello\n"))
+		Ω(subtests[1].Name).Should(Equal("fails"))
+		Ω(subtests[1].State).Should(Equal(types.SpecStateFailed))
+		Ω(subtests[1].Failure.Message).Should(Equal("boom\n"))
+		Ω(subtests[2].Name).Should(Equal("nested"))
+		Ω(subtests[2].Subtests).Should(HaveLen(1))
+		Ω(subtests[2].Subtests[0].Name).Should(Equal("inner"))
+
+		Ω(reporter.Did.Find("B").Subtests).Should(HaveLen(1))
+		Ω(reporter.Did.Find("B").Subtests[0].State).Should(Equal(types.SpecStateSkipped))
+	})
+
+	It("only reports the subtests of the final attempt", func() {
+		Ω(reporter.Did.Find("C")).Should(HavePassed())
+		Ω(reporter.Did.Find("C").NumAttempts).Should(Equal(2))
+		Ω(reporter.Did.Find("C").Subtests).Should(HaveLen(1))
+		Ω(reporter.Did.Find("C").Subtests[0].State).Should(Equal(types.SpecStatePassed))
+	})
+})
@@ -0,0 +1,81 @@
+package internal_integration_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recording spans for --trace-export", func() {
+	var spanContexts map[string]types.SpanContext
+	var fixture func()
+
+	BeforeEach(func() {
+		spanContexts = map[string]types.SpanContext{}
+		fixture = func() {
+			Describe("a container", func() {
+				BeforeEach(func(ctx SpecContext) {
+					spanContexts["bef"] = SpanContextFor(ctx)
+				})
+				It("A", func(ctx SpecContext) {
+					spanContexts["A"] = SpanContextFor(ctx)
+					derived, cancel := context.WithCancel(ctx)
+					defer cancel()
+					spanContexts["A-derived"] = SpanContextFor(derived)
+					By("a step")
+				})
+			})
+		}
+	})
+
+	Context("when the suite has a span", func() {
+		BeforeEach(func() {
+			conf.TraceSuiteSpan = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+			success, _ := RunFixture("tracing", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("gives each spec and each node and By step a span in the suite's trace", func() {
+			Ω(spanContexts["bef"].TraceID).Should(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Ω(spanContexts["A"].TraceID).Should(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Ω(spanContexts["bef"].IsValid()).Should(BeTrue())
+			Ω(spanContexts["A"].IsValid()).Should(BeTrue())
+			Ω(spanContexts["bef"].SpanID).ShouldNot(Equal(spanContexts["A"].SpanID))
+
+			spec := reporter.Did.Find("A")
+			Ω(spec.SpanID).Should(HaveLen(16))
+			spanIDs := map[string]bool{spec.SpanID: true}
+			for _, event := range spec.SpecEvents {
+				Ω(event.SpanID).Should(HaveLen(16))
+				spanIDs[event.SpanID] = true
+			}
+			Ω(spanIDs).Should(HaveKey(spanContexts["bef"].SpanID))
+			Ω(spanIDs).Should(HaveKey(spanContexts["A"].SpanID))
+			Ω(spanIDs).Should(HaveLen(4), "the spec, the BeforeEach, the It, and the By step each get their own span")
+		})
+
+		It("finds the span from contexts derived from the SpecContext - but not from other contexts", func() {
+			Ω(spanContexts["A-derived"]).Should(Equal(spanContexts["A"]))
+			Ω(SpanContextFor(context.Background()).IsValid()).Should(BeFalse())
+		})
+	})
+
+	Context("when the suite does not have a span", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("not tracing", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("does not record spans", func() {
+			Ω(spanContexts["bef"].IsValid()).Should(BeFalse())
+			Ω(spanContexts["A"].IsValid()).Should(BeFalse())
+			spec := reporter.Did.Find("A")
+			Ω(spec.SpanID).Should(BeEmpty())
+			for _, event := range spec.SpecEvents {
+				Ω(event.SpanID).Should(BeEmpty())
+			}
+		})
+	})
+})
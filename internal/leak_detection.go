@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type LeakDetection uint
+
+const (
+	LeakDetectionNone LeakDetection = iota
+	LeakDetectionFail
+	LeakDetectionWarn
+)
+
+const DetectLeaks = LeakDetectionFail
+const WarnOnLeaks = LeakDetectionWarn
+
+func LeakDetectionFromConfig(value string) LeakDetection {
+	switch strings.ToLower(value) {
+	case "fail":
+		return LeakDetectionFail
+	case "warn":
+		return LeakDetectionWarn
+	default:
+		return LeakDetectionNone
+	}
+}
+
+// IgnoredLeaks are substrings matched against the functions in a leaked goroutine's stack and against the target of a leaked file descriptor
+type IgnoredLeaks []string
+
+// LeakDetectionTimeout is how long Ginkgo waits for goroutines and file descriptors to be cleaned up after a spec ends before reporting them as leaked
+var LeakDetectionTimeout = time.Second
+
+// goroutines created by these functions are managed by Ginkgo or by the Go runtime and are never considered leaks
+var leakDetectionIgnoredCreators = []string{
+	"github.com/onsi/ginkgo/v2/internal.",
+	"os/signal.",
+	"runtime.",
+	"testing.",
+}
+
+// file descriptors used by the Go runtime's network poller may be opened lazily by any spec and are never considered leaks
+var leakDetectionIgnoredFileDescriptors = []string{
+	"anon_inode:[eventpoll]",
+	"anon_inode:[eventfd]",
+}
+
+type leakSnapshot struct {
+	goroutines      map[uint64]bool
+	fileDescriptors map[int]string
+}
+
+type leakedFileDescriptor struct {
+	FD     int
+	Target string
+}
+
+type leaks struct {
+	Goroutines      []types.Goroutine
+	FileDescriptors []leakedFileDescriptor
+}
+
+func takeLeakSnapshot() leakSnapshot {
+	snapshot := leakSnapshot{goroutines: map[uint64]bool{}, fileDescriptors: openFileDescriptors()}
+	goroutines, _ := extractRunningGoroutines()
+	for _, goroutine := range goroutines {
+		snapshot.goroutines[goroutine.ID] = true
+	}
+	return snapshot
+}
+
+/*
+findLeaks compares the running goroutines and open file descriptors to the baseline snapshot.  Goroutines and file descriptors often take a moment to wind down after a spec's cleanup code asks them to so findLeaks polls until they're gone or LeakDetectionTimeout elapses.
+*/
+func findLeaks(baseline leakSnapshot, ignored IgnoredLeaks) leaks {
+	deadline := time.Now().Add(LeakDetectionTimeout)
+	interval := time.Millisecond
+	for {
+		found := leaksSince(baseline, ignored)
+		if found.IsZero() || time.Now().After(deadline) {
+			return found
+		}
+		time.Sleep(interval)
+		interval = min(interval*2, 100*time.Millisecond)
+	}
+}
+
+func leaksSince(baseline leakSnapshot, ignored IgnoredLeaks) leaks {
+	out := leaks{}
+	goroutines, _ := extractRunningGoroutines()
+	for _, goroutine := range goroutines {
+		if baseline.goroutines[goroutine.ID] || len(goroutine.Stack) == 0 {
+			continue
+		}
+		creator := goroutine.Stack[len(goroutine.Stack)-1].Function
+		if slices.ContainsFunc(leakDetectionIgnoredCreators, func(prefix string) bool { return strings.HasPrefix(creator, prefix) }) {
+			continue
+		}
+		if slices.ContainsFunc(goroutine.Stack, func(fc types.FunctionCall) bool { return ignored.matches(fc.Function) }) {
+			continue
+		}
+		out.Goroutines = append(out.Goroutines, goroutine)
+	}
+
+	// file descriptor numbers are recycled and Ginkgo's output interceptor replaces its pipes between specs so
+	// we compare the number of open file descriptors of each kind and only report the excess as leaks
+	excess := map[string]int{}
+	for _, target := range baseline.fileDescriptors {
+		excess[fileDescriptorKind(target)] -= 1
+	}
+	current := openFileDescriptors()
+	for _, target := range current {
+		excess[fileDescriptorKind(target)] += 1
+	}
+	fds := []int{}
+	for fd := range current {
+		fds = append(fds, fd)
+	}
+	slices.Sort(fds)
+	for i := len(fds) - 1; i >= 0; i-- {
+		fd, target := fds[i], current[fds[i]]
+		kind := fileDescriptorKind(target)
+		if excess[kind] <= 0 || baseline.fileDescriptors[fd] == target {
+			continue
+		}
+		excess[kind] -= 1
+		if slices.Contains(leakDetectionIgnoredFileDescriptors, target) || ignored.matches(target) {
+			continue
+		}
+		out.FileDescriptors = append(out.FileDescriptors, leakedFileDescriptor{FD: fd, Target: target})
+	}
+	slices.Reverse(out.FileDescriptors)
+	return out
+}
+
+// fileDescriptorKind maps targets like pipe:[1234] and socket:[1234] to pipe and socket.  Files are identified by their path.
+func fileDescriptorKind(target string) string {
+	if strings.HasPrefix(target, "anon_inode:") {
+		return target
+	}
+	if idx := strings.Index(target, ":["); idx > 0 && strings.HasSuffix(target, "]") {
+		return target[:idx]
+	}
+	return target
+}
+
+func (i IgnoredLeaks) matches(s string) bool {
+	for _, substring := range i {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l leaks) IsZero() bool {
+	return len(l.Goroutines) == 0 && len(l.FileDescriptors) == 0
+}
+
+func (l leaks) String() string {
+	counts := []string{}
+	if len(l.Goroutines) > 0 {
+		counts = append(counts, fmt.Sprintf("%d %s", len(l.Goroutines), pluralize("goroutine", len(l.Goroutines))))
+	}
+	if len(l.FileDescriptors) > 0 {
+		counts = append(counts, fmt.Sprintf("%d %s", len(l.FileDescriptors), pluralize("file descriptor", len(l.FileDescriptors))))
+	}
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "Spec leaked %s that outlived its AfterEach and DeferCleanup nodes", strings.Join(counts, " and "))
+	for _, goroutine := range l.Goroutines {
+		fmt.Fprintf(out, "\n\ngoroutine %d [%s]:", goroutine.ID, goroutine.State)
+		for idx, fc := range goroutine.Stack {
+			if idx == len(goroutine.Stack)-1 {
+				fmt.Fprintf(out, "\n  created by %s\n    %s:%d", fc.Function, fc.Filename, fc.Line)
+			} else {
+				fmt.Fprintf(out, "\n  %s\n    %s:%d", fc.Function, fc.Filename, fc.Line)
+			}
+		}
+	}
+	if len(l.FileDescriptors) > 0 {
+		out.WriteString("\n")
+		for _, fd := range l.FileDescriptors {
+			fmt.Fprintf(out, "\nfile descriptor %d: %s", fd.FD, fd.Target)
+		}
+	}
+	return out.String()
+}
+
+func pluralize(word string, count int) string {
+	if count == 1 {
+		return word
+	}
+	return word + "s"
+}
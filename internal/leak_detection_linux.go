@@ -0,0 +1,30 @@
+//go:build linux
+// +build linux
+
+package internal
+
+import (
+	"os"
+	"strconv"
+)
+
+func openFileDescriptors() map[int]string {
+	out := map[int]string{}
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return out
+	}
+	for _, entry := range entries {
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// the descriptor ReadDir used to list /proc/self/fd is closed by now, so Readlink fails for it and it is skipped
+		target, err := os.Readlink("/proc/self/fd/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		out[fd] = target
+	}
+	return out
+}
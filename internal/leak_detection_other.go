@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package internal
+
+// file descriptor leak detection relies on /proc/self/fd and is only supported on Linux
+func openFileDescriptors() map[int]string {
+	return map[int]string{}
+}
@@ -66,6 +66,13 @@ type Node struct {
 	AroundNodes                  types.AroundNodes
 	HasExplicitlySetSpecPriority bool
 	SpecPriority                 int
+	LeakDetection                LeakDetection
+	IgnoredLeaks                 IgnoredLeaks
+	SpecID                       string
+	Prerequisites                Prerequisites
+	SharedFixtures               UsesSharedFixtures
+	MarkedProperty               bool
+	Property                     PropertyConfig
 
 	NodeIDWhereCleanupWasGenerated uint
 }
@@ -218,6 +225,18 @@ func isDecoration(arg any) bool {
 		return true
 	case t == reflect.TypeOf(SpecPriority(0)):
 		return true
+	case t == reflect.TypeOf(LeakDetection(0)):
+		return true
+	case t == reflect.TypeOf(IgnoredLeaks{}):
+		return true
+	case t == reflect.TypeOf(SpecID("")):
+		return true
+	case t == reflect.TypeOf(Prerequisites{}):
+		return true
+	case t == reflect.TypeOf(UsesSharedFixtures{}):
+		return true
+	case t == reflect.TypeOf(PropertyConfig{}):
+		return true
 	case t.Kind() == reflect.Slice && isSliceOfDecorations(arg):
 		return true
 	default:
@@ -282,6 +301,7 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 	labelsSeen := map[string]bool{}
 	semVerConstraintsSeen := map[string]bool{}
 	trackedFunctionError := false
+	var propertyBody func(SpecContext, Generators)
 	args = remainingArgs
 	remainingArgs = []any{}
 	// now process the rest of the args
@@ -365,6 +385,48 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 			}
 			node.SpecPriority = int(arg.(SpecPriority))
 			node.HasExplicitlySetSpecPriority = true
+		case t == reflect.TypeOf(LeakDetection(0)):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "DetectLeaks"))
+			}
+			node.LeakDetection = arg.(LeakDetection)
+		case t == reflect.TypeOf(IgnoredLeaks{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "IgnoreLeaks"))
+			}
+			node.IgnoredLeaks = append(node.IgnoredLeaks, arg.(IgnoredLeaks)...)
+		case t == reflect.TypeOf(SpecID("")):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "SpecID"))
+			}
+			if arg.(SpecID) == "" {
+				appendError(types.GinkgoErrors.InvalidEmptySpecID(node.CodeLocation))
+			}
+			node.SpecID = string(arg.(SpecID))
+		case t == reflect.TypeOf(Prerequisites{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "DependsOn"))
+			}
+			for _, id := range arg.(Prerequisites) {
+				if id == "" {
+					appendError(types.GinkgoErrors.InvalidEmptySpecID(node.CodeLocation))
+				}
+			}
+			node.Prerequisites = unionOf(node.Prerequisites, arg.(Prerequisites))
+		case t == reflect.TypeOf(UsesSharedFixtures{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "UsesFixture"))
+			}
+			node.SharedFixtures = unionOf(node.SharedFixtures, arg.(UsesSharedFixtures))
+		case t == reflect.TypeOf(PropertyConfig{}):
+			if !nodeType.Is(types.NodeTypeIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Property"))
+			}
+			node.MarkedProperty = true
+			node.Property = arg.(PropertyConfig)
+			if node.Property.NumRuns < 1 {
+				appendError(types.GinkgoErrors.InvalidPropertyNumRuns(node.CodeLocation))
+			}
 		case t == reflect.TypeOf(types.AroundNodeDecorator{}):
 			node.AroundNodes = append(node.AroundNodes, arg.(types.AroundNodeDecorator))
 		case t == reflect.TypeOf(Labels{}):
@@ -505,6 +567,12 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 					trackedFunctionError = true
 					break
 				}
+				if body, hasContext, ok := extractPropertyBody(arg); ok {
+					propertyBody, node.HasContext = body, hasContext
+					cl := node.CodeLocation
+					node.Body = func(sc SpecContext) { runProperty(sc, cl, node.Property, propertyBody) }
+					break
+				}
 				node.Body, node.HasContext = extractBodyFunction(deprecationTracker, node.CodeLocation, arg)
 				if node.Body == nil {
 					appendError(types.GinkgoErrors.InvalidBodyType(t, node.CodeLocation, nodeType))
@@ -522,6 +590,10 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 		appendError(types.GinkgoErrors.InvalidDeclarationOfFocusedAndPending(node.CodeLocation, nodeType))
 	}
 
+	if (node.MarkedProperty && node.Body != nil && propertyBody == nil) || (propertyBody != nil && !node.MarkedProperty) {
+		appendError(types.GinkgoErrors.InvalidBodyTypeForProperty(node.CodeLocation))
+	}
+
 	if node.MarkedContinueOnFailure && !node.MarkedOrdered {
 		appendError(types.GinkgoErrors.InvalidContinueOnFailureDecoration(node.CodeLocation))
 	}
@@ -585,6 +657,26 @@ func extractBodyFunction(deprecationTracker *types.DeprecationTracker, cl types.
 	return func(SpecContext) { body() }, false
 }
 
+func extractPropertyBody(arg any) (func(SpecContext, Generators), bool, bool) {
+	t := reflect.TypeOf(arg)
+	if t.NumOut() > 0 {
+		return nil, false, false
+	}
+	if t.NumIn() == 1 && t.In(0) == generatorsType {
+		body := arg.(func(Generators))
+		return func(_ SpecContext, g Generators) { body(g) }, false, true
+	}
+	if t.NumIn() == 2 && t.In(1) == generatorsType {
+		if t.In(0).Implements(specContextType) {
+			return arg.(func(SpecContext, Generators)), true, true
+		} else if t.In(0).Implements(contextType) {
+			body := arg.(func(context.Context, Generators))
+			return func(c SpecContext, g Generators) { body(c, g) }, true, true
+		}
+	}
+	return nil, false, false
+}
+
 var byteType = reflect.TypeOf([]byte{})
 
 func extractSynchronizedBeforeSuiteProc1Body(arg any) (func(SpecContext) []byte, bool) {
@@ -1084,6 +1176,49 @@ func (n Nodes) GetMaxMustPassRepeatedly() int {
 	return maxMustPassRepeatedly
 }
 
+func (n Nodes) GetLeakDetection() LeakDetection {
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i].LeakDetection != LeakDetectionNone {
+			return n[i].LeakDetection
+		}
+	}
+	return LeakDetectionNone
+}
+
+func (n Nodes) UnionOfIgnoredLeaks() IgnoredLeaks {
+	out := IgnoredLeaks{}
+	for i := range n {
+		out = unionOf(out, n[i].IgnoredLeaks)
+	}
+	return out
+}
+
+func (n Nodes) SpecIDs() []string {
+	out := []string{}
+	for i := range n {
+		if n[i].SpecID != "" {
+			out = append(out, n[i].SpecID)
+		}
+	}
+	return out
+}
+
+func (n Nodes) UnionOfPrerequisites() Prerequisites {
+	out := Prerequisites{}
+	for i := range n {
+		out = unionOf(out, n[i].Prerequisites)
+	}
+	return out
+}
+
+func (n Nodes) UnionOfSharedFixtures() UsesSharedFixtures {
+	out := UsesSharedFixtures{}
+	for i := range n {
+		out = unionOf(out, n[i].SharedFixtures)
+	}
+	return out
+}
+
 func (n Nodes) GetSpecPriority() int {
 	for i := len(n) - 1; i >= 0; i-- {
 		if n[i].HasExplicitlySetSpecPriority {
@@ -1101,7 +1236,7 @@ func UnrollInterfaceSlice(args any) []any {
 	out := []any{}
 	for i := 0; i < v.Len(); i++ {
 		el := reflect.ValueOf(v.Index(i).Interface())
-		if el.Kind() == reflect.Slice && el.Type() != reflect.TypeOf(Labels{}) && el.Type() != reflect.TypeOf(SemVerConstraints{}) {
+		if el.Kind() == reflect.Slice && el.Type() != reflect.TypeOf(Labels{}) && el.Type() != reflect.TypeOf(SemVerConstraints{}) && el.Type() != reflect.TypeOf(IgnoredLeaks{}) && el.Type() != reflect.TypeOf(Prerequisites{}) && el.Type() != reflect.TypeOf(UsesSharedFixtures{}) {
 			out = append(out, UnrollInterfaceSlice(el.Interface())...)
 		} else {
 			out = append(out, v.Index(i).Interface())
@@ -612,6 +612,148 @@ var _ = Describe("Constructing nodes", func() {
 		})
 	})
 
+	Describe("The leak detection decorators", func() {
+		It("has no leak detection by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node.LeakDetection).Should(Equal(internal.LeakDetectionNone))
+			Ω(node.IgnoredLeaks).Should(BeEmpty())
+			ExpectAllWell(errors)
+		})
+
+		It("can be applied to containers and its", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, DetectLeaks, IgnoreLeaks("a", "b"), IgnoreLeaks("c"))
+			Ω(node.LeakDetection).Should(Equal(internal.LeakDetectionFail))
+			Ω(node.IgnoredLeaks).Should(Equal(IgnoredLeaks{"a", "b", "c"}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body, WarnOnLeaks)
+			Ω(node.LeakDetection).Should(Equal(internal.LeakDetectionWarn))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to non-container/it nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, DetectLeaks, IgnoreLeaks("a"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "DetectLeaks"),
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "IgnoreLeaks"),
+			))
+		})
+	})
+
+	Describe("The UsesFixture decorator", func() {
+		var db, cache *internal.SharedFixture
+		BeforeEach(func() {
+			db, cache = &internal.SharedFixture{Name: "db"}, &internal.SharedFixture{Name: "cache"}
+		})
+
+		It("can be applied to containers and its", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, internal.UsesSharedFixtures{db}, []any{internal.UsesSharedFixtures{db, cache}})
+			Ω(node.SharedFixtures).Should(Equal(internal.UsesSharedFixtures{db, cache}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body, internal.UsesSharedFixtures{cache})
+			Ω(node.SharedFixtures).Should(Equal(internal.UsesSharedFixtures{cache}))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to non-container/it nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, internal.UsesSharedFixtures{db})
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "UsesFixture")))
+		})
+
+		It("computes the union of the fixtures in a node hierarchy", func() {
+			Ω(Nodes{N(ntCon, internal.UsesSharedFixtures{db}), N(ntCon), N(ntIt, internal.UsesSharedFixtures{cache, db})}.UnionOfSharedFixtures()).Should(Equal(internal.UsesSharedFixtures{db, cache}))
+		})
+	})
+
+	Describe("The SpecID and DependsOn decorators", func() {
+		It("has no SpecID or prerequisites by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node.SpecID).Should(BeEmpty())
+			Ω(node.Prerequisites).Should(BeEmpty())
+			ExpectAllWell(errors)
+		})
+
+		It("can be applied to containers and its", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, SpecID("db"), DependsOn("a", "b"), DependsOn("b", "c"))
+			Ω(node.SpecID).Should(Equal("db"))
+			Ω(node.Prerequisites).Should(Equal(Prerequisites{"a", "b", "c"}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body, SpecID("migrate"))
+			Ω(node.SpecID).Should(Equal("migrate"))
+			ExpectAllWell(errors)
+		})
+
+		It("errors when passed empty SpecIDs", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, cl, SpecID(""), DependsOn(""))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(
+				types.GinkgoErrors.InvalidEmptySpecID(cl),
+				types.GinkgoErrors.InvalidEmptySpecID(cl),
+			))
+		})
+
+		It("cannot be applied to non-container/it nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, SpecID("db"), DependsOn("a"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "SpecID"),
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "DependsOn"),
+			))
+		})
+	})
+
+	Describe("The Property decorator", func() {
+		It("is not a property by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node.MarkedProperty).Should(BeFalse())
+			ExpectAllWell(errors)
+		})
+
+		It("accepts bodies that take Generators", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", func(g Generators) {}, Property())
+			Ω(node.MarkedProperty).Should(BeTrue())
+			Ω(node.Property).Should(Equal(internal.PropertyConfig{NumRuns: internal.DefaultPropertyNumRuns, MaxShrinks: internal.DefaultPropertyMaxShrinks}))
+			Ω(node.Body).ShouldNot(BeNil())
+			Ω(node.HasContext).Should(BeFalse())
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntIt, "text", Property(NumRuns(7), MaxShrinks(3)), func(ctx SpecContext, g Generators) {})
+			Ω(node.Property).Should(Equal(internal.PropertyConfig{NumRuns: 7, MaxShrinks: 3}))
+			Ω(node.HasContext).Should(BeTrue())
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntIt, "text", Property(), func(ctx context.Context, g Generators) {})
+			Ω(node.HasContext).Should(BeTrue())
+			ExpectAllWell(errors)
+		})
+
+		It("errors when the body and decorator don't match", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, cl, Property())
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidBodyTypeForProperty(cl)))
+
+			node, errors = internal.NewNode(dt, ntIt, "text", func(g Generators) {}, cl)
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidBodyTypeForProperty(cl)))
+		})
+
+		It("errors when NumRuns is zero", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", func(g Generators) {}, cl, Property(NumRuns(0)))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidPropertyNumRuns(cl)))
+		})
+
+		It("cannot be applied to non-it nodes", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, cl, Property())
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ContainElement(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntCon, "Property")))
+		})
+	})
+
 	Describe("the timeout-related decorators", func() {
 		It("correctly assigned timeouts when specified", func() {
 			node, errors := internal.NewNode(dt, ntIt, "spec", func(_ SpecContext) {}, cl, NodeTimeout(time.Second), SpecTimeout(2*time.Second), GracePeriod(3*time.Second))
@@ -1719,6 +1861,22 @@ var _ = Describe("Nodes", func() {
 		})
 	})
 
+	Describe("Computing leak detection", func() {
+		It("chooses the inner-most leak detection and the union of ignored leaks", func() {
+			Ω(Nodes{N(ntCon), N(ntIt)}.GetLeakDetection()).Should(Equal(internal.LeakDetectionNone))
+			Ω(Nodes{N(ntCon, DetectLeaks), N(ntCon), N(ntIt)}.GetLeakDetection()).Should(Equal(internal.LeakDetectionFail))
+			Ω(Nodes{N(ntCon, DetectLeaks), N(ntIt, WarnOnLeaks)}.GetLeakDetection()).Should(Equal(internal.LeakDetectionWarn))
+			Ω(Nodes{N(ntCon, IgnoreLeaks("a", "b")), N(ntIt, IgnoreLeaks("b", "c"))}.UnionOfIgnoredLeaks()).Should(Equal(IgnoredLeaks{"a", "b", "c"}))
+		})
+	})
+
+	Describe("Computing spec dependencies", func() {
+		It("returns every SpecID in the hierarchy and the union of prerequisites", func() {
+			Ω(Nodes{N(ntCon, SpecID("a")), N(ntCon), N(ntIt, SpecID("b"))}.SpecIDs()).Should(Equal([]string{"a", "b"}))
+			Ω(Nodes{N(ntCon, DependsOn("a", "b")), N(ntIt, DependsOn("b", "c"))}.UnionOfPrerequisites()).Should(Equal(Prerequisites{"a", "b", "c"}))
+		})
+	})
+
 	Describe("BestTextFor", func() {
 		var nIt, nBef1, nBef2 Node
 		var nodes Nodes
@@ -2294,5 +2452,9 @@ func newConstructionNodeReport(report types.ConstructionNodeReport, containers [
 		report.ContainerHierarchySemVerConstraints = append(report.ContainerHierarchySemVerConstraints, container.semVerConstraints)
 		report.ContainerHierarchyComponentSemVerConstraints = append(report.ContainerHierarchyComponentSemVerConstraints, container.componentSemVerConstraints)
 	}
+	// the first entry in the hierarchy is the root of the tree, which does not contribute to the ID
+	if len(report.ContainerHierarchyTexts) > 1 {
+		report.ID = hashedSpecID(report.ContainerHierarchyTexts[1:]...)
+	}
 	return report
 }
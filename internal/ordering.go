@@ -3,6 +3,7 @@ package internal
 import (
 	"math/rand"
 	"sort"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
 )
@@ -81,7 +82,9 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 
 		In addition, spec containers can be marked as Ordered.  Specs within an Ordered container are never shuffled.
 
-		Finally, specs and spec containers can be marked as Serial.  When running in parallel, serial specs run on Process #1 _after_ all other processes have finished.
+		Specs and spec containers can be marked as Serial.  When running in parallel, serial specs run on Process #1 _after_ all other processes have finished.
+
+		Finally, specs can depend on other specs via DependsOn.  Dependent specs are deferred until after the specs they depend on.
 	*/
 
 	// Seed a new random source based on thee configured random seed.
@@ -97,14 +100,10 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 	executionGroupIDs := []uint{}
 	executionGroups := map[uint]SpecIndices{}
 	for _, idx := range sortableSpecs.Indexes {
-		spec := specs[idx]
-		groupNode := spec.Nodes.FirstNodeMarkedOrdered()
-		if groupNode.IsZero() {
-			groupNode = spec.Nodes.FirstNodeWithType(types.NodeTypeIt)
-		}
-		executionGroups[groupNode.ID] = append(executionGroups[groupNode.ID], idx)
-		if len(executionGroups[groupNode.ID]) == 1 {
-			executionGroupIDs = append(executionGroupIDs, groupNode.ID)
+		groupID := executionGroupID(specs[idx])
+		executionGroups[groupID] = append(executionGroups[groupID], idx)
+		if len(executionGroups[groupID]) == 1 {
+			executionGroupIDs = append(executionGroupIDs, groupID)
 		}
 	}
 
@@ -171,6 +170,9 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 		}
 	}
 
+	// specs that depend on other specs (via DependsOn) must run after them
+	orderedGroups = orderByPrerequisites(specs, orderedGroups)
+
 	// If we're running in series, we're done.
 	if suiteConfig.ParallelTotal == 1 {
 		return orderedGroups, GroupedSpecIndices{}
@@ -190,3 +192,66 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 
 	return parallelizableGroups, serialGroups
 }
+
+/*
+ScheduleByExpectedRunTime reorders groupedSpecIndices so that the groups that are expected to take the longest run first.  When parallel processes pull groups off of a shared counter this amounts to longest-processing-time-first scheduling and avoids the common case where one process is left running a long spec while the others sit idle.
+
+The expected run time of a group is the sum of the expected run times of its specs (so Ordered containers are scheduled as a unit).  Specs that do not appear in timings are assumed to take the average of the specs that do.  Skipped specs are assumed to take no time at all.
+
+SpecPriority continues to take precedence: groups are only reordered relative to other groups with the same priority.  Since the sort is stable, groups with identical expected run times retain the (randomized) order produced by OrderSpecs.  Groups that depend on other groups (via DependsOn) are still deferred until after the groups they depend on.
+*/
+func ScheduleByExpectedRunTime(specs Specs, groupedSpecIndices GroupedSpecIndices, suitePath string, timings types.SpecTimings) GroupedSpecIndices {
+	if len(timings) == 0 {
+		return groupedSpecIndices
+	}
+
+	expectedRunTimes := make([]time.Duration, len(specs))
+	known := make([]bool, len(specs))
+	var total time.Duration
+	var numKnown int
+	for idx, spec := range specs {
+		if spec.Skip {
+			continue
+		}
+		key := types.SpecTimingKey(suitePath, spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation, spec.Text())
+		if runTime, ok := timings[key]; ok {
+			expectedRunTimes[idx], known[idx] = runTime, true
+			total += runTime
+			numKnown += 1
+		}
+	}
+	if numKnown > 0 {
+		average := total / time.Duration(numKnown)
+		for idx, spec := range specs {
+			if !spec.Skip && !known[idx] {
+				expectedRunTimes[idx] = average
+			}
+		}
+	}
+
+	type scheduledGroup struct {
+		specIndices     SpecIndices
+		priority        int
+		expectedRunTime time.Duration
+	}
+	scheduledGroups := make([]scheduledGroup, len(groupedSpecIndices))
+	for i, specIndices := range groupedSpecIndices {
+		scheduledGroups[i] = scheduledGroup{specIndices: specIndices, priority: -1 << 31}
+		for _, idx := range specIndices {
+			scheduledGroups[i].priority = max(scheduledGroups[i].priority, specs[idx].Nodes.GetSpecPriority())
+			scheduledGroups[i].expectedRunTime += expectedRunTimes[idx]
+		}
+	}
+	sort.SliceStable(scheduledGroups, func(i, j int) bool {
+		if scheduledGroups[i].priority != scheduledGroups[j].priority {
+			return scheduledGroups[i].priority > scheduledGroups[j].priority
+		}
+		return scheduledGroups[i].expectedRunTime > scheduledGroups[j].expectedRunTime
+	})
+
+	out := make(GroupedSpecIndices, len(scheduledGroups))
+	for i := range scheduledGroups {
+		out[i] = scheduledGroups[i].specIndices
+	}
+	return orderByPrerequisites(specs, out)
+}
@@ -214,6 +214,47 @@ var _ = Describe("OrderSpecs", func() {
 		})
 	})
 
+	Context("when specs depend on other specs", func() {
+		BeforeEach(func() {
+			con1 := N(ntCon, SpecID("con1"))
+			con2 := N(ntCon, Ordered)
+			specs = Specs{
+				S(N("A", ntIt, DependsOn("F"))),
+				S(N("B", ntIt)),
+				S(con1, N("C", ntIt)),
+				S(con1, N("D", ntIt)),
+				S(N("E", ntIt, DependsOn("con1", "H"))),
+				S(N("F", ntIt, SpecID("F"))),
+				S(con2, N("G", ntIt)),
+				S(con2, N("H", ntIt, SpecID("H"))),
+			}
+		})
+
+		It("runs specs after the specs they depend on", func() {
+			for _, randomizeAllSpecs := range []bool{false, true} {
+				conf.RandomizeAllSpecs = randomizeAllSpecs
+				for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
+					groupedSpecIndices, _ := internal.OrderSpecs(specs, conf)
+					order := getTexts(specs, groupedSpecIndices).Join()
+					Ω(order).Should(HaveLen(8))
+					Ω(order).Should(ContainSubstring("GH"))
+					Ω(strings.Index(order, "F")).Should(BeNumerically("<", strings.Index(order, "A")))
+					Ω(strings.Index(order, "C")).Should(BeNumerically("<", strings.Index(order, "E")))
+					Ω(strings.Index(order, "D")).Should(BeNumerically("<", strings.Index(order, "E")))
+					Ω(strings.Index(order, "H")).Should(BeNumerically("<", strings.Index(order, "E")))
+				}
+			}
+		})
+
+		It("continues to shuffle the specs", func() {
+			conf.RandomSeed = 1
+			groupedSpecIndices1, _ := internal.OrderSpecs(specs, conf)
+			conf.RandomSeed = 2
+			groupedSpecIndices2, _ := internal.OrderSpecs(specs, conf)
+			Ω(getTexts(specs, groupedSpecIndices1)).ShouldNot(Equal(getTexts(specs, groupedSpecIndices2)))
+		})
+	})
+
 	Context("when there are serial specs", func() {
 		BeforeEach(func() {
 			con1 := N(ntCon, Ordered, Serial)
@@ -363,3 +404,63 @@ var _ = Describe("OrderSpecs", func() {
 		})
 	})
 })
+
+var _ = Describe("ScheduleByExpectedRunTime", func() {
+	var specs Specs
+	var groupedSpecIndices internal.GroupedSpecIndices
+	var timings types.SpecTimings
+
+	key := func(spec Spec) string {
+		return types.SpecTimingKey("/suite", spec.FirstNodeWithType(ntIt).CodeLocation, spec.Text())
+	}
+
+	BeforeEach(func() {
+		con1 := N(ntCon, Ordered, CL("/suite/file_A", 10))
+		specs = Specs{
+			S(N("A", ntIt, CL("/suite/file_A", 1))),
+			S(N("B", ntIt, CL("/suite/file_A", 2))),
+			S(con1, N("C", ntIt, CL("/suite/file_A", 11))),
+			S(con1, N("D", ntIt, CL("/suite/file_A", 12))),
+			S(N("E", ntIt, CL("/suite/file_A", 20))),
+			S(N("F", ntIt, CL("/suite/file_A", 21))),
+		}
+		groupedSpecIndices = internal.GroupedSpecIndices{{0}, {1}, {2, 3}, {4}, {5}}
+		timings = types.SpecTimings{
+			key(specs[0]): time.Second,
+			key(specs[1]): 5 * time.Second,
+			key(specs[2]): 2 * time.Second,
+			key(specs[3]): 2 * time.Second,
+			key(specs[4]): 3 * time.Second,
+		}
+	})
+
+	It("leaves the order untouched when there are no timings", func() {
+		Ω(getTexts(specs, internal.ScheduleByExpectedRunTime(specs, groupedSpecIndices, "/suite", types.SpecTimings{}))).Should(Equal(SpecTexts{"A", "B", "C", "D", "E", "F"}))
+	})
+
+	It("schedules the longest groups first, treating ordered containers as a unit and assuming unknown specs take the average run time", func() {
+		// B: 5s, CD: 4s, E: 3s, F: 2.6s (the average), A: 1s
+		Ω(getTexts(specs, internal.ScheduleByExpectedRunTime(specs, groupedSpecIndices, "/suite", timings))).Should(Equal(SpecTexts{"B", "C", "D", "E", "F", "A"}))
+	})
+
+	It("schedules skipped specs last", func() {
+		specs[1].Skip = true
+		Ω(getTexts(specs, internal.ScheduleByExpectedRunTime(specs, groupedSpecIndices, "/suite", timings))).Should(Equal(SpecTexts{"C", "D", "E", "F", "A", "B"}))
+	})
+
+	It("continues to honor SpecPriority", func() {
+		specs[0] = S(N("A", ntIt, SpecPriority(1), CL("/suite/file_A", 1)))
+		Ω(getTexts(specs, internal.ScheduleByExpectedRunTime(specs, groupedSpecIndices, "/suite", timings))).Should(Equal(SpecTexts{"A", "B", "C", "D", "E", "F"}))
+	})
+
+	It("defers groups until after the groups they depend on", func() {
+		specs[0] = S(N("A", ntIt, SpecID("A"), CL("/suite/file_A", 1)))
+		specs[1] = S(N("B", ntIt, DependsOn("A"), CL("/suite/file_A", 2)))
+		Ω(getTexts(specs, internal.ScheduleByExpectedRunTime(specs, groupedSpecIndices, "/suite", timings))).Should(Equal(SpecTexts{"C", "D", "E", "F", "A", "B"}))
+	})
+
+	It("preserves the incoming order for groups with identical expected run times", func() {
+		timings[key(specs[4])] = 5 * time.Second
+		Ω(getTexts(specs, internal.ScheduleByExpectedRunTime(specs, groupedSpecIndices, "/suite", timings))).Should(Equal(SpecTexts{"B", "E", "C", "D", "F", "A"}))
+	})
+})
@@ -19,6 +19,39 @@ type ParallelIndexCounter struct {
 	Index int
 }
 
+// SpecIDState captures the final state of a spec that bears a SpecID other specs depend on
+type SpecIDState struct {
+	SpecID string
+	State  types.SpecState
+}
+
+// SharedFixtureState captures the outcome of setting up a SharedFixture on the process that claimed it.  Data is the fixture's JSON-encoded value.
+type SharedFixtureState struct {
+	Name  string
+	Proc  int
+	State types.SpecState
+	Data  []byte
+}
+
+// SharedFixtureUsers tells the server that one of the Users specs that declared they use a SharedFixture has finished
+type SharedFixtureUsers struct {
+	Name  string
+	Users int
+}
+
+// FinishedRunningSpecs tells the server that a process has run all the specs it's going to run.  Processes that still have to tear down a SharedFixture once its users on other processes finish are AwaitingSharedFixtureUsers.
+type FinishedRunningSpecs struct {
+	Proc                       int
+	AwaitingSharedFixtureUsers bool
+}
+
+// SnapshotUpdate asks the server to store Value under Key in the snapshot file at Path.  Routing updates through the server ensures that parallel processes don't clobber each other's writes to the same snapshot file.
+type SnapshotUpdate struct {
+	Path  string
+	Key   string
+	Value string
+}
+
 var ErrorGone = fmt.Errorf("gone")
 var ErrorFailed = fmt.Errorf("failed")
 var ErrorEarly = fmt.Errorf("early")
@@ -33,6 +66,7 @@ type Server interface {
 	GetSuiteDone() chan any
 	GetOutputDestination() io.Writer
 	SetOutputDestination(io.Writer)
+	SetEventStream(*reporters.EventStream)
 }
 
 type Client interface {
@@ -47,25 +81,50 @@ type Client interface {
 	PostSynchronizedBeforeSuiteCompleted(state types.SpecState, data []byte) error
 	BlockUntilSynchronizedBeforeSuiteData() (types.SpecState, []byte, error)
 	BlockUntilNonprimaryProcsHaveFinished() error
+	BlockUntilNonprimaryProcsHaveFinishedRunningSpecs() error
 	BlockUntilAggregatedNonprimaryProcsReport() (types.Report, error)
 	FetchNextCounter() (int, error)
+	PostSpecIDState(specID string, state types.SpecState) error
+	BlockUntilSpecIDStates(expected map[string]int) (map[string][]types.SpecState, error)
+	ClaimSharedFixture(name string, proc int) (bool, error)
+	PostSharedFixtureState(state SharedFixtureState) error
+	BlockUntilSharedFixtureState(name string) (SharedFixtureState, error)
+	PostSharedFixtureUserFinished(name string, users int) error
+	PostFinishedRunningSpecs(proc int, awaitingSharedFixtureUsers bool) error
+	FetchSharedFixtureReleased(name string, proc int) (bool, error)
+	BlockUntilSharedFixtureReleased(name string, proc int) error
+	PostSnapshotUpdate(update SnapshotUpdate) error
+	PostSnapshotsUsed(used map[string][]string) error
+	FetchSnapshotsUsed() (map[string][]string, error)
 	PostAbort() error
 	ShouldAbort() bool
 	PostEmitProgressReport(report types.ProgressReport) error
+	PostEmitStreamEvent(event types.StreamEvent) error
 	Write(p []byte) (int, error)
 }
 
+// PARALLEL_TOKEN_ENV_VAR holds the token clients must present to servers created with NewServerListeningOn
+const PARALLEL_TOKEN_ENV_VAR = "GINKGO_PARALLEL_TOKEN"
+
 func NewServer(parallelTotal int, reporter reporters.Reporter) (Server, error) {
 	if os.Getenv("GINKGO_PARALLEL_PROTOCOL") == "HTTP" {
-		return newHttpServer(parallelTotal, reporter)
+		return newHttpServer("127.0.0.1:0", "", parallelTotal, reporter)
 	} else {
-		return newRPCServer(parallelTotal, reporter)
+		return newRPCServer("127.0.0.1:0", parallelTotal, reporter)
+	}
+}
+
+// NewServerListeningOn returns an HTTP server listening on the passed-in address.  This allows procs running on other machines to reach the server so every request must carry token (clients read it from $GINKGO_PARALLEL_TOKEN).
+func NewServerListeningOn(address string, token string, parallelTotal int, reporter reporters.Reporter) (Server, error) {
+	if token == "" {
+		return nil, fmt.Errorf("a parallel support server reachable by other machines requires a token")
 	}
+	return newHttpServer(address, token, parallelTotal, reporter)
 }
 
 func NewClient(serverHost string) Client {
 	if os.Getenv("GINKGO_PARALLEL_PROTOCOL") == "HTTP" {
-		return newHttpClient(serverHost)
+		return newHttpClient(serverHost, os.Getenv(PARALLEL_TOKEN_ENV_VAR))
 	} else {
 		return newRPCClient(serverHost)
 	}
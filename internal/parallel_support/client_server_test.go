@@ -1,8 +1,12 @@
 package parallel_support_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,6 +16,7 @@ import (
 	"github.com/onsi/ginkgo/v2/internal"
 	"github.com/onsi/ginkgo/v2/internal/parallel_support"
 	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -192,6 +197,49 @@ var _ = Describe("The Parallel Support Client & Server", func() {
 				})
 			})
 
+			Describe("Streaming events", func() {
+				var events *bytes.Buffer
+				streamedEvents := func() []types.StreamEvent {
+					out := []types.StreamEvent{}
+					for _, line := range strings.Split(strings.TrimSpace(events.String()), "\n") {
+						var event types.StreamEvent
+						Ω(json.Unmarshal([]byte(line), &event)).Should(Succeed())
+						out = append(out, event)
+					}
+					return out
+				}
+
+				BeforeEach(func() {
+					events = &bytes.Buffer{}
+					server.SetEventStream(reporters.NewEventStream(events))
+				})
+
+				It("forwards events to the event stream, combining the SuiteWillBegin and SuiteDidEnd events of all the procs", func() {
+					for proc := 1; proc <= 3; proc++ {
+						Ω(client.PostEmitStreamEvent(types.StreamEvent{Type: types.StreamEventSuiteWillBegin, ParallelProcess: proc, Report: &types.Report{SuiteDescription: "my sweet suite"}})).Should(Succeed())
+					}
+					cl := types.NewCodeLocation(0)
+					entry, err := internal.NewReportEntry("Custom Type Value Entry", cl, ColorableStringerStruct{Label: "apples", Count: 17})
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(client.PostEmitStreamEvent(types.StreamEvent{Type: types.StreamEventWillRun, ParallelProcess: 2, SpecReport: &types.SpecReport{LeafNodeText: "A"}})).Should(Succeed())
+					Ω(client.PostEmitStreamEvent(types.StreamEvent{Type: types.StreamEventReportEntry, ParallelProcess: 2, ReportEntry: &entry})).Should(Succeed())
+					Ω(client.PostEmitStreamEvent(types.StreamEvent{Type: types.StreamEventSuiteDidEnd, ParallelProcess: 1, Report: &types.Report{SuiteSucceeded: true, PreRunStats: types.PreRunStats{SpecsThatWillRun: 1}}})).Should(Succeed())
+					Ω(client.PostEmitStreamEvent(types.StreamEvent{Type: types.StreamEventSuiteDidEnd, ParallelProcess: 2, Report: &types.Report{SuiteSucceeded: false}})).Should(Succeed())
+					Ω(strings.Count(events.String(), "\n")).Should(Equal(3))
+					Ω(client.PostEmitStreamEvent(types.StreamEvent{Type: types.StreamEventSuiteDidEnd, ParallelProcess: 3, Report: &types.Report{SuiteSucceeded: true}})).Should(Succeed())
+
+					streamed := streamedEvents()
+					Ω(streamed).Should(HaveLen(4))
+					Ω(streamed[0].Type).Should(Equal(types.StreamEventSuiteWillBegin))
+					Ω(streamed[0].ParallelProcess).Should(Equal(1))
+					Ω(streamed[1].SpecReport.LeafNodeText).Should(Equal("A"))
+					Ω(streamed[2].ReportEntry.StringRepresentation()).Should(Equal("{{red}}apples {{green}}17{{/}}"))
+					Ω(streamed[3].Type).Should(Equal(types.StreamEventSuiteDidEnd))
+					Ω(streamed[3].ParallelProcess).Should(Equal(0))
+					Ω(streamed[3].Report.SuiteSucceeded).Should(BeFalse())
+				})
+			})
+
 			Describe("Streaming output", func() {
 				It("is configured to stream to stdout", func() {
 					server, err := parallel_support.NewServer(3, reporter)
@@ -426,6 +474,185 @@ var _ = Describe("The Parallel Support Client & Server", func() {
 					})
 				})
 
+				Describe("Sharing spec states", func() {
+					It("blocks until the expected number of states have been recorded for each SpecID", func() {
+						done := make(chan any)
+						go func() {
+							defer GinkgoRecover()
+							states, err := client.BlockUntilSpecIDStates(map[string]int{"migrations": 2, "seed": 1})
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(states).Should(Equal(map[string][]types.SpecState{
+								"migrations": {types.SpecStatePassed, types.SpecStateFailed},
+								"seed":       {types.SpecStatePassed},
+							}))
+							close(done)
+						}()
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.PostSpecIDState("migrations", types.SpecStatePassed)).Should(Succeed())
+						Ω(client.PostSpecIDState("seed", types.SpecStatePassed)).Should(Succeed())
+						Ω(client.PostSpecIDState("other", types.SpecStateSkipped)).Should(Succeed())
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.PostSpecIDState("migrations", types.SpecStateFailed)).Should(Succeed())
+						Eventually(done).Should(BeClosed())
+					})
+
+					Context("when the other procs disappear before the specs complete", func() {
+						It("returns an error", func() {
+							done := make(chan any)
+							go func() {
+								defer GinkgoRecover()
+								_, err := client.BlockUntilSpecIDStates(map[string]int{"migrations": 1})
+								Ω(err).Should(MatchError(parallel_support.ErrorGone))
+								close(done)
+							}()
+							Consistently(done).ShouldNot(BeClosed())
+							close(proc2Exited)
+							Consistently(done).ShouldNot(BeClosed())
+							close(proc3Exited)
+							Eventually(done).Should(BeClosed())
+						})
+					})
+				})
+
+				Describe("Sharing shared fixtures", func() {
+					It("lets only the first process claim a fixture", func() {
+						Ω(client.ClaimSharedFixture("db", 2)).Should(BeTrue())
+						Ω(client.ClaimSharedFixture("db", 3)).Should(BeFalse())
+						Ω(client.ClaimSharedFixture("db", 2)).Should(BeFalse())
+						Ω(client.ClaimSharedFixture("cache", 3)).Should(BeTrue())
+					})
+
+					It("blocks until the claiming process has set up the fixture", func() {
+						Ω(client.ClaimSharedFixture("db", 2)).Should(BeTrue())
+						done := make(chan any)
+						go func() {
+							defer GinkgoRecover()
+							state, err := client.BlockUntilSharedFixtureState("db")
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(state).Should(Equal(parallel_support.SharedFixtureState{Name: "db", Proc: 2, State: types.SpecStatePassed, Data: []byte(`"postgres://"`)}))
+							close(done)
+						}()
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.PostSharedFixtureState(parallel_support.SharedFixtureState{Name: "db", Proc: 2, State: types.SpecStatePassed, Data: []byte(`"postgres://"`)})).Should(Succeed())
+						Eventually(done).Should(BeClosed())
+					})
+
+					Context("when the claiming process disappears before setting up the fixture", func() {
+						It("returns an error", func() {
+							Ω(client.ClaimSharedFixture("db", 2)).Should(BeTrue())
+							done := make(chan any)
+							go func() {
+								defer GinkgoRecover()
+								_, err := client.BlockUntilSharedFixtureState("db")
+								Ω(err).Should(MatchError(parallel_support.ErrorGone))
+								close(done)
+							}()
+							Consistently(done).ShouldNot(BeClosed())
+							close(proc2Exited)
+							Eventually(done).Should(BeClosed())
+						})
+					})
+				})
+
+				Describe("Tearing down shared fixtures", func() {
+					It("releases a fixture once its users have finished", func() {
+						Ω(client.FetchSharedFixtureReleased("db", 2)).Should(BeFalse())
+						done := make(chan any)
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilSharedFixtureReleased("db", 2)).Should(Succeed())
+							close(done)
+						}()
+						Ω(client.PostSharedFixtureUserFinished("db", 3)).Should(Succeed())
+						Ω(client.PostSharedFixtureUserFinished("cache", 1)).Should(Succeed())
+						Ω(client.PostSharedFixtureUserFinished("db", 3)).Should(Succeed())
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.FetchSharedFixtureReleased("db", 2)).Should(BeFalse())
+						Ω(client.FetchSharedFixtureReleased("cache", 2)).Should(BeTrue())
+
+						Ω(client.PostSharedFixtureUserFinished("db", 3)).Should(Succeed())
+						Eventually(done).Should(BeClosed())
+						Ω(client.FetchSharedFixtureReleased("db", 2)).Should(BeTrue())
+					})
+
+					It("gives up once no other process can finish the fixture's remaining users", func() {
+						Ω(client.PostSharedFixtureUserFinished("db", 3)).Should(Succeed())
+						done := make(chan any)
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilSharedFixtureReleased("db", 2)).Should(MatchError(parallel_support.ErrorGone))
+							close(done)
+						}()
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.PostFinishedRunningSpecs(1, false)).Should(Succeed())
+						Consistently(done).ShouldNot(BeClosed())
+						close(proc3Exited)
+						Eventually(done).Should(BeClosed())
+
+						_, err := client.FetchSharedFixtureReleased("db", 2)
+						Ω(err).Should(MatchError(parallel_support.ErrorGone))
+					})
+
+					It("lets proc 1 run Serial specs while the other processes only wait for the users of their fixtures", func() {
+						done := make(chan any)
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilNonprimaryProcsHaveFinishedRunningSpecs()).Should(Succeed())
+							close(done)
+						}()
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.PostFinishedRunningSpecs(2, true)).Should(Succeed())
+						Consistently(done).ShouldNot(BeClosed())
+						Ω(client.PostFinishedRunningSpecs(3, false)).Should(Succeed())
+						Consistently(done).ShouldNot(BeClosed())
+						close(proc3Exited)
+						Eventually(done).Should(BeClosed())
+					})
+				})
+
+				Describe("Sharing snapshots", func() {
+					It("applies snapshot updates without losing concurrent writes to the same file", func() {
+						path := filepath.Join(GinkgoT().TempDir(), "__snapshots__", "a_test.ginkgo.snap")
+						done := make(chan any)
+						for i := 0; i < 10; i++ {
+							go func(i int) {
+								defer GinkgoRecover()
+								Ω(client.PostSnapshotUpdate(parallel_support.SnapshotUpdate{Path: path, Key: fmt.Sprintf("A - %d", i), Value: fmt.Sprintf("value %d", i)})).Should(Succeed())
+								done <- true
+							}(i)
+						}
+						for i := 0; i < 10; i++ {
+							Eventually(done).Should(Receive())
+						}
+						snapshots, err := types.LoadSnapshots(path)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(snapshots).Should(HaveLen(10))
+						Ω(snapshots).Should(HaveKeyWithValue("A - 7", "value 7"))
+					})
+
+					It("refuses to write to files that aren't snapshot files", func() {
+						dir := GinkgoT().TempDir()
+						for _, path := range []string{
+							filepath.Join(dir, "a_test.go"),
+							filepath.Join(dir, "__snapshots__", "a_test.go"),
+							filepath.Join(dir, "elsewhere", "a_test.ginkgo.snap"),
+							filepath.Join(dir, "__snapshots__", "..", "a_test.ginkgo.snap"),
+							filepath.Join(dir, "__snapshots__", ".ginkgo.snap"),
+						} {
+							Ω(client.PostSnapshotUpdate(parallel_support.SnapshotUpdate{Path: path, Key: "A - 1", Value: "value"})).ShouldNot(Succeed(), path)
+						}
+						Ω(client.PostSnapshotUpdate(parallel_support.SnapshotUpdate{Path: dir + "/__snapshots__/../a_test.ginkgo.snap", Key: "A - 1", Value: "value"})).ShouldNot(Succeed())
+						Ω(os.ReadDir(dir)).Should(BeEmpty())
+					})
+
+					It("collects the snapshots used by each process", func() {
+						Ω(client.FetchSnapshotsUsed()).Should(BeEmpty())
+						Ω(client.PostSnapshotsUsed(map[string][]string{"a.snap": {"A - 1"}, "b.snap": {"B - 1"}})).Should(Succeed())
+						Ω(client.PostSnapshotsUsed(map[string][]string{"a.snap": {"A - 2"}})).Should(Succeed())
+						Ω(client.FetchSnapshotsUsed()).Should(Equal(map[string][]string{"a.snap": {"A - 1", "A - 2"}, "b.snap": {"B - 1"}}))
+					})
+				})
+
 				Describe("Aborting", func() {
 					It("should not abort by default", func() {
 						Ω(client.ShouldAbort()).Should(BeFalse())
@@ -446,3 +673,36 @@ var _ = Describe("The Parallel Support Client & Server", func() {
 		})
 	}
 })
+
+var _ = Describe("A Parallel Support Server reachable by other machines", func() {
+	var server parallel_support.Server
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("GINKGO_PARALLEL_PROTOCOL", "HTTP")
+		var err error
+		server, err = parallel_support.NewServerListeningOn("127.0.0.1:0", "s3cret", 1, NewFakeReporter())
+		Ω(err).ShouldNot(HaveOccurred())
+		server.Start()
+		DeferCleanup(server.Close)
+	})
+
+	It("requires a token", func() {
+		_, err := parallel_support.NewServerListeningOn("127.0.0.1:0", "", 1, NewFakeReporter())
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("only accepts clients that present its token", func() {
+		GinkgoT().Setenv(parallel_support.PARALLEL_TOKEN_ENV_VAR, "")
+		Ω(parallel_support.NewClient(server.Address()).Connect()).Should(BeFalse())
+
+		GinkgoT().Setenv(parallel_support.PARALLEL_TOKEN_ENV_VAR, "guess")
+		client := parallel_support.NewClient(server.Address())
+		Ω(client.Connect()).Should(BeFalse())
+		Ω(client.FetchNextCounter()).Error().Should(HaveOccurred())
+
+		GinkgoT().Setenv(parallel_support.PARALLEL_TOKEN_ENV_VAR, "s3cret")
+		client = parallel_support.NewClient(server.Address())
+		Ω(client.Connect()).Should(BeTrue())
+		Ω(client.FetchNextCounter()).Should(Equal(0))
+	})
+})
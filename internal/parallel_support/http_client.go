@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -13,16 +15,33 @@ import (
 
 type httpClient struct {
 	serverHost string
+	token      string
 }
 
-func newHttpClient(serverHost string) *httpClient {
+func newHttpClient(serverHost string, token string) *httpClient {
 	return &httpClient{
 		serverHost: serverHost,
+		token:      token,
 	}
 }
 
+// do sends a request to the server, carrying the client's token if it has one
+func (client *httpClient) do(method string, path string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, client.serverHost+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if client.token != "" {
+		req.Header.Set("Authorization", "Bearer "+client.token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return http.DefaultClient.Do(req)
+}
+
 func (client *httpClient) Connect() bool {
-	resp, err := http.Get(client.serverHost + "/up")
+	resp, err := client.do(http.MethodGet, "/up", "", nil)
 	if err != nil {
 		return false
 	}
@@ -43,7 +62,7 @@ func (client *httpClient) post(path string, data any) error {
 		}
 		body = bytes.NewBuffer(encoded)
 	}
-	resp, err := http.Post(client.serverHost+path, "application/json", body)
+	resp, err := client.do(http.MethodPost, path, "application/json", body)
 	if err != nil {
 		return err
 	}
@@ -56,7 +75,7 @@ func (client *httpClient) post(path string, data any) error {
 
 func (client *httpClient) poll(path string, data any) error {
 	for {
-		resp, err := http.Get(client.serverHost + path)
+		resp, err := client.do(http.MethodGet, path, "", nil)
 		if err != nil {
 			return err
 		}
@@ -98,6 +117,10 @@ func (client *httpClient) PostEmitProgressReport(report types.ProgressReport) er
 	return client.post("/progress-report", report)
 }
 
+func (client *httpClient) PostEmitStreamEvent(event types.StreamEvent) error {
+	return client.post("/stream-event", event)
+}
+
 func (client *httpClient) PostReportBeforeSuiteCompleted(state types.SpecState) error {
 	return client.post("/report-before-suite-completed", state)
 }
@@ -132,6 +155,10 @@ func (client *httpClient) BlockUntilNonprimaryProcsHaveFinished() error {
 	return client.poll("/have-nonprimary-procs-finished", nil)
 }
 
+func (client *httpClient) BlockUntilNonprimaryProcsHaveFinishedRunningSpecs() error {
+	return client.poll("/have-nonprimary-procs-finished-running-specs", nil)
+}
+
 func (client *httpClient) BlockUntilAggregatedNonprimaryProcsReport() (types.Report, error) {
 	var report types.Report
 	err := client.poll("/aggregated-nonprimary-procs-report", &report)
@@ -147,6 +174,79 @@ func (client *httpClient) FetchNextCounter() (int, error) {
 	return counter.Index, err
 }
 
+func (client *httpClient) PostSpecIDState(specID string, state types.SpecState) error {
+	return client.post("/spec-id-state-completed", SpecIDState{SpecID: specID, State: state})
+}
+
+func (client *httpClient) BlockUntilSpecIDStates(expected map[string]int) (map[string][]types.SpecState, error) {
+	query := url.Values{}
+	for specID, count := range expected {
+		query.Set(specID, strconv.Itoa(count))
+	}
+	var states map[string][]types.SpecState
+	err := client.poll("/spec-id-states?"+query.Encode(), &states)
+	return states, err
+}
+
+func (client *httpClient) ClaimSharedFixture(name string, proc int) (bool, error) {
+	var claimed bool
+	err := client.poll("/claim-shared-fixture?"+url.Values{"name": {name}, "proc": {strconv.Itoa(proc)}}.Encode(), &claimed)
+	return claimed, err
+}
+
+func (client *httpClient) PostSharedFixtureState(state SharedFixtureState) error {
+	return client.post("/shared-fixture-completed", state)
+}
+
+func (client *httpClient) BlockUntilSharedFixtureState(name string) (SharedFixtureState, error) {
+	var state SharedFixtureState
+	err := client.poll("/shared-fixture-state?"+url.Values{"name": {name}}.Encode(), &state)
+	return state, err
+}
+
+func (client *httpClient) PostSharedFixtureUserFinished(name string, users int) error {
+	return client.post("/shared-fixture-user-finished", SharedFixtureUsers{Name: name, Users: users})
+}
+
+func (client *httpClient) PostFinishedRunningSpecs(proc int, awaitingSharedFixtureUsers bool) error {
+	return client.post("/finished-running-specs", FinishedRunningSpecs{Proc: proc, AwaitingSharedFixtureUsers: awaitingSharedFixtureUsers})
+}
+
+func (client *httpClient) FetchSharedFixtureReleased(name string, proc int) (bool, error) {
+	resp, err := client.do(http.MethodGet, "/shared-fixture-released?"+url.Values{"name": {name}, "proc": {strconv.Itoa(proc)}}.Encode(), "", nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusTooEarly:
+		return false, nil
+	case http.StatusGone:
+		return false, ErrorGone
+	}
+	return false, fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+}
+
+func (client *httpClient) BlockUntilSharedFixtureReleased(name string, proc int) error {
+	return client.poll("/shared-fixture-released?"+url.Values{"name": {name}, "proc": {strconv.Itoa(proc)}}.Encode(), nil)
+}
+
+func (client *httpClient) PostSnapshotUpdate(update SnapshotUpdate) error {
+	return client.post("/snapshot-update", update)
+}
+
+func (client *httpClient) PostSnapshotsUsed(used map[string][]string) error {
+	return client.post("/snapshots-used", used)
+}
+
+func (client *httpClient) FetchSnapshotsUsed() (map[string][]string, error) {
+	var used map[string][]string
+	err := client.poll("/snapshots-used", &used)
+	return used, err
+}
+
 func (client *httpClient) PostAbort() error {
 	return client.post("/abort", nil)
 }
@@ -157,7 +257,7 @@ func (client *httpClient) ShouldAbort() bool {
 }
 
 func (client *httpClient) Write(p []byte) (int, error) {
-	resp, err := http.Post(client.serverHost+"/emit-output", "text/plain;charset=UTF-8 ", bytes.NewReader(p))
+	resp, err := client.do(http.MethodPost, "/emit-output", "text/plain;charset=UTF-8 ", bytes.NewReader(p))
 	resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		return 0, fmt.Errorf("failed to emit output")
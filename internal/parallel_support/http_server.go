@@ -8,10 +8,12 @@ This is used, primarily, to enable streaming parallel test output but has, in pr
 package parallel_support
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"io"
 	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
@@ -24,17 +26,19 @@ It then forwards that communication to attached reporters.
 type httpServer struct {
 	listener net.Listener
 	handler  *ServerHandler
+	token    string
 }
 
-// Create a new server, automatically selecting a port
-func newHttpServer(parallelTotal int, reporter reporters.Reporter) (*httpServer, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+// Create a new server listening on address.  Use port 0 to automatically select a port.  If token is set, requests that don't carry it are rejected.
+func newHttpServer(address string, token string, parallelTotal int, reporter reporters.Reporter) (*httpServer, error) {
+	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		return nil, err
 	}
 	return &httpServer{
 		listener: listener,
 		handler:  newServerHandler(parallelTotal, reporter),
+		token:    token,
 	}, nil
 }
 
@@ -42,7 +46,7 @@ func newHttpServer(parallelTotal int, reporter reporters.Reporter) (*httpServer,
 func (server *httpServer) Start() {
 	httpServer := &http.Server{}
 	mux := http.NewServeMux()
-	httpServer.Handler = mux
+	httpServer.Handler = server.authenticate(mux)
 
 	//streaming endpoints
 	mux.HandleFunc("/suite-will-begin", server.specSuiteWillBegin)
@@ -50,6 +54,7 @@ func (server *httpServer) Start() {
 	mux.HandleFunc("/suite-did-end", server.specSuiteDidEnd)
 	mux.HandleFunc("/emit-output", server.emitOutput)
 	mux.HandleFunc("/progress-report", server.emitProgressReport)
+	mux.HandleFunc("/stream-event", server.emitStreamEvent)
 
 	//synchronization endpoints
 	mux.HandleFunc("/report-before-suite-completed", server.handleReportBeforeSuiteCompleted)
@@ -57,14 +62,40 @@ func (server *httpServer) Start() {
 	mux.HandleFunc("/before-suite-completed", server.handleBeforeSuiteCompleted)
 	mux.HandleFunc("/before-suite-state", server.handleBeforeSuiteState)
 	mux.HandleFunc("/have-nonprimary-procs-finished", server.handleHaveNonprimaryProcsFinished)
+	mux.HandleFunc("/have-nonprimary-procs-finished-running-specs", server.handleHaveNonprimaryProcsFinishedRunningSpecs)
 	mux.HandleFunc("/aggregated-nonprimary-procs-report", server.handleAggregatedNonprimaryProcsReport)
 	mux.HandleFunc("/counter", server.handleCounter)
+	mux.HandleFunc("/spec-id-state-completed", server.handleSpecIDStateCompleted)
+	mux.HandleFunc("/spec-id-states", server.handleSpecIDStates)
+	mux.HandleFunc("/claim-shared-fixture", server.handleClaimSharedFixture)
+	mux.HandleFunc("/shared-fixture-completed", server.handleSharedFixtureCompleted)
+	mux.HandleFunc("/shared-fixture-state", server.handleSharedFixtureState)
+	mux.HandleFunc("/shared-fixture-user-finished", server.handleSharedFixtureUserFinished)
+	mux.HandleFunc("/shared-fixture-released", server.handleSharedFixtureReleased)
+	mux.HandleFunc("/finished-running-specs", server.handleFinishedRunningSpecs)
+	mux.HandleFunc("/snapshot-update", server.handleSnapshotUpdate)
+	mux.HandleFunc("/snapshots-used", server.handleSnapshotsUsed)
 	mux.HandleFunc("/up", server.handleUp)
 	mux.HandleFunc("/abort", server.handleAbort)
 
 	go httpServer.Serve(server.listener)
 }
 
+// authenticate rejects requests that don't carry the server's token, if it has one
+func (server *httpServer) authenticate(handler http.Handler) http.Handler {
+	if server.token == "" {
+		return handler
+	}
+	expected := []byte("Bearer " + server.token)
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(request.Header.Get("Authorization")), expected) != 1 {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	})
+}
+
 // Stop the server
 func (server *httpServer) Close() {
 	server.listener.Close()
@@ -87,6 +118,10 @@ func (server *httpServer) SetOutputDestination(w io.Writer) {
 	server.handler.outputDestination = w
 }
 
+func (server *httpServer) SetEventStream(stream *reporters.EventStream) {
+	server.handler.eventStream = stream
+}
+
 func (server *httpServer) RegisterAlive(node int, alive func() bool) {
 	server.handler.registerAlive(node, alive)
 }
@@ -166,6 +201,14 @@ func (server *httpServer) emitProgressReport(writer http.ResponseWriter, request
 	server.handleError(server.handler.EmitProgressReport(report, voidReceiver), writer)
 }
 
+func (server *httpServer) emitStreamEvent(writer http.ResponseWriter, request *http.Request) {
+	var event types.StreamEvent
+	if !server.decode(writer, request, &event) {
+		return
+	}
+	server.handleError(server.handler.EmitStreamEvent(event, voidReceiver), writer)
+}
+
 func (server *httpServer) handleReportBeforeSuiteCompleted(writer http.ResponseWriter, request *http.Request) {
 	var state types.SpecState
 	if !server.decode(writer, request, &state) {
@@ -207,6 +250,13 @@ func (server *httpServer) handleHaveNonprimaryProcsFinished(writer http.Response
 	writer.WriteHeader(http.StatusOK)
 }
 
+func (server *httpServer) handleHaveNonprimaryProcsFinishedRunningSpecs(writer http.ResponseWriter, request *http.Request) {
+	if server.handleError(server.handler.HaveNonprimaryProcsFinishedRunningSpecs(voidSender, voidReceiver), writer) {
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
 func (server *httpServer) handleAggregatedNonprimaryProcsReport(writer http.ResponseWriter, request *http.Request) {
 	var aggregatedReport types.Report
 	if server.handleError(server.handler.AggregatedNonprimaryProcsReport(voidSender, &aggregatedReport), writer) {
@@ -223,6 +273,112 @@ func (server *httpServer) handleCounter(writer http.ResponseWriter, request *htt
 	json.NewEncoder(writer).Encode(ParallelIndexCounter{Index: n})
 }
 
+func (server *httpServer) handleSpecIDStateCompleted(writer http.ResponseWriter, request *http.Request) {
+	var specIDState SpecIDState
+	if !server.decode(writer, request, &specIDState) {
+		return
+	}
+	server.handleError(server.handler.SpecIDStateCompleted(specIDState, voidReceiver), writer)
+}
+
+func (server *httpServer) handleSpecIDStates(writer http.ResponseWriter, request *http.Request) {
+	expected := map[string]int{}
+	for specID, counts := range request.URL.Query() {
+		count, err := strconv.Atoi(counts[0])
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		expected[specID] = count
+	}
+	var specIDStates map[string][]types.SpecState
+	if server.handleError(server.handler.SpecIDStates(expected, &specIDStates), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(specIDStates)
+}
+
+func (server *httpServer) handleClaimSharedFixture(writer http.ResponseWriter, request *http.Request) {
+	proc, err := strconv.Atoi(request.URL.Query().Get("proc"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var claimed bool
+	if server.handleError(server.handler.ClaimSharedFixture(SharedFixtureState{Name: request.URL.Query().Get("name"), Proc: proc}, &claimed), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(claimed)
+}
+
+func (server *httpServer) handleSharedFixtureCompleted(writer http.ResponseWriter, request *http.Request) {
+	var sharedFixtureState SharedFixtureState
+	if !server.decode(writer, request, &sharedFixtureState) {
+		return
+	}
+	server.handleError(server.handler.SharedFixtureCompleted(sharedFixtureState, voidReceiver), writer)
+}
+
+func (server *httpServer) handleSharedFixtureState(writer http.ResponseWriter, request *http.Request) {
+	var sharedFixtureState SharedFixtureState
+	if server.handleError(server.handler.SharedFixtureState(request.URL.Query().Get("name"), &sharedFixtureState), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(sharedFixtureState)
+}
+
+func (server *httpServer) handleSharedFixtureUserFinished(writer http.ResponseWriter, request *http.Request) {
+	var users SharedFixtureUsers
+	if !server.decode(writer, request, &users) {
+		return
+	}
+	server.handleError(server.handler.SharedFixtureUserFinished(users, voidReceiver), writer)
+}
+
+func (server *httpServer) handleSharedFixtureReleased(writer http.ResponseWriter, request *http.Request) {
+	proc, err := strconv.Atoi(request.URL.Query().Get("proc"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if server.handleError(server.handler.SharedFixtureReleased(SharedFixtureState{Name: request.URL.Query().Get("name"), Proc: proc}, voidReceiver), writer) {
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (server *httpServer) handleFinishedRunningSpecs(writer http.ResponseWriter, request *http.Request) {
+	var finished FinishedRunningSpecs
+	if !server.decode(writer, request, &finished) {
+		return
+	}
+	server.handleError(server.handler.FinishedRunningSpecs(finished, voidReceiver), writer)
+}
+
+func (server *httpServer) handleSnapshotUpdate(writer http.ResponseWriter, request *http.Request) {
+	var update SnapshotUpdate
+	if !server.decode(writer, request, &update) {
+		return
+	}
+	server.handleError(server.handler.UpdateSnapshot(update, voidReceiver), writer)
+}
+
+func (server *httpServer) handleSnapshotsUsed(writer http.ResponseWriter, request *http.Request) {
+	if request.Method == "POST" {
+		var used map[string][]string
+		if !server.decode(writer, request, &used) {
+			return
+		}
+		server.handleError(server.handler.RecordSnapshotsUsed(used, voidReceiver), writer)
+		return
+	}
+	var used map[string][]string
+	if server.handleError(server.handler.SnapshotsUsed(voidSender, &used), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(used)
+}
+
 func (server *httpServer) handleUp(writer http.ResponseWriter, request *http.Request) {
 	writer.WriteHeader(http.StatusOK)
 }
@@ -36,8 +36,12 @@ func (client *rpcClient) Close() error {
 }
 
 func (client *rpcClient) poll(method string, data any) error {
+	return client.pollWithArgs(method, voidSender, data)
+}
+
+func (client *rpcClient) pollWithArgs(method string, args any, data any) error {
 	for {
-		err := client.client.Call(method, voidSender, data)
+		err := client.client.Call(method, args, data)
 		if err == nil {
 			return nil
 		}
@@ -76,6 +80,10 @@ func (client *rpcClient) PostEmitProgressReport(report types.ProgressReport) err
 	return client.client.Call("Server.EmitProgressReport", report, voidReceiver)
 }
 
+func (client *rpcClient) PostEmitStreamEvent(event types.StreamEvent) error {
+	return client.client.Call("Server.EmitStreamEvent", event, voidReceiver)
+}
+
 func (client *rpcClient) PostReportBeforeSuiteCompleted(state types.SpecState) error {
 	return client.client.Call("Server.ReportBeforeSuiteCompleted", state, voidReceiver)
 }
@@ -110,6 +118,10 @@ func (client *rpcClient) BlockUntilNonprimaryProcsHaveFinished() error {
 	return client.poll("Server.HaveNonprimaryProcsFinished", voidReceiver)
 }
 
+func (client *rpcClient) BlockUntilNonprimaryProcsHaveFinishedRunningSpecs() error {
+	return client.poll("Server.HaveNonprimaryProcsFinishedRunningSpecs", voidReceiver)
+}
+
 func (client *rpcClient) BlockUntilAggregatedNonprimaryProcsReport() (types.Report, error) {
 	var report types.Report
 	err := client.poll("Server.AggregatedNonprimaryProcsReport", &report)
@@ -125,6 +137,71 @@ func (client *rpcClient) FetchNextCounter() (int, error) {
 	return counter, err
 }
 
+func (client *rpcClient) PostSpecIDState(specID string, state types.SpecState) error {
+	return client.client.Call("Server.SpecIDStateCompleted", SpecIDState{SpecID: specID, State: state}, voidReceiver)
+}
+
+func (client *rpcClient) BlockUntilSpecIDStates(expected map[string]int) (map[string][]types.SpecState, error) {
+	var states map[string][]types.SpecState
+	err := client.pollWithArgs("Server.SpecIDStates", expected, &states)
+	return states, err
+}
+
+func (client *rpcClient) ClaimSharedFixture(name string, proc int) (bool, error) {
+	var claimed bool
+	err := client.client.Call("Server.ClaimSharedFixture", SharedFixtureState{Name: name, Proc: proc}, &claimed)
+	return claimed, err
+}
+
+func (client *rpcClient) PostSharedFixtureState(state SharedFixtureState) error {
+	return client.client.Call("Server.SharedFixtureCompleted", state, voidReceiver)
+}
+
+func (client *rpcClient) BlockUntilSharedFixtureState(name string) (SharedFixtureState, error) {
+	var state SharedFixtureState
+	err := client.pollWithArgs("Server.SharedFixtureState", name, &state)
+	return state, err
+}
+
+func (client *rpcClient) PostSharedFixtureUserFinished(name string, users int) error {
+	return client.client.Call("Server.SharedFixtureUserFinished", SharedFixtureUsers{Name: name, Users: users}, voidReceiver)
+}
+
+func (client *rpcClient) PostFinishedRunningSpecs(proc int, awaitingSharedFixtureUsers bool) error {
+	return client.client.Call("Server.FinishedRunningSpecs", FinishedRunningSpecs{Proc: proc, AwaitingSharedFixtureUsers: awaitingSharedFixtureUsers}, voidReceiver)
+}
+
+func (client *rpcClient) FetchSharedFixtureReleased(name string, proc int) (bool, error) {
+	err := client.client.Call("Server.SharedFixtureReleased", SharedFixtureState{Name: name, Proc: proc}, voidReceiver)
+	switch {
+	case err == nil:
+		return true, nil
+	case err.Error() == ErrorEarly.Error():
+		return false, nil
+	case err.Error() == ErrorGone.Error():
+		return false, ErrorGone
+	}
+	return false, err
+}
+
+func (client *rpcClient) BlockUntilSharedFixtureReleased(name string, proc int) error {
+	return client.pollWithArgs("Server.SharedFixtureReleased", SharedFixtureState{Name: name, Proc: proc}, voidReceiver)
+}
+
+func (client *rpcClient) PostSnapshotUpdate(update SnapshotUpdate) error {
+	return client.client.Call("Server.UpdateSnapshot", update, voidReceiver)
+}
+
+func (client *rpcClient) PostSnapshotsUsed(used map[string][]string) error {
+	return client.client.Call("Server.RecordSnapshotsUsed", used, voidReceiver)
+}
+
+func (client *rpcClient) FetchSnapshotsUsed() (map[string][]string, error) {
+	var used map[string][]string
+	err := client.client.Call("Server.SnapshotsUsed", voidSender, &used)
+	return used, err
+}
+
 func (client *rpcClient) PostAbort() error {
 	return client.client.Call("Server.Abort", voidSender, voidReceiver)
 }
@@ -25,9 +25,9 @@ type RPCServer struct {
 	handler  *ServerHandler
 }
 
-// Create a new server, automatically selecting a port
-func newRPCServer(parallelTotal int, reporter reporters.Reporter) (*RPCServer, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+// Create a new server listening on address.  Use port 0 to automatically select a port
+func newRPCServer(address string, parallelTotal int, reporter reporters.Reporter) (*RPCServer, error) {
+	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +70,10 @@ func (server *RPCServer) SetOutputDestination(w io.Writer) {
 	server.handler.outputDestination = w
 }
 
+func (server *RPCServer) SetEventStream(stream *reporters.EventStream) {
+	server.handler.eventStream = stream
+}
+
 func (server *RPCServer) RegisterAlive(node int, alive func() bool) {
 	server.handler.registerAlive(node, alive)
 }
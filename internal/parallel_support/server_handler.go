@@ -1,6 +1,7 @@
 package parallel_support
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"sync"
@@ -21,6 +22,7 @@ type ServerHandler struct {
 	done                   chan any
 	outputDestination      io.Writer
 	reporter               reporters.Reporter
+	eventStream            *reporters.EventStream
 	alives                 []func() bool
 	lock                   *sync.Mutex
 	beforeSuiteState       BeforeSuiteState
@@ -29,20 +31,33 @@ type ServerHandler struct {
 	counter                int
 	counterLock            *sync.Mutex
 	shouldAbort            bool
+	specIDStates           map[string][]types.SpecState
+	sharedFixtureStates    []SharedFixtureState
+	sharedFixtureUsers     map[string]int
+	finishedRunningSpecs   map[int]bool
+	snapshotsUsed          map[string][]string
 
 	numSuiteDidBegins int
 	numSuiteDidEnds   int
 	aggregatedReport  types.Report
 	reportHoldingArea []types.SpecReport
+
+	numStreamSuiteWillBegins int
+	numStreamSuiteDidEnds    int
+	aggregatedStreamReport   types.Report
 }
 
 func newServerHandler(parallelTotal int, reporter reporters.Reporter) *ServerHandler {
 	return &ServerHandler{
-		reporter:         reporter,
-		lock:             &sync.Mutex{},
-		counterLock:      &sync.Mutex{},
-		alives:           make([]func() bool, parallelTotal),
-		beforeSuiteState: BeforeSuiteState{Data: nil, State: types.SpecStateInvalid},
+		reporter:             reporter,
+		lock:                 &sync.Mutex{},
+		counterLock:          &sync.Mutex{},
+		alives:               make([]func() bool, parallelTotal),
+		beforeSuiteState:     BeforeSuiteState{Data: nil, State: types.SpecStateInvalid},
+		specIDStates:         map[string][]types.SpecState{},
+		sharedFixtureUsers:   map[string]int{},
+		finishedRunningSpecs: map[int]bool{},
+		snapshotsUsed:        map[string][]string{},
 
 		parallelTotal:     parallelTotal,
 		outputDestination: os.Stdout,
@@ -117,6 +132,39 @@ func (handler *ServerHandler) EmitProgressReport(report types.ProgressReport, _
 	return nil
 }
 
+// EmitStreamEvent forwards an event emitted by a parallel process to the --event-stream.  Every process emits its own SuiteWillBegin and SuiteDidEnd events - the first SuiteWillBegin is forwarded and the SuiteDidEnd events are aggregated into a single event once all the processes have finished.
+func (handler *ServerHandler) EmitStreamEvent(event types.StreamEvent, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if handler.eventStream == nil {
+		return nil
+	}
+
+	switch event.Type {
+	case types.StreamEventSuiteWillBegin:
+		handler.numStreamSuiteWillBegins += 1
+		if handler.numStreamSuiteWillBegins > 1 {
+			return nil
+		}
+	case types.StreamEventSuiteDidEnd:
+		handler.numStreamSuiteDidEnds += 1
+		if handler.numStreamSuiteDidEnds == 1 {
+			handler.aggregatedStreamReport = *event.Report
+		} else {
+			handler.aggregatedStreamReport = handler.aggregatedStreamReport.Add(*event.Report)
+		}
+		if handler.numStreamSuiteDidEnds < handler.parallelTotal {
+			return nil
+		}
+		if handler.parallelTotal > 1 {
+			event.ParallelProcess = 0
+		}
+		event.Report = &handler.aggregatedStreamReport
+	}
+
+	return handler.eventStream.Emit(event)
+}
+
 func (handler *ServerHandler) registerAlive(proc int, alive func() bool) {
 	handler.lock.Lock()
 	defer handler.lock.Unlock()
@@ -219,6 +267,173 @@ func (handler *ServerHandler) Counter(_ Void, counter *int) error {
 	return nil
 }
 
+func (handler *ServerHandler) SpecIDStateCompleted(specIDState SpecIDState, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	handler.specIDStates[specIDState.SpecID] = append(handler.specIDStates[specIDState.SpecID], specIDState.State)
+	return nil
+}
+
+// SpecIDStates returns the states recorded for each SpecID once the expected number of states have been recorded.  If they haven't and the only process left running is the one asking then the processes that were running the specs have disappeared.
+func (handler *ServerHandler) SpecIDStates(expected map[string]int, specIDStates *map[string][]types.SpecState) error {
+	numAlive := 0
+	for proc := 1; proc <= handler.parallelTotal; proc++ {
+		if handler.procIsAlive(proc) {
+			numAlive += 1
+		}
+	}
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	out := map[string][]types.SpecState{}
+	for specID, count := range expected {
+		if len(handler.specIDStates[specID]) < count {
+			if numAlive > 1 {
+				return ErrorEarly
+			}
+			return ErrorGone
+		}
+		out[specID] = handler.specIDStates[specID]
+	}
+	*specIDStates = out
+	return nil
+}
+
+// ClaimSharedFixture records that the passed-in process will set up the named SharedFixture.  Only the first process to claim a fixture gets to set it up.
+func (handler *ServerHandler) ClaimSharedFixture(claim SharedFixtureState, claimed *bool) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if handler.sharedFixtureState(claim.Name) != nil {
+		*claimed = false
+		return nil
+	}
+	handler.sharedFixtureStates = append(handler.sharedFixtureStates, SharedFixtureState{Name: claim.Name, Proc: claim.Proc, State: types.SpecStateInvalid})
+	*claimed = true
+	return nil
+}
+
+func (handler *ServerHandler) SharedFixtureCompleted(sharedFixtureState SharedFixtureState, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if state := handler.sharedFixtureState(sharedFixtureState.Name); state != nil {
+		*state = sharedFixtureState
+	}
+	return nil
+}
+
+func (handler *ServerHandler) SharedFixtureState(name string, sharedFixtureState *SharedFixtureState) error {
+	handler.lock.Lock()
+	state := handler.sharedFixtureState(name)
+	if state == nil || state.State == types.SpecStateInvalid {
+		proc := 0
+		if state != nil {
+			proc = state.Proc
+		}
+		handler.lock.Unlock()
+		if proc == 0 || handler.procIsAlive(proc) {
+			return ErrorEarly
+		}
+		return ErrorGone
+	}
+	defer handler.lock.Unlock()
+	*sharedFixtureState = *state
+	return nil
+}
+
+// SharedFixtureUserFinished counts down the specs that use the named SharedFixture.  Every process knows how many specs declared that they use the fixture so the count starts from the number of users sent by whichever process reports first.
+func (handler *ServerHandler) SharedFixtureUserFinished(users SharedFixtureUsers, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	remaining, ok := handler.sharedFixtureUsers[users.Name]
+	if !ok {
+		remaining = users.Users
+	}
+	handler.sharedFixtureUsers[users.Name] = max(remaining-1, 0)
+	return nil
+}
+
+// SharedFixtureReleased succeeds once every spec that uses the named SharedFixture has finished.  If the fixture still has users but every process other than the owner has exited or finished running specs those users will never finish and SharedFixtureReleased returns ErrorGone.
+func (handler *ServerHandler) SharedFixtureReleased(owner SharedFixtureState, _ *Void) error {
+	handler.lock.Lock()
+	remaining, ok := handler.sharedFixtureUsers[owner.Name]
+	handler.lock.Unlock()
+	if ok && remaining == 0 {
+		return nil
+	}
+	for proc := 1; proc <= handler.parallelTotal; proc++ {
+		if proc != owner.Proc && !handler.hasFinishedRunningSpecs(proc) {
+			return ErrorEarly
+		}
+	}
+	return ErrorGone
+}
+
+func (handler *ServerHandler) FinishedRunningSpecs(finished FinishedRunningSpecs, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	handler.finishedRunningSpecs[finished.Proc] = finished.AwaitingSharedFixtureUsers
+	return nil
+}
+
+// HaveNonprimaryProcsFinishedRunningSpecs succeeds once every non-primary process has exited or is only waiting to tear down its SharedFixtures.  Proc 1 waits for this before running Serial specs - which may well be the users those processes are waiting for.
+func (handler *ServerHandler) HaveNonprimaryProcsFinishedRunningSpecs(_ Void, _ *Void) error {
+	for proc := 2; proc <= handler.parallelTotal; proc++ {
+		handler.lock.Lock()
+		awaitingSharedFixtureUsers := handler.finishedRunningSpecs[proc]
+		handler.lock.Unlock()
+		if !awaitingSharedFixtureUsers && handler.procIsAlive(proc) {
+			return ErrorEarly
+		}
+	}
+	return nil
+}
+
+func (handler *ServerHandler) hasFinishedRunningSpecs(proc int) bool {
+	handler.lock.Lock()
+	_, finished := handler.finishedRunningSpecs[proc]
+	handler.lock.Unlock()
+	return finished || !handler.procIsAlive(proc)
+}
+
+func (handler *ServerHandler) sharedFixtureState(name string) *SharedFixtureState {
+	for i := range handler.sharedFixtureStates {
+		if handler.sharedFixtureStates[i].Name == name {
+			return &handler.sharedFixtureStates[i]
+		}
+	}
+	return nil
+}
+
+// UpdateSnapshot applies a SnapshotUpdate.  Updates are applied one at a time so that concurrent updates to the same snapshot file are not lost.  Only snapshot files (see types.SnapshotPath) can be updated - the server won't write anywhere else on behalf of its clients.
+func (handler *ServerHandler) UpdateSnapshot(update SnapshotUpdate, _ *Void) error {
+	if !types.IsSnapshotPath(update.Path) {
+		return fmt.Errorf("refusing to update %s: it is not a snapshot file", update.Path)
+	}
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	return types.UpdateSnapshot(update.Path, update.Key, update.Value)
+}
+
+// RecordSnapshotsUsed records the snapshot keys, grouped by snapshot file, that a process matched during its run
+func (handler *ServerHandler) RecordSnapshotsUsed(used map[string][]string, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	for path, keys := range used {
+		handler.snapshotsUsed[path] = append(handler.snapshotsUsed[path], keys...)
+	}
+	return nil
+}
+
+func (handler *ServerHandler) SnapshotsUsed(_ Void, used *map[string][]string) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	out := map[string][]string{}
+	for path, keys := range handler.snapshotsUsed {
+		out[path] = append([]string{}, keys...)
+	}
+	*used = out
+	return nil
+}
+
 func (handler *ServerHandler) Abort(_ Void, _ *Void) error {
 	handler.lock.Lock()
 	defer handler.lock.Unlock()
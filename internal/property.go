@@ -0,0 +1,360 @@
+package internal
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const DefaultPropertyNumRuns = 100
+const DefaultPropertyMaxShrinks = 1000
+
+// PropertyConfig is the decorator that turns an It into a property.  Its body is run NumRuns times with inputs drawn from Generators.
+type PropertyConfig struct {
+	NumRuns    int
+	MaxShrinks int
+}
+
+// PropertyOption configures a Property decorator
+type PropertyOption interface {
+	applyToProperty(*PropertyConfig)
+}
+
+// NumRuns is the number of times a property's body is run with freshly generated inputs
+type NumRuns uint
+
+func (n NumRuns) applyToProperty(config *PropertyConfig) {
+	config.NumRuns = int(n)
+}
+
+// MaxShrinks is the number of times Ginkgo will re-run a failing property's body while searching for a smaller counterexample
+type MaxShrinks uint
+
+func (n MaxShrinks) applyToProperty(config *PropertyConfig) {
+	config.MaxShrinks = int(n)
+}
+
+func NewPropertyConfig(options ...PropertyOption) PropertyConfig {
+	config := PropertyConfig{NumRuns: DefaultPropertyNumRuns, MaxShrinks: DefaultPropertyMaxShrinks}
+	for _, option := range options {
+		option.applyToProperty(&config)
+	}
+	return config
+}
+
+/*
+Generators generate the inputs for a property.
+
+Every value is derived from a sequence of random choices.  When a property fails Ginkgo shrinks the counterexample by replaying the body with shorter sequences and smaller choices - so generated values shrink towards zero, the minimum of their range, empty strings and slices, and false.
+*/
+type Generators interface {
+	// Bool returns true or false
+	Bool() bool
+	// Int returns an int that grows in magnitude as the runs progress
+	Int() int
+	// IntBetween returns an int in [min, max]
+	IntBetween(min, max int) int
+	// Float64 returns a float64 in [0, 1)
+	Float64() float64
+	// Float64Between returns a float64 in [min, max]
+	Float64Between(min, max float64) float64
+	// String returns a string of printable ASCII characters whose length grows as the runs progress
+	String() string
+	// StringFrom returns a string made up of characters in alphabet whose length grows as the runs progress
+	StringFrom(alphabet string) string
+	// Bytes returns a []byte whose length grows as the runs progress
+	Bytes() []byte
+}
+
+var generatorsType = reflect.TypeOf(new(Generators)).Elem()
+
+// the first character shrinks best
+const printableAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+type generators struct {
+	rand *rand.Rand
+	size int
+
+	// prefix is replayed while shrinking.  Choices beyond the prefix are zero.
+	prefix  []uint64
+	choices []uint64
+	values  []string
+}
+
+func newGenerators(r *rand.Rand, size int, prefix []uint64) *generators {
+	return &generators{rand: r, size: size, prefix: prefix}
+}
+
+// choose returns a choice in [0, max]
+func (g *generators) choose(max uint64) uint64 {
+	var choice uint64
+	if g.prefix != nil {
+		if len(g.choices) < len(g.prefix) {
+			choice = min(g.prefix[len(g.choices)], max)
+		}
+	} else if max == ^uint64(0) {
+		choice = g.rand.Uint64()
+	} else {
+		choice = g.rand.Uint64N(max + 1)
+	}
+	g.choices = append(g.choices, choice)
+	return choice
+}
+
+func (g *generators) record(call string, value any) {
+	g.values = append(g.values, fmt.Sprintf("%s = %#v", call, value))
+}
+
+func (g *generators) Bool() bool {
+	value := g.choose(1) == 1
+	g.record("Bool()", value)
+	return value
+}
+
+func (g *generators) Int() int {
+	magnitude := g.choose(uint64(1)<<min(62, g.size*62/100+1) - 1)
+	value := int(magnitude)
+	if g.choose(1) == 1 {
+		value = -value
+	}
+	g.record("Int()", value)
+	return value
+}
+
+func (g *generators) IntBetween(min, max int) int {
+	if max < min {
+		panic(fmt.Sprintf("IntBetween: max (%d) must not be less than min (%d)", max, min))
+	}
+	value := int(uint64(min) + g.choose(uint64(max)-uint64(min)))
+	g.record(fmt.Sprintf("IntBetween(%d, %d)", min, max), value)
+	return value
+}
+
+func (g *generators) float64() float64 {
+	return float64(g.choose(1<<53-1)) / (1 << 53)
+}
+
+func (g *generators) Float64() float64 {
+	value := g.float64()
+	g.record("Float64()", value)
+	return value
+}
+
+func (g *generators) Float64Between(min, max float64) float64 {
+	if max < min {
+		panic(fmt.Sprintf("Float64Between: max (%g) must not be less than min (%g)", max, min))
+	}
+	value := min + (max-min)*float64(g.choose(1<<53))/(1<<53)
+	g.record(fmt.Sprintf("Float64Between(%g, %g)", min, max), value)
+	return value
+}
+
+func (g *generators) stringFrom(alphabet []rune) string {
+	out := make([]rune, g.choose(uint64(g.size)))
+	for i := range out {
+		out[i] = alphabet[g.choose(uint64(len(alphabet)-1))]
+	}
+	return string(out)
+}
+
+func (g *generators) String() string {
+	value := g.stringFrom([]rune(printableAlphabet))
+	g.record("String()", value)
+	return value
+}
+
+func (g *generators) StringFrom(alphabet string) string {
+	if alphabet == "" {
+		panic("StringFrom: alphabet must not be empty")
+	}
+	value := g.stringFrom([]rune(alphabet))
+	g.record(fmt.Sprintf("StringFrom(%q)", alphabet), value)
+	return value
+}
+
+func (g *generators) Bytes() []byte {
+	value := make([]byte, g.choose(uint64(g.size)))
+	for i := range value {
+		value[i] = byte(g.choose(255))
+	}
+	g.record("Bytes()", value)
+	return value
+}
+
+// propertySize grows from 1 to 100 as the runs progress so that early runs try small inputs
+func propertySize(run int, numRuns int) int {
+	if numRuns <= 1 {
+		return 100
+	}
+	return 1 + run*99/(numRuns-1)
+}
+
+// PropertySeed is the seed used to generate the inputs for the property at cl.  Different properties get different inputs even though they share the suite's seed.
+func PropertySeed(randomSeed int64, cl types.CodeLocation) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s:%d", cl.FileName, cl.LineNumber)
+	return randomSeed ^ int64(h.Sum64())
+}
+
+/*
+ShrinkChoices searches for a sequence of choices that is shorter - or, if it is the same length, smaller - than choices and that still makes the property fail.
+
+fails runs the property with a candidate sequence and returns the choices the property actually consumed along with whether it failed in the same way.  ShrinkChoices calls fails at most maxShrinks times and returns the smallest failing sequence it found along with the number of times it found a smaller one.
+*/
+func ShrinkChoices(choices []uint64, maxShrinks int, fails func([]uint64) ([]uint64, bool)) ([]uint64, int) {
+	current := choices
+	attempts, shrinks := 0, 0
+	try := func(candidate []uint64) bool {
+		if attempts >= maxShrinks || !choicesLess(candidate, current) {
+			return false
+		}
+		attempts += 1
+		consumed, failed := fails(candidate)
+		if !failed || !choicesLess(consumed, current) {
+			return false
+		}
+		current = consumed
+		shrinks += 1
+		return true
+	}
+
+	improved := true
+	for improved && attempts < maxShrinks {
+		improved = false
+		for _, k := range []int{8, 4, 2, 1} {
+			for i := 0; i+k <= len(current); {
+				candidate := append(append([]uint64{}, current[:i]...), current[i+k:]...)
+				if try(candidate) {
+					improved = true
+				} else {
+					i += 1
+				}
+			}
+		}
+		for i := 0; i < len(current); i++ {
+			lo, hi := uint64(0), current[i]
+			for lo < hi {
+				mid := lo + (hi-lo)/2
+				candidate := append([]uint64{}, current...)
+				candidate[i] = mid
+				if try(candidate) {
+					improved = true
+					if i >= len(current) {
+						break
+					}
+					hi = current[i]
+				} else {
+					lo = mid + 1
+				}
+			}
+		}
+	}
+	return current, shrinks
+}
+
+// choicesLess returns true if a is shorter than b or, if they have the same length, lexicographically smaller
+func choicesLess(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// runProperty is the body Ginkgo runs for an It decorated with Property
+func runProperty(sc SpecContext, cl types.CodeLocation, config PropertyConfig, body func(SpecContext, Generators)) {
+	suite := sc.(*specContext).suite
+	report := suite.CurrentSpecReport()
+	seed := PropertySeed(suite.config.RandomSeed, cl)
+	// MustPassRepeatedly should explore new inputs with each attempt.  FlakeAttempts, on the other hand, retries the inputs that failed.
+	if report.MaxMustPassRepeatedly > 1 && report.NumAttempts > 1 {
+		seed += int64(report.NumAttempts - 1)
+	}
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)>>32))
+
+	for run := 0; run < config.NumRuns; run++ {
+		if sc.Err() != nil {
+			return
+		}
+		size := propertySize(run, config.NumRuns)
+		g := newGenerators(r, size, nil)
+		state, failure := suite.runPropertyTrial(sc, body, g)
+		switch {
+		case state == types.SpecStatePassed:
+			continue
+		case state.Is(types.SpecStateFailed | types.SpecStatePanicked):
+			suite.reportPropertyFailure(sc, cl, config, body, run, size, g, state, failure)
+		default:
+			// a skip, an abort, or a timeout stop the property
+			suite.failer.Record(state, failure)
+		}
+		return
+	}
+
+	entry, _ := NewReportEntry("Property", cl, types.ReportEntryVisibilityFailureOrVerbose, fmt.Sprintf("Passed %d runs", config.NumRuns))
+	suite.AddReportEntry(entry)
+}
+
+// reportPropertyFailure shrinks the counterexample that made the property fail and records the failure
+func (suite *Suite) reportPropertyFailure(sc SpecContext, cl types.CodeLocation, config PropertyConfig, body func(SpecContext, Generators), run int, size int, g *generators, state types.SpecState, failure types.Failure) {
+	// we only shrink towards counterexamples that fail in the same place, lest we end up reporting a different bug
+	sameFailure := func(s types.SpecState, f types.Failure) bool {
+		return s == state && f.Location.FileName == failure.Location.FileName && f.Location.LineNumber == failure.Location.LineNumber
+	}
+
+	choices, shrinks := ShrinkChoices(g.choices, config.MaxShrinks, func(candidate []uint64) ([]uint64, bool) {
+		if sc.Err() != nil {
+			return nil, false
+		}
+		cg := newGenerators(nil, size, candidate)
+		s, f := suite.runPropertyTrial(sc, body, cg)
+		return cg.choices, sameFailure(s, f)
+	})
+	if shrinks > 0 {
+		mg := newGenerators(nil, size, choices)
+		if s, f := suite.runPropertyTrial(sc, body, mg); sameFailure(s, f) {
+			g, failure = mg, f
+		} else {
+			shrinks = 0
+		}
+	}
+
+	summary := &strings.Builder{}
+	fmt.Fprintf(summary, "Failed on run %d of %d (--seed=%d).", run+1, config.NumRuns, suite.config.RandomSeed)
+	if shrinks > 0 {
+		fmt.Fprintf(summary, "  Shrunk %d times to this minimal counterexample:", shrinks)
+	} else {
+		fmt.Fprintf(summary, "  Counterexample:")
+	}
+	for _, value := range g.values {
+		fmt.Fprintf(summary, "\n  %s", value)
+	}
+	if len(g.values) == 0 {
+		fmt.Fprintf(summary, "\n  (no values were generated)")
+	}
+
+	entry, _ := NewReportEntry("Property", cl, summary.String())
+	suite.AddReportEntry(entry)
+	failure.Message = failure.Message + "\n\nProperty " + summary.String()
+	suite.failer.Record(state, failure)
+}
+
+// runPropertyTrial runs the property's body once, returning its outcome
+func (suite *Suite) runPropertyTrial(sc SpecContext, body func(SpecContext, Generators), g *generators) (state types.SpecState, failure types.Failure) {
+	defer func() {
+		if e := recover(); e != nil {
+			suite.failer.Panic(types.NewCodeLocationWithStackTrace(2), e)
+		}
+		state, failure = suite.failer.Drain()
+	}()
+	body(sc, g)
+	return
+}
@@ -0,0 +1,85 @@
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Properties", func() {
+	Describe("NewPropertyConfig", func() {
+		It("applies the defaults and the options", func() {
+			Ω(internal.NewPropertyConfig()).Should(Equal(internal.PropertyConfig{NumRuns: 100, MaxShrinks: 1000}))
+			Ω(internal.NewPropertyConfig(internal.NumRuns(3), internal.MaxShrinks(0))).Should(Equal(internal.PropertyConfig{NumRuns: 3, MaxShrinks: 0}))
+		})
+	})
+
+	Describe("PropertySeed", func() {
+		It("is stable for a given seed and location, but differs between locations", func() {
+			clA := types.CodeLocation{FileName: "a_test.go", LineNumber: 3}
+			clB := types.CodeLocation{FileName: "a_test.go", LineNumber: 4}
+			Ω(internal.PropertySeed(17, clA)).Should(Equal(internal.PropertySeed(17, clA)))
+			Ω(internal.PropertySeed(17, clA)).ShouldNot(Equal(internal.PropertySeed(17, clB)))
+			Ω(internal.PropertySeed(17, clA)).ShouldNot(Equal(internal.PropertySeed(18, clA)))
+		})
+	})
+
+	Describe("ShrinkChoices", func() {
+		var calls int
+		BeforeEach(func() {
+			calls = 0
+		})
+
+		// sumExceeds fails when the choices sum to more than n, consuming every choice it is given
+		sumExceeds := func(n uint64) func([]uint64) ([]uint64, bool) {
+			return func(choices []uint64) ([]uint64, bool) {
+				calls += 1
+				sum := uint64(0)
+				for _, c := range choices {
+					sum += c
+				}
+				return choices, sum > n
+			}
+		}
+
+		It("deletes choices and minimizes the ones that remain", func() {
+			choices, shrinks := internal.ShrinkChoices([]uint64{3, 900, 12, 0, 7, 81}, 1000, sumExceeds(10))
+			Ω(choices).Should(Equal([]uint64{11}))
+			Ω(shrinks).Should(BeNumerically(">", 0))
+		})
+
+		It("only accepts the choices the property actually consumed", func() {
+			// a property that consumes only the first choice and fails when it is at least 5
+			choices, _ := internal.ShrinkChoices([]uint64{9, 1, 2}, 1000, func(candidate []uint64) ([]uint64, bool) {
+				if len(candidate) == 0 {
+					return []uint64{0}, false
+				}
+				return candidate[:1], candidate[0] >= 5
+			})
+			Ω(choices).Should(Equal([]uint64{5}))
+		})
+
+		It("returns the original choices when nothing smaller fails", func() {
+			choices, shrinks := internal.ShrinkChoices([]uint64{0}, 1000, func(candidate []uint64) ([]uint64, bool) {
+				return candidate, len(candidate) == 1
+			})
+			Ω(choices).Should(Equal([]uint64{0}))
+			Ω(shrinks).Should(BeZero())
+		})
+
+		It("respects maxShrinks", func() {
+			choices, shrinks := internal.ShrinkChoices([]uint64{1000, 1000}, 3, sumExceeds(10))
+			Ω(calls).Should(Equal(3))
+			Ω(shrinks).Should(BeNumerically("<=", 3))
+			Ω(choices).ShouldNot(Equal([]uint64{11}))
+
+			calls = 0
+			choices, shrinks = internal.ShrinkChoices([]uint64{1000, 1000}, 0, sumExceeds(10))
+			Ω(calls).Should(BeZero())
+			Ω(shrinks).Should(BeZero())
+			Ω(choices).Should(Equal([]uint64{1000, 1000}))
+		})
+	})
+})
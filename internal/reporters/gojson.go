@@ -21,6 +21,7 @@ type encoder interface {
 // gojsonEvent matches the format from go internals
 // https://github.com/golang/go/blob/master/src/cmd/internal/test2json/test2json.go#L31-L41
 // https://pkg.go.dev/cmd/test2json
+// with the addition of SpecID which identifies the spec in events that pertain to a spec (see types.SpecReport.ID)
 type gojsonEvent struct {
 	Time        *time.Time `json:",omitempty"`
 	Action      GoJSONAction
@@ -29,6 +30,7 @@ type gojsonEvent struct {
 	Elapsed     *float64 `json:",omitempty"`
 	Output      *string  `json:",omitempty"`
 	FailedBuild string   `json:",omitempty"`
+	SpecID      string   `json:",omitempty"`
 }
 
 type GoJSONAction string
@@ -62,6 +64,8 @@ func goJSONActionFromSpecState(state types.SpecState) GoJSONAction {
 		return GoJSONSkip
 	case types.SpecStateSkipped:
 		return GoJSONSkip
+	case types.SpecStateQuarantined:
+		return GoJSONSkip
 	case types.SpecStatePassed:
 		return GoJSONPass
 	case types.SpecStateFailed:
@@ -55,6 +55,7 @@ func (r *GoJSONEventWriter) WriteSpecStart(report *gojsonReport, specReport *goj
 		Time:        &specReport.o.StartTime,
 		Action:      GoJSONRun,
 		Test:        specReport.testName,
+		SpecID:      specReport.o.ID,
 		Package:     report.goPkg,
 		Output:      nil,
 		FailedBuild: "",
@@ -71,6 +72,7 @@ func (r *GoJSONEventWriter) WriteSpecOut(report *gojsonReport, specReport *gojso
 			Time:        &specReport.o.EndTime,
 			Action:      GoJSONOutput,
 			Test:        specReport.testName,
+			SpecID:      specReport.o.ID,
 			Package:     report.goPkg,
 			Output:      ptr(stdErr),
 			FailedBuild: "",
@@ -82,6 +84,7 @@ func (r *GoJSONEventWriter) WriteSpecOut(report *gojsonReport, specReport *gojso
 			Time:        &specReport.o.EndTime,
 			Action:      GoJSONOutput,
 			Test:        specReport.testName,
+			SpecID:      specReport.o.ID,
 			Package:     report.goPkg,
 			Output:      ptr(stdOut),
 			FailedBuild: "",
@@ -102,6 +105,7 @@ func (r *GoJSONEventWriter) WriteSpecResult(report *gojsonReport, specReport *go
 		Time:        &specReport.o.EndTime,
 		Action:      specReport.action,
 		Test:        specReport.testName,
+		SpecID:      specReport.o.ID,
 		Package:     report.goPkg,
 		Elapsed:     ptr(specReport.elapsed),
 		Output:      nil,
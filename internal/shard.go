@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type shardGroup struct {
+	specIndices SpecIndices
+	size        int
+}
+
+/*
+ApplyShardToSpecs partitions specs into the number of shards requested by --shard=i/n and returns only the specs in the i-th shard.
+
+Sharding must be deterministic across independent invocations of the suite (which won't, in general, share a random seed) so the partition only depends on the specs themselves:
+
+- Specs are first grouped into units that must not be split up.  Specs in a container marked Serial or Ordered are kept together (the outermost such container determines the group).  Every other spec is its own group.
+- Groups that depend on one another via DependsOn are merged so that specs always land in the same shard as their prerequisites.
+- Groups are sorted by code location (using the same sort OrderSpecs uses to arrive at a deterministic order) and then, largest first, assigned to whichever shard has the fewest specs that will run.
+
+Skipped specs are sharded too so that each spec appears in exactly one shard's report - but they don't count towards a shard's size.
+*/
+func ApplyShardToSpecs(specs Specs, suiteConfig types.SuiteConfig) Specs {
+	if suiteConfig.Shard == "" {
+		return specs
+	}
+	shard, totalShards, err := types.ParseShard(suiteConfig.Shard)
+	if err != nil || totalShards == 1 {
+		return specs
+	}
+
+	sortableSpecs := NewSortableSpecs(specs)
+	sort.Sort(sortableSpecs)
+
+	groupIDs := []uint{}
+	groups := map[uint]*shardGroup{}
+	for _, idx := range sortableSpecs.Indexes {
+		spec := specs[idx]
+		groupNode := spec.FirstNodeWithType(types.NodeTypeIt)
+		for _, node := range spec.Nodes.WithType(types.NodeTypesForContainerAndIt) {
+			if node.MarkedSerial || node.MarkedOrdered {
+				groupNode = node
+				break
+			}
+		}
+		group, ok := groups[groupNode.ID]
+		if !ok {
+			group = &shardGroup{}
+			groups[groupNode.ID] = group
+			groupIDs = append(groupIDs, groupNode.ID)
+		}
+		group.specIndices = append(group.specIndices, idx)
+		if !spec.Skip {
+			group.size += 1
+		}
+	}
+
+	groupIDs = mergeShardGroupsWithDependencies(specs, groupIDs, groups)
+
+	sort.SliceStable(groupIDs, func(i, j int) bool {
+		return groups[groupIDs[i]].size > groups[groupIDs[j]].size
+	})
+
+	shardSizes := make([]int, totalShards)
+	inShard := make([]bool, len(specs))
+	for _, groupID := range groupIDs {
+		group := groups[groupID]
+		smallestShard := 0
+		for i := range shardSizes {
+			if shardSizes[i] < shardSizes[smallestShard] {
+				smallestShard = i
+			}
+		}
+		shardSizes[smallestShard] += group.size
+		if smallestShard == shard-1 {
+			for _, idx := range group.specIndices {
+				inShard[idx] = true
+			}
+		}
+	}
+
+	out := Specs{}
+	for idx, spec := range specs {
+		if inShard[idx] {
+			out = append(out, spec)
+		}
+	}
+	return out
+}
+
+// mergeShardGroupsWithDependencies merges groups that are connected by DependsOn into the first such group (in groupIDs order) and returns the IDs of the groups that remain
+func mergeShardGroupsWithDependencies(specs Specs, groupIDs []uint, groups map[uint]*shardGroup) []uint {
+	d := newSpecDependencies(specs)
+	if d.IsZero() {
+		return groupIDs
+	}
+
+	groupIDForSubjectID := map[uint]uint{}
+	for _, groupID := range groupIDs {
+		for _, idx := range groups[groupID].specIndices {
+			groupIDForSubjectID[specs[idx].SubjectID()] = groupID
+		}
+	}
+	position := map[uint]int{}
+	for i, groupID := range groupIDs {
+		position[groupID] = i
+	}
+	parent := map[uint]uint{}
+	var root func(groupID uint) uint
+	root = func(groupID uint) uint {
+		if p, ok := parent[groupID]; ok && p != groupID {
+			parent[groupID] = root(p)
+			return parent[groupID]
+		}
+		return groupID
+	}
+	for _, spec := range specs {
+		for _, subjectID := range d.prerequisiteSpecsFor(spec) {
+			a, b := root(groupIDForSubjectID[spec.SubjectID()]), root(groupIDForSubjectID[subjectID])
+			if a == b {
+				continue
+			}
+			if position[b] < position[a] {
+				a, b = b, a
+			}
+			parent[b] = a
+		}
+	}
+
+	out := []uint{}
+	for _, groupID := range groupIDs {
+		if r := root(groupID); r != groupID {
+			groups[r].specIndices = append(groups[r].specIndices, groups[groupID].specIndices...)
+			groups[r].size += groups[groupID].size
+		} else {
+			out = append(out, groupID)
+		}
+	}
+	return out
+}
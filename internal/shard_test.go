@@ -0,0 +1,71 @@
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("ApplyShardToSpecs", func() {
+	var specs Specs
+
+	textsInShard := func(shard string) []string {
+		texts := []string{}
+		for _, spec := range internal.ApplyShardToSpecs(specs, types.SuiteConfig{Shard: shard}) {
+			texts = append(texts, spec.Text())
+		}
+		return texts
+	}
+
+	BeforeEach(func() {
+		ordered := N(ntCon, Ordered, CL("file_A", 10))
+		serial := N(ntCon, Serial, CL("file_B", 10))
+		skipped := S(N("I", ntIt, CL("file_C", 1)))
+		skipped.Skip = true
+		specs = Specs{
+			S(N("A", ntIt, CL("file_A", 1))),
+			S(N("B", ntIt, CL("file_A", 5))),
+			S(ordered, N("C", ntIt, CL("file_A", 15))),
+			S(ordered, N("D", ntIt, CL("file_A", 20))),
+			S(ordered, N(ntCon, CL("file_A", 25)), N("E", ntIt, CL("file_A", 30))),
+			S(N("F", ntIt, CL("file_B", 1))),
+			S(serial, N("G", ntIt, CL("file_B", 15))),
+			S(serial, N("H", ntIt, CL("file_B", 20))),
+			skipped,
+		}
+	})
+
+	It("returns all the specs when not sharding", func() {
+		Ω(internal.ApplyShardToSpecs(specs, types.SuiteConfig{})).Should(Equal(specs))
+		Ω(internal.ApplyShardToSpecs(specs, types.SuiteConfig{Shard: "1/1"})).Should(Equal(specs))
+	})
+
+	It("partitions the specs into disjoint shards that cover every spec", func() {
+		all := []string{}
+		for _, shard := range []string{"1/3", "2/3", "3/3"} {
+			all = append(all, textsInShard(shard)...)
+		}
+		Ω(all).Should(ConsistOf("A", "B", "C", "D", "E", "F", "G", "H", "I"))
+	})
+
+	It("keeps Ordered and Serial containers in a single shard, balancing by the number of specs that will run", func() {
+		Ω(textsInShard("1/3")).Should(ConsistOf("C", "D", "E"))
+		Ω(textsInShard("2/3")).Should(ConsistOf("G", "H", "F"))
+		Ω(textsInShard("3/3")).Should(ConsistOf("A", "B", "I"))
+	})
+
+	It("is deterministic and independent of the order of the specs", func() {
+		shard := textsInShard("2/3")
+		for i, j := 0, len(specs)-1; i < j; i, j = i+1, j-1 {
+			specs[i], specs[j] = specs[j], specs[i]
+		}
+		Ω(textsInShard("2/3")).Should(ConsistOf(shard))
+	})
+	It("keeps specs in the same shard as the specs they depend on", func() {
+		specs[0] = S(N("A", ntIt, DependsOn("F"), CL("file_A", 1)))
+		specs[5] = S(N("F", ntIt, SpecID("F"), CL("file_B", 1)))
+		Ω(textsInShard("2/3")).Should(ConsistOf("A", "F", "B"))
+	})
+})
@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+SharedFixture is the type-erased representation of a fixture declared with ginkgo.SharedFixture.
+
+Fixtures are set up lazily - the first time a spec asks for one.  When running in parallel the first process to ask sets the fixture up and shares its encoded value with the other processes via the parallel support server.
+
+Fixtures are always torn down on the process that set them up.  Specs declare the fixtures they use with the UsesFixture decorator so every process knows how many users each fixture has.  As each spec finishes it counts down the users of its fixtures (on the parallel support server when running in parallel) and the fixture is torn down once none remain - see tearDownReleasedSharedFixtures.  Fixtures that no spec declares it uses are torn down at the end of the suite.
+*/
+type SharedFixture struct {
+	Name         string
+	CodeLocation types.CodeLocation
+
+	Setup    func() any
+	Teardown func(any)
+	Encode   func(any) ([]byte, error)
+	Decode   func([]byte) (any, error)
+}
+
+// UsesSharedFixtures are the SharedFixtures a container or subject node declares it uses with the UsesFixture decorator
+type UsesSharedFixtures []*SharedFixture
+
+// sharedFixtureState tracks a SharedFixture's value on this process
+type sharedFixtureState struct {
+	lock     *sync.Mutex
+	resolved bool
+	value    any
+	err      error
+
+	// teardown is set on the process that set the fixture up until the fixture has been torn down
+	teardown func()
+}
+
+func (suite *Suite) RegisterSharedFixture(fixture *SharedFixture) error {
+	if suite.phase == PhaseRun {
+		return types.GinkgoErrors.SharedFixtureDuringRunPhase(fixture.CodeLocation, fixture.Name)
+	}
+	for _, existing := range suite.sharedFixtures {
+		if existing.Name == fixture.Name {
+			return types.GinkgoErrors.DuplicateSharedFixture(fixture.CodeLocation, fixture.Name, existing.CodeLocation)
+		}
+	}
+	suite.sharedFixtures = append(suite.sharedFixtures, fixture)
+	return nil
+}
+
+/*
+GetSharedFixture returns the fixture's value, setting it up if no spec has asked for it yet.
+
+Setup runs in the node that first asks for the fixture so any failures in the setup function fail that node.  Subsequent requests for a fixture that failed to set up return an error.
+
+Once any spec declares that it uses a fixture only the specs that declare it may ask for it - the fixture is torn down when they finish.
+*/
+func (suite *Suite) GetSharedFixture(fixture *SharedFixture) (any, error) {
+	suite.selectiveLock.Lock()
+	if suite.sharedFixtureUsers[fixture] > 0 && !slices.Contains(suite.currentSpecSharedFixtures, fixture) {
+		suite.selectiveLock.Unlock()
+		return nil, types.GinkgoErrors.SharedFixtureNotDeclared(fixture.Name)
+	}
+	if suite.sharedFixtureStates == nil {
+		suite.sharedFixtureStates = map[*SharedFixture]*sharedFixtureState{}
+	}
+	state, ok := suite.sharedFixtureStates[fixture]
+	if !ok {
+		state = &sharedFixtureState{lock: &sync.Mutex{}}
+		suite.sharedFixtureStates[fixture] = state
+	}
+	suite.selectiveLock.Unlock()
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if state.resolved {
+		return state.value, state.err
+	}
+
+	if !suite.isRunningInParallel() {
+		suite.setUpSharedFixture(fixture, state)
+		return state.value, state.err
+	}
+
+	claimed, err := suite.client.ClaimSharedFixture(fixture.Name, suite.config.ParallelProcess)
+	if err != nil {
+		state.resolved, state.err = true, err
+		return nil, err
+	}
+	if claimed {
+		suite.setUpSharedFixture(fixture, state)
+		return state.value, state.err
+	}
+
+	state.resolved = true
+	sharedFixtureState, err := suite.client.BlockUntilSharedFixtureState(fixture.Name)
+	switch {
+	case err == parallel_support.ErrorGone:
+		state.err = types.GinkgoErrors.SharedFixtureDisappeared(fixture.Name)
+	case err != nil:
+		state.err = err
+	case !sharedFixtureState.State.Is(types.SpecStatePassed):
+		state.err = types.GinkgoErrors.SharedFixtureSetupFailed(fixture.Name, sharedFixtureState.Proc)
+	default:
+		state.value, err = fixture.Decode(sharedFixtureState.Data)
+		if err != nil {
+			state.value, state.err = nil, types.GinkgoErrors.SharedFixtureEncodingFailed(fixture.Name, err)
+		}
+	}
+	return state.value, state.err
+}
+
+/*
+setUpSharedFixture runs the fixture's setup function on this process.  If the setup function fails (i.e. panics) the panic is allowed to propagate so that it fails the running node - but we first record the failure so that subsequent requests for the fixture fail fast.
+
+This process is then responsible for tearing the fixture down with the in-memory value returned by the setup function.  Fixtures that no spec declares it uses are torn down at the end of the suite.
+*/
+func (suite *Suite) setUpSharedFixture(fixture *SharedFixture, state *sharedFixtureState) {
+	succeeded := false
+	defer func() {
+		state.resolved = true
+		if !succeeded {
+			state.value, state.err = nil, types.GinkgoErrors.SharedFixtureSetupFailed(fixture.Name, suite.config.ParallelProcess)
+		}
+		if !suite.isRunningInParallel() {
+			return
+		}
+		sharedFixtureState := parallel_support.SharedFixtureState{Name: fixture.Name, Proc: suite.config.ParallelProcess, State: types.SpecStatePassed}
+		if state.err == nil {
+			var err error
+			sharedFixtureState.Data, err = fixture.Encode(state.value)
+			if err != nil {
+				state.err = types.GinkgoErrors.SharedFixtureEncodingFailed(fixture.Name, err)
+			}
+		}
+		if state.err != nil {
+			sharedFixtureState.State, sharedFixtureState.Data = types.SpecStateFailed, nil
+		}
+		suite.client.PostSharedFixtureState(sharedFixtureState)
+	}()
+
+	state.value = fixture.Setup()
+	succeeded = true
+	if fixture.Teardown == nil {
+		return
+	}
+	value := state.value
+	state.teardown = func() { fixture.Teardown(value) }
+	if suite.sharedFixtureUsers[fixture] == 0 {
+		suite.addSharedFixtureTeardownNode(fixture, state)
+	}
+}
+
+// countSharedFixtureUsers counts the specs that declare they use each SharedFixture.  Every process arrives at the same counts.
+func countSharedFixtureUsers(specs Specs) map[*SharedFixture]int {
+	users := map[*SharedFixture]int{}
+	for _, spec := range specs {
+		for _, fixture := range spec.Nodes.UnionOfSharedFixtures() {
+			users[fixture] += 1
+		}
+	}
+	return users
+}
+
+// sharedFixtureUserFinished is called after each spec has been reported - whether or not it ran - and counts it down from the users of the fixtures it uses
+func (suite *Suite) sharedFixtureUserFinished(spec Spec) {
+	for _, fixture := range spec.Nodes.UnionOfSharedFixtures() {
+		if suite.isRunningInParallel() {
+			suite.client.PostSharedFixtureUserFinished(fixture.Name, suite.sharedFixtureUsers[fixture])
+		} else {
+			suite.sharedFixtureUsersRemaining[fixture] -= 1
+		}
+	}
+}
+
+// sharedFixturesToTearDown returns the fixtures with users that were set up on this process and have yet to be torn down
+func (suite *Suite) sharedFixturesToTearDown() []*SharedFixture {
+	out := []*SharedFixture{}
+	for _, fixture := range suite.sharedFixtures {
+		state := suite.sharedFixtureStates[fixture]
+		if state != nil && state.teardown != nil && suite.sharedFixtureUsers[fixture] > 0 {
+			out = append(out, fixture)
+		}
+	}
+	return out
+}
+
+/*
+tearDownReleasedSharedFixtures runs between groups of specs and tears down the fixtures set up on this process whose users have all finished.
+*/
+func (suite *Suite) tearDownReleasedSharedFixtures() {
+	for _, fixture := range suite.sharedFixturesToTearDown() {
+		released := suite.sharedFixtureUsersRemaining[fixture] <= 0
+		if suite.isRunningInParallel() {
+			released, _ = suite.client.FetchSharedFixtureReleased(fixture.Name, suite.config.ParallelProcess)
+		}
+		if released {
+			suite.runSharedFixtureTeardown(fixture, suite.sharedFixtureStates[fixture])
+		}
+	}
+}
+
+/*
+awaitSharedFixtureUsers runs once this process has finished running specs.  When running in parallel the users of the fixtures set up on this process may still be running on other processes so we wait for them to finish and then tear the fixtures down.  While waiting this process doesn't hold up Serial specs - they may well be the users we're waiting for.
+
+Fixtures whose users never finish (e.g. because the suite was aborted) are torn down at the end of the suite.
+*/
+func (suite *Suite) awaitSharedFixtureUsers() {
+	fixtures := suite.sharedFixturesToTearDown()
+	if suite.isRunningInParallel() {
+		suite.client.PostFinishedRunningSpecs(suite.config.ParallelProcess, len(fixtures) > 0)
+		for _, fixture := range fixtures {
+			if suite.client.BlockUntilSharedFixtureReleased(fixture.Name, suite.config.ParallelProcess) == nil {
+				suite.runSharedFixtureTeardown(fixture, suite.sharedFixtureStates[fixture])
+			}
+		}
+	}
+	for _, fixture := range suite.sharedFixturesToTearDown() {
+		suite.addSharedFixtureTeardownNode(fixture, suite.sharedFixtureStates[fixture])
+	}
+}
+
+// runSharedFixtureTeardown tears a fixture down while specs are still running - unlike the cleanup nodes run by runSuiteNode it must not wait for the other processes to finish
+func (suite *Suite) runSharedFixtureTeardown(fixture *SharedFixture, state *sharedFixtureState) {
+	node := newSharedFixtureTeardownNode(fixture, state.teardown)
+	state.teardown = nil
+
+	suite.selectiveLock.Lock()
+	suite.currentSpecReport = types.SpecReport{
+		LeafNodeType:      node.NodeType,
+		LeafNodeLocation:  node.CodeLocation,
+		LeafNodeText:      node.Text,
+		ParallelProcess:   suite.config.ParallelProcess,
+		RunningInParallel: suite.isRunningInParallel(),
+	}
+	suite.selectiveLock.Unlock()
+
+	suite.reporter.WillRun(suite.currentSpecReport)
+	suite.writer.Truncate()
+	suite.outputInterceptor.StartInterceptingOutput()
+	suite.currentSpecReport.StartTime = time.Now()
+	suite.currentSpecReport.State, suite.currentSpecReport.Failure = suite.runNode(node, time.Time{}, "")
+	suite.currentSpecReport.EndTime = time.Now()
+	suite.currentSpecReport.RunTime = suite.currentSpecReport.EndTime.Sub(suite.currentSpecReport.StartTime)
+	suite.currentSpecReport.CapturedGinkgoWriterOutput = string(suite.writer.Bytes())
+	suite.currentSpecReport.CapturedStdOutErr = suite.outputInterceptor.StopInterceptingAndReturnOutput()
+	suite.processCurrentSpecReport()
+}
+
+// addSharedFixtureTeardownNode schedules the fixture's teardown at the end of the suite.  Since the cleanup nodes run in reverse order fixtures are torn down before any DeferCleanup registered in the suite's setup nodes.
+func (suite *Suite) addSharedFixtureTeardownNode(fixture *SharedFixture, state *sharedFixtureState) {
+	suite.selectiveLock.Lock()
+	suite.cleanupNodes = append(suite.cleanupNodes, newSharedFixtureTeardownNode(fixture, state.teardown))
+	suite.selectiveLock.Unlock()
+	state.teardown = nil
+}
+
+func newSharedFixtureTeardownNode(fixture *SharedFixture, teardown func()) Node {
+	return Node{
+		ID:           UniqueNodeID(),
+		NodeType:     types.NodeTypeCleanupAfterSuite,
+		Text:         fmt.Sprintf("SharedFixture \"%s\"", fixture.Name),
+		Body:         func(SpecContext) { teardown() },
+		CodeLocation: fixture.CodeLocation,
+		NestingLevel: -1,
+	}
+}
@@ -0,0 +1,275 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// snapshotDiffContext is the number of unchanged lines shown around each change when a snapshot does not match
+const snapshotDiffContext = 3
+
+/*
+MatchSnapshot compares value with the snapshot called name that is stored for the currently running spec.
+
+If the snapshot does not exist yet - or the suite is running with --update-snapshots - value is stored as the snapshot and MatchSnapshot returns an empty string.  Otherwise MatchSnapshot returns a description of the differences between the stored snapshot and value, or an empty string if they match.
+*/
+func (suite *Suite) MatchSnapshot(name string, value any, cl types.CodeLocation) (string, error) {
+	if suite.phase != PhaseRun {
+		return "", types.GinkgoErrors.MatchSnapshotNotDuringSpec(cl)
+	}
+	report := suite.CurrentSpecReport()
+	if !report.LeafNodeType.Is(types.NodeTypeIt) {
+		return "", types.GinkgoErrors.MatchSnapshotNotDuringSpec(cl)
+	}
+	if name == "" || strings.ContainsAny(name, "\r\n") {
+		return "", types.GinkgoErrors.InvalidSnapshotName(cl)
+	}
+
+	path := types.SnapshotPath(report.LeafNodeLocation.FileName)
+	key := types.SnapshotKey(report.FullText(), name)
+	received := snapshotValue(value)
+
+	suite.snapshotLock.Lock()
+	defer suite.snapshotLock.Unlock()
+	if suite.snapshotsUsed[path] == nil {
+		suite.snapshotsUsed[path] = map[string]bool{}
+	}
+	suite.snapshotsUsed[path][key] = true
+
+	snapshots, err := types.LoadSnapshots(path)
+	if err != nil {
+		return "", err
+	}
+	stored, exists := snapshots[key]
+	if exists && stored == received {
+		return "", nil
+	}
+	if exists && !suite.config.UpdateSnapshots {
+		return fmt.Sprintf("Snapshot \"%s\" does not match (- snapshot, + received):\n%s\nRun with --update-snapshots to update the snapshot stored in %s", name, snapshotDiff(stored, received), path), nil
+	}
+
+	if suite.isRunningInParallel() {
+		err = suite.client.PostSnapshotUpdate(parallel_support.SnapshotUpdate{Path: path, Key: key, Value: received})
+	} else {
+		err = types.UpdateSnapshot(path, key, received)
+	}
+	if err != nil {
+		return "", err
+	}
+	verb := "Wrote new"
+	if exists {
+		verb = "Updated"
+	}
+	entry, _ := NewReportEntry("Snapshot", cl, types.ReportEntryVisibilityFailureOrVerbose, fmt.Sprintf("%s snapshot \"%s\" in %s", verb, name, path))
+	suite.AddReportEntry(entry)
+	return "", nil
+}
+
+// snapshotValue converts a value to the text stored in its snapshot.  Strings and []byte are stored as-is, everything else is stored as indented JSON (or, if it can't be encoded as JSON, with %#v)
+func snapshotValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+	return string(data)
+}
+
+// snapshotDiff returns a line-by-line diff between expected and actual, showing only the changed lines and the lines around them
+func snapshotDiff(expected string, actual string) string {
+	a, b := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		prefix string
+		text   string
+	}
+	lines := []line{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{" ", a[i]})
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{"-", a[i]})
+			i += 1
+		default:
+			lines = append(lines, line{"+", b[j]})
+			j += 1
+		}
+	}
+
+	show := make([]bool, len(lines))
+	for idx, l := range lines {
+		if l.prefix != " " {
+			for k := max(0, idx-snapshotDiffContext); k <= min(len(lines)-1, idx+snapshotDiffContext); k++ {
+				show[k] = true
+			}
+		}
+	}
+	out := []string{}
+	for idx, l := range lines {
+		if !show[idx] {
+			if idx > 0 && show[idx-1] {
+				out = append(out, "  ...")
+			}
+			continue
+		}
+		out = append(out, fmt.Sprintf("  %s %s", l.prefix, l.text))
+	}
+	return strings.Join(out, "\n")
+}
+
+/*
+detectObsoleteSnapshots finds the snapshots that are stored alongside the suite's spec files but are no longer matched by any spec.  With --update-snapshots the obsolete snapshots are removed.
+
+A snapshot is obsolete if the spec it belongs to no longer exists or if that spec ran, passed, and did not match the snapshot.  Snapshots that belong to specs that were skipped or failed are left alone as those specs may simply not have reached their call to MatchSnapshot.
+
+When running in parallel the other processes send the snapshots they matched to the server and process #1 - which has access to the aggregated report - performs the detection.
+*/
+func (suite *Suite) detectObsoleteSnapshots(specs Specs) {
+	if suite.config.DryRun {
+		return
+	}
+	if suite.config.ParallelProcess != 1 {
+		if len(suite.snapshotsUsed) > 0 {
+			suite.client.PostSnapshotsUsed(snapshotKeysByPath(suite.snapshotsUsed))
+		}
+		return
+	}
+
+	snapshotFiles := []string{}
+	seenDirs := map[string]bool{}
+	for _, spec := range specs {
+		dir := filepath.Dir(types.SnapshotPath(spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation.FileName))
+		if seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+		matches, _ := filepath.Glob(filepath.Join(dir, "*"+types.SnapshotFileExtension))
+		snapshotFiles = append(snapshotFiles, matches...)
+	}
+	if len(snapshotFiles) == 0 {
+		return
+	}
+
+	specReports := suite.report.SpecReports
+	used := snapshotKeysByPath(suite.snapshotsUsed)
+	if suite.isRunningInParallel() {
+		aggregatedReport, err := suite.client.BlockUntilAggregatedNonprimaryProcsReport()
+		if err != nil {
+			return
+		}
+		specReports = append(specReports, aggregatedReport.SpecReports...)
+		otherUsed, err := suite.client.FetchSnapshotsUsed()
+		if err != nil {
+			return
+		}
+		for path, keys := range otherUsed {
+			used[path] = append(used[path], keys...)
+		}
+	}
+
+	// specPassed[path][fullText] tracks whether every spec with the given full text whose snapshots are stored at path ran and passed
+	specPassed := map[string]map[string]bool{}
+	for _, spec := range specs {
+		path := types.SnapshotPath(spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation.FileName)
+		if specPassed[path] == nil {
+			specPassed[path] = map[string]bool{}
+		}
+		specPassed[path][spec.Text()] = true
+	}
+	ran := map[string]map[string]bool{}
+	for _, specReport := range specReports {
+		if !specReport.LeafNodeType.Is(types.NodeTypeIt) {
+			continue
+		}
+		path := types.SnapshotPath(specReport.LeafNodeLocation.FileName)
+		if ran[path] == nil {
+			ran[path] = map[string]bool{}
+		}
+		ran[path][specReport.FullText()] = true
+		if !specReport.State.Is(types.SpecStatePassed) && specPassed[path] != nil {
+			specPassed[path][specReport.FullText()] = false
+		}
+	}
+	for path, texts := range specPassed {
+		for fullText := range texts {
+			if !ran[path][fullText] {
+				texts[fullText] = false
+			}
+		}
+	}
+
+	sort.Strings(snapshotFiles)
+	for _, path := range snapshotFiles {
+		snapshots, err := types.LoadSnapshots(path)
+		if err != nil {
+			continue
+		}
+		usedKeys := map[string]bool{}
+		for _, key := range used[path] {
+			usedKeys[key] = true
+		}
+		obsolete := []string{}
+		for key := range snapshots {
+			if usedKeys[key] {
+				continue
+			}
+			owner, ownerLength := "", -1
+			for fullText := range specPassed[path] {
+				if strings.HasPrefix(key, types.SnapshotKey(fullText, "")) && len(fullText) > ownerLength {
+					owner, ownerLength = fullText, len(fullText)
+				}
+			}
+			if ownerLength == -1 || specPassed[path][owner] {
+				obsolete = append(obsolete, key)
+			}
+		}
+		sort.Strings(obsolete)
+		for _, key := range obsolete {
+			suite.report.ObsoleteSnapshots = append(suite.report.ObsoleteSnapshots, types.ObsoleteSnapshot{Path: path, Key: key})
+			if suite.config.UpdateSnapshots {
+				delete(snapshots, key)
+			}
+		}
+		if suite.config.UpdateSnapshots && len(obsolete) > 0 {
+			snapshots.Save(path)
+		}
+	}
+}
+
+func snapshotKeysByPath(used map[string]map[string]bool) map[string][]string {
+	out := map[string][]string{}
+	for path, keys := range used {
+		for key := range keys {
+			out[path] = append(out[path], key)
+		}
+	}
+	return out
+}
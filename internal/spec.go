@@ -10,6 +10,10 @@ import (
 type Spec struct {
 	Nodes Nodes
 	Skip  bool
+	// SkipReason, if set, explains why the spec was skipped and is reported as the skipped spec's failure message
+	SkipReason string
+	// ID is the spec's stable ID - see AssignSpecIDs
+	ID string
 }
 
 func (s Spec) SubjectID() uint {
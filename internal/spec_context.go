@@ -21,7 +21,8 @@ type specContext struct {
 
 	cancel context.CancelCauseFunc
 
-	suite *Suite
+	suite       *Suite
+	spanContext types.SpanContext
 }
 
 /*
@@ -52,6 +53,21 @@ func (sc *specContext) WrappedContext() context.Context {
 	return sc.Context
 }
 
+/*
+SpanContextFor returns the span of the node running with ctx - or any context derived from it - in the traces exported by --trace-export.
+
+The returned SpanContext is not valid when --trace-export isn't set or when ctx is not a SpecContext.
+*/
+func SpanContextFor(ctx context.Context) types.SpanContext {
+	if ctx == nil {
+		return types.SpanContext{}
+	}
+	if sc, ok := ctx.Value("GINKGO_SPEC_CONTEXT").(*specContext); ok {
+		return sc.spanContext
+	}
+	return types.SpanContext{}
+}
+
 /*
 The user is allowed to wrap `SpecContext` in a new context.Context when using AroundNodes.  But body functions expect SpecContext.
 We support this by taking their context.Context and returning a SpecContext that wraps it.
@@ -68,6 +84,7 @@ func wrapContextChain(ctx context.Context) SpecContext {
 			ProgressReporterManager: sc.ProgressReporterManager,
 			cancel:                  sc.cancel,
 			suite:                   sc.suite,
+			spanContext:             sc.spanContext,
 		}
 	}
 	return nil
@@ -0,0 +1,281 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// SpecID names a container or It so that other specs can depend on the specs it contains with DependsOn
+type SpecID string
+
+// Prerequisites are the SpecIDs a spec depends on
+type Prerequisites []string
+
+/*
+specDependencies captures the dependency graph formed by SpecID and DependsOn.
+
+Specs are identified by their SubjectID (the ID of their It node).  Outcomes are shared between parallel processes by SpecID, however, as node IDs are only guaranteed to be consistent within a process.
+*/
+type specDependencies struct {
+	// the SubjectIDs of the specs that bear each SpecID
+	specsWithID map[string][]uint
+	// the SpecIDs each spec depends on, keyed by SubjectID
+	prerequisites map[uint][]string
+	// true for the SpecIDs that specs depend on
+	dependedOn map[string]bool
+}
+
+func newSpecDependencies(specs Specs) specDependencies {
+	d := specDependencies{
+		specsWithID:   map[string][]uint{},
+		prerequisites: map[uint][]string{},
+		dependedOn:    map[string]bool{},
+	}
+	for _, spec := range specs {
+		for _, id := range spec.Nodes.SpecIDs() {
+			d.specsWithID[id] = append(d.specsWithID[id], spec.SubjectID())
+		}
+	}
+	for _, spec := range specs {
+		prerequisites := spec.Nodes.UnionOfPrerequisites()
+		if len(prerequisites) == 0 {
+			continue
+		}
+		d.prerequisites[spec.SubjectID()] = prerequisites
+		for _, id := range prerequisites {
+			d.dependedOn[id] = true
+		}
+	}
+	return d
+}
+
+func (d specDependencies) IsZero() bool {
+	return len(d.prerequisites) == 0
+}
+
+// prerequisiteSpecsFor returns the SubjectIDs of all the specs the passed-in spec depends on
+func (d specDependencies) prerequisiteSpecsFor(spec Spec) []uint {
+	out := []uint{}
+	for _, id := range d.prerequisites[spec.SubjectID()] {
+		out = unionOf(out, d.specsWithID[id])
+	}
+	return out
+}
+
+// expectedStatesFor returns the number of outcomes that must be recorded for each of the spec's prerequisites before the spec can run, ignoring specs in yetToRun
+func (d specDependencies) expectedStatesFor(spec Spec, yetToRun map[uint]bool) map[string]int {
+	out := map[string]int{}
+	for _, id := range d.prerequisites[spec.SubjectID()] {
+		for _, subjectID := range d.specsWithID[id] {
+			if !yetToRun[subjectID] {
+				out[id] += 1
+			}
+		}
+	}
+	return out
+}
+
+/*
+unmetPrerequisite returns the first of the spec's prerequisites that did not pass along with a description of what happened to it.  states contains the final states of the specs that bear each SpecID.
+
+A prerequisite passes only if every spec bearing its SpecID passed.  Quarantined failures don't fail the suite but dependents still can't rely on them having succeeded.
+*/
+func (d specDependencies) unmetPrerequisite(spec Spec, states map[string][]types.SpecState) (string, string) {
+	for _, id := range d.prerequisites[spec.SubjectID()] {
+		if len(d.specsWithID[id]) == 0 {
+			return id, "did not run"
+		}
+		for _, state := range states[id] {
+			if state.Is(types.SpecStateFailureStates | types.SpecStateQuarantined) {
+				return id, "failed"
+			}
+		}
+		for _, state := range states[id] {
+			if state.Is(types.SpecStateSkipped | types.SpecStatePending) {
+				return id, "was skipped"
+			}
+		}
+	}
+	return "", ""
+}
+
+// executionGroupID identifies the group a spec runs in - specs in an Ordered container run together, every other spec runs on its own
+func executionGroupID(spec Spec) uint {
+	groupNode := spec.Nodes.FirstNodeMarkedOrdered()
+	if groupNode.IsZero() {
+		groupNode = spec.FirstNodeWithType(types.NodeTypeIt)
+	}
+	return groupNode.ID
+}
+
+/*
+ValidateSpecDependencies ensures the dependency graph formed by SpecID and DependsOn can be scheduled:
+
+- every SpecID must be unique and every SpecID passed to DependsOn must exist
+- specs can't depend on themselves, on specs that run after them in the same Ordered container, or (directly or indirectly) on specs that depend on them
+- specs that aren't Serial can't depend on Serial specs as, when running in parallel, Serial specs only run after all the other specs have finished
+
+It is called with every spec in the tree - before focus filters are applied - so that a suite's validity doesn't depend on how it is invoked.
+*/
+func ValidateSpecDependencies(specs Specs) error {
+	idLocations := map[string]types.CodeLocation{}
+	idNodeIDs := map[string]uint{}
+	for _, spec := range specs {
+		for _, node := range spec.Nodes {
+			if node.SpecID == "" {
+				continue
+			}
+			if nodeID, ok := idNodeIDs[node.SpecID]; ok && nodeID != node.ID {
+				return types.GinkgoErrors.DuplicateSpecID(node.CodeLocation, node.SpecID, idLocations[node.SpecID])
+			}
+			idNodeIDs[node.SpecID], idLocations[node.SpecID] = node.ID, node.CodeLocation
+		}
+	}
+
+	sortableSpecs := NewSortableSpecs(specs)
+	sort.Sort(sortableSpecs)
+	d := newSpecDependencies(specs)
+	specsBySubjectID := map[uint]Spec{}
+	for _, spec := range specs {
+		specsBySubjectID[spec.SubjectID()] = spec
+	}
+
+	groupIDs := []uint{}
+	groupLocations := map[uint]types.CodeLocation{}
+	groupPrerequisites := map[uint][]uint{}
+	for _, idx := range sortableSpecs.Indexes {
+		spec := specs[idx]
+		groupID := executionGroupID(spec)
+		if _, ok := groupLocations[groupID]; !ok {
+			groupIDs = append(groupIDs, groupID)
+			groupLocations[groupID] = spec.Nodes.FirstNodeMarkedOrdered().CodeLocation
+			if spec.Nodes.FirstNodeMarkedOrdered().IsZero() {
+				groupLocations[groupID] = spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation
+			}
+		}
+		for _, node := range spec.Nodes {
+			for _, id := range node.Prerequisites {
+				if _, ok := idNodeIDs[id]; !ok {
+					return types.GinkgoErrors.UnknownSpecDependency(node.CodeLocation, id)
+				}
+				for _, subjectID := range d.specsWithID[id] {
+					prerequisite := specsBySubjectID[subjectID]
+					switch {
+					case subjectID == spec.SubjectID():
+						return types.GinkgoErrors.CyclicSpecDependencies(node.CodeLocation, fmt.Sprintf("The spec at %s depends on \"%s\" which it bears itself", spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation, id))
+					case prerequisite.Nodes.HasNodeMarkedSerial() && !spec.Nodes.HasNodeMarkedSerial():
+						return types.GinkgoErrors.SpecDependsOnSerialSpec(node.CodeLocation, id)
+					case executionGroupID(prerequisite) == groupID:
+						if subjectID > spec.SubjectID() {
+							return types.GinkgoErrors.CyclicSpecDependencies(node.CodeLocation, fmt.Sprintf("The spec at %s depends on \"%s\" but runs before it in the same Ordered container", spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation, id))
+						}
+					default:
+						groupPrerequisites[groupID] = unionOf(groupPrerequisites[groupID], []uint{executionGroupID(prerequisite)})
+					}
+				}
+			}
+		}
+	}
+
+	// finally, we make sure the graph of execution groups is acyclic
+	const visiting, visited = 1, 2
+	status := map[uint]int{}
+	path := []uint{}
+	var visit func(groupID uint) []uint
+	visit = func(groupID uint) []uint {
+		switch status[groupID] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == groupID {
+					return append(path[i:], groupID)
+				}
+			}
+		}
+		status[groupID] = visiting
+		path = append(path, groupID)
+		for _, prerequisiteGroupID := range groupPrerequisites[groupID] {
+			if cycle := visit(prerequisiteGroupID); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		status[groupID] = visited
+		return nil
+	}
+	for _, groupID := range groupIDs {
+		if cycle := visit(groupID); cycle != nil {
+			description := "The specs at these locations depend on one another:"
+			for _, cycleGroupID := range cycle {
+				description += fmt.Sprintf("\n  %s", groupLocations[cycleGroupID])
+			}
+			return types.GinkgoErrors.CyclicSpecDependencies(groupLocations[cycle[0]], description)
+		}
+	}
+
+	return nil
+}
+
+/*
+orderByPrerequisites reorders groupedSpecIndices so that every group runs after the groups it depends on.  Groups that are ready to run keep their position while groups that depend on groups later in the order are deferred until their last prerequisite has been scheduled.  The dependency graph is validated by ValidateSpecDependencies so, in practice, every group gets scheduled.
+*/
+func orderByPrerequisites(specs Specs, groupedSpecIndices GroupedSpecIndices) GroupedSpecIndices {
+	d := newSpecDependencies(specs)
+	if d.IsZero() {
+		return groupedSpecIndices
+	}
+
+	groupForSubjectID := map[uint]int{}
+	for i, specIndices := range groupedSpecIndices {
+		for _, idx := range specIndices {
+			groupForSubjectID[specs[idx].SubjectID()] = i
+		}
+	}
+	prerequisiteGroups := make([][]int, len(groupedSpecIndices))
+	for i, specIndices := range groupedSpecIndices {
+		for _, idx := range specIndices {
+			for _, subjectID := range d.prerequisiteSpecsFor(specs[idx]) {
+				if j, ok := groupForSubjectID[subjectID]; ok && j != i {
+					prerequisiteGroups[i] = unionOf(prerequisiteGroups[i], []int{j})
+				}
+			}
+		}
+	}
+
+	out := GroupedSpecIndices{}
+	scheduled := make([]bool, len(groupedSpecIndices))
+	isReady := func(i int) bool {
+		for _, j := range prerequisiteGroups[i] {
+			if !scheduled[j] {
+				return false
+			}
+		}
+		return true
+	}
+	deferred := []int{}
+	for i := range groupedSpecIndices {
+		if !isReady(i) {
+			deferred = append(deferred, i)
+			continue
+		}
+		out, scheduled[i] = append(out, groupedSpecIndices[i]), true
+		for scheduledDeferredGroup := true; scheduledDeferredGroup; {
+			scheduledDeferredGroup = false
+			for k, j := range deferred {
+				if isReady(j) {
+					out, scheduled[j] = append(out, groupedSpecIndices[j]), true
+					deferred = append(deferred[:k], deferred[k+1:]...)
+					scheduledDeferredGroup = true
+					break
+				}
+			}
+		}
+	}
+	for _, j := range deferred {
+		out = append(out, groupedSpecIndices[j])
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+AssignSpecIDs gives each spec its stable ID (see types.SpecReport.ID) and returns an error if two specs end up with the same ID.
+
+IDs are derived from the texts of the spec's containers and It.  A SpecID decorator pins the ID: a SpecID on the It becomes the spec's ID while a SpecID on a container stands in for the texts of the container and its ancestors - so renaming them doesn't change the IDs of the specs in the container.  Specs that share the same texts (e.g. table entries with identical descriptions) are told apart by their index among those specs.
+
+specs must be in the order in which they were declared - i.e. the order generated by GenerateSpecsFromTreeRoot - so that the IDs are the same on every run and on every parallel process.
+*/
+func AssignSpecIDs(specs Specs) error {
+	occurrences := map[string]int{}
+	locations := map[string]types.CodeLocation{}
+	for i := range specs {
+		leaf := specs[i].FirstNodeWithType(types.NodeTypeIt)
+		id := leaf.SpecID
+		if id == "" {
+			parts := specIDParts(specs[i].Nodes.WithType(types.NodeTypeContainer | types.NodeTypeIt))
+			key := strings.Join(parts, "\x00")
+			id = hashSpecIDParts(parts, occurrences[key])
+			occurrences[key] += 1
+		}
+		if location, ok := locations[id]; ok {
+			return types.GinkgoErrors.DuplicateSpecID(leaf.CodeLocation, id, location)
+		}
+		locations[id] = leaf.CodeLocation
+		specs[i].ID = id
+	}
+	return nil
+}
+
+// containerSpecID returns the ID of the last container in chain.  Unlike spec IDs, container IDs are not disambiguated so containers that share the same texts share the same ID.
+func containerSpecID(chain Nodes) string {
+	if len(chain) == 0 {
+		return ""
+	}
+	if id := chain[len(chain)-1].SpecID; id != "" {
+		return id
+	}
+	return hashSpecIDParts(specIDParts(chain), 0)
+}
+
+func specIDParts(nodes Nodes) []string {
+	parts := []string{}
+	for _, node := range nodes {
+		if node.SpecID != "" {
+			// the \x01 prefix prevents a SpecID from colliding with a container that has the same text
+			parts = []string{"\x01" + node.SpecID}
+		} else {
+			parts = append(parts, node.Text)
+		}
+	}
+	return parts
+}
+
+func hashSpecIDParts(parts []string, occurrence int) string {
+	hash := sha256.New()
+	hash.Write([]byte(strings.Join(parts, "\x00")))
+	if occurrence > 0 {
+		hash.Write([]byte("\x00" + strconv.Itoa(occurrence)))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -0,0 +1,75 @@
+package internal_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// hashedSpecID spells out how IDs are derived so that these tests catch changes that would break the IDs users have already recorded
+func hashedSpecID(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+var _ = Describe("AssignSpecIDs", func() {
+	harvestIDs := func(specs Specs) []string {
+		out := []string{}
+		for _, spec := range specs {
+			out = append(out, spec.ID)
+		}
+		return out
+	}
+
+	It("derives the IDs from the texts of the spec's containers and It", func() {
+		specs := Specs{
+			S(N(ntCon, "A"), N(ntIt, "B")),
+			S(N(ntCon, "A"), N(ntBef, "ignored"), N(ntCon, "C"), N(ntIt, "D"), N(ntAf)),
+			S(N(ntIt, "A B")),
+		}
+		Ω(internal.AssignSpecIDs(specs)).Should(Succeed())
+		Ω(harvestIDs(specs)).Should(Equal([]string{hashedSpecID("A", "B"), hashedSpecID("A", "C", "D"), hashedSpecID("A B")}))
+	})
+
+	It("tells specs with identical texts apart by the order in which they are declared", func() {
+		specs := Specs{
+			S(N(ntCon, "A"), N(ntIt, "B")),
+			S(N(ntCon, "A"), N(ntIt, "C")),
+			S(N(ntCon, "A"), N(ntIt, "B")),
+			S(N(ntCon, "A"), N(ntIt, "B")),
+		}
+		Ω(internal.AssignSpecIDs(specs)).Should(Succeed())
+		Ω(harvestIDs(specs)).Should(Equal([]string{hashedSpecID("A", "B"), hashedSpecID("A", "C"), hashedSpecID("A", "B\x001"), hashedSpecID("A", "B\x002")}))
+	})
+
+	Context("when nodes are decorated with SpecID", func() {
+		It("uses the It's SpecID as the spec's ID", func() {
+			specs := Specs{S(N(ntCon, "A"), N(ntIt, "B", SpecID("migrate")))}
+			Ω(internal.AssignSpecIDs(specs)).Should(Succeed())
+			Ω(specs[0].ID).Should(Equal("migrate"))
+		})
+
+		It("uses a container's SpecID in place of the texts of the container and its ancestors", func() {
+			specs := Specs{
+				S(N(ntCon, "A"), N(ntCon, "B", SpecID("db")), N(ntCon, "C"), N(ntIt, "D")),
+				S(N(ntCon, "renamed"), N(ntCon, "also renamed", SpecID("db")), N(ntCon, "C"), N(ntIt, "E")),
+			}
+			Ω(internal.AssignSpecIDs(specs)).Should(Succeed())
+			Ω(harvestIDs(specs)).Should(Equal([]string{hashedSpecID("\x01db", "C", "D"), hashedSpecID("\x01db", "C", "E")}))
+		})
+	})
+
+	It("errors when a SpecID collides with another spec's ID", func() {
+		specs := Specs{
+			S(N(ntCon, "A"), N(ntIt, "B", CL("file_a", 1))),
+			S(N(ntIt, "C", SpecID(hashedSpecID("A", "B")), CL("file_a", 3))),
+		}
+		Ω(internal.AssignSpecIDs(specs)).Should(MatchError(types.GinkgoErrors.DuplicateSpecID(CL("file_a", 3), hashedSpecID("A", "B"), CL("file_a", 1))))
+	})
+})
@@ -3,6 +3,8 @@ package internal
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -40,8 +42,21 @@ type Suite struct {
 	outputInterceptor OutputInterceptor
 	interruptHandler  interrupt_handler.InterruptHandlerInterface
 	config            types.SuiteConfig
+	suiteSpan         types.SpanContext
+	quarantine        types.Quarantine
 	deadline          time.Time
 
+	specDependencies specDependencies
+	specIDStates     map[string][]types.SpecState
+
+	sharedFixtures              []*SharedFixture
+	sharedFixtureStates         map[*SharedFixture]*sharedFixtureState
+	sharedFixtureUsers          map[*SharedFixture]int
+	sharedFixtureUsersRemaining map[*SharedFixture]int
+
+	snapshotLock  *sync.Mutex
+	snapshotsUsed map[string]map[string]bool
+
 	currentConstructionNodeReport *types.ConstructionNodeReport
 
 	skipAll              bool
@@ -50,6 +65,9 @@ type Suite struct {
 	currentNode          Node
 	currentNodeStartTime time.Time
 
+	// the SharedFixtures the running spec declares it uses - only these can be requested while it runs
+	currentSpecSharedFixtures UsesSharedFixtures
+
 	currentSpecContext *specContext
 
 	currentByStep types.SpecEvent
@@ -77,6 +95,7 @@ func NewSuite() *Suite {
 		ProgressReporterManager: NewProgressReporterManager(),
 
 		selectiveLock: &sync.Mutex{},
+		snapshotLock:  &sync.Mutex{},
 	}
 }
 
@@ -91,6 +110,7 @@ func (suite *Suite) Clone() (*Suite, error) {
 		topLevelContainers:      suite.topLevelContainers.Clone(),
 		suiteNodes:              suite.suiteNodes.Clone(),
 		aroundNodes:             suite.aroundNodes.Clone(),
+		sharedFixtures:          slices.Clone(suite.sharedFixtures),
 		selectiveLock:           &sync.Mutex{},
 	}, nil
 }
@@ -105,7 +125,11 @@ func (suite *Suite) BuildTree() error {
 			return err
 		}
 	}
-	return nil
+	specs := GenerateSpecsFromTreeRoot(suite.tree)
+	if err := ValidateSpecDependencies(specs); err != nil {
+		return err
+	}
+	return AssignSpecIDs(specs)
 }
 
 func (suite *Suite) Run(description string, suiteLabels Labels, suiteSemVerConstraints SemVerConstraints, suiteComponentSemVerConstraints ComponentSemVerConstraints, suiteAroundNodes types.AroundNodes, suitePath string, failer *Failer, reporter reporters.Reporter, writer WriterInterface, outputInterceptor OutputInterceptor, interruptHandler interrupt_handler.InterruptHandlerInterface, client parallel_support.Client, progressSignalRegistrar ProgressSignalRegistrar, suiteConfig types.SuiteConfig) (bool, bool) {
@@ -114,7 +138,9 @@ func (suite *Suite) Run(description string, suiteLabels Labels, suiteSemVerConst
 	}
 	ApplyNestedFocusPolicyToTree(suite.tree)
 	specs := GenerateSpecsFromTreeRoot(suite.tree)
-	specs, hasProgrammaticFocus := ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suiteConfig)
+	AssignSpecIDs(specs) //the IDs have already been vetted by BuildTree
+	specs, hasProgrammaticFocus := ApplyFocusToSpecs(specs, description, suiteLabels, suiteSemVerConstraints, suiteComponentSemVerConstraints, suitePath, suiteConfig)
+	specs = ApplyShardToSpecs(specs, suiteConfig)
 	specs = ComputeAroundNodes(specs)
 
 	suite.phase = PhaseRun
@@ -126,6 +152,12 @@ func (suite *Suite) Run(description string, suiteLabels Labels, suiteSemVerConst
 	suite.interruptHandler = interruptHandler
 	suite.config = suiteConfig
 	suite.aroundNodes = suiteAroundNodes
+	if suiteConfig.QuarantineFile != "" {
+		suite.quarantine, _ = types.LoadQuarantine(suiteConfig.QuarantineFile) //the quarantine file has already been vetted by types.VetConfig
+	}
+	if suiteConfig.TraceSuiteSpan != "" {
+		suite.suiteSpan, _ = types.ParseTraceParent(suiteConfig.TraceSuiteSpan) //the suite span has already been vetted by types.VetConfig
+	}
 
 	if suite.config.Timeout > 0 {
 		suite.deadline = time.Now().Add(suite.config.Timeout)
@@ -296,6 +328,20 @@ func (suite *Suite) generateTimelineLocation() types.TimelineLocation {
 	}
 }
 
+// newNodeSpanContext returns a new span for a node or By step in the current spec - and makes sure the spec has a span of its own
+// spans are only tracked when --trace-export is set
+func (suite *Suite) newNodeSpanContext() types.SpanContext {
+	if !suite.suiteSpan.IsValid() {
+		return types.SpanContext{}
+	}
+	suite.selectiveLock.Lock()
+	if suite.currentSpecReport.SpanID == "" {
+		suite.currentSpecReport.SpanID = types.NewSpanID()
+	}
+	suite.selectiveLock.Unlock()
+	return types.SpanContext{TraceID: suite.suiteSpan.TraceID, SpanID: types.NewSpanID()}
+}
+
 func (suite *Suite) handleSpecEvent(event types.SpecEvent) types.SpecEvent {
 	event.TimelineLocation = suite.generateTimelineLocation()
 	suite.selectiveLock.Lock()
@@ -326,6 +372,7 @@ func (suite *Suite) By(text string, callback ...func()) error {
 		SpecEventType: types.SpecEventByStart,
 		CodeLocation:  cl,
 		Message:       text,
+		SpanID:        suite.newNodeSpanContext().SpanID,
 	})
 	suite.selectiveLock.Lock()
 	suite.currentByStep = event
@@ -367,6 +414,7 @@ func (suite *Suite) CurrentSpecReport() types.SpecReport {
 	}
 	report.ReportEntries = make([]ReportEntry, len(report.ReportEntries))
 	copy(report.ReportEntries, suite.currentSpecReport.ReportEntries)
+	report.Subtests = slices.Clone(suite.currentSpecReport.Subtests)
 	return report
 }
 
@@ -393,6 +441,18 @@ func (suite *Suite) AddReportEntry(entry ReportEntry) error {
 	return nil
 }
 
+/*
+AddSubtestReport records a subtest run via GinkgoTRunner.Run.  As with *testing.T a failing subtest fails the current spec without stopping it - the failure is picked up when the running node completes.  Subtests that panic re-panic in the running node so they are handled like any other panic.
+*/
+func (suite *Suite) AddSubtestReport(report types.SubtestReport) {
+	suite.selectiveLock.Lock()
+	suite.currentSpecReport.Subtests = append(suite.currentSpecReport.Subtests, report)
+	suite.selectiveLock.Unlock()
+	if report.State.Is(types.SpecStateFailed) {
+		suite.failer.Fail(fmt.Sprintf("Subtest \"%s\" failed:\n%s", report.Name, report.Failure.Message), report.Failure.Location)
+	}
+}
+
 func (suite *Suite) generateProgressReport(fullReport bool) types.ProgressReport {
 	timelineLocation := suite.generateTimelineLocation()
 	suite.selectiveLock.Lock()
@@ -456,6 +516,38 @@ func (suite *Suite) processCurrentSpecReport() {
 	}
 }
 
+// recordSpecState tracks the final state of specs that other specs depend on.  When running in parallel the state is shared with the other processes.
+func (suite *Suite) recordSpecState(spec Spec, state types.SpecState) {
+	for _, id := range spec.Nodes.SpecIDs() {
+		if !suite.specDependencies.dependedOn[id] {
+			continue
+		}
+		suite.specIDStates[id] = append(suite.specIDStates[id], state)
+		if suite.isRunningInParallel() {
+			suite.client.PostSpecIDState(id, state)
+		}
+	}
+}
+
+/*
+unmetPrerequisite returns the first of the spec's prerequisites that did not pass along with a description of what happened to it.  Specs in yetToRun are ignored.  When running in parallel the other prerequisites may be running on other processes, in which case unmetPrerequisite blocks until they've finished.
+*/
+func (suite *Suite) unmetPrerequisite(spec Spec, yetToRun map[uint]bool) (string, string) {
+	states := suite.specIDStates
+	expected := suite.specDependencies.expectedStatesFor(spec, yetToRun)
+	for id, count := range expected {
+		if len(states[id]) < count && suite.isRunningInParallel() {
+			var err error
+			states, err = suite.client.BlockUntilSpecIDStates(expected)
+			if err != nil {
+				return id, "did not finish running on another process"
+			}
+			break
+		}
+	}
+	return suite.specDependencies.unmetPrerequisite(spec, states)
+}
+
 func (suite *Suite) runSpecs(description string, suiteLabels Labels, suiteSemVerConstraints SemVerConstraints, suiteComponentSemVerConstraints ComponentSemVerConstraints, suitePath string, hasProgrammaticFocus bool, specs Specs) bool {
 	numSpecsThatWillBeRun := specs.CountWithoutSkip()
 
@@ -473,6 +565,13 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suiteSemVer
 		},
 		StartTime: time.Now(),
 	}
+	if suite.config.Shard != "" {
+		suite.report.PreRunStats.Shard, suite.report.PreRunStats.TotalShards, _ = types.ParseShard(suite.config.Shard)
+	}
+
+	suite.snapshotsUsed = map[string]map[string]bool{}
+	suite.sharedFixtureUsers = countSharedFixtureUsers(specs)
+	suite.sharedFixtureUsersRemaining = maps.Clone(suite.sharedFixtureUsers)
 
 	suite.reporter.SuiteWillBegin(suite.report)
 	if suite.isRunningInParallel() {
@@ -489,7 +588,13 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suiteSemVer
 	}
 
 	if suite.report.SuiteSucceeded {
+		suite.specDependencies, suite.specIDStates = newSpecDependencies(specs), map[string][]types.SpecState{}
 		groupedSpecIndices, serialGroupedSpecIndices := OrderSpecs(specs, suite.config)
+		if suite.isRunningInParallel() && suite.config.SpecTimings != "" {
+			// every process loads the same timings and so arrives at the same schedule.  unreadable timings are treated as empty - they'll be overwritten at the end of the run
+			timings, _ := types.LoadSpecTimings(suite.config.SpecTimings)
+			groupedSpecIndices = ScheduleByExpectedRunTime(specs, groupedSpecIndices, suitePath, timings)
+		}
 		nextIndex := MakeIncrementingIndexCounter()
 		if suite.isRunningInParallel() {
 			nextIndex = suite.client.FetchNextCounter
@@ -506,7 +611,7 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suiteSemVer
 			if groupedSpecIdx >= len(groupedSpecIndices) {
 				if suite.config.ParallelProcess == 1 && len(serialGroupedSpecIndices) > 0 {
 					groupedSpecIndices, serialGroupedSpecIndices, nextIndex = serialGroupedSpecIndices, GroupedSpecIndices{}, MakeIncrementingIndexCounter()
-					suite.client.BlockUntilNonprimaryProcsHaveFinished()
+					suite.client.BlockUntilNonprimaryProcsHaveFinishedRunningSpecs()
 					continue
 				}
 				break
@@ -517,7 +622,9 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suiteSemVer
 			// Group is really just an extension of suite so it gets passed a suite and has access to all its internals
 			// Note that group is stateful and intended for single use!
 			newGroup(suite).run(specs.AtIndices(groupedSpecIndices[groupedSpecIdx]))
+			suite.tearDownReleasedSharedFixtures()
 		}
+		suite.awaitSharedFixtureUsers()
 
 		if suite.config.FailOnPending && specs.HasAnySpecsMarkedPending() {
 			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Detected pending specs and --fail-on-pending is set")
@@ -546,6 +653,7 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suiteSemVer
 		suite.report.SuiteSucceeded = false
 	}
 
+	suite.detectObsoleteSnapshots(specs)
 	suite.runReportSuiteNodesIfNeedBe(types.NodeTypeReportAfterSuite)
 	suite.reporter.SuiteDidEnd(suite.report)
 	if suite.isRunningInParallel() {
@@ -601,6 +709,7 @@ func (suite *Suite) runAfterSuiteCleanup(numSpecsThatWillBeRun int) {
 			suite.currentSpecReport = types.SpecReport{
 				LeafNodeType:      cleanupNode.NodeType,
 				LeafNodeLocation:  cleanupNode.CodeLocation,
+				LeafNodeText:      cleanupNode.Text,
 				ParallelProcess:   suite.config.ParallelProcess,
 				RunningInParallel: suite.isRunningInParallel(),
 			}
@@ -837,11 +946,13 @@ func (suite *Suite) runNode(node Node, specDeadline time.Time, text string) (typ
 	if text == "" {
 		text = "TOP-LEVEL"
 	}
+	spanContext := suite.newNodeSpanContext()
 	event := suite.handleSpecEvent(types.SpecEvent{
 		SpecEventType: types.SpecEventNodeStart,
 		NodeType:      node.NodeType,
 		Message:       text,
 		CodeLocation:  node.CodeLocation,
+		SpanID:        spanContext.SpanID,
 	})
 	defer func() {
 		suite.handleSpecEventEnd(types.SpecEventNodeEnd, event)
@@ -893,6 +1004,7 @@ func (suite *Suite) runNode(node Node, specDeadline time.Time, text string) (typ
 	}
 
 	sc := NewSpecContext(suite)
+	sc.spanContext = spanContext
 	defer sc.cancel(fmt.Errorf("spec has finished"))
 
 	suite.selectiveLock.Lock()
@@ -194,6 +194,19 @@ var _ = Describe("Suite", func() {
 			})
 		})
 
+		Context("when two specs end up with the same ID", func() {
+			BeforeEach(func() {
+				suite.PushNode(N(ntCon, "A", func() {
+					suite.PushNode(N(ntIt, "B", cl))
+				}))
+				suite.PushNode(N(ntIt, "C", SpecID(hashedSpecID("A", "B")), CL("file_a", 3)))
+			})
+
+			It("errors", func() {
+				Ω(suite.BuildTree()).Should(MatchError(types.GinkgoErrors.DuplicateSpecID(CL("file_a", 3), hashedSpecID("A", "B"), cl)))
+			})
+		})
+
 		Describe("Suite Nodes", func() {
 			Context("when pushing suite nodes at the top level", func() {
 				BeforeEach(func() {
@@ -320,6 +333,84 @@ var _ = Describe("Suite", func() {
 				})
 			})
 
+			Context("when specs depend on other specs", func() {
+				var clA, clB types.CodeLocation
+				BeforeEach(func() {
+					clA, clB = CL("a.go", 1), CL("b.go", 1)
+				})
+
+				It("succeeds when the dependencies can be scheduled", func() {
+					suite.PushNode(N(ntCon, "container", SpecID("db"), func() {
+						suite.PushNode(N(ntIt, "A", func() {}))
+						suite.PushNode(N(ntIt, "B", func() {}))
+					}))
+					suite.PushNode(N(ntCon, "ordered", Ordered, func() {
+						suite.PushNode(N(ntIt, "C", SpecID("C"), DependsOn("db"), func() {}))
+						suite.PushNode(N(ntIt, "D", DependsOn("C"), func() {}))
+					}))
+					Ω(suite.BuildTree()).Should(Succeed())
+				})
+
+				It("errors when a SpecID is used more than once", func() {
+					suite.PushNode(N(ntIt, "A", SpecID("a"), clA, func() {}))
+					suite.PushNode(N(ntIt, "B", SpecID("a"), clB, func() {}))
+					Ω(suite.BuildTree()).Should(MatchError(types.GinkgoErrors.DuplicateSpecID(clB, "a", clA)))
+				})
+
+				It("errors when a spec depends on an unknown SpecID", func() {
+					suite.PushNode(N(ntIt, "A", DependsOn("nope"), clA, func() {}))
+					Ω(suite.BuildTree()).Should(MatchError(types.GinkgoErrors.UnknownSpecDependency(clA, "nope")))
+				})
+
+				It("errors when a spec depends on itself", func() {
+					suite.PushNode(N(ntCon, "container", SpecID("a"), func() {
+						suite.PushNode(N(ntIt, "A", DependsOn("a"), clA, func() {}))
+					}))
+					err := suite.BuildTree()
+					Ω(err).Should(HaveOccurred())
+					Ω(err.(types.GinkgoError).Heading).Should(Equal("Cyclic Spec Dependencies"))
+					Ω(err.(types.GinkgoError).CodeLocation).Should(Equal(clA))
+				})
+
+				It("errors when a spec depends on a spec that runs after it in the same Ordered container", func() {
+					suite.PushNode(N(ntCon, "ordered", Ordered, func() {
+						suite.PushNode(N(ntIt, "A", DependsOn("b"), clA, func() {}))
+						suite.PushNode(N(ntIt, "B", SpecID("b"), func() {}))
+					}))
+					err := suite.BuildTree()
+					Ω(err).Should(HaveOccurred())
+					Ω(err.(types.GinkgoError).Heading).Should(Equal("Cyclic Spec Dependencies"))
+					Ω(err.(types.GinkgoError).Message).Should(ContainSubstring("runs before it in the same Ordered container"))
+				})
+
+				It("errors when the dependencies form a cycle", func() {
+					suite.PushNode(N(ntIt, "A", SpecID("a"), DependsOn("c"), clA, func() {}))
+					suite.PushNode(N(ntIt, "B", SpecID("b"), DependsOn("a"), clB, func() {}))
+					suite.PushNode(N(ntIt, "C", SpecID("c"), DependsOn("b"), func() {}))
+					suite.PushNode(N(ntIt, "D", DependsOn("c"), func() {}))
+					err := suite.BuildTree()
+					Ω(err).Should(HaveOccurred())
+					Ω(err.(types.GinkgoError).Heading).Should(Equal("Cyclic Spec Dependencies"))
+					Ω(err.(types.GinkgoError).Message).Should(ContainSubstring(clA.String()))
+					Ω(err.(types.GinkgoError).Message).Should(ContainSubstring(clB.String()))
+				})
+
+				It("errors when a spec that isn't Serial depends on a Serial spec", func() {
+					suite.PushNode(N(ntIt, "A", SpecID("a"), Serial, func() {}))
+					suite.PushNode(N(ntIt, "B", DependsOn("a"), clB, func() {}))
+					Ω(suite.BuildTree()).Should(MatchError(types.GinkgoErrors.SpecDependsOnSerialSpec(clB, "a")))
+				})
+			})
+
+			Context("when registering shared fixtures", func() {
+				It("errors when a name is used more than once", func() {
+					clA, clB := CL("a.go", 1), CL("b.go", 1)
+					Ω(suite.RegisterSharedFixture(&internal.SharedFixture{Name: "db", CodeLocation: clA})).Should(Succeed())
+					Ω(suite.RegisterSharedFixture(&internal.SharedFixture{Name: "cache", CodeLocation: clA})).Should(Succeed())
+					Ω(suite.RegisterSharedFixture(&internal.SharedFixture{Name: "db", CodeLocation: clB})).Should(MatchError(types.GinkgoErrors.DuplicateSharedFixture(clB, "db", clA)))
+				})
+			})
+
 			Context("when pushing a suite node during PhaseBuildTree", func() {
 				It("errors", func() {
 					var pushSuiteNodeErr error
@@ -335,6 +335,10 @@ func HaveAborted(options ...any) OmegaMatcher {
 	return failureMatcherForState(types.SpecStateAborted, "Failure.Message", options...)
 }
 
+func HaveBeenQuarantined(options ...any) OmegaMatcher {
+	return failureMatcherForState(types.SpecStateQuarantined, "Failure.Message", options...)
+}
+
 func HavePanicked(options ...any) OmegaMatcher {
 	return failureMatcherForState(types.SpecStatePanicked, "Failure.ForwardedPanic", options...)
 }
@@ -0,0 +1,79 @@
+package testingtproxy
+
+import (
+	"context"
+	"io"
+)
+
+/*
+The portion of the interface returned by GinkgoT() that maps onto methods in the testing package's T.
+*/
+type GinkgoTInterface interface {
+	Cleanup(func())
+	Chdir(dir string)
+	Context() context.Context
+	Setenv(kev, value string)
+	Error(args ...any)
+	Errorf(format string, args ...any)
+	Fail()
+	FailNow()
+	Failed() bool
+	Fatal(args ...any)
+	Fatalf(format string, args ...any)
+	Helper()
+	Log(args ...any)
+	Logf(format string, args ...any)
+	Name() string
+	Parallel()
+	Skip(args ...any)
+	SkipNow()
+	Skipf(format string, args ...any)
+	Skipped() bool
+	TempDir() string
+	Attr(key, value string)
+	Output() io.Writer
+	ArtifactDir() string
+}
+
+/*
+Additional methods returned by GinkgoT() that provide deeper integration points into Ginkgo
+*/
+type FullGinkgoTInterface interface {
+	GinkgoTInterface
+
+	AddReportEntryVisibilityAlways(name string, args ...any)
+	AddReportEntryVisibilityFailureOrVerbose(name string, args ...any)
+	AddReportEntryVisibilityNever(name string, args ...any)
+
+	//Prints to the GinkgoWriter
+	Print(a ...any)
+	Printf(format string, a ...any)
+	Println(a ...any)
+
+	//Provides access to Ginkgo's color formatting, correctly configured to match the color settings specified in the invocation of ginkgo
+	F(format string, args ...any) string
+	Fi(indentation uint, format string, args ...any) string
+	Fiw(indentation uint, maxWidth uint, format string, args ...any) string
+
+	//Generates a formatted string version of the current spec's timeline
+	RenderTimeline() string
+
+	GinkgoRecover()
+	DeferCleanup(args ...any)
+
+	RandomSeed() int64
+	ParallelProcess() int
+	ParallelTotal() int
+
+	AttachProgressReporter(func() string) func()
+}
+
+/*
+GinkgoTRunner is implemented by the value returned by GinkgoT() and by the t passed to its subtests.  Run runs f as a subtest.
+
+GinkgoTRunner is separate from GinkgoTInterface and FullGinkgoTInterface so that existing implementations of those interfaces continue to satisfy them.  As with *testing.T, f receives the type that Run is called on - so GinkgoTRunner can be passed to helpers that are generic over interface{ Run(string, func(T)) bool }.
+*/
+type GinkgoTRunner interface {
+	FullGinkgoTInterface
+	Run(name string, f func(t GinkgoTRunner)) bool
+}
@@ -0,0 +1,137 @@
+package testingtproxy
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ GinkgoTRunner = &ginkgoTestingTProxy{}
+
+// subtestAbort is the panic used to stop a subtest when it fails or is skipped.  Run recovers it.
+type subtestAbort struct{}
+
+// subtest tracks the state of a subtest started with Run
+type subtest struct {
+	// name is the full name of the subtest - including the names of the spec and any parent subtests
+	name   string
+	report types.SubtestReport
+}
+
+func (s *subtest) fail(message string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+	s.recordFailure(types.SpecStateFailed, types.Failure{Message: message, Location: types.NewCodeLocationWithStackTrace(skip + 1)})
+	panic(subtestAbort{})
+}
+
+func (s *subtest) skip(message string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+	s.recordFailure(types.SpecStateSkipped, types.Failure{Message: message, Location: types.NewCodeLocationWithStackTrace(skip + 1)})
+	panic(subtestAbort{})
+}
+
+// only the first failure is recorded - just like the spec's Failer
+func (s *subtest) recordFailure(state types.SpecState, failure types.Failure) {
+	if s.report.State == types.SpecStatePassed {
+		s.report.State, s.report.Failure = state, failure
+	}
+}
+
+// subtestWriter captures the output written by a subtest while forwarding it to its parent's writer
+type subtestWriter struct {
+	lock   *sync.Mutex
+	parent ginkgoWriterInterface
+	output *bytes.Buffer
+}
+
+func (w *subtestWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	w.output.Write(p)
+	w.lock.Unlock()
+	return w.parent.Write(p)
+}
+
+func (w *subtestWriter) Print(a ...any) {
+	fmt.Fprint(w, a...)
+}
+
+func (w *subtestWriter) Printf(format string, a ...any) {
+	fmt.Fprintf(w, format, a...)
+}
+
+func (w *subtestWriter) Println(a ...any) {
+	fmt.Fprintln(w, a...)
+}
+
+func (w *subtestWriter) String() string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.output.String()
+}
+
+/*
+Run runs f as a subtest named name and reports whether it succeeded.
+
+Each subtest is reported separately, with its own outcome, timing, and captured GinkgoWriter output.  As with Ginkgo's Fail, failing a subtest stops it immediately.  Unlike Fail, however, a failing subtest does not stop its parent: the parent is marked as failed and carries on, just like *testing.T.  Skipping a subtest only skips the subtest.
+
+Failures reported directly to Ginkgo (e.g. via Gomega's global Expect) and panics stop the subtest and its parents.  Use the t passed to f to attribute failures to the subtest.
+*/
+func (t *ginkgoTestingTProxy) Run(name string, f func(t GinkgoTRunner)) (passed bool) {
+	s := &subtest{
+		name:   t.Name() + "/" + name,
+		report: types.SubtestReport{Name: name, State: types.SpecStatePassed, StartTime: time.Now()},
+	}
+	writer := &subtestWriter{lock: &sync.Mutex{}, parent: t.writer, output: &bytes.Buffer{}}
+
+	subT := *t
+	subT.subtest = s
+	subT.fail, subT.skip = s.fail, s.skip
+	subT.writer = writer
+
+	defer func() {
+		e := recover()
+		s.report.EndTime = time.Now()
+		s.report.RunTime = s.report.EndTime.Sub(s.report.StartTime)
+		s.report.CapturedGinkgoWriterOutput = writer.String()
+
+		_, aborted := e.(subtestAbort)
+		_, stoppedByGinkgo := e.(types.GinkgoError)
+		switch {
+		case e == nil || aborted:
+			e = nil
+		case stoppedByGinkgo:
+			s.recordFailure(types.SpecStateFailed, types.Failure{Message: "Subtest stopped by a failure in the spec", Location: types.NewCodeLocationWithStackTrace(2)})
+		default:
+			s.recordFailure(types.SpecStatePanicked, types.Failure{Message: "Test Panicked", Location: types.NewCodeLocationWithStackTrace(2), ForwardedPanic: fmt.Sprintf("%v", e)})
+		}
+
+		t.recordSubtest(s.report)
+		passed = !s.report.Failed()
+		if e != nil {
+			panic(e)
+		}
+	}()
+
+	f(&subT)
+	return
+}
+
+func (t *ginkgoTestingTProxy) recordSubtest(report types.SubtestReport) {
+	if t.subtest == nil {
+		t.addSubtestReport(report)
+		return
+	}
+	t.subtest.report.Subtests = append(t.subtest.report.Subtests, report)
+	if report.State.Is(types.SpecStateFailed) {
+		t.subtest.recordFailure(types.SpecStateFailed, types.Failure{Message: fmt.Sprintf("Subtest \"%s\" failed:\n%s", report.Name, report.Failure.Message), Location: report.Failure.Location})
+	}
+}
@@ -17,6 +17,7 @@ type skipFunc func(message string, callerSkip ...int)
 type cleanupFunc func(args ...any)
 type reportFunc func() types.SpecReport
 type addReportEntryFunc func(names string, args ...any)
+type addSubtestReportFunc func(report types.SubtestReport)
 type ginkgoWriterInterface interface {
 	io.Writer
 
@@ -32,7 +33,7 @@ var formatters = map[bool]formatter.Formatter{
 	false: formatter.NewWithNoColorBool(false),
 }
 
-func New(writer ginkgoWriterInterface, fail failFunc, skip skipFunc, cleanup cleanupFunc, report reportFunc, addReportEntry addReportEntryFunc, ginkgoRecover ginkgoRecoverFunc, attachProgressReporter attachProgressReporterFunc, randomSeed int64, parallelProcess int, parallelTotal int, noColor bool, offset int) *ginkgoTestingTProxy {
+func New(writer ginkgoWriterInterface, fail failFunc, skip skipFunc, cleanup cleanupFunc, report reportFunc, addReportEntry addReportEntryFunc, addSubtestReport addSubtestReportFunc, ginkgoRecover ginkgoRecoverFunc, attachProgressReporter attachProgressReporterFunc, randomSeed int64, parallelProcess int, parallelTotal int, noColor bool, offset int) *ginkgoTestingTProxy {
 	return &ginkgoTestingTProxy{
 		fail:                   fail,
 		offset:                 offset,
@@ -41,6 +42,7 @@ func New(writer ginkgoWriterInterface, fail failFunc, skip skipFunc, cleanup cle
 		cleanup:                cleanup,
 		report:                 report,
 		addReportEntry:         addReportEntry,
+		addSubtestReport:       addSubtestReport,
 		ginkgoRecover:          ginkgoRecover,
 		attachProgressReporter: attachProgressReporter,
 		randomSeed:             randomSeed,
@@ -58,12 +60,16 @@ type ginkgoTestingTProxy struct {
 	offset                 int
 	writer                 ginkgoWriterInterface
 	addReportEntry         addReportEntryFunc
+	addSubtestReport       addSubtestReportFunc
 	ginkgoRecover          ginkgoRecoverFunc
 	attachProgressReporter attachProgressReporterFunc
 	randomSeed             int64
 	parallelProcess        int
 	parallelTotal          int
 	f                      formatter.Formatter
+
+	// subtest is only set for the proxies passed to the functions given to Run
+	subtest *subtest
 }
 
 // basic testing.T support
@@ -123,6 +129,9 @@ func (t *ginkgoTestingTProxy) FailNow() {
 }
 
 func (t *ginkgoTestingTProxy) Failed() bool {
+	if t.subtest != nil {
+		return t.subtest.report.Failed()
+	}
 	return t.report().Failed()
 }
 
@@ -147,6 +156,9 @@ func (t *ginkgoTestingTProxy) Logf(format string, args ...any) {
 }
 
 func (t *ginkgoTestingTProxy) Name() string {
+	if t.subtest != nil {
+		return t.subtest.name
+	}
 	return t.report().FullText()
 }
 
@@ -167,6 +179,9 @@ func (t *ginkgoTestingTProxy) Skipf(format string, args ...any) {
 }
 
 func (t *ginkgoTestingTProxy) Skipped() bool {
+	if t.subtest != nil {
+		return t.subtest.report.State.Is(types.SpecStateSkipped)
+	}
 	return t.report().State.Is(types.SpecStateSkipped)
 }
 
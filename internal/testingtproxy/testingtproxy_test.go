@@ -23,12 +23,28 @@ type messagedCall struct {
 	callerSkip []int
 }
 
+// runCases runs each name as a subtest of the previous one - as a third-party helper written against *testing.T's Run might
+func runCases[T interface {
+	Name() string
+	Run(string, func(T)) bool
+}](t T, names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	var ran []string
+	t.Run(names[0], func(t T) {
+		ran = append([]string{t.Name()}, runCases(t, names[1:])...)
+	})
+	return ran
+}
+
 var _ = Describe("Testingtproxy", func() {
 	var t FullGinkgoTInterface
 
 	var failFunc func(message string, callerSkip ...int)
 	var skipFunc func(message string, callerSkip ...int)
 	var reportFunc func() types.SpecReport
+	var subtestReports []types.SubtestReport
 
 	var failFuncCall messagedCall
 	var skipFuncCall messagedCall
@@ -47,6 +63,7 @@ var _ = Describe("Testingtproxy", func() {
 		skipFuncCall = messagedCall{}
 		offset = 3
 		reportToReturn = types.SpecReport{}
+		subtestReports = nil
 
 		failFunc = func(message string, callerSkip ...int) {
 			failFuncCall.message = message
@@ -81,6 +98,7 @@ var _ = Describe("Testingtproxy", func() {
 			DeferCleanup,
 			reportFunc,
 			AddReportEntry,
+			func(report types.SubtestReport) { subtestReports = append(subtestReports, report) },
 			ginkgoRecoverFunc,
 			attachProgressReporterFunc,
 			17,
@@ -447,6 +465,116 @@ var _ = Describe("Testingtproxy", func() {
 		})
 	})
 
+	Describe("Run", func() {
+		var runner GinkgoTRunner
+		BeforeEach(func() {
+			reportToReturn.LeafNodeText = "spec"
+			runner = t.(GinkgoTRunner)
+		})
+
+		It("runs passing subtests and records their output and timing", func() {
+			var name string
+			Ω(runner.Run("a", func(t GinkgoTRunner) {
+				name = t.Name()
+				t.Log("hi from a")
+			})).Should(BeTrue())
+
+			Ω(name).Should(Equal("spec/a"))
+			Ω(string(buf.Contents())).Should(Equal("  hi from a\n"))
+			Ω(subtestReports).Should(HaveLen(1))
+			Ω(subtestReports[0].Name).Should(Equal("a"))
+			Ω(subtestReports[0].State).Should(Equal(types.SpecStatePassed))
+			Ω(subtestReports[0].CapturedGinkgoWriterOutput).Should(Equal("hi from a\n"))
+			Ω(subtestReports[0].RunTime).Should(BeNumerically(">", 0))
+			Ω(subtestReports[0].EndTime).Should(BeTemporally(">=", subtestReports[0].StartTime))
+			Ω(failFuncCall.message).Should(BeZero())
+		})
+
+		It("stops failing subtests without stopping the parent", func() {
+			ranAfterFailure := false
+			Ω(runner.Run("a", func(t GinkgoTRunner) {
+				t.Fatalf("boom %d", 17)
+				ranAfterFailure = true
+			})).Should(BeFalse())
+			Ω(runner.Run("b", func(t GinkgoTRunner) {})).Should(BeTrue())
+
+			Ω(ranAfterFailure).Should(BeFalse())
+			Ω(failFuncCall.message).Should(BeZero())
+			Ω(subtestReports).Should(HaveLen(2))
+			Ω(subtestReports[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(subtestReports[0].Failure.Message).Should(Equal("boom 17"))
+			Ω(subtestReports[1].State).Should(Equal(types.SpecStatePassed))
+		})
+
+		It("only skips skipped subtests", func() {
+			var skipped bool
+			Ω(runner.Run("a", func(t GinkgoTRunner) {
+				t.Skip("not today")
+			})).Should(BeTrue())
+			Ω(runner.Run("b", func(t GinkgoTRunner) {
+				skipped = t.Skipped()
+			})).Should(BeTrue())
+
+			Ω(skipFuncCall.message).Should(BeZero())
+			Ω(subtestReports[0].State).Should(Equal(types.SpecStateSkipped))
+			Ω(subtestReports[0].Failure.Message).Should(Equal("not today\n"))
+			Ω(skipped).Should(BeFalse())
+		})
+
+		It("records nested subtests as children, failing the parents of failed subtests", func() {
+			var failed bool
+			Ω(runner.Run("a", func(t GinkgoTRunner) {
+				t.Run("b", func(t GinkgoTRunner) {
+					t.Run("c", func(t GinkgoTRunner) {
+						t.Error("boom")
+					})
+					failed = t.Failed()
+				})
+				t.Run("d", func(t GinkgoTRunner) {
+					t.Print("hi from d")
+				})
+			})).Should(BeFalse())
+
+			Ω(failed).Should(BeTrue())
+			Ω(subtestReports).Should(HaveLen(1))
+			a := subtestReports[0]
+			Ω(a.State).Should(Equal(types.SpecStateFailed))
+			Ω(a.Failure.Message).Should(Equal("Subtest \"b\" failed:\nSubtest \"c\" failed:\nboom\n"))
+			Ω(a.CapturedGinkgoWriterOutput).Should(Equal("hi from d"))
+			Ω(a.Subtests).Should(HaveLen(2))
+			Ω(a.Subtests[0].Name).Should(Equal("b"))
+			Ω(a.Subtests[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(a.Subtests[0].Subtests[0].Name).Should(Equal("c"))
+			Ω(a.Subtests[0].Subtests[0].Failure.Message).Should(Equal("boom\n"))
+			Ω(a.Subtests[1].Name).Should(Equal("d"))
+			Ω(a.Subtests[1].State).Should(Equal(types.SpecStatePassed))
+			Ω(a.Subtests[1].CapturedGinkgoWriterOutput).Should(Equal("hi from d"))
+		})
+
+		It("can be driven by helpers that are generic over the Run method - like *testing.T", func() {
+			Ω(runCases(runner, []string{"a", "b"})).Should(Equal([]string{"spec/a", "spec/a/b"}))
+			Ω(subtestReports).Should(HaveLen(1))
+			Ω(subtestReports[0].Subtests[0].Name).Should(Equal("b"))
+		})
+
+		It("is not part of GinkgoTInterface or FullGinkgoTInterface, so that existing implementations still satisfy them", func() {
+			_, hasRun := reflect.TypeFor[FullGinkgoTInterface]().MethodByName("Run")
+			Ω(hasRun).Should(BeFalse())
+		})
+
+		It("records panicking subtests and forwards the panic", func() {
+			Ω(func() {
+				runner.Run("a", func(t GinkgoTRunner) {
+					panic("bam")
+				})
+			}).Should(PanicWith("bam"))
+
+			Ω(subtestReports).Should(HaveLen(1))
+			Ω(subtestReports[0].State).Should(Equal(types.SpecStatePanicked))
+			Ω(subtestReports[0].Failure.ForwardedPanic).Should(Equal("bam"))
+		})
+	})
+
 	Describe("Attr", func() {
 		It("adds report entries with visibility FailureOrVerbose", func() {
 			cl := types.NewCodeLocation(0)
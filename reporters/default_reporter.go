@@ -80,6 +80,9 @@ func (r *DefaultReporter) SuiteWillBegin(report types.Report) {
 			r.emit(r.f("{{coral}}[Components: %s]{{/}} ", formatComponentSemVerConstraintsToString(report.SuiteComponentSemVerConstraints)))
 		}
 		r.emit(r.f("- %d/%d specs ", report.PreRunStats.SpecsThatWillRun, report.PreRunStats.TotalSpecs))
+		if report.PreRunStats.TotalShards > 1 {
+			r.emit(r.f("- shard %d/%d ", report.PreRunStats.Shard, report.PreRunStats.TotalShards))
+		}
 		if report.SuiteConfig.ParallelTotal > 1 {
 			r.emit(r.f("- %d procs ", report.SuiteConfig.ParallelTotal))
 		}
@@ -119,6 +122,9 @@ func (r *DefaultReporter) SuiteWillBegin(report types.Report) {
 		r.emitBlock(out)
 		r.emit("\n")
 		r.emitBlock(r.f("Will run {{bold}}%d{{/}} of {{bold}}%d{{/}} specs", report.PreRunStats.SpecsThatWillRun, report.PreRunStats.TotalSpecs))
+		if report.PreRunStats.TotalShards > 1 {
+			r.emitBlock(r.f("Running shard {{bold}}%d{{/}} of {{bold}}%d{{/}}", report.PreRunStats.Shard, report.PreRunStats.TotalShards))
+		}
 		if report.SuiteConfig.ParallelTotal > 1 {
 			r.emitBlock(r.f("Running in parallel across {{bold}}%d{{/}} processes", report.SuiteConfig.ParallelTotal))
 		}
@@ -151,6 +157,39 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 		}
 	}
 
+	quarantined := report.SpecReports.WithState(types.SpecStateQuarantined)
+	if !r.conf.FdOutput && len(quarantined) > 0 {
+		r.emitBlock("\n")
+		if len(quarantined) > 1 {
+			r.emitBlock(r.f("{{light-yellow}}{{bold}}Summarizing %d Quarantined Failures:{{/}}", len(quarantined)))
+		} else {
+			r.emitBlock(r.f("{{light-yellow}}{{bold}}Summarizing 1 Quarantined Failure:{{/}}"))
+		}
+		for _, specReport := range quarantined {
+			locationBlock := r.codeLocationBlock(specReport, "{{light-yellow}}", false, true)
+			r.emitBlock(r.fi(1, "{{light-yellow}}[QUARANTINED]{{/}} %s", locationBlock))
+		}
+	}
+
+	if !r.conf.FdOutput && len(report.ObsoleteSnapshots) > 0 {
+		r.emitBlock("\n")
+		verb := "Found"
+		if report.SuiteConfig.UpdateSnapshots {
+			verb = "Removed"
+		}
+		if len(report.ObsoleteSnapshots) > 1 {
+			r.emitBlock(r.f("{{yellow}}{{bold}}%s %d Obsolete Snapshots:{{/}}", verb, len(report.ObsoleteSnapshots)))
+		} else {
+			r.emitBlock(r.f("{{yellow}}{{bold}}%s 1 Obsolete Snapshot:{{/}}", verb))
+		}
+		for _, snapshot := range report.ObsoleteSnapshots {
+			r.emitBlock(r.fi(1, "{{yellow}}[OBSOLETE]{{/}} %s {{gray}}%s{{/}}", snapshot.Key, snapshot.Path))
+		}
+		if !report.SuiteConfig.UpdateSnapshots {
+			r.emitBlock(r.fi(1, "Run with {{bold}}--update-snapshots{{/}} to remove them"))
+		}
+	}
+
 	//summarize the suite
 	if r.conf.Verbosity().Is(types.VerbosityLevelSuccinct) && report.SuiteSucceeded {
 		r.emit(r.f(" {{green}}SUCCESS!{{/}} %s ", report.RunTime))
@@ -165,7 +204,7 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 
 	specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt) //exclude any suite setup nodes
 	r.emitBlock(r.f(color+"Ran %d of %d Specs in %.3f seconds{{/}}",
-		specs.CountWithState(types.SpecStatePassed)+specs.CountWithState(types.SpecStateFailureStates),
+		specs.CountWithState(types.SpecStatePassed|types.SpecStateQuarantined)+specs.CountWithState(types.SpecStateFailureStates),
 		report.PreRunStats.TotalSpecs,
 		report.RunTime.Seconds()),
 	)
@@ -184,6 +223,9 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 	} else {
 		r.emit(r.f("{{green}}{{bold}}%d Passed{{/}} | ", specs.CountWithState(types.SpecStatePassed)))
 		r.emit(r.f("{{red}}{{bold}}%d Failed{{/}} | ", specs.CountWithState(types.SpecStateFailureStates)))
+		if specs.CountWithState(types.SpecStateQuarantined) > 0 {
+			r.emit(r.f("{{light-yellow}}{{bold}}%d Quarantined{{/}} | ", specs.CountWithState(types.SpecStateQuarantined)))
+		}
 		if specs.CountOfFlakedSpecs() > 0 {
 			r.emit(r.f("{{light-yellow}}{{bold}}%d Flaked{{/}} | ", specs.CountOfFlakedSpecs()))
 		}
@@ -239,18 +281,20 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		header = fmt.Sprintf("[%s]", report.LeafNodeType)
 	}
 	highlightColor := r.highlightColorForState(report.State)
+	// quarantined failures are rendered in as much detail as real failures - they just don't fail the suite
+	failedOrQuarantined := report.Failed() || report.State.Is(types.SpecStateQuarantined)
 
 	// have we already been streaming the timeline?
 	timelineHasBeenStreaming := v.GTE(types.VerbosityLevelVerbose) && !inParallel
 
 	// should we show the timeline?
 	var timeline types.Timeline
-	showTimeline := !timelineHasBeenStreaming && (v.GTE(types.VerbosityLevelVerbose) || report.Failed())
+	showTimeline := !timelineHasBeenStreaming && (v.GTE(types.VerbosityLevelVerbose) || failedOrQuarantined)
 	if showTimeline {
 		timeline = report.Timeline().WithoutHiddenReportEntries()
 		keepVeryVerboseSpecEvents := v.Is(types.VerbosityLevelVeryVerbose) ||
 			(v.Is(types.VerbosityLevelVerbose) && r.conf.ShowNodeEvents) ||
-			(report.Failed() && r.conf.ShowNodeEvents)
+			(failedOrQuarantined && r.conf.ShowNodeEvents)
 		if !keepVeryVerboseSpecEvents {
 			timeline = timeline.WithoutVeryVerboseSpecEvents()
 		}
@@ -274,7 +318,7 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 	showSeparateStdSection := inParallel && (report.CapturedStdOutErr != "")
 
 	// given all that - do we have any actual content to show? or are we a single denoter in a stream?
-	reportHasContent := v.Is(types.VerbosityLevelVeryVerbose) || showTimeline || showSeparateVisibilityAlwaysReportsSection || showSeparateStdSection || report.Failed() || (v.Is(types.VerbosityLevelVerbose) && !report.State.Is(types.SpecStateSkipped))
+	reportHasContent := v.Is(types.VerbosityLevelVeryVerbose) || showTimeline || showSeparateVisibilityAlwaysReportsSection || showSeparateStdSection || failedOrQuarantined || (v.Is(types.VerbosityLevelVerbose) && !report.State.Is(types.SpecStateSkipped))
 
 	// should we show a runtime?
 	includeRuntime := !report.State.Is(types.SpecStateSkipped|types.SpecStatePending) || (report.State.Is(types.SpecStateSkipped) && report.Failure.Message != "")
@@ -353,6 +397,15 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		})
 	}
 
+	if report.State.Is(types.SpecStateQuarantined) {
+		r.emitBlock("\n")
+		if report.QuarantineReason != "" {
+			r.emitBlock(r.fi(1, highlightColor+"This spec is quarantined so its failure does not fail the suite: {{bold}}%s{{/}}", report.QuarantineReason))
+		} else {
+			r.emitBlock(r.fi(1, highlightColor+"This spec is quarantined so its failure does not fail the suite{{/}}"))
+		}
+	}
+
 	// Emit Failure Message
 	if !report.Failure.IsZero() && !v.Is(types.VerbosityLevelVeryVerbose) {
 		r.emitBlock("\n")
@@ -399,6 +452,8 @@ func (r *DefaultReporter) didRunFd(report types.SpecReport) {
 	switch report.State {
 	case types.SpecStateFailed, types.SpecStatePanicked:
 		label = fmt.Sprintf("%s (FAILED)", label)
+	case types.SpecStateQuarantined:
+		label = fmt.Sprintf("%s (QUARANTINED)", label)
 	case types.SpecStatePending:
 		label = fmt.Sprintf("%s (PENDING)", label)
 	case types.SpecStateSkipped:
@@ -428,6 +483,8 @@ func (r *DefaultReporter) highlightColorForState(state types.SpecState) string {
 		return "{{orange}}"
 	case types.SpecStateAborted:
 		return "{{coral}}"
+	case types.SpecStateQuarantined:
+		return "{{light-yellow}}"
 	default:
 		return "{{gray}}"
 	}
@@ -511,6 +568,8 @@ func (r *DefaultReporter) emitFailure(indent uint, state types.SpecState, failur
 		level := "error"
 		if state.Is(types.SpecStateSkipped) {
 			level = "notice"
+		} else if state.Is(types.SpecStateQuarantined) {
+			level = "warning"
 		}
 		r.emitBlock(r.fi(indent, "::%s file=%s,line=%d::%s %s", level, failure.Location.FileName, failure.Location.LineNumber, failure.FailureNodeType, failure.TimelineLocation.Time.Format(types.GINKGO_TIME_FORMAT)))
 	} else {
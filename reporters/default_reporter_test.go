@@ -111,6 +111,7 @@ func AF(state types.SpecState, options ...any) types.AdditionalFailure {
 
 type STD string
 type GW string
+type QuarantineReason string
 
 // convenience helper to quickly make SpecReports
 func S(options ...any) types.SpecReport {
@@ -157,6 +158,8 @@ func S(options ...any) types.SpecReport {
 			report.LeafNodeComponentSemVerConstraints = x
 		case types.SpecState:
 			report.State = x
+		case SpecID:
+			report.ID = string(x)
 		case time.Duration:
 			report.RunTime = x
 		case int:
@@ -169,6 +172,9 @@ func S(options ...any) types.SpecReport {
 			report.CapturedStdOutErr = string(x)
 		case GW:
 			report.CapturedGinkgoWriterOutput = string(x)
+		case QuarantineReason:
+			report.IsQuarantined = true
+			report.QuarantineReason = string(x)
 		case types.Failure:
 			report.Failure = x
 		case types.AdditionalFailure:
@@ -553,6 +559,20 @@ var _ = Describe("DefaultReporter", func() {
 			"Running in parallel across {{bold}}3{{/}} processes",
 			"",
 		),
+		Entry("when sharded",
+			C(),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 5, TotalSpecs: 7, Shard: 2, TotalShards: 3},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1},
+			},
+			"Running Suite: My Suite - /path/to/suite",
+			"========================================",
+			"Random Seed: {{bold}}17{{/}}",
+			"",
+			"Will run {{bold}}5{{/}} of {{bold}}7{{/}} specs",
+			"Running shard {{bold}}2{{/}} of {{bold}}3{{/}}",
+			"",
+		),
 		Entry("when succinct and in series",
 			C(Succinct),
 			types.Report{
@@ -569,6 +589,14 @@ var _ = Describe("DefaultReporter", func() {
 			},
 			"[17] {{bold}}My Suite{{/}} - 15/20 specs - 3 procs ",
 		),
+		Entry("when succinct and sharded",
+			C(Succinct),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 5, TotalSpecs: 7, Shard: 2, TotalShards: 3},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1},
+			},
+			"[17] {{bold}}My Suite{{/}} - 5/7 specs - shard 2/3 ",
+		),
 		Entry("when succinct and with labels",
 			C(Succinct),
 			types.Report{
@@ -1525,6 +1553,39 @@ var _ = Describe("DefaultReporter", func() {
 				DELIMITER,
 				""),
 		),
+		Entry("a quarantined failure",
+			S(types.NodeTypeIt, CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateQuarantined, QuarantineReason("flakes under load"),
+				F("failure\nmessage", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt, TL(0)),
+			),
+			Case(Succinct, Succinct|Parallel, Normal, Normal|Parallel, Verbose|Parallel,
+				DELIMITER,
+				spr("{{light-yellow}}%s [QUARANTINED] [1.000 seconds]{{/}}", DENOTER),
+				"{{/}}A {{gray}}B {{light-yellow}}{{bold}}[It] C{{/}}",
+				"{{gray}}cl2.go:80{{/}}",
+				"",
+				"  {{light-yellow}}This spec is quarantined so its failure does not fail the suite: {{bold}}flakes under load{{/}}",
+				"",
+				"  {{light-yellow}}[QUARANTINED] failure",
+				"  message{{/}}",
+				spr("  {{light-yellow}}In {{bold}}[It]{{/}}{{light-yellow}} at: {{bold}}cl3.go:103{{/}} {{gray}}@ %s{{/}}", FORMATTED_TIME),
+				DELIMITER,
+				""),
+			Case(Verbose,
+				DELIMITER,
+				"{{/}}A {{gray}}B {{/}}{{bold}}[It] C{{/}}",
+				"{{gray}}cl2.go:80{{/}}",
+				spr("{{light-yellow}}%s [QUARANTINED] [1.000 seconds]{{/}}", DENOTER),
+				"{{/}}A {{gray}}B {{light-yellow}}{{bold}}[It] C{{/}}",
+				"{{gray}}cl2.go:80{{/}}",
+				"",
+				"  {{light-yellow}}This spec is quarantined so its failure does not fail the suite: {{bold}}flakes under load{{/}}",
+				"",
+				"  {{light-yellow}}[QUARANTINED] failure",
+				"  message{{/}}",
+				spr("  {{light-yellow}}In {{bold}}[It]{{/}}{{light-yellow}} at: {{bold}}cl3.go:103{{/}} {{gray}}@ %s{{/}}", FORMATTED_TIME),
+				DELIMITER,
+				""),
+		),
 		Entry("a failed test with GinkgoWriter output",
 			S(types.NodeTypeIt, CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateTimedout, GW("some ginkgowriter\noutput\n"),
 				F("failure\nmessage", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt, TL("some ginkgowriter\n"), AF(types.SpecStatePanicked, cl4, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt, TL("some ginkgowriter\noutput\n"))),
@@ -2033,6 +2094,71 @@ var _ = Describe("DefaultReporter", func() {
 			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}7 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{light-yellow}}{{bold}}2 Flaked{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}3 Skipped{{/}}",
 			"",
 		),
+		Entry("the suite passes with quarantined failures",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 5, SpecsThatWillRun: 5},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed), S(types.SpecStatePassed), S(types.SpecStatePassed, QuarantineReason("")),
+					S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateQuarantined, QuarantineReason("flakes"), F("FAILURE MESSAGE", cl2)),
+					S(CTS("Describe A"), "The Other Test", CLS(cl0), cl3, types.SpecStateQuarantined, QuarantineReason("flakes"), F("FAILURE MESSAGE", cl4)),
+				},
+			},
+			"",
+			"{{light-yellow}}{{bold}}Summarizing 2 Quarantined Failures:{{/}}",
+			"  {{light-yellow}}[QUARANTINED]{{/}} {{/}}Describe A {{light-yellow}}{{bold}}The Test{{/}}",
+			"  {{gray}}cl2.go:80{{/}}",
+			"  {{light-yellow}}[QUARANTINED]{{/}} {{/}}Describe A {{light-yellow}}{{bold}}The Other Test{{/}}",
+			"  {{gray}}cl4.go:144{{/}}",
+			"",
+			"{{green}}{{bold}}Ran 5 of 5 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}3 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{light-yellow}}{{bold}}2 Quarantined{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes with obsolete snapshots",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 1, SpecsThatWillRun: 1},
+				RunTime:        time.Minute,
+				SpecReports:    types.SpecReports{S(types.SpecStatePassed)},
+				ObsoleteSnapshots: []types.ObsoleteSnapshot{
+					{Path: "__snapshots__/a_test.ginkgo.snap", Key: "A - renders"},
+					{Path: "__snapshots__/b_test.ginkgo.snap", Key: "B - renders"},
+				},
+			},
+			"",
+			"{{yellow}}{{bold}}Found 2 Obsolete Snapshots:{{/}}",
+			"  {{yellow}}[OBSOLETE]{{/}} A - renders {{gray}}__snapshots__/a_test.ginkgo.snap{{/}}",
+			"  {{yellow}}[OBSOLETE]{{/}} B - renders {{gray}}__snapshots__/b_test.ginkgo.snap{{/}}",
+			"  Run with {{bold}}--update-snapshots{{/}} to remove them",
+			"",
+			"{{green}}{{bold}}Ran 1 of 1 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite removed obsolete snapshots",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				SuiteConfig:    types.SuiteConfig{UpdateSnapshots: true},
+				PreRunStats:    types.PreRunStats{TotalSpecs: 1, SpecsThatWillRun: 1},
+				RunTime:        time.Minute,
+				SpecReports:    types.SpecReports{S(types.SpecStatePassed)},
+				ObsoleteSnapshots: []types.ObsoleteSnapshot{
+					{Path: "__snapshots__/a_test.ginkgo.snap", Key: "A - renders"},
+				},
+			},
+			"",
+			"{{yellow}}{{bold}}Removed 1 Obsolete Snapshot:{{/}}",
+			"  {{yellow}}[OBSOLETE]{{/}} A - renders {{gray}}__snapshots__/a_test.ginkgo.snap{{/}}",
+			"",
+			"{{green}}{{bold}}Ran 1 of 1 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
 		Entry("the suite fails with one failed test",
 			C(),
 			types.Report{
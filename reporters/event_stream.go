@@ -0,0 +1,153 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+EventStream writes types.StreamEvents to a destination as newline-delimited JSON - one event per line.  It is safe for concurrent use.
+
+This is the destination of --event-stream.  See EventStreamReporter for the reporter that generates the events.
+*/
+type EventStream struct {
+	lock    *sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewEventStream returns an EventStream that writes to w
+func NewEventStream(w io.Writer) *EventStream {
+	stream := &EventStream{
+		lock:    &sync.Mutex{},
+		encoder: json.NewEncoder(w),
+	}
+	if closer, ok := w.(io.Closer); ok {
+		stream.closer = closer
+	}
+	return stream
+}
+
+// OpenEventStream opens the destination passed to --event-stream and returns an EventStream that writes to it.  See OpenEventStreamDestination.
+func OpenEventStream(destination string) (*EventStream, error) {
+	w, err := OpenEventStreamDestination(destination)
+	if err != nil {
+		return nil, err
+	}
+	return NewEventStream(w), nil
+}
+
+// OpenEventStreamDestination opens the destination passed to --event-stream.  If destination is a unix socket OpenEventStreamDestination connects to it, otherwise it creates (or truncates) destination as a file.
+func OpenEventStreamDestination(destination string) (io.WriteCloser, error) {
+	if info, err := os.Stat(destination); err == nil && info.Mode()&os.ModeSocket != 0 {
+		conn, err := net.Dial("unix", destination)
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+	if err := os.MkdirAll(path.Dir(destination), 0770); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(destination)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Emit writes event to the stream
+func (stream *EventStream) Emit(event types.StreamEvent) error {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+	return stream.encoder.Encode(event)
+}
+
+// Close closes the stream's destination
+func (stream *EventStream) Close() error {
+	if stream.closer == nil {
+		return nil
+	}
+	return stream.closer.Close()
+}
+
+/*
+EventStreamReporter is a Reporter that turns the callbacks it receives into types.StreamEvents and passes them to emit as they happen.
+
+The event stream is best-effort: once emit returns an error (e.g. because whoever is reading the stream has gone away) the EventStreamReporter stops emitting events.  This never affects the outcome of the suite.
+*/
+type EventStreamReporter struct {
+	lock             *sync.Mutex
+	parallelProcess  int
+	suitePath        string
+	suiteDescription string
+	emit             func(types.StreamEvent) error
+	failed           bool
+}
+
+// NewEventStreamReporter returns an EventStreamReporter for the passed-in parallel process
+func NewEventStreamReporter(parallelProcess int, emit func(types.StreamEvent) error) *EventStreamReporter {
+	return &EventStreamReporter{
+		lock:            &sync.Mutex{},
+		parallelProcess: parallelProcess,
+		emit:            emit,
+	}
+}
+
+func (r *EventStreamReporter) SuiteWillBegin(report types.Report) {
+	r.lock.Lock()
+	r.suitePath, r.suiteDescription = report.SuitePath, report.SuiteDescription
+	r.lock.Unlock()
+	report.SpecReports = nil
+	r.send(types.StreamEvent{Type: types.StreamEventSuiteWillBegin, Report: &report})
+}
+
+func (r *EventStreamReporter) WillRun(report types.SpecReport) {
+	r.send(types.StreamEvent{Type: types.StreamEventWillRun, SpecReport: &report})
+}
+
+func (r *EventStreamReporter) DidRun(report types.SpecReport) {
+	r.send(types.StreamEvent{Type: types.StreamEventDidRun, SpecReport: &report})
+}
+
+func (r *EventStreamReporter) SuiteDidEnd(report types.Report) {
+	report.SpecReports = nil
+	r.send(types.StreamEvent{Type: types.StreamEventSuiteDidEnd, Report: &report})
+}
+
+func (r *EventStreamReporter) EmitFailure(state types.SpecState, failure types.Failure) {
+	r.send(types.StreamEvent{Type: types.StreamEventFailure, State: state, Failure: &failure})
+}
+
+func (r *EventStreamReporter) EmitProgressReport(progressReport types.ProgressReport) {
+	r.send(types.StreamEvent{Type: types.StreamEventProgressReport, ProgressReport: &progressReport})
+}
+
+func (r *EventStreamReporter) EmitReportEntry(entry types.ReportEntry) {
+	r.send(types.StreamEvent{Type: types.StreamEventReportEntry, ReportEntry: &entry})
+}
+
+func (r *EventStreamReporter) EmitSpecEvent(event types.SpecEvent) {
+	r.send(types.StreamEvent{Type: types.StreamEventSpecEvent, SpecEvent: &event})
+}
+
+func (r *EventStreamReporter) send(event types.StreamEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.failed {
+		return
+	}
+	event.Time = time.Now()
+	event.SuitePath, event.SuiteDescription = r.suitePath, r.suiteDescription
+	event.ParallelProcess = r.parallelProcess
+	if r.emit(event) != nil {
+		r.failed = true
+	}
+}
@@ -0,0 +1,126 @@
+package reporters_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("EventStream", func() {
+	Describe("EventStreamReporter", func() {
+		var events []types.StreamEvent
+		var emitErr error
+		var reporter *reporters.EventStreamReporter
+
+		BeforeEach(func() {
+			events, emitErr = nil, nil
+			reporter = reporters.NewEventStreamReporter(3, func(event types.StreamEvent) error {
+				events = append(events, event)
+				return emitErr
+			})
+		})
+
+		It("emits an event for each callback, tagged with the suite and the parallel process", func() {
+			reporter.SuiteWillBegin(types.Report{SuitePath: "/path/to/suite", SuiteDescription: "My Suite", SpecReports: types.SpecReports{S("A")}})
+			reporter.WillRun(S("A", cl0))
+			reporter.EmitSpecEvent(SE(types.SpecEventByStart, "a step", cl1))
+			reporter.EmitReportEntry(RE("an entry", cl2))
+			reporter.EmitFailure(types.SpecStateFailed, F("boom", cl3))
+			reporter.EmitProgressReport(PR(LeafNodeText("A")))
+			reporter.DidRun(S("A", cl0, types.SpecStateFailed))
+			reporter.SuiteDidEnd(types.Report{SuitePath: "/path/to/suite", SuiteDescription: "My Suite", SpecReports: types.SpecReports{S("A")}})
+
+			eventTypes := []types.StreamEventType{}
+			for _, event := range events {
+				eventTypes = append(eventTypes, event.Type)
+				Ω(event.SuitePath).Should(Equal("/path/to/suite"))
+				Ω(event.SuiteDescription).Should(Equal("My Suite"))
+				Ω(event.ParallelProcess).Should(Equal(3))
+				Ω(event.Time).ShouldNot(BeZero())
+			}
+			Ω(eventTypes).Should(Equal([]types.StreamEventType{
+				types.StreamEventSuiteWillBegin, types.StreamEventWillRun, types.StreamEventSpecEvent, types.StreamEventReportEntry,
+				types.StreamEventFailure, types.StreamEventProgressReport, types.StreamEventDidRun, types.StreamEventSuiteDidEnd,
+			}))
+			Ω(events[0].Report.SpecReports).Should(BeEmpty())
+			Ω(events[1].SpecReport.LeafNodeText).Should(Equal("A"))
+			Ω(events[2].SpecEvent.Message).Should(Equal("a step"))
+			Ω(events[3].ReportEntry.Name).Should(Equal("an entry"))
+			Ω(events[4].State).Should(Equal(types.SpecStateFailed))
+			Ω(events[4].Failure.Message).Should(Equal("boom"))
+			Ω(events[5].ProgressReport.LeafNodeText).Should(Equal("A"))
+			Ω(events[6].SpecReport.State).Should(Equal(types.SpecStateFailed))
+			Ω(events[7].Report.SpecReports).Should(BeEmpty())
+		})
+
+		It("stops emitting events once emitting an event fails", func() {
+			emitErr = errors.New("gone")
+			reporter.WillRun(S("A"))
+			reporter.DidRun(S("A"))
+			Ω(events).Should(HaveLen(1))
+		})
+	})
+
+	Describe("OpenEventStream", func() {
+		var dir string
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		It("writes newline-delimited JSON to a file", func() {
+			path := filepath.Join(dir, "nested", "events.ndjson")
+			stream, err := reporters.OpenEventStream(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(stream.Emit(types.StreamEvent{Type: types.StreamEventWillRun, ParallelProcess: 1})).Should(Succeed())
+			Ω(stream.Emit(types.StreamEvent{Type: types.StreamEventDidRun, ParallelProcess: 2})).Should(Succeed())
+			Ω(stream.Close()).Should(Succeed())
+
+			data, err := os.ReadFile(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			Ω(lines).Should(HaveLen(2))
+			Ω(lines[0]).Should(ContainSubstring(`"Type":"WillRun"`))
+			var event types.StreamEvent
+			Ω(json.Unmarshal([]byte(lines[1]), &event)).Should(Succeed())
+			Ω(event.Type).Should(Equal(types.StreamEventDidRun))
+			Ω(event.ParallelProcess).Should(Equal(2))
+			Ω(event.SpecReport).Should(BeNil())
+		})
+
+		It("connects to a unix socket", func() {
+			socket, err := os.MkdirTemp("", "ginkgo-event-stream")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, socket)
+			socket = filepath.Join(socket, "events.sock")
+			listener, err := net.Listen("unix", socket)
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(listener.Close)
+
+			received := make(chan string)
+			go func() {
+				defer GinkgoRecover()
+				conn, err := listener.Accept()
+				Ω(err).ShouldNot(HaveOccurred())
+				defer conn.Close()
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				received <- line
+			}()
+
+			stream, err := reporters.OpenEventStream(socket)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(stream.Emit(types.StreamEvent{Type: types.StreamEventSuiteWillBegin})).Should(Succeed())
+			Eventually(received).Should(Receive(ContainSubstring(`"Type":"SuiteWillBegin"`)))
+			Ω(stream.Close()).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,542 @@
+/*
+
+HTML Reporter for Ginkgo
+
+Generates a self-contained HTML page that can be browsed offline.  The page embeds the reports it was generated from so that per-suite HTML reports can be merged.
+*/
+
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// the number of specs listed in the HTML report's slowest specs table
+const htmlReportSlowestSpecs = 10
+
+const htmlReportDataOpenTag = `<script type="application/json" id="ginkgo-reports">`
+const htmlReportDataCloseTag = `</script>`
+
+var ansiEscapeRe = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+// GenerateHTMLReport produces an HTML-formatted report at the passed in destination
+func GenerateHTMLReport(report types.Report, destination string) error {
+	return GenerateHTMLReportForReports([]types.Report{report}, destination)
+}
+
+// GenerateHTMLReportForReports produces a single HTML-formatted report that covers all the passed-in reports at the passed in destination
+func GenerateHTMLReportForReports(reports []types.Report, destination string) error {
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	err = htmlReportTemplate.Execute(buf, newHTMLReport(reports, string(data)))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(destination), 0770); err != nil {
+		return err
+	}
+	return os.WriteFile(destination, buf.Bytes(), 0666)
+}
+
+// MergeAndCleanupHTMLReports produces a single HTML-formatted report at the passed in destination by merging the HTML-formatted reports provided in sources
+// It skips over reports that fail to decode but reports on them via the returned messages []string
+func MergeAndCleanupHTMLReports(sources []string, destination string) ([]string, error) {
+	messages := []string{}
+	allReports := []types.Report{}
+	for _, source := range sources {
+		reports, err := loadHTMLReport(source)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not decode %s:\n%s", source, err.Error()))
+			continue
+		}
+		os.Remove(source)
+		allReports = append(allReports, reports...)
+	}
+	return messages, GenerateHTMLReportForReports(allReports, destination)
+}
+
+func loadHTMLReport(source string) ([]types.Report, error) {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	_, data, found := bytes.Cut(data, []byte(htmlReportDataOpenTag))
+	if !found {
+		return nil, fmt.Errorf("not a Ginkgo HTML report")
+	}
+	data, _, found = bytes.Cut(data, []byte(htmlReportDataCloseTag))
+	if !found {
+		return nil, fmt.Errorf("not a Ginkgo HTML report")
+	}
+	reports := []types.Report{}
+	err = json.Unmarshal(data, &reports)
+	return reports, err
+}
+
+type htmlReport struct {
+	Title       string
+	Suites      []htmlSuite
+	StateCounts []htmlStateCount
+	Labels      []string
+	Slowest     []*htmlSpec
+	Data        template.JS
+}
+
+type htmlStateCount struct {
+	State string
+	Count int
+}
+
+type htmlSuite struct {
+	Description       string
+	Path              string
+	Succeeded         bool
+	RunTime           string
+	Labels            []string
+	FailureReasons    []string
+	SuiteNodes        []*htmlSpec
+	Root              *htmlContainer
+	TotalSpecs        int
+	SpecsThatWillRun  int
+	StateCounts       []htmlStateCount
+	ParallelProcesses int
+}
+
+type htmlContainer struct {
+	Text       string
+	Location   string
+	Labels     []string
+	Containers []*htmlContainer
+	Specs      []*htmlSpec
+}
+
+type htmlSpec struct {
+	ID                 string
+	NodeType           string
+	Text               string
+	FullText           string
+	State              string
+	Location           string
+	RunTime            string
+	runTime            time.Duration
+	Labels             []string
+	LabelsJSON         string
+	SemVerConstraints  []string
+	NumAttempts        int
+	Timeline           []htmlEvent
+	GinkgoWriterOutput string
+	StdOutErrOutput    string
+	ParallelProcess    int
+	Failed             bool
+}
+
+type htmlEvent struct {
+	Kind     string
+	Offset   string
+	Title    string
+	Location string
+	Body     string
+}
+
+func newHTMLReport(reports []types.Report, data string) htmlReport {
+	out := htmlReport{Title: "Ginkgo Report", Data: template.JS(data)}
+	if len(reports) == 1 {
+		out.Title = reports[0].SuiteDescription
+	}
+	stateCounts := map[string]int{}
+	labels := map[string]bool{}
+	allSpecs := []*htmlSpec{}
+	for i, report := range reports {
+		suite := htmlSuite{
+			Description:       report.SuiteDescription,
+			Path:              report.SuitePath,
+			Succeeded:         report.SuiteSucceeded,
+			RunTime:           formatHTMLDuration(report.RunTime),
+			Labels:            append(append([]string{}, report.SuiteLabels...), report.SuiteSemVerConstraints...),
+			FailureReasons:    report.SpecialSuiteFailureReasons,
+			Root:              &htmlContainer{},
+			TotalSpecs:        report.PreRunStats.TotalSpecs,
+			SpecsThatWillRun:  report.PreRunStats.SpecsThatWillRun,
+			ParallelProcesses: report.SuiteConfig.ParallelTotal,
+		}
+		for _, label := range report.SuiteLabels {
+			labels[label] = true
+		}
+
+		specReports := append(types.SpecReports{}, report.SpecReports...)
+		sort.SliceStable(specReports, func(a, b int) bool {
+			la, lb := specReports[a].LeafNodeLocation, specReports[b].LeafNodeLocation
+			if la.FileName != lb.FileName {
+				return la.FileName < lb.FileName
+			}
+			return la.LineNumber < lb.LineNumber
+		})
+		suiteStateCounts := map[string]int{}
+		for j, specReport := range specReports {
+			spec := newHTMLSpec(fmt.Sprintf("spec-%d-%d", i, j), specReport, report.SuiteLabels)
+			if !specReport.LeafNodeType.Is(types.NodeTypeIt) {
+				suite.SuiteNodes = append(suite.SuiteNodes, spec)
+				continue
+			}
+			suite.Root.add(specReport, spec)
+			allSpecs = append(allSpecs, spec)
+			stateCounts[spec.State] += 1
+			suiteStateCounts[spec.State] += 1
+			for _, label := range spec.Labels {
+				labels[label] = true
+			}
+		}
+		suite.StateCounts = sortedHTMLStateCounts(suiteStateCounts)
+		out.Suites = append(out.Suites, suite)
+	}
+	out.StateCounts = sortedHTMLStateCounts(stateCounts)
+	for label := range labels {
+		out.Labels = append(out.Labels, label)
+	}
+	sort.Strings(out.Labels)
+
+	sort.SliceStable(allSpecs, func(a, b int) bool { return allSpecs[a].runTime > allSpecs[b].runTime })
+	for _, spec := range allSpecs {
+		if len(out.Slowest) == htmlReportSlowestSpecs || spec.runTime == 0 {
+			break
+		}
+		out.Slowest = append(out.Slowest, spec)
+	}
+	return out
+}
+
+// the order in which the HTML and Markdown reports list spec states
+var reportedSpecStates = []types.SpecState{types.SpecStatePassed, types.SpecStateFailed, types.SpecStatePanicked, types.SpecStateTimedout, types.SpecStateInterrupted, types.SpecStateAborted, types.SpecStateQuarantined, types.SpecStateSkipped, types.SpecStatePending}
+
+func sortedHTMLStateCounts(counts map[string]int) []htmlStateCount {
+	out := []htmlStateCount{}
+	for _, state := range reportedSpecStates {
+		if counts[state.String()] > 0 {
+			out = append(out, htmlStateCount{State: state.String(), Count: counts[state.String()]})
+		}
+	}
+	return out
+}
+
+// add places spec in the container hierarchy described by specReport
+func (c *htmlContainer) add(specReport types.SpecReport, spec *htmlSpec) {
+	container := c
+	for i, text := range specReport.ContainerHierarchyTexts {
+		location := ""
+		if i < len(specReport.ContainerHierarchyLocations) {
+			location = specReport.ContainerHierarchyLocations[i].String()
+		}
+		var child *htmlContainer
+		for _, candidate := range container.Containers {
+			if candidate.Text == text && candidate.Location == location {
+				child = candidate
+				break
+			}
+		}
+		if child == nil {
+			child = &htmlContainer{Text: text, Location: location}
+			if i < len(specReport.ContainerHierarchyLabels) {
+				child.Labels = specReport.ContainerHierarchyLabels[i]
+			}
+			container.Containers = append(container.Containers, child)
+		}
+		container = child
+	}
+	container.Specs = append(container.Specs, spec)
+}
+
+func newHTMLSpec(id string, report types.SpecReport, suiteLabels []string) *htmlSpec {
+	labels := append(append([]string{}, suiteLabels...), report.Labels()...)
+	labelsJSON, _ := json.Marshal(labels)
+	spec := &htmlSpec{
+		ID:                 id,
+		NodeType:           report.LeafNodeType.String(),
+		Text:               report.LeafNodeText,
+		FullText:           report.FullText(),
+		State:              report.State.String(),
+		Location:           report.LeafNodeLocation.String(),
+		RunTime:            formatHTMLDuration(report.RunTime),
+		runTime:            report.RunTime,
+		Labels:             report.Labels(),
+		LabelsJSON:         string(labelsJSON),
+		SemVerConstraints:  report.SemVerConstraints(),
+		NumAttempts:        report.NumAttempts,
+		GinkgoWriterOutput: stripHTMLReportColors(report.CapturedGinkgoWriterOutput),
+		StdOutErrOutput:    stripHTMLReportColors(report.CapturedStdOutErr),
+		ParallelProcess:    report.ParallelProcess,
+		Failed:             report.State.Is(types.SpecStateFailureStates),
+	}
+	if spec.Text == "" {
+		spec.Text = "[" + spec.NodeType + "]"
+	}
+	componentSemVerConstraints := []string{}
+	for component, constraints := range report.ComponentSemVerConstraints() {
+		componentSemVerConstraints = append(componentSemVerConstraints, component+": "+strings.Join(constraints, ", "))
+	}
+	sort.Strings(componentSemVerConstraints)
+	spec.SemVerConstraints = append(spec.SemVerConstraints, componentSemVerConstraints...)
+
+	for _, event := range report.Timeline().WithoutHiddenReportEntries().WithoutVeryVerboseSpecEvents() {
+		spec.Timeline = append(spec.Timeline, newHTMLEvent(report, event))
+	}
+	return spec
+}
+
+func newHTMLEvent(report types.SpecReport, event types.TimelineEvent) htmlEvent {
+	out := htmlEvent{}
+	if t := event.GetTimelineLocation().Time; !t.IsZero() && !report.StartTime.IsZero() {
+		out.Offset = "+" + formatHTMLDuration(t.Sub(report.StartTime))
+	}
+	switch x := event.(type) {
+	case types.Failure:
+		out.Kind = "failure"
+		out.Title = fmt.Sprintf("[%s] in [%s]", strings.ToUpper(report.State.String()), failureNodeType(x, report))
+		out.Location = x.Location.String()
+		out.Body = stripHTMLReportColors(failureBody(x))
+	case types.AdditionalFailure:
+		out.Kind = "failure"
+		out.Title = fmt.Sprintf("[%s] in [%s] (additional failure)", strings.ToUpper(x.State.String()), failureNodeType(x.Failure, report))
+		out.Location = x.Failure.Location.String()
+		out.Body = stripHTMLReportColors(failureBody(x.Failure))
+	case types.ReportEntry:
+		out.Kind = "report-entry"
+		out.Title = "Report Entry: " + x.Name
+		out.Location = x.Location.String()
+		out.Body = x.StringRepresentation()
+		if out.Body == "" {
+			out.Body = x.Value.Representation
+		}
+		out.Body = stripHTMLReportColors(out.Body)
+	case types.ProgressReport:
+		out.Kind = "progress-report"
+		out.Title = "Progress Report"
+		if x.CurrentNodeType != types.NodeTypeInvalid {
+			out.Title += fmt.Sprintf(" in [%s] %s", x.CurrentNodeType, x.CurrentNodeText)
+			out.Location = x.CurrentNodeLocation.String()
+		}
+		out.Body = stripHTMLReportColors(progressReportBody(x))
+	case types.SpecEvent:
+		out.Kind = "step"
+		out.Title = x.SpecEventType.String()
+		if x.SpecEventType.Is(types.SpecEventByStart) {
+			out.Title = "STEP: " + x.Message
+		} else if x.Message != "" {
+			out.Title += ": " + x.Message
+		}
+		if x.Attempt > 0 {
+			out.Title += fmt.Sprintf(" (attempt #%d)", x.Attempt)
+		}
+		out.Location = x.CodeLocation.String()
+	}
+	return out
+}
+
+func failureNodeType(failure types.Failure, report types.SpecReport) types.NodeType {
+	if failure.FailureNodeType != types.NodeTypeInvalid {
+		return failure.FailureNodeType
+	}
+	return report.LeafNodeType
+}
+
+func failureBody(failure types.Failure) string {
+	body := failure.Message
+	if failure.ForwardedPanic != "" {
+		body += "\n\n" + failure.ForwardedPanic
+	}
+	if failure.Location.FullStackTrace != "" {
+		body += "\n\nFull Stack Trace\n" + failure.Location.FullStackTrace
+	}
+	return strings.TrimSpace(body)
+}
+
+func progressReportBody(report types.ProgressReport) string {
+	out := &strings.Builder{}
+	if report.Message != "" {
+		fmt.Fprintln(out, report.Message)
+	}
+	if report.CurrentStepText != "" {
+		fmt.Fprintf(out, "At [By Step] %s (%s)\n", report.CurrentStepText, report.CurrentStepLocation)
+	}
+	for _, additionalReport := range report.AdditionalReports {
+		fmt.Fprintf(out, "\n%s\n", additionalReport)
+	}
+	for _, goroutine := range report.Goroutines {
+		fmt.Fprintf(out, "\ngoroutine %d [%s]", goroutine.ID, goroutine.State)
+		if goroutine.IsSpecGoroutine {
+			fmt.Fprint(out, " (spec goroutine)")
+		}
+		fmt.Fprintln(out)
+		for _, call := range goroutine.Stack {
+			marker := " "
+			if call.Highlight {
+				marker = ">"
+			}
+			fmt.Fprintf(out, "%s %s\n    %s:%d\n", marker, call.Function, call.Filename, call.Line)
+		}
+	}
+	return strings.TrimSpace(out.String())
+}
+
+func stripHTMLReportColors(s string) string {
+	return ansiEscapeRe.ReplaceAllString(formatter.NewWithNoColorBool(true).F(s), "")
+}
+
+func formatHTMLDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.String()
+	}
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="generator" content="Ginkgo">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; padding: 1em 2em; color: #1f2328; background: #fff; }
+h1 { font-size: 1.6em; margin-bottom: 0.2em; }
+h2 { font-size: 1.3em; margin: 0; display: inline; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.85em; }
+pre { background: #f6f8fa; padding: 0.6em; overflow-x: auto; white-space: pre-wrap; margin: 0.3em 0; }
+table { border-collapse: collapse; margin: 0.5em 0 1em 0; }
+td, th { border: 1px solid #d0d7de; padding: 0.25em 0.6em; text-align: left; }
+summary { cursor: pointer; }
+.toolbar { position: sticky; top: 0; background: #fff; padding: 0.5em 0; border-bottom: 1px solid #d0d7de; margin-bottom: 1em; }
+.toolbar label { margin-right: 1em; }
+.suite { margin-bottom: 1.5em; }
+.container { margin-left: 1.2em; }
+.container > summary { font-weight: 600; }
+.spec { margin-left: 1.2em; }
+.spec-body { margin-left: 1.2em; border-left: 3px solid #d0d7de; padding-left: 0.8em; }
+.hidden { display: none; }
+.state { display: inline-block; min-width: 6em; font-weight: 600; }
+.location, .meta, .offset { color: #656d76; font-size: 0.85em; }
+.label { display: inline-block; background: #ddf4ff; color: #0969da; border-radius: 1em; padding: 0 0.6em; font-size: 0.8em; margin-left: 0.3em; }
+.event { margin: 0.4em 0; }
+.event-title { font-weight: 600; }
+.state-passed { color: #1a7f37; }
+.state-failed, .state-panicked, .state-timedout, .state-interrupted, .state-aborted { color: #cf222e; }
+.state-skipped, .state-quarantined { color: #0969da; }
+.state-pending { color: #9a6700; }
+.event-failure .event-title { color: #cf222e; }
+.event-progress-report .event-title { color: #9a6700; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr>{{range .StateCounts}}<th class="state-{{.State}}">{{.State}}</th>{{end}}</tr>
+<tr>{{range .StateCounts}}<td>{{.Count}}</td>{{end}}</tr>
+</table>
+
+<div class="toolbar">
+<strong>Show:</strong>
+{{range .StateCounts}}<label><input type="checkbox" class="state-filter" value="{{.State}}" checked> <span class="state-{{.State}}">{{.State}}</span></label>{{end}}
+{{if .Labels}}<label>Label: <select id="label-filter"><option value="">(any)</option>{{range .Labels}}<option value="{{.}}">{{.}}</option>{{end}}</select></label>{{end}}
+<label><input type="checkbox" id="expand-all"> Expand all</label>
+</div>
+
+{{if .Slowest}}
+<details open>
+<summary><h2>Slowest Specs</h2></summary>
+<table>
+<tr><th>Run Time</th><th>State</th><th>Spec</th></tr>
+{{range .Slowest}}<tr><td>{{.RunTime}}</td><td class="state-{{.State}}">{{.State}}</td><td><a href="#{{.ID}}">{{.FullText}}</a></td></tr>
+{{end}}</table>
+</details>
+{{end}}
+
+{{range .Suites}}
+<details class="suite" open>
+<summary><h2>{{.Description}}</h2> <span class="{{if .Succeeded}}state-passed{{else}}state-failed{{end}}">{{if .Succeeded}}Passed{{else}}Failed{{end}}</span>{{range .Labels}}<span class="label">{{.}}</span>{{end}}
+<div class="meta">{{.Path}} - ran {{.SpecsThatWillRun}} of {{.TotalSpecs}} specs in {{.RunTime}}{{if gt .ParallelProcesses 1}} across {{.ParallelProcesses}} parallel processes{{end}}{{range .StateCounts}} | <span class="state-{{.State}}">{{.Count}} {{.State}}</span>{{end}}</div>
+</summary>
+{{range .FailureReasons}}<pre class="state-failed">{{.}}</pre>{{end}}
+{{range .SuiteNodes}}{{template "spec" .}}{{end}}
+{{template "container" .Root}}
+</details>
+{{end}}
+
+{{define "container"}}
+{{range .Containers}}
+<details class="container" open>
+<summary>{{.Text}}{{range .Labels}}<span class="label">{{.}}</span>{{end}} <span class="location">{{.Location}}</span></summary>
+{{template "container" .}}
+</details>
+{{end}}
+{{range .Specs}}{{template "spec" .}}{{end}}
+{{end}}
+
+{{define "spec"}}
+<details class="spec" id="{{.ID}}" data-state="{{.State}}" data-labels="{{.LabelsJSON}}"{{if eq .NodeType "It"}} data-filterable{{end}}{{if .Failed}} open{{end}}>
+<summary><span class="state state-{{.State}}">{{.State}}</span> {{.Text}}{{range .Labels}}<span class="label">{{.}}</span>{{end}}{{range .SemVerConstraints}}<span class="label">{{.}}</span>{{end}} <span class="meta">{{.RunTime}}</span></summary>
+<div class="spec-body">
+<div class="location">{{.Location}}{{if gt .NumAttempts 1}} - {{.NumAttempts}} attempts{{end}}{{if gt .ParallelProcess 0}} - process #{{.ParallelProcess}}{{end}}</div>
+{{range .Timeline}}
+<div class="event event-{{.Kind}}">
+<span class="offset">{{.Offset}}</span> <span class="event-title">{{.Title}}</span> <span class="location">{{.Location}}</span>
+{{if .Body}}<pre>{{.Body}}</pre>{{end}}
+</div>
+{{end}}
+{{if .GinkgoWriterOutput}}<details><summary>Captured GinkgoWriter Output</summary><pre>{{.GinkgoWriterOutput}}</pre></details>{{end}}
+{{if .StdOutErrOutput}}<details><summary>Captured StdOut/StdErr Output</summary><pre>{{.StdOutErrOutput}}</pre></details>{{end}}
+</div>
+</details>
+{{end}}
+
+` + htmlReportDataOpenTag + `{{.Data}}` + htmlReportDataCloseTag + `
+<script>
+(function() {
+  function applyFilters() {
+    var states = {};
+    document.querySelectorAll(".state-filter").forEach(function(box) { states[box.value] = box.checked; });
+    var labelFilter = document.getElementById("label-filter");
+    var label = labelFilter ? labelFilter.value : "";
+    document.querySelectorAll(".spec[data-filterable]").forEach(function(spec) {
+      var visible = states[spec.dataset.state] !== false && (label === "" || JSON.parse(spec.dataset.labels).indexOf(label) !== -1);
+      spec.classList.toggle("hidden", !visible);
+    });
+    var containers = Array.prototype.slice.call(document.querySelectorAll(".container")).reverse();
+    containers.forEach(function(container) {
+      container.classList.toggle("hidden", container.querySelector(".spec[data-filterable]:not(.hidden)") === null);
+    });
+  }
+  document.querySelectorAll(".state-filter").forEach(function(box) { box.addEventListener("change", applyFilters); });
+  var labelFilter = document.getElementById("label-filter");
+  if (labelFilter) { labelFilter.addEventListener("change", applyFilters); }
+  document.getElementById("expand-all").addEventListener("change", function(e) {
+    document.querySelectorAll(".spec").forEach(function(spec) { spec.open = e.target.checked; });
+  });
+  document.querySelectorAll("a[href^='#spec-']").forEach(function(link) {
+    link.addEventListener("click", function() {
+      var spec = document.getElementById(link.getAttribute("href").slice(1));
+      for (var el = spec; el; el = el.parentElement) { if (el.tagName === "DETAILS") { el.open = true; } }
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
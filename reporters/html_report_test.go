@@ -0,0 +1,97 @@
+package reporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("HTMLReport", func() {
+	var report types.Report
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		report = types.Report{
+			SuiteDescription: "My Suite",
+			SuitePath:        "/path/to/suite",
+			PreRunStats:      types.PreRunStats{SpecsThatWillRun: 3, TotalSpecs: 4},
+			SuiteConfig:      types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1},
+			RunTime:          time.Minute,
+			SpecReports: types.SpecReports{
+				S(types.NodeTypeIt, Label("cat"), CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateFailed, time.Second*3, STD("some captured stdout\n"), GW("ginkgowriter <output>"),
+					SE(types.SpecEventByStart, "a by step", cl0),
+					F("failure\nmessage", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt),
+					RE("a report entry", cl1),
+					RE("a hidden report entry", cl1, types.ReportEntryVisibilityNever),
+				),
+				S(types.NodeTypeIt, CTS("A"), CLS(cl0), "D", cl3, time.Second*5,
+					PR("my progress report", LeafNodeText("D")),
+				),
+				S(types.NodeTypeIt, "E", cl4, types.SpecStatePending),
+				S(types.NodeTypeBeforeSuite, cl0, types.SpecStatePassed),
+			},
+		}
+	})
+
+	It("renders the report as a self-contained HTML page", func() {
+		path := filepath.Join(dir, "nested", "report.html")
+		Ω(reporters.GenerateHTMLReport(report, path)).Should(Succeed())
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		html, data, found := strings.Cut(string(content), `<script type="application/json" id="ginkgo-reports">`)
+		Ω(found).Should(BeTrue())
+		Ω(data).Should(ContainSubstring(`"SuiteDescription":"My Suite"`))
+
+		Ω(html).Should(ContainSubstring("<title>My Suite</title>"))
+		Ω(html).ShouldNot(ContainSubstring("<link "))
+		Ω(html).ShouldNot(MatchRegexp(`<script[^>]+src=`))
+
+		By("rendering the container hierarchy")
+		Ω(strings.Index(html, "<summary>A")).Should(BeNumerically("<", strings.Index(html, "<summary>B")))
+		Ω(strings.Count(html, `<details class="container"`)).Should(Equal(2))
+
+		By("rendering each spec's timeline and output")
+		Ω(html).Should(ContainSubstring(`data-state="failed" data-labels="[&#34;cat&#34;]" data-filterable open>`))
+		Ω(html).Should(ContainSubstring("STEP: a by step"))
+		Ω(html).Should(ContainSubstring("[FAILED] in [It]"))
+		Ω(html).Should(ContainSubstring("failure\nmessage"))
+		Ω(html).Should(ContainSubstring("Report Entry: a report entry"))
+		Ω(html).ShouldNot(ContainSubstring("a hidden report entry"))
+		Ω(html).Should(ContainSubstring("Progress Report"))
+		Ω(html).Should(ContainSubstring("ginkgowriter &lt;output&gt;"))
+		Ω(html).Should(ContainSubstring("some captured stdout"))
+
+		By("rendering the filters and the slowest specs")
+		Ω(html).Should(ContainSubstring(`<input type="checkbox" class="state-filter" value="pending" checked>`))
+		Ω(html).Should(ContainSubstring(`<option value="cat">cat</option>`))
+		Ω(html).Should(MatchRegexp(`<td>5s</td><td class="state-passed">passed</td><td><a href="#spec-0-\d">A D</a></td></tr>\s*<tr><td>3s</td><td class="state-failed">failed</td><td><a href="#spec-0-\d">A B C</a></td>`))
+	})
+
+	It("merges HTML reports across suites", func() {
+		other := report
+		other.SuiteDescription = "Other Suite"
+		other.SuitePath = "/path/to/other"
+		Ω(reporters.GenerateHTMLReport(report, filepath.Join(dir, "a.html"))).Should(Succeed())
+		Ω(reporters.GenerateHTMLReport(other, filepath.Join(dir, "b.html"))).Should(Succeed())
+		Ω(os.WriteFile(filepath.Join(dir, "c.html"), []byte("<html></html>"), 0644)).Should(Succeed())
+
+		messages, err := reporters.MergeAndCleanupHTMLReports([]string{filepath.Join(dir, "a.html"), filepath.Join(dir, "b.html"), filepath.Join(dir, "c.html")}, filepath.Join(dir, "merged.html"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(messages).Should(HaveLen(1))
+		Ω(filepath.Join(dir, "a.html")).ShouldNot(BeAnExistingFile())
+
+		content, err := os.ReadFile(filepath.Join(dir, "merged.html"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(ContainSubstring("<title>Ginkgo Report</title>"))
+		Ω(string(content)).Should(ContainSubstring("<h2>My Suite</h2>"))
+		Ω(string(content)).Should(ContainSubstring("<h2>Other Suite</h2>"))
+	})
+})
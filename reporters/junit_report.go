@@ -49,6 +49,9 @@ type JunitReportConfig struct {
 
 	// Enable OmitSuiteSetupNodes to prevent the creation of testcase entries for setup nodes
 	OmitSuiteSetupNodes bool
+
+	// Enable OmitSubtests to prevent the creation of testcase entries for subtests run via GinkgoTRunner.Run
+	OmitSubtests bool
 }
 
 type JUnitTestSuites struct {
@@ -117,9 +120,12 @@ var ownerRE = regexp.MustCompile(`(?i)^owner:(.*)$`)
 
 type JUnitTestCase struct {
 	// Name maps onto the full text of the spec - equivalent to "[SpecReport.LeafNodeType] SpecReport.FullText()"
+	// Subtests run via GinkgoTRunner.Run get their own testcase entries named after the spec and their parent subtests - e.g. "[It] spec text/subtest/nested subtest"
 	Name string `xml:"name,attr"`
 	// Classname maps onto the name of the test suite - equivalent to Report.SuiteDescription
 	Classname string `xml:"classname,attr"`
+	// ID maps onto the spec's stable ID - equivalent to SpecReport.ID.  It is omitted for suite setup nodes and subtests.
+	ID string `xml:"id,attr,omitempty"`
 	// Status maps onto the string representation of SpecReport.State
 	Status string `xml:"status,attr"`
 	// Time is the time in seconds to execute the spec - maps onto SpecReport.RunTime
@@ -140,6 +146,7 @@ type JUnitTestCase struct {
 
 type JUnitSkipped struct {
 	// Message maps onto "pending" if the test was marked pending, "skipped" if the test was marked skipped, and "skipped - REASON" if the user called Skip(REASON)
+	// Quarantined failures (see --quarantine-file) are reported as skipped with the message "quarantined (REASON) - FAILURE MESSAGE"
 	Message string `xml:"message,attr"`
 }
 
@@ -231,6 +238,7 @@ func GenerateJUnitReportWithConfig(report types.Report, dst string, config Junit
 		test := JUnitTestCase{
 			Name:      name,
 			Classname: report.SuiteDescription,
+			ID:        spec.ID,
 			Status:    spec.State.String(),
 			Time:      spec.RunTime.Seconds(),
 			Owner:     owner,
@@ -251,6 +259,9 @@ func GenerateJUnitReportWithConfig(report types.Report, dst string, config Junit
 			}
 			test.Skipped = &JUnitSkipped{Message: message}
 			suite.Skipped += 1
+		case types.SpecStateQuarantined:
+			test.Skipped = &JUnitSkipped{Message: quarantineMessageForUnstructuredReporters(spec)}
+			suite.Skipped += 1
 		case types.SpecStatePending:
 			test.Skipped = &JUnitSkipped{Message: "pending"}
 			suite.Disabled += 1
@@ -307,6 +318,9 @@ func GenerateJUnitReportWithConfig(report types.Report, dst string, config Junit
 		}
 
 		suite.TestCases = append(suite.TestCases, test)
+		if !config.OmitSubtests {
+			addJUnitTestCasesForSubtests(&suite, name, spec.Subtests, config)
+		}
 	}
 
 	junitReport := JUnitTestSuites{
@@ -333,6 +347,56 @@ func GenerateJUnitReportWithConfig(report types.Report, dst string, config Junit
 	return f.Close()
 }
 
+func addJUnitTestCasesForSubtests(suite *JUnitTestSuite, parentName string, subtests []types.SubtestReport, config JunitReportConfig) {
+	for _, subtest := range subtests {
+		test := JUnitTestCase{
+			Name:      parentName + "/" + subtest.Name,
+			Classname: suite.Name,
+			Status:    subtest.State.String(),
+			Time:      subtest.RunTime.Seconds(),
+		}
+		if !subtest.State.Is(config.OmitTimelinesForSpecState) {
+			test.SystemErr = subtest.CapturedGinkgoWriterOutput
+		}
+		suite.Tests += 1
+
+		description := &strings.Builder{}
+		NewDefaultReporter(types.ReporterConfig{NoColor: true, VeryVerbose: true}, description).emitFailure(0, subtest.State, subtest.Failure, true)
+		switch subtest.State {
+		case types.SpecStateSkipped:
+			message := "skipped"
+			if subtest.Failure.Message != "" {
+				message += " - " + subtest.Failure.Message
+			}
+			test.Skipped = &JUnitSkipped{Message: message}
+			suite.Skipped += 1
+		case types.SpecStateFailed:
+			test.Failure = &JUnitFailure{
+				Message:     subtest.Failure.Message,
+				Type:        "failed",
+				Description: description.String(),
+			}
+			if config.OmitFailureMessageAttr {
+				test.Failure.Message = ""
+			}
+			suite.Failures += 1
+		case types.SpecStatePanicked:
+			test.Error = &JUnitError{
+				Message:     subtest.Failure.ForwardedPanic,
+				Type:        "panicked",
+				Description: description.String(),
+			}
+			if config.OmitFailureMessageAttr {
+				test.Error.Message = ""
+			}
+			suite.Errors += 1
+		}
+
+		suite.TestCases = append(suite.TestCases, test)
+		addJUnitTestCasesForSubtests(suite, test.Name, subtest.Subtests, config)
+	}
+}
+
 func MergeAndCleanupJUnitReports(sources []string, dst string) ([]string, error) {
 	messages := []string{}
 	mergedReport := JUnitTestSuites{}
@@ -383,6 +447,17 @@ func failureDescriptionForUnstructuredReporters(spec types.SpecReport) string {
 	return out.String()
 }
 
+func quarantineMessageForUnstructuredReporters(spec types.SpecReport) string {
+	message := "quarantined"
+	if spec.QuarantineReason != "" {
+		message += " (" + spec.QuarantineReason + ")"
+	}
+	if spec.Failure.Message != "" {
+		message += " - " + spec.Failure.Message
+	}
+	return message
+}
+
 func systemErrForUnstructuredReporters(spec types.SpecReport) string {
 	return RenderTimeline(spec, true)
 }
@@ -28,7 +28,7 @@ var _ = Describe("JunitReport", func() {
 			SuiteConfig:      types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1},
 			RunTime:          time.Minute,
 			SpecReports: types.SpecReports{
-				S(types.NodeTypeIt, Label("cat", "dog"), CLabels(Label("dolphin"), Label("gorilla", "cow")), CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateTimedout, STD("some captured stdout\n"), GW("ginkgowriter\noutput\ncleanup!"), SE(types.SpecEventByStart, "a by step", cl0),
+				S(types.NodeTypeIt, SpecID("a-b-c"), Label("cat", "dog"), CLabels(Label("dolphin"), Label("gorilla", "cow")), CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateTimedout, STD("some captured stdout\n"), GW("ginkgowriter\noutput\ncleanup!"), SE(types.SpecEventByStart, "a by step", cl0),
 					SE(types.SpecEventNodeStart, types.NodeTypeIt, "C", cl2, TL(0)),
 					F("failure\nmessage", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt, TL("ginkgowriter\n"), AF(types.SpecStatePanicked, cl4, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt, TL("ginkgowriter\noutput\n"), ForwardedPanic("the panic!"))),
 					SE(types.SpecEventNodeEnd, types.NodeTypeIt, "C", cl2, TL("ginkgowriter\noutput\n"), time.Microsecond*87230),
@@ -95,6 +95,7 @@ var _ = Describe("JunitReport", func() {
 			failingSpec := suite.TestCases[0]
 			Ω(failingSpec.Name).Should(Equal("[It] A B C [dolphin, gorilla, cow, cat, dog]"))
 			Ω(failingSpec.Classname).Should(Equal("My Suite"))
+			Ω(failingSpec.ID).Should(Equal("a-b-c"))
 			Ω(failingSpec.Status).Should(Equal("timedout"))
 			Ω(failingSpec.Skipped).Should(BeNil())
 			Ω(failingSpec.Error).Should(BeNil())
@@ -147,6 +148,7 @@ var _ = Describe("JunitReport", func() {
 			passingSpec := suite.TestCases[1]
 			Ω(passingSpec.Name).Should(Equal("[It] A [cat, owner:frank, OWNer:bob]"))
 			Ω(passingSpec.Classname).Should(Equal("My Suite"))
+			Ω(passingSpec.ID).Should(BeEmpty())
 			Ω(passingSpec.Status).Should(Equal("passed"))
 			Ω(passingSpec.Skipped).Should(BeNil())
 			Ω(passingSpec.Error).Should(BeNil())
@@ -420,4 +422,101 @@ var _ = Describe("JunitReport", func() {
 			snaps.MatchSnapshot(GinkgoT(), summaryOutput.String(), "package summary output match")
 		})
 	})
+
+	Describe("when a spec is quarantined", func() {
+		var generated reporters.JUnitTestSuites
+
+		BeforeEach(func() {
+			report.SpecReports = types.SpecReports{
+				S(types.NodeTypeIt, CTS("A"), CLS(cl0), "B", cl1, types.SpecStateQuarantined, QuarantineReason("flakes under load"),
+					F("failure\nmessage", cl2, types.FailureNodeIsLeafNode, FailureNodeLocation(cl1), types.NodeTypeIt),
+				),
+				S(types.NodeTypeIt, CTS("A"), CLS(cl0), "C", cl3, QuarantineReason("")),
+			}
+			fname := fmt.Sprintf("./report-%d", GinkgoParallelProcess())
+			Ω(reporters.GenerateJUnitReport(report, fname)).Should(Succeed())
+			DeferCleanup(os.Remove, fname)
+
+			generated = reporters.JUnitTestSuites{}
+			f, err := os.Open(fname)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(xml.NewDecoder(f).Decode(&generated)).Should(Succeed())
+		})
+
+		It("reports quarantined failures as skipped and quarantined specs that pass as passed", func() {
+			Ω(generated.Failures).Should(Equal(0))
+			Ω(generated.Errors).Should(Equal(0))
+			suite := generated.TestSuites[0]
+			Ω(suite.Skipped).Should(Equal(1))
+
+			quarantinedSpec := suite.TestCases[0]
+			Ω(quarantinedSpec.Status).Should(Equal("quarantined"))
+			Ω(quarantinedSpec.Failure).Should(BeNil())
+			Ω(quarantinedSpec.Skipped.Message).Should(Equal("quarantined (flakes under load) - failure\nmessage"))
+			Ω(quarantinedSpec.SystemErr).Should(ContainSubstring("[QUARANTINED] failure"))
+
+			passingSpec := suite.TestCases[1]
+			Ω(passingSpec.Status).Should(Equal("passed"))
+			Ω(passingSpec.Skipped).Should(BeNil())
+		})
+	})
+
+	Describe("when a spec runs subtests", func() {
+		var generated reporters.JUnitTestSuites
+
+		BeforeEach(func() {
+			spec := S(types.NodeTypeIt, CTS("A"), CLS(cl0), "B", cl1, types.SpecStateFailed,
+				F("Subtest \"fails\" failed:\nboom", cl2, types.FailureNodeIsLeafNode, FailureNodeLocation(cl1), types.NodeTypeIt),
+			)
+			spec.Subtests = []types.SubtestReport{
+				{Name: "passes", State: types.SpecStatePassed, RunTime: time.Second, CapturedGinkgoWriterOutput: "hello"},
+				{Name: "fails", State: types.SpecStateFailed, Failure: types.Failure{Message: "boom", Location: cl2}, Subtests: []types.SubtestReport{
+					{Name: "skips", State: types.SpecStateSkipped, Failure: types.Failure{Message: "not now", Location: cl3}},
+				}},
+			}
+			report.SpecReports = types.SpecReports{spec}
+			fname := fmt.Sprintf("./report-%d", GinkgoParallelProcess())
+			Ω(reporters.GenerateJUnitReport(report, fname)).Should(Succeed())
+			DeferCleanup(os.Remove, fname)
+
+			generated = reporters.JUnitTestSuites{}
+			f, err := os.Open(fname)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(xml.NewDecoder(f).Decode(&generated)).Should(Succeed())
+		})
+
+		It("emits a testcase for each subtest, named after the spec and its parent subtests", func() {
+			Ω(generated.Tests).Should(Equal(4))
+			Ω(generated.Failures).Should(Equal(2))
+			suite := generated.TestSuites[0]
+			Ω(suite.Skipped).Should(Equal(1))
+			Ω(suite.TestCases).Should(HaveLen(4))
+
+			passes := suite.TestCases[1]
+			Ω(passes.Name).Should(Equal("[It] A B/passes"))
+			Ω(passes.Status).Should(Equal("passed"))
+			Ω(passes.Time).Should(Equal(1.0))
+			Ω(passes.SystemErr).Should(Equal("hello"))
+
+			fails := suite.TestCases[2]
+			Ω(fails.Name).Should(Equal("[It] A B/fails"))
+			Ω(fails.Failure.Message).Should(Equal("boom"))
+			Ω(fails.Failure.Description).Should(ContainSubstring(cl2.String()))
+
+			skips := suite.TestCases[3]
+			Ω(skips.Name).Should(Equal("[It] A B/fails/skips"))
+			Ω(skips.Skipped.Message).Should(Equal("skipped - not now"))
+		})
+
+		It("omits subtests when configured to", func() {
+			fname := fmt.Sprintf("./report-omit-%d", GinkgoParallelProcess())
+			Ω(reporters.GenerateJUnitReportWithConfig(report, fname, reporters.JunitReportConfig{OmitSubtests: true})).Should(Succeed())
+			DeferCleanup(os.Remove, fname)
+			generated = reporters.JUnitTestSuites{}
+			f, err := os.Open(fname)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(xml.NewDecoder(f).Decode(&generated)).Should(Succeed())
+			Ω(generated.TestSuites[0].TestCases).Should(HaveLen(1))
+		})
+	})
 })
@@ -0,0 +1,334 @@
+/*
+
+Markdown Reporter for Ginkgo
+
+Generates a compact Markdown summary of a test run that is suitable for GitHub's job summaries.  The summary embeds a trimmed-down copy of the reports it was generated from in an HTML comment so that per-suite Markdown reports can be merged.
+*/
+
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// the number of specs listed in the Markdown report's slowest specs table
+const markdownReportSlowestSpecs = 10
+
+// the number of failures listed in the Markdown report - GitHub limits job summaries to 1MiB
+const markdownReportMaxFailures = 50
+
+// failure messages are trimmed to this many lines and bytes
+const markdownReportMaxMessageLines = 10
+const markdownReportMaxMessageLength = 1000
+
+const markdownReportDataOpenTag = "<!-- ginkgo-reports\n"
+const markdownReportDataCloseTag = "\n-->"
+
+// GenerateMarkdownReport produces a Markdown-formatted summary at the passed in destination
+func GenerateMarkdownReport(report types.Report, destination string) error {
+	return GenerateMarkdownReportForReports([]types.Report{report}, destination)
+}
+
+// GenerateMarkdownReportForReports produces a single Markdown-formatted summary that covers all the passed-in reports at the passed in destination
+func GenerateMarkdownReportForReports(reports []types.Report, destination string) error {
+	reports = trimReportsForMarkdown(reports)
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(renderMarkdownReport(reports))
+	buf.WriteString("\n" + markdownReportDataOpenTag)
+	buf.Write(data)
+	buf.WriteString(markdownReportDataCloseTag + "\n")
+	if err := os.MkdirAll(path.Dir(destination), 0770); err != nil {
+		return err
+	}
+	return os.WriteFile(destination, buf.Bytes(), 0666)
+}
+
+// AppendMarkdownSummary appends a Markdown-formatted summary of the passed-in reports to the file at destination.  Ginkgo uses this to write to $GITHUB_STEP_SUMMARY.
+func AppendMarkdownSummary(reports []types.Report, destination string) error {
+	f, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(renderMarkdownReport(trimReportsForMarkdown(reports)))
+	return err
+}
+
+// MergeAndCleanupMarkdownReports produces a single Markdown-formatted summary at the passed in destination by merging the Markdown-formatted reports provided in sources
+// It skips over reports that fail to decode but reports on them via the returned messages []string
+func MergeAndCleanupMarkdownReports(sources []string, destination string) ([]string, error) {
+	messages := []string{}
+	allReports := []types.Report{}
+	for _, source := range sources {
+		reports, err := LoadMarkdownReport(source)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not decode %s:\n%s", source, err.Error()))
+			continue
+		}
+		os.Remove(source)
+		allReports = append(allReports, reports...)
+	}
+	return messages, GenerateMarkdownReportForReports(allReports, destination)
+}
+
+// LoadMarkdownReport loads the (trimmed-down) reports embedded in a Markdown report generated by GenerateMarkdownReport
+func LoadMarkdownReport(source string) ([]types.Report, error) {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	_, data, found := bytes.Cut(data, []byte(markdownReportDataOpenTag))
+	if !found {
+		return nil, fmt.Errorf("not a Ginkgo Markdown report")
+	}
+	data, _, found = bytes.Cut(data, []byte(markdownReportDataCloseTag))
+	if !found {
+		return nil, fmt.Errorf("not a Ginkgo Markdown report")
+	}
+	reports := []types.Report{}
+	err = json.Unmarshal(data, &reports)
+	return reports, err
+}
+
+// trimReportsForMarkdown drops everything the Markdown report doesn't render so that the embedded reports stay small
+func trimReportsForMarkdown(reports []types.Report) []types.Report {
+	out := make([]types.Report, len(reports))
+	for i, report := range reports {
+		out[i] = types.Report{
+			SuitePath:                  report.SuitePath,
+			SuiteDescription:           report.SuiteDescription,
+			SuiteSucceeded:             report.SuiteSucceeded,
+			SpecialSuiteFailureReasons: report.SpecialSuiteFailureReasons,
+			PreRunStats:                report.PreRunStats,
+			RunTime:                    report.RunTime,
+			SpecReports:                make(types.SpecReports, len(report.SpecReports)),
+		}
+		for j, spec := range report.SpecReports {
+			out[i].SpecReports[j] = types.SpecReport{
+				ContainerHierarchyTexts: spec.ContainerHierarchyTexts,
+				LeafNodeType:            spec.LeafNodeType,
+				LeafNodeLocation:        spec.LeafNodeLocation,
+				LeafNodeText:            spec.LeafNodeText,
+				State:                   spec.State,
+				RunTime:                 spec.RunTime,
+				NumAttempts:             spec.NumAttempts,
+				MaxFlakeAttempts:        spec.MaxFlakeAttempts,
+			}
+			if spec.State.Is(types.SpecStateFailureStates) {
+				out[i].SpecReports[j].Failure = types.Failure{
+					Message:         trimMarkdownFailureMessage(spec.Failure.Message),
+					Location:        types.CodeLocation{FileName: spec.Failure.Location.FileName, LineNumber: spec.Failure.Location.LineNumber},
+					FailureNodeType: spec.Failure.FailureNodeType,
+				}
+			}
+		}
+	}
+	return out
+}
+
+type markdownSpec struct {
+	suite  types.Report
+	report types.SpecReport
+}
+
+func (s markdownSpec) text(prefixSuite bool) string {
+	text := s.report.FullText()
+	if text == "" {
+		text = "[" + s.report.LeafNodeType.String() + "]"
+	}
+	if prefixSuite {
+		text = markdownSuiteName(s.suite) + ": " + text
+	}
+	return escapeMarkdown(text)
+}
+
+func (s markdownSpec) location(location types.CodeLocation) string {
+	fileName := location.FileName
+	if rel, err := filepath.Rel(s.suite.SuitePath, fileName); err == nil && !strings.HasPrefix(rel, "..") {
+		fileName = rel
+	}
+	return fmt.Sprintf("`%s:%d`", fileName, location.LineNumber)
+}
+
+func renderMarkdownReport(reports []types.Report) string {
+	out := &strings.Builder{}
+	multipleSuites := len(reports) > 1
+
+	succeeded := true
+	totals := map[types.SpecState]int{}
+	suiteTotals := make([]map[types.SpecState]int, len(reports))
+	failures, flaky, specs := []markdownSpec{}, []markdownSpec{}, []markdownSpec{}
+	for i, report := range reports {
+		succeeded = succeeded && report.SuiteSucceeded
+		suiteTotals[i] = map[types.SpecState]int{}
+		for _, spec := range report.SpecReports {
+			if spec.State.Is(types.SpecStateFailureStates) {
+				failures = append(failures, markdownSpec{report, spec})
+			}
+			if !spec.LeafNodeType.Is(types.NodeTypeIt) {
+				continue
+			}
+			totals[spec.State] += 1
+			suiteTotals[i][spec.State] += 1
+			specs = append(specs, markdownSpec{report, spec})
+			if (types.SpecReports{spec}).CountOfFlakedSpecs() > 0 {
+				flaky = append(flaky, markdownSpec{report, spec})
+			}
+		}
+	}
+
+	states := []types.SpecState{}
+	for _, state := range reportedSpecStates {
+		if totals[state] > 0 || state.Is(types.SpecStatePassed|types.SpecStateFailed) {
+			states = append(states, state)
+		}
+	}
+
+	title := "Ginkgo Report"
+	if len(reports) == 1 {
+		title = markdownSuiteName(reports[0])
+	}
+	fmt.Fprintf(out, "## %s: %s\n\n", escapeMarkdown(title), markdownResult(succeeded))
+
+	fmt.Fprint(out, "| Suite | Result |")
+	for _, state := range states {
+		fmt.Fprintf(out, " %s |", capitalize(state.String()))
+	}
+	fmt.Fprint(out, " Flaky | Run Time |\n|:--|:--|")
+	fmt.Fprint(out, strings.Repeat("--:|", len(states)+2)+"\n")
+	for i, report := range reports {
+		fmt.Fprintf(out, "| %s | %s |", escapeMarkdown(markdownSuiteName(report)), markdownResult(report.SuiteSucceeded))
+		for _, state := range states {
+			fmt.Fprintf(out, " %d |", suiteTotals[i][state])
+		}
+		fmt.Fprintf(out, " %d | %s |\n", report.SpecReports.CountOfFlakedSpecs(), formatHTMLDuration(report.RunTime))
+	}
+	if multipleSuites {
+		fmt.Fprintf(out, "| **Total** | %s |", markdownResult(succeeded))
+		for _, state := range states {
+			fmt.Fprintf(out, " **%d** |", totals[state])
+		}
+		fmt.Fprintf(out, " **%d** | |\n", len(flaky))
+	}
+
+	failedSuites := []types.Report{}
+	for _, report := range reports {
+		if len(report.SpecialSuiteFailureReasons) > 0 {
+			failedSuites = append(failedSuites, report)
+		}
+	}
+	if len(failures) > 0 || len(failedSuites) > 0 {
+		fmt.Fprint(out, "\n### Failures\n\n")
+		for _, report := range failedSuites {
+			fmt.Fprintf(out, "- **%s** failed:\n", escapeMarkdown(markdownSuiteName(report)))
+			for _, reason := range report.SpecialSuiteFailureReasons {
+				writeMarkdownCodeBlock(out, trimMarkdownFailureMessage(reason))
+			}
+		}
+		for i, failure := range failures {
+			if i == markdownReportMaxFailures {
+				fmt.Fprintf(out, "- _...and %d more_\n", len(failures)-markdownReportMaxFailures)
+				break
+			}
+			fmt.Fprintf(out, "- **[%s]** %s at %s\n", strings.ToUpper(failure.report.State.String()), failure.text(multipleSuites), failure.location(failure.report.Failure.Location))
+			if message := trimMarkdownFailureMessage(failure.report.Failure.Message); message != "" {
+				writeMarkdownCodeBlock(out, message)
+			}
+		}
+	}
+
+	if len(flaky) > 0 {
+		fmt.Fprint(out, "\n### Flaky Specs\n\nThese specs failed at first but passed on a retry:\n\n| Spec | Attempts | Location |\n|:--|--:|:--|\n")
+		for _, spec := range flaky {
+			fmt.Fprintf(out, "| %s | %d | %s |\n", spec.text(multipleSuites), spec.report.NumAttempts, spec.location(spec.report.LeafNodeLocation))
+		}
+	}
+
+	sort.SliceStable(specs, func(a, b int) bool { return specs[a].report.RunTime > specs[b].report.RunTime })
+	slowest := []markdownSpec{}
+	for _, spec := range specs {
+		if len(slowest) == markdownReportSlowestSpecs || spec.report.RunTime == 0 {
+			break
+		}
+		slowest = append(slowest, spec)
+	}
+	if len(slowest) > 0 {
+		fmt.Fprint(out, "\n### Slowest Specs\n\n| Run Time | Spec | Location |\n|--:|:--|:--|\n")
+		for _, spec := range slowest {
+			fmt.Fprintf(out, "| %s | %s | %s |\n", formatHTMLDuration(spec.report.RunTime), spec.text(multipleSuites), spec.location(spec.report.LeafNodeLocation))
+		}
+	}
+
+	return out.String()
+}
+
+// suites that failed to compile don't have a description
+func markdownSuiteName(report types.Report) string {
+	if report.SuiteDescription == "" {
+		return report.SuitePath
+	}
+	return report.SuiteDescription
+}
+
+func markdownResult(succeeded bool) string {
+	if succeeded {
+		return "✅ Passed"
+	}
+	return "❌ Failed"
+}
+
+func trimMarkdownFailureMessage(message string) string {
+	message = strings.TrimSpace(stripHTMLReportColors(message))
+	lines := strings.Split(message, "\n")
+	trimmed := len(lines) > markdownReportMaxMessageLines
+	if trimmed {
+		lines = lines[:markdownReportMaxMessageLines]
+	}
+	message = strings.Join(lines, "\n")
+	if len(message) > markdownReportMaxMessageLength {
+		message, trimmed = strings.ToValidUTF8(message[:markdownReportMaxMessageLength], ""), true
+	}
+	if trimmed {
+		message += "\n..."
+	}
+	return message
+}
+
+// writeMarkdownCodeBlock writes s as an indented code block in the current list item, picking a fence that s doesn't contain
+func writeMarkdownCodeBlock(out *strings.Builder, s string) {
+	fence := "```"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(out, "  %s\n", fence)
+	for _, line := range strings.Split(s, "\n") {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	fmt.Fprintf(out, "  %s\n", fence)
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ", "<", "&lt;", ">", "&gt;", "*", "\\*", "_", "\\_", "`", "\\`", "[", "\\[", "]", "\\]")
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
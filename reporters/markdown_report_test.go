@@ -0,0 +1,109 @@
+package reporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("MarkdownReport", func() {
+	var report types.Report
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		report = types.Report{
+			SuiteDescription: "My Suite",
+			SuitePath:        "/path/to/suite",
+			SuiteSucceeded:   false,
+			RunTime:          time.Minute,
+			SpecReports: types.SpecReports{
+				S(CTS("A", "B"), "C", cl0, types.SpecStateFailed, time.Second*3, STD("some captured stdout"),
+					F("failure\nmessage", types.CodeLocation{FileName: "/path/to/suite/c_test.go", LineNumber: 17}, types.FailureNodeIsLeafNode, types.NodeTypeIt),
+				),
+				S(CTS("A"), "D | E", cl1, time.Second*5, 3, FlakeAttempts(3)),
+				S("F", cl2, types.SpecStatePending),
+				S("G", cl3, types.SpecStateSkipped, time.Duration(0)),
+				S(types.NodeTypeBeforeSuite, cl4, time.Second*7),
+			},
+		}
+	})
+
+	It("renders a compact summary of the report", func() {
+		path := filepath.Join(dir, "nested", "report.md")
+		Ω(reporters.GenerateMarkdownReport(report, path)).Should(Succeed())
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		summary, data, found := strings.Cut(string(content), "<!-- ginkgo-reports")
+		Ω(found).Should(BeTrue())
+		Ω(data).Should(ContainSubstring(`"SuiteDescription":"My Suite"`))
+		Ω(data).ShouldNot(ContainSubstring("some captured stdout"))
+
+		By("totalling the specs in each state")
+		Ω(summary).Should(HavePrefix("## My Suite: ❌ Failed\n"))
+		Ω(summary).Should(ContainSubstring("| Suite | Result | Passed | Failed | Skipped | Pending | Flaky | Run Time |"))
+		Ω(summary).Should(ContainSubstring("| My Suite | ❌ Failed | 1 | 1 | 1 | 1 | 1 | 1m0s |"))
+
+		By("listing the failures")
+		Ω(summary).Should(ContainSubstring("### Failures\n\n- **[FAILED]** A B C at `c_test.go:17`\n  ```\n  failure\n  message\n  ```\n"))
+
+		By("listing the flaky specs")
+		Ω(summary).Should(ContainSubstring("### Flaky Specs"))
+		Ω(summary).Should(ContainSubstring("| A D \\| E | 3 | `cl1.go:37` |"))
+
+		By("listing the slowest specs")
+		Ω(summary).Should(ContainSubstring("### Slowest Specs\n\n| Run Time | Spec | Location |\n|--:|:--|:--|\n| 5s | A D \\| E | `cl1.go:37` |\n| 3s | A B C | `cl0.go:12` |\n| 1s | F |"))
+	})
+
+	It("trims long failure messages", func() {
+		report.SpecReports[0].Failure.Message = strings.Repeat("line\n", 20)
+		path := filepath.Join(dir, "report.md")
+		Ω(reporters.GenerateMarkdownReport(report, path)).Should(Succeed())
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(ContainSubstring(strings.Repeat("  line\n", 10) + "  ...\n  ```"))
+	})
+
+	It("merges Markdown reports across suites", func() {
+		other := report
+		other.SuiteDescription = "Other Suite"
+		other.SuiteSucceeded = true
+		other.SpecReports = types.SpecReports{S("H", cl0)}
+		Ω(reporters.GenerateMarkdownReport(report, filepath.Join(dir, "a.md"))).Should(Succeed())
+		Ω(reporters.GenerateMarkdownReport(other, filepath.Join(dir, "b.md"))).Should(Succeed())
+		Ω(os.WriteFile(filepath.Join(dir, "c.md"), []byte("# not ginkgo"), 0644)).Should(Succeed())
+
+		messages, err := reporters.MergeAndCleanupMarkdownReports([]string{filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md"), filepath.Join(dir, "c.md")}, filepath.Join(dir, "merged.md"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(messages).Should(HaveLen(1))
+		Ω(filepath.Join(dir, "a.md")).ShouldNot(BeAnExistingFile())
+
+		content, err := os.ReadFile(filepath.Join(dir, "merged.md"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(HavePrefix("## Ginkgo Report: ❌ Failed\n"))
+		Ω(string(content)).Should(ContainSubstring("| Other Suite | ✅ Passed | 1 | 0 | 0 | 0 | 0 | 1m0s |"))
+		Ω(string(content)).Should(ContainSubstring("| **Total** | ❌ Failed | **2** | **1** | **1** | **1** | **1** | |"))
+		Ω(string(content)).Should(ContainSubstring("- **[FAILED]** My Suite: A B C at `c_test.go:17`"))
+
+		reports, err := reporters.LoadMarkdownReport(filepath.Join(dir, "merged.md"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reports).Should(HaveLen(2))
+	})
+
+	It("appends summaries without the embedded reports", func() {
+		path := filepath.Join(dir, "step-summary.md")
+		Ω(os.WriteFile(path, []byte("# Earlier Step\n"), 0644)).Should(Succeed())
+		Ω(reporters.AppendMarkdownSummary([]types.Report{report}, path)).Should(Succeed())
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(HavePrefix("# Earlier Step\n## My Suite: ❌ Failed\n"))
+		Ω(string(content)).ShouldNot(ContainSubstring("ginkgo-reports"))
+	})
+})
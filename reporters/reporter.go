@@ -27,3 +27,47 @@ func (n NoopReporter) EmitFailure(state types.SpecState, failure types.Failure)
 func (n NoopReporter) EmitProgressReport(progressReport types.ProgressReport)   {}
 func (n NoopReporter) EmitReportEntry(entry types.ReportEntry)                  {}
 func (n NoopReporter) EmitSpecEvent(event types.SpecEvent)                      {}
+
+// CompositeReporter forwards every callback to each of its reporters, in order
+type CompositeReporter []Reporter
+
+func (c CompositeReporter) SuiteWillBegin(report types.Report) {
+	for _, reporter := range c {
+		reporter.SuiteWillBegin(report)
+	}
+}
+func (c CompositeReporter) WillRun(report types.SpecReport) {
+	for _, reporter := range c {
+		reporter.WillRun(report)
+	}
+}
+func (c CompositeReporter) DidRun(report types.SpecReport) {
+	for _, reporter := range c {
+		reporter.DidRun(report)
+	}
+}
+func (c CompositeReporter) SuiteDidEnd(report types.Report) {
+	for _, reporter := range c {
+		reporter.SuiteDidEnd(report)
+	}
+}
+func (c CompositeReporter) EmitFailure(state types.SpecState, failure types.Failure) {
+	for _, reporter := range c {
+		reporter.EmitFailure(state, failure)
+	}
+}
+func (c CompositeReporter) EmitProgressReport(progressReport types.ProgressReport) {
+	for _, reporter := range c {
+		reporter.EmitProgressReport(progressReport)
+	}
+}
+func (c CompositeReporter) EmitReportEntry(entry types.ReportEntry) {
+	for _, reporter := range c {
+		reporter.EmitReportEntry(entry)
+	}
+}
+func (c CompositeReporter) EmitSpecEvent(event types.SpecEvent) {
+	for _, reporter := range c {
+		reporter.EmitSpecEvent(event)
+	}
+}
@@ -70,6 +70,9 @@ func GenerateTeamcityReport(report types.Report, dst string) error {
 
 		name = tcEscape(name)
 		fmt.Fprintf(f, "##teamcity[testStarted name='%s']\n", name)
+		if spec.ID != "" {
+			fmt.Fprintf(f, "##teamcity[testMetadata testName='%s' name='id' value='%s']\n", name, tcEscape(spec.ID))
+		}
 		switch spec.State {
 		case types.SpecStatePending:
 			fmt.Fprintf(f, "##teamcity[testIgnored name='%s' message='pending']\n", name)
@@ -79,6 +82,8 @@ func GenerateTeamcityReport(report types.Report, dst string) error {
 				message += " - " + spec.Failure.Message
 			}
 			fmt.Fprintf(f, "##teamcity[testIgnored name='%s' message='%s']\n", name, tcEscape(message))
+		case types.SpecStateQuarantined:
+			fmt.Fprintf(f, "##teamcity[testIgnored name='%s' message='%s']\n", name, tcEscape(quarantineMessageForUnstructuredReporters(spec)))
 		case types.SpecStateFailed:
 			details := failureDescriptionForUnstructuredReporters(spec)
 			fmt.Fprintf(f, "##teamcity[testFailed name='%s' message='failed - %s' details='%s']\n", name, tcEscape(spec.Failure.Message), tcEscape(details))
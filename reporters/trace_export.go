@@ -0,0 +1,427 @@
+/*
+
+Trace Exporter for Ginkgo
+
+Exports test runs as OpenTelemetry traces in the OTLP JSON format (https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding).  Each suite is a span with a child span for every spec.  Each spec span has child spans for the nodes that ran (BeforeEach, It, DeferCleanup, etc.) and these, in turn, have child spans for the By steps they ran.
+
+The spans are built from each SpecReport's SpecEvents.  When --trace-export is set Ginkgo records the IDs of these spans as the suite runs so that code under test can attach spans of its own via SpanContextFor(ctx).
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// OTLP span kinds and status codes
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeUnset  = 0
+	otlpStatusCodeOK     = 1
+	otlpStatusCodeError  = 2
+	traceExportScopeName = "github.com/onsi/ginkgo/v2"
+)
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes otlpAttributes `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        otlpAttributes `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   otlpAttributes `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// 64-bit integers are encoded as strings in OTLP JSON
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+type otlpAttributes []otlpKeyValue
+
+func (a otlpAttributes) withString(key string, value string) otlpAttributes {
+	if value == "" {
+		return a
+	}
+	return append(a, otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}})
+}
+
+func (a otlpAttributes) withInt(key string, value int64) otlpAttributes {
+	s := fmt.Sprintf("%d", value)
+	return append(a, otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &s}})
+}
+
+func (a otlpAttributes) withBool(key string, value bool) otlpAttributes {
+	return append(a, otlpKeyValue{Key: key, Value: otlpAnyValue{BoolValue: &value}})
+}
+
+func (a otlpAttributes) withStrings(key string, values []string) otlpAttributes {
+	if len(values) == 0 {
+		return a
+	}
+	array := &otlpArrayValue{}
+	for i := range values {
+		array.Values = append(array.Values, otlpAnyValue{StringValue: &values[i]})
+	}
+	return append(a, otlpKeyValue{Key: key, Value: otlpAnyValue{ArrayValue: array}})
+}
+
+func (a otlpAttributes) withCodeLocation(location types.CodeLocation) otlpAttributes {
+	if location.FileName == "" {
+		return a
+	}
+	return a.withString("code.filepath", location.FileName).withInt("code.lineno", int64(location.LineNumber))
+}
+
+func otlpTime(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return fmt.Sprintf("%d", t.UnixNano())
+}
+
+// GenerateTraceExport exports the passed in report as OTLP JSON-formatted traces at the passed in destination
+func GenerateTraceExport(report types.Report, destination string) error {
+	return writeTraceExport(otlpTraces{ResourceSpans: []otlpResourceSpans{tracesForReport(report)}}, destination)
+}
+
+// MergeAndCleanupTraceExports produces a single OTLP JSON-formatted trace export at the passed in destination by merging the trace exports provided in sources
+// It skips over exports that fail to decode but reports on them via the returned messages []string
+func MergeAndCleanupTraceExports(sources []string, destination string) ([]string, error) {
+	messages := []string{}
+	merged := otlpTraces{ResourceSpans: []otlpResourceSpans{}}
+	for _, source := range sources {
+		traces := otlpTraces{}
+		data, err := os.ReadFile(source)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not open %s:\n%s", source, err.Error()))
+			continue
+		}
+		err = json.Unmarshal(data, &traces)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not decode %s:\n%s", source, err.Error()))
+			continue
+		}
+		os.Remove(source)
+		merged.ResourceSpans = append(merged.ResourceSpans, traces.ResourceSpans...)
+	}
+
+	return messages, writeTraceExport(merged, destination)
+}
+
+func writeTraceExport(traces otlpTraces, destination string) error {
+	if err := os.MkdirAll(path.Dir(destination), 0770); err != nil {
+		return err
+	}
+	f, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(traces)
+}
+
+func tracesForReport(report types.Report) otlpResourceSpans {
+	suiteSpan, err := types.ParseTraceParent(report.SuiteConfig.TraceSuiteSpan)
+	if err != nil {
+		suiteSpan = types.SpanContext{TraceID: types.NewTraceID(), SpanID: types.NewSpanID()}
+	}
+
+	name := report.SuiteDescription
+	if name == "" {
+		name = report.SuitePath
+	}
+	suite := otlpSpan{
+		TraceID:           suiteSpan.TraceID,
+		SpanID:            suiteSpan.SpanID,
+		ParentSpanID:      report.SuiteConfig.TraceParentSpan,
+		Name:              name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: otlpTime(report.StartTime),
+		EndTimeUnixNano:   otlpTime(report.EndTime),
+		Attributes: otlpAttributes{}.
+			withString("ginkgo.suite.description", report.SuiteDescription).
+			withString("ginkgo.suite.path", report.SuitePath).
+			withStrings("ginkgo.suite.labels", report.SuiteLabels).
+			withBool("ginkgo.suite.succeeded", report.SuiteSucceeded).
+			withInt("ginkgo.suite.random_seed", report.SuiteConfig.RandomSeed).
+			withInt("ginkgo.suite.total_specs", int64(report.PreRunStats.TotalSpecs)).
+			withInt("ginkgo.suite.specs_that_will_run", int64(report.PreRunStats.SpecsThatWillRun)).
+			withInt("ginkgo.parallel.total", int64(report.SuiteConfig.ParallelTotal)),
+		Status: otlpStatus{Code: otlpStatusCodeOK},
+	}
+	if !report.SuiteSucceeded {
+		suite.Status = otlpStatus{Code: otlpStatusCodeError, Message: strings.Join(report.SpecialSuiteFailureReasons, "\n")}
+	}
+
+	spans := []otlpSpan{suite}
+	for _, spec := range report.SpecReports {
+		// specs that were skipped before they started (e.g. by a filter) didn't run anything
+		if spec.StartTime.IsZero() {
+			continue
+		}
+		spans = append(spans, spansForSpec(suiteSpan, spec)...)
+	}
+
+	return otlpResourceSpans{
+		Resource: otlpResource{Attributes: otlpAttributes{}.
+			withString("service.name", "ginkgo").
+			withString("ginkgo.suite.path", report.SuitePath),
+		},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: traceExportScopeName, Version: types.VERSION},
+			Spans: spans,
+		}},
+	}
+}
+
+func spansForSpec(suiteSpan types.SpanContext, spec types.SpecReport) []otlpSpan {
+	spanID := spec.SpanID
+	if spanID == "" {
+		spanID = types.NewSpanID()
+	}
+
+	name := spec.FullText()
+	if !spec.LeafNodeType.Is(types.NodeTypeIt) {
+		name = strings.TrimSpace("[" + spec.LeafNodeType.String() + "] " + name)
+	}
+	specSpan := otlpSpan{
+		TraceID:           suiteSpan.TraceID,
+		SpanID:            spanID,
+		ParentSpanID:      suiteSpan.SpanID,
+		Name:              name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: otlpTime(spec.StartTime),
+		EndTimeUnixNano:   otlpTime(spec.EndTime),
+		Attributes: otlpAttributes{}.
+			withString("ginkgo.spec.text", spec.FullText()).
+			withStrings("ginkgo.spec.container_hierarchy", spec.ContainerHierarchyTexts).
+			withString("ginkgo.spec.leaf_node_type", spec.LeafNodeType.String()).
+			withString("ginkgo.spec.state", spec.State.String()).
+			withStrings("ginkgo.spec.labels", spec.Labels()).
+			withInt("ginkgo.spec.num_attempts", int64(spec.NumAttempts)).
+			withInt("ginkgo.parallel.process", int64(spec.ParallelProcess)).
+			withCodeLocation(spec.LeafNodeLocation),
+	}
+	if spec.IsSerial {
+		specSpan.Attributes = specSpan.Attributes.withBool("ginkgo.spec.serial", true)
+	}
+	if spec.IsInOrderedContainer {
+		specSpan.Attributes = specSpan.Attributes.withBool("ginkgo.spec.ordered", true)
+	}
+
+	switch {
+	case spec.State.Is(types.SpecStatePassed):
+		specSpan.Status = otlpStatus{Code: otlpStatusCodeOK}
+	case spec.State.Is(types.SpecStateFailureStates):
+		specSpan.Status = otlpStatus{Code: otlpStatusCodeError, Message: spec.Failure.Message}
+		specSpan.Attributes = specSpan.Attributes.
+			withString("ginkgo.failure.message", spec.Failure.Message).
+			withString("ginkgo.failure.location", spec.Failure.Location.String()).
+			withString("ginkgo.failure.node_type", spec.Failure.FailureNodeType.String())
+		specSpan.Events = append(specSpan.Events, exceptionEventForFailure(spec.State, spec.Failure, spec.EndTime))
+	default:
+		specSpan.Status = otlpStatus{Code: otlpStatusCodeUnset}
+	}
+	for _, additionalFailure := range spec.AdditionalFailures {
+		specSpan.Events = append(specSpan.Events, exceptionEventForFailure(additionalFailure.State, additionalFailure.Failure, spec.EndTime))
+	}
+	for _, entry := range spec.ReportEntries {
+		specSpan.Events = append(specSpan.Events, otlpEvent{
+			TimeUnixNano: otlpTime(entry.Time),
+			Name:         entry.Name,
+			Attributes:   otlpAttributes{}.withString("ginkgo.report_entry.value", entry.StringRepresentation()).withCodeLocation(entry.Location),
+		})
+	}
+
+	nodeSpans := spansForSpecEvents(suiteSpan.TraceID, &specSpan, spec)
+	return append([]otlpSpan{specSpan}, nodeSpans...)
+}
+
+func exceptionEventForFailure(state types.SpecState, failure types.Failure, fallbackTime time.Time) otlpEvent {
+	stackTrace := failure.Location.FullStackTrace
+	if failure.ForwardedPanic != "" {
+		stackTrace = failure.ForwardedPanic + "\n" + stackTrace
+	}
+	t := failure.TimelineLocation.Time
+	if t.IsZero() {
+		t = fallbackTime
+	}
+	return otlpEvent{
+		TimeUnixNano: otlpTime(t),
+		Name:         "exception",
+		Attributes: otlpAttributes{}.
+			withString("exception.type", state.String()).
+			withString("exception.message", failure.Message).
+			withString("exception.stacktrace", stackTrace).
+			withCodeLocation(failure.Location),
+	}
+}
+
+type openSpan struct {
+	span       otlpSpan
+	spanEvent  types.SpecEvent
+	hasEndTime bool
+}
+
+/*
+spansForSpecEvents turns the Node and By SpecEvents of a spec into spans.  Nodes run one after the other and are children of the spec.  By steps are children of the node (or By step) they were called in.
+
+By steps that are passed a callback have an end event.  By steps without a callback end when the next By step in the same node starts, or when the node ends.
+*/
+func spansForSpecEvents(traceID string, specSpan *otlpSpan, spec types.SpecReport) []otlpSpan {
+	byStepsWithEndEvents := map[int]bool{}
+	openByStarts := []int{}
+	for i, event := range spec.SpecEvents {
+		switch event.SpecEventType {
+		case types.SpecEventNodeStart:
+			openByStarts = []int{}
+		case types.SpecEventByStart:
+			openByStarts = append(openByStarts, i)
+		case types.SpecEventByEnd:
+			// an end event belongs to the most recent By step at the same location that hasn't ended yet
+			for j := len(openByStarts) - 1; j >= 0; j-- {
+				start := spec.SpecEvents[openByStarts[j]]
+				if start.CodeLocation.String() == event.CodeLocation.String() && start.Message == event.Message && start.SpanID == event.SpanID {
+					byStepsWithEndEvents[openByStarts[j]] = true
+					openByStarts = append(openByStarts[:j], openByStarts[j+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	spans := []otlpSpan{}
+	stack := []openSpan{}
+	closeSpans := func(n int, t time.Time) {
+		for ; n > 0 && len(stack) > 0; n-- {
+			closed := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			closed.span.EndTimeUnixNano = otlpTime(t)
+			spans = append(spans, closed.span)
+		}
+	}
+	closeStepsWithoutEndEvents := func(t time.Time) {
+		for len(stack) > 0 && stack[len(stack)-1].spanEvent.SpecEventType.Is(types.SpecEventByStart) && !stack[len(stack)-1].hasEndTime {
+			closeSpans(1, t)
+		}
+	}
+	openSpanFor := func(event types.SpecEvent, name string, attributes otlpAttributes) otlpSpan {
+		parentSpanID := specSpan.SpanID
+		if len(stack) > 0 {
+			parentSpanID = stack[len(stack)-1].span.SpanID
+		}
+		spanID := event.SpanID
+		if spanID == "" {
+			spanID = types.NewSpanID()
+		}
+		return otlpSpan{
+			TraceID:           traceID,
+			SpanID:            spanID,
+			ParentSpanID:      parentSpanID,
+			Name:              name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: otlpTime(event.TimelineLocation.Time),
+			Attributes:        attributes.withCodeLocation(event.CodeLocation),
+		}
+	}
+
+	attempt := 1
+	failedNodeSpanIdx := -1
+	for i, event := range spec.SpecEvents {
+		t := event.TimelineLocation.Time
+		switch event.SpecEventType {
+		case types.SpecEventNodeStart:
+			closeSpans(len(stack), t)
+			attributes := otlpAttributes{}.withString("ginkgo.node.type", event.NodeType.String()).withString("ginkgo.node.text", event.Message)
+			if spec.NumAttempts > 1 {
+				attributes = attributes.withInt("ginkgo.spec.attempt", int64(attempt))
+			}
+			stack = append(stack, openSpan{span: openSpanFor(event, fmt.Sprintf("[%s] %s", event.NodeType, event.Message), attributes), spanEvent: event, hasEndTime: true})
+		case types.SpecEventNodeEnd:
+			closeSpans(len(stack), t)
+			// the spec's failure is attributed to the last run of the node that failed
+			if spec.State.Is(types.SpecStateFailureStates) && event.NodeType == spec.Failure.FailureNodeType && event.CodeLocation.String() == spec.Failure.FailureNodeLocation.String() {
+				failedNodeSpanIdx = len(spans) - 1
+			}
+		case types.SpecEventByStart:
+			closeStepsWithoutEndEvents(t)
+			stack = append(stack, openSpan{span: openSpanFor(event, "STEP: "+event.Message, otlpAttributes{}), spanEvent: event, hasEndTime: byStepsWithEndEvents[i]})
+		case types.SpecEventByEnd:
+			closeStepsWithoutEndEvents(t)
+			closeSpans(1, t)
+		case types.SpecEventSpecRetry, types.SpecEventSpecRepeat:
+			closeSpans(len(stack), t)
+			attempt = event.Attempt + 1
+			specSpan.Events = append(specSpan.Events, otlpEvent{
+				TimeUnixNano: otlpTime(t),
+				Name:         event.SpecEventType.String(),
+				Attributes:   otlpAttributes{}.withInt("ginkgo.spec.attempt", int64(attempt)),
+			})
+		}
+	}
+	closeSpans(len(stack), spec.EndTime)
+	if failedNodeSpanIdx >= 0 {
+		spans[failedNodeSpanIdx].Status = otlpStatus{Code: otlpStatusCodeError, Message: spec.Failure.Message}
+	}
+
+	return spans
+}
@@ -0,0 +1,222 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type exportedAttribute struct {
+	Key   string
+	Value map[string]any
+}
+
+type exportedSpan struct {
+	TraceID           string
+	SpanID            string
+	ParentSpanID      string
+	Name              string
+	StartTimeUnixNano string
+	EndTimeUnixNano   string
+	Attributes        []exportedAttribute
+	Events            []struct {
+		Name       string
+		Attributes []exportedAttribute
+	}
+	Status struct {
+		Code    int
+		Message string
+	}
+}
+
+func (s exportedSpan) Attribute(key string) any {
+	for _, attribute := range s.Attributes {
+		if attribute.Key == key {
+			for _, value := range attribute.Value {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+func loadExportedSpans(path string) [][]exportedSpan {
+	data, err := os.ReadFile(path)
+	Ω(err).ShouldNot(HaveOccurred())
+	traces := struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Scope struct{ Name string }
+				Spans []exportedSpan
+			}
+		}
+	}{}
+	Ω(json.Unmarshal(data, &traces)).Should(Succeed())
+	out := [][]exportedSpan{}
+	for _, resourceSpans := range traces.ResourceSpans {
+		Ω(resourceSpans.ScopeSpans).Should(HaveLen(1))
+		Ω(resourceSpans.ScopeSpans[0].Scope.Name).Should(Equal("github.com/onsi/ginkgo/v2"))
+		out = append(out, resourceSpans.ScopeSpans[0].Spans)
+	}
+	return out
+}
+
+var _ = Describe("TraceExport", func() {
+	var report types.Report
+	var dir string
+	var t0 time.Time
+	at := func(ms int) types.TimelineLocation {
+		return TL(t0.Add(time.Duration(ms) * time.Millisecond))
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		failing := S(CTS("A"), "B", cl0, types.SpecStateFailed, Label("cat"),
+			SE(types.SpecEventNodeStart, types.NodeTypeBeforeEach, "A", cl1, at(1)),
+			SE(types.SpecEventByStart, "with a callback", cl2, at(2)),
+			SE(types.SpecEventByStart, "without a callback", cl3, at(3)),
+			SE(types.SpecEventByEnd, "with a callback", cl2, at(4)),
+			SE(types.SpecEventByStart, "another step", cl4, at(5)),
+			SE(types.SpecEventNodeEnd, types.NodeTypeBeforeEach, "A", cl1, at(6)),
+			SE(types.SpecEventNodeStart, types.NodeTypeIt, "B", cl0, at(7)),
+			SE(types.SpecEventNodeEnd, types.NodeTypeIt, "B", cl0, at(8)),
+			F("boom", cl0, types.NodeTypeIt, FailureNodeLocation(cl0), types.FailureNodeIsLeafNode, at(8)),
+		)
+		failing.StartTime, failing.EndTime, failing.ParallelProcess, failing.SpanID = t0, t0.Add(10*time.Millisecond), 2, "00f067aa0ba902b8"
+		failing.SpecEvents[0].SpanID, failing.SpecEvents[5].SpanID = "00f067aa0ba902b9", "00f067aa0ba902b9"
+
+		retried := S("C", cl1, 2, FlakeAttempts(2),
+			SE(types.SpecEventNodeStart, types.NodeTypeIt, "C", cl1, at(11)),
+			SE(types.SpecEventNodeEnd, types.NodeTypeIt, "C", cl1, at(12)),
+			SE(types.SpecEventSpecRetry, 1, at(13)),
+			SE(types.SpecEventNodeStart, types.NodeTypeIt, "C", cl1, at(14)),
+			SE(types.SpecEventNodeEnd, types.NodeTypeIt, "C", cl1, at(15)),
+		)
+		retried.StartTime, retried.EndTime = t0.Add(10*time.Millisecond), t0.Add(16*time.Millisecond)
+
+		report = types.Report{
+			SuiteDescription: "My Suite",
+			SuitePath:        "/path/to/suite",
+			SuiteSucceeded:   false,
+			SuiteConfig: types.SuiteConfig{
+				RandomSeed:      17,
+				ParallelTotal:   2,
+				TraceSuiteSpan:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				TraceParentSpan: "00f067aa0ba902b6",
+			},
+			StartTime: t0,
+			EndTime:   t0.Add(time.Second),
+			SpecReports: types.SpecReports{
+				failing,
+				retried,
+				S("D", cl2, types.SpecStateSkipped),
+			},
+		}
+	})
+
+	It("exports the suite as a tree of spans", func() {
+		path := filepath.Join(dir, "nested", "traces.json")
+		Ω(reporters.GenerateTraceExport(report, path)).Should(Succeed())
+		exported := loadExportedSpans(path)
+		Ω(exported).Should(HaveLen(1))
+		spans := map[string]exportedSpan{}
+		for _, span := range exported[0] {
+			Ω(span.TraceID).Should(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			spans[span.Name] = span
+		}
+		Ω(spans).Should(HaveLen(9))
+
+		By("parenting the suite span with the trace parent")
+		suite := spans["My Suite"]
+		Ω(suite.SpanID).Should(Equal("00f067aa0ba902b7"))
+		Ω(suite.ParentSpanID).Should(Equal("00f067aa0ba902b6"))
+		Ω(suite.Status.Code).Should(Equal(2))
+		Ω(suite.Attribute("ginkgo.suite.random_seed")).Should(Equal("17"))
+
+		By("adding spans for the specs that ran, with their attributes")
+		spec := spans["A B"]
+		Ω(spec.SpanID).Should(Equal("00f067aa0ba902b8"))
+		Ω(spec.ParentSpanID).Should(Equal(suite.SpanID))
+		Ω(spec.StartTimeUnixNano).Should(Equal("1767225600000000000"))
+		Ω(spec.Attribute("ginkgo.spec.state")).Should(Equal("failed"))
+		Ω(spec.Attribute("ginkgo.parallel.process")).Should(Equal("2"))
+		Ω(spec.Attribute("ginkgo.failure.message")).Should(Equal("boom"))
+		Ω(spec.Attribute("ginkgo.spec.labels")).Should(Equal(map[string]any{"values": []any{map[string]any{"stringValue": "cat"}}}))
+		Ω(spec.Status.Code).Should(Equal(2))
+		Ω(spec.Events).Should(HaveLen(1))
+		Ω(spec.Events[0].Name).Should(Equal("exception"))
+		Ω(spans).ShouldNot(HaveKey("D"))
+
+		By("adding spans for the nodes that ran, as children of the spec")
+		beforeEach := spans["[BeforeEach] A"]
+		Ω(beforeEach.SpanID).Should(Equal("00f067aa0ba902b9"))
+		Ω(beforeEach.ParentSpanID).Should(Equal(spec.SpanID))
+		Ω(beforeEach.EndTimeUnixNano).Should(Equal("1767225600006000000"))
+		Ω(beforeEach.Status.Code).Should(Equal(0))
+		it := spans["[It] B"]
+		Ω(it.ParentSpanID).Should(Equal(spec.SpanID))
+		Ω(it.Status.Code).Should(Equal(2))
+		Ω(it.Status.Message).Should(Equal("boom"))
+
+		By("adding spans for By steps as children of the node they ran in")
+		withCallback := spans["STEP: with a callback"]
+		Ω(withCallback.ParentSpanID).Should(Equal(beforeEach.SpanID))
+		Ω(withCallback.EndTimeUnixNano).Should(Equal("1767225600004000000"))
+		withoutCallback := spans["STEP: without a callback"]
+		Ω(withoutCallback.ParentSpanID).Should(Equal(withCallback.SpanID))
+		Ω(withoutCallback.EndTimeUnixNano).Should(Equal("1767225600004000000"))
+		another := spans["STEP: another step"]
+		Ω(another.ParentSpanID).Should(Equal(beforeEach.SpanID))
+		Ω(another.EndTimeUnixNano).Should(Equal("1767225600006000000"))
+
+		By("recording retries as events on the spec and tagging each attempt's nodes")
+		retried := spans["C"]
+		Ω(retried.Events).Should(HaveLen(1))
+		Ω(retried.Events[0].Name).Should(Equal("Retry"))
+		attempts := []any{}
+		for _, span := range exported[0] {
+			if span.Name == "[It] C" {
+				Ω(span.ParentSpanID).Should(Equal(retried.SpanID))
+				attempts = append(attempts, span.Attribute("ginkgo.spec.attempt"))
+			}
+		}
+		Ω(attempts).Should(Equal([]any{"1", "2"}))
+	})
+
+	It("starts a new trace when the report doesn't have a suite span", func() {
+		report.SuiteConfig.TraceSuiteSpan, report.SuiteConfig.TraceParentSpan = "", ""
+		path := filepath.Join(dir, "traces.json")
+		Ω(reporters.GenerateTraceExport(report, path)).Should(Succeed())
+		spans := loadExportedSpans(path)[0]
+		Ω(spans[0].Name).Should(Equal("My Suite"))
+		Ω(spans[0].TraceID).Should(HaveLen(32))
+		Ω(spans[0].TraceID).ShouldNot(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+		Ω(spans[0].ParentSpanID).Should(BeEmpty())
+		for _, span := range spans {
+			Ω(span.TraceID).Should(Equal(spans[0].TraceID))
+		}
+	})
+
+	It("merges trace exports", func() {
+		other := report
+		other.SuiteDescription = "Other Suite"
+		Ω(reporters.GenerateTraceExport(report, filepath.Join(dir, "a.json"))).Should(Succeed())
+		Ω(reporters.GenerateTraceExport(other, filepath.Join(dir, "b.json"))).Should(Succeed())
+		messages, err := reporters.MergeAndCleanupTraceExports([]string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"), filepath.Join(dir, "missing.json")}, filepath.Join(dir, "merged.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(messages).Should(HaveLen(1))
+		exported := loadExportedSpans(filepath.Join(dir, "merged.json"))
+		Ω(exported).Should(HaveLen(2))
+		Ω(exported[0][0].Name).Should(Equal("My Suite"))
+		Ω(exported[1][0].Name).Should(Equal("Other Suite"))
+		Ω(filepath.Join(dir, "a.json")).ShouldNot(BeAnExistingFile())
+	})
+})
@@ -192,7 +192,8 @@ ReportAfterSuite nodes must be created at the top-level (i.e. not nested in a Co
 When running in parallel, Ginkgo ensures that only one of the parallel nodes runs the ReportAfterSuite and that it is passed a report that is aggregated across
 all parallel nodes
 
-In addition to using ReportAfterSuite to programmatically generate suite reports, you can also generate JSON, GoJSON, JUnit, and Teamcity formatted reports using the --json-report, --gojson-report, --junit-report, and --teamcity-report ginkgo CLI flags.
+In addition to using ReportAfterSuite to programmatically generate suite reports, you can also generate JSON, GoJSON, JUnit, Teamcity, HTML, and Markdown formatted reports using the --json-report, --gojson-report, --junit-report, --teamcity-report, --html-report, and --markdown-report ginkgo CLI flags - and export the run as OTLP traces with --trace-export.
+When $GITHUB_STEP_SUMMARY is set Ginkgo also appends a Markdown summary of the suite to it, unless --no-github-step-summary is set.
 
 You cannot nest any other Ginkgo nodes within a ReportAfterSuite node's closure.
 You can learn more about ReportAfterSuite here: https://onsi.github.io/ginkgo/#generating-reports-programmatically
@@ -207,7 +208,7 @@ func ReportAfterSuite(text string, body any, args ...any) bool {
 	return pushNode(internal.NewNode(internal.TransformNewNodeArgs(exitIfErrors, deprecationTracker, types.NodeTypeReportAfterSuite, text, combinedArgs...)))
 }
 
-func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.ReporterConfig) {
+func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.ReporterConfig, githubStepSummary string) {
 	body := func(report Report) {
 		if reporterConfig.JSONReport != "" {
 			err := reporters.GenerateJSONReport(report, reporterConfig.JSONReport)
@@ -233,6 +234,30 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 				Fail(fmt.Sprintf("Failed to generate Teamcity report:\n%s", err.Error()))
 			}
 		}
+		if reporterConfig.HTMLReport != "" {
+			err := reporters.GenerateHTMLReport(report, reporterConfig.HTMLReport)
+			if err != nil {
+				Fail(fmt.Sprintf("Failed to generate HTML report:\n%s", err.Error()))
+			}
+		}
+		if reporterConfig.MarkdownReport != "" {
+			err := reporters.GenerateMarkdownReport(report, reporterConfig.MarkdownReport)
+			if err != nil {
+				Fail(fmt.Sprintf("Failed to generate Markdown report:\n%s", err.Error()))
+			}
+		}
+		if reporterConfig.TraceExport != "" {
+			err := reporters.GenerateTraceExport(report, reporterConfig.TraceExport)
+			if err != nil {
+				Fail(fmt.Sprintf("Failed to generate trace export:\n%s", err.Error()))
+			}
+		}
+		if githubStepSummary != "" {
+			err := reporters.AppendMarkdownSummary([]types.Report{report}, githubStepSummary)
+			if err != nil {
+				Fail(fmt.Sprintf("Failed to write to $GITHUB_STEP_SUMMARY:\n%s", err.Error()))
+			}
+		}
 	}
 
 	flags := []string{}
@@ -248,6 +273,18 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 	if reporterConfig.TeamcityReport != "" {
 		flags = append(flags, "--teamcity-report")
 	}
+	if reporterConfig.HTMLReport != "" {
+		flags = append(flags, "--html-report")
+	}
+	if reporterConfig.MarkdownReport != "" {
+		flags = append(flags, "--markdown-report")
+	}
+	if reporterConfig.TraceExport != "" {
+		flags = append(flags, "--trace-export")
+	}
+	if githubStepSummary != "" {
+		flags = append(flags, "$GITHUB_STEP_SUMMARY")
+	}
 	pushNode(internal.NewNode(
 		internal.TransformNewNodeArgs(
 			exitIfErrors, deprecationTracker, types.NodeTypeReportAfterSuite,
@@ -257,3 +294,22 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 		),
 	))
 }
+
+func registerReportAfterSuiteNodeForSpecTimings(path string) {
+	body := func(report Report) {
+		timings, _ := types.LoadSpecTimings(path)
+		err := timings.WithReport(report).Save(path)
+		if err != nil {
+			Fail(fmt.Sprintf("Failed to save spec timings:\n%s", err.Error()))
+		}
+	}
+
+	pushNode(internal.NewNode(
+		internal.TransformNewNodeArgs(
+			exitIfErrors, deprecationTracker, types.NodeTypeReportAfterSuite,
+			"Autogenerated ReportAfterSuite for --spec-timings",
+			body,
+			types.NewCustomCodeLocation("autogenerated by Ginkgo"),
+		),
+	))
+}
@@ -0,0 +1,26 @@
+package ginkgo
+
+import (
+	"github.com/onsi/ginkgo/v2/internal/global"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+MatchSnapshot compares value with the snapshot called name that is stored for the current spec and fails the spec if they differ.
+
+Snapshots are stored in a __snapshots__ directory next to the spec's file and are keyed by the spec's full text and name.  Strings and []byte are stored as-is, anything else is stored as indented JSON.  The first time MatchSnapshot is called for a given name the snapshot is written and the spec passes.  Run with --update-snapshots to overwrite snapshots that no longer match and to remove snapshots that are no longer used by any spec.
+
+MatchSnapshot can be called from any node that runs as part of a spec (e.g. It, BeforeEach, AfterEach) - but not from container nodes or suite-level nodes such as BeforeSuite.  Because snapshots are keyed by the spec's full text, renaming a spec will orphan its snapshots - Ginkgo reports such obsolete snapshots at the end of the suite.
+
+You can learn more here: https://onsi.github.io/ginkgo/#snapshot-testing
+*/
+func MatchSnapshot(name string, value any) {
+	cl := types.NewCodeLocation(1)
+	mismatch, err := global.Suite.MatchSnapshot(name, value, cl)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	if mismatch != "" {
+		Fail(mismatch, 1)
+	}
+}
@@ -193,6 +193,9 @@ You can mark a particular entry as pending with XEntry.  This is equivalent to X
 */
 var XEntry = PEntry
 
+// tableConstructionHook is called when the table's container is entered - before any of its entries are generated.  It returns any additional entries the table should generate.
+type tableConstructionHook func() []TableEntry
+
 var contextType = reflect.TypeOf(new(context.Context)).Elem()
 var specContextType = reflect.TypeOf(new(SpecContext)).Elem()
 
@@ -202,6 +205,7 @@ func generateTable(description string, isSubtree bool, args ...any) {
 	containerNodeArgs := []any{cl}
 
 	entries := []TableEntry{}
+	constructionHooks := []tableConstructionHook{}
 	var internalBody any
 	var internalBodyType reflect.Type
 
@@ -228,6 +232,8 @@ func generateTable(description string, isSubtree bool, args ...any) {
 			entries = append(entries, arg.([]TableEntry)...)
 		case t == reflect.TypeOf(EntryDescription("")):
 			tableLevelEntryDescription = arg.(EntryDescription).render
+		case t == reflect.TypeOf(tableConstructionHook(nil)):
+			constructionHooks = append(constructionHooks, arg.(tableConstructionHook))
 		case t.Kind() == reflect.Func && t.NumOut() == 1 && t.Out(0) == reflect.TypeOf(""):
 			tableLevelEntryDescription = arg
 		case t.Kind() == reflect.Func:
@@ -242,6 +248,10 @@ func generateTable(description string, isSubtree bool, args ...any) {
 	}
 
 	containerNodeArgs = append(containerNodeArgs, func() {
+		entries := entries
+		for _, hook := range constructionHooks {
+			entries = append(entries, hook()...)
+		}
 		for _, entry := range entries {
 			var err error
 			entry := entry
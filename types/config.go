@@ -9,6 +9,7 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -23,6 +24,10 @@ type SuiteConfig struct {
 	SkipStrings           []string
 	FocusFiles            []string
 	SkipFiles             []string
+	FocusIDs              []string
+	RerunFailed           string
+	ImpactFilter          string
+	Shard                 string
 	LabelFilter           string
 	SemVerFilter          string
 	FailOnPending         bool
@@ -30,7 +35,11 @@ type SuiteConfig struct {
 	FailFast              bool
 	FlakeAttempts         int
 	MustPassRepeatedly    int
+	QuarantineFile        string
+	DetectLeaks           string
+	UpdateSnapshots       bool
 	DryRun                bool
+	Fuzz                  string
 	PollProgressAfter     time.Duration
 	PollProgressInterval  time.Duration
 	Timeout               time.Duration
@@ -39,10 +48,14 @@ type SuiteConfig struct {
 	SourceRoots           []string
 	GracePeriod           time.Duration
 	SleepOnFailure        time.Duration
+	SpecTimings           string
 
 	ParallelProcess int
 	ParallelTotal   int
 	ParallelHost    string
+
+	TraceSuiteSpan  string
+	TraceParentSpan string
 }
 
 func NewDefaultSuiteConfig() SuiteConfig {
@@ -101,6 +114,15 @@ type ReporterConfig struct {
 	GoJSONReport   string
 	JUnitReport    string
 	TeamcityReport string
+	HTMLReport     string
+	MarkdownReport string
+	TraceExport    string
+
+	NoGithubStepSummary bool
+
+	EventStream string
+	// EmitStreamEvents is set by the Ginkgo CLI when it streams events (to --event-stream or to --reporter plugins).  The suite then sends its events to the CLI via the parallel support server.
+	EmitStreamEvents bool
 }
 
 func (rc ReporterConfig) Verbosity() VerbosityLevel {
@@ -115,7 +137,15 @@ func (rc ReporterConfig) Verbosity() VerbosityLevel {
 }
 
 func (rc ReporterConfig) WillGenerateReport() bool {
-	return rc.JSONReport != "" || rc.GoJSONReport != "" || rc.JUnitReport != "" || rc.TeamcityReport != ""
+	return rc.JSONReport != "" || rc.GoJSONReport != "" || rc.JUnitReport != "" || rc.TeamcityReport != "" || rc.HTMLReport != "" || rc.MarkdownReport != "" || rc.TraceExport != ""
+}
+
+// GithubStepSummary returns the path in $GITHUB_STEP_SUMMARY that Ginkgo appends a Markdown summary of the run to - or "" if it isn't set or --no-github-step-summary is
+func (rc ReporterConfig) GithubStepSummary() string {
+	if rc.NoGithubStepSummary {
+		return ""
+	}
+	return os.Getenv("GITHUB_STEP_SUMMARY")
 }
 
 func NewDefaultReporterConfig() ReporterConfig {
@@ -139,20 +169,39 @@ type CLIConfig struct {
 	KeepSeparateReports       bool
 
 	//for run only
-	KeepGoing       bool
-	UntilItFails    bool
-	Repeat          int
-	RandomizeSuites bool
+	KeepGoing           bool
+	UntilItFails        bool
+	Repeat              int
+	FlakeHunt           int
+	FuzzTime            time.Duration
+	RandomizeSuites     bool
+	LastFailed          bool
+	ChangedSince        string
+	ImpactBaseline      string
+	SpecCoverage        string
+	RemoteWorkers       int
+	CoordinatorAddress  string
+	RemoteWorkerToken   string
+	RemoteWorkerTimeout time.Duration
+	Reporters           []string
 
 	//for watch only
-	Depth       int
-	WatchRegExp string
+	Depth              int
+	WatchRegExp        string
+	TUI                bool
+	AffectedSpecs      bool
+	ThenRemainingSpecs bool
+
+	//for list only
+	ListFormat string
 }
 
 func NewDefaultCLIConfig() CLIConfig {
 	return CLIConfig{
-		Depth:       1,
-		WatchRegExp: `\.go$`,
+		Depth:               1,
+		WatchRegExp:         `\.go$`,
+		CoordinatorAddress:  "127.0.0.1:7331",
+		RemoteWorkerTimeout: time.Minute,
 	}
 }
 
@@ -287,12 +336,21 @@ var SuiteConfigFlags = GinkgoFlags{
 	{KeyPath: "S.RandomizeAllSpecs", Name: "randomize-all", SectionKey: "order", DeprecatedName: "randomizeAllSpecs", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will randomize all specs together.  By default, ginkgo only randomizes the top level Describe, Context and When containers."},
 
+	{KeyPath: "S.SpecTimings", Name: "spec-timings", SectionKey: "parallel", UsageArgument: "filename.json",
+		Usage: "If set, Ginkgo will record the run time of each spec in the specified file and, when running in parallel, will use the timings recorded by prior runs to schedule the longest-running specs first."},
+
 	{KeyPath: "S.FailOnPending", Name: "fail-on-pending", SectionKey: "failure", DeprecatedName: "failOnPending", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will mark the test suite as failed if any specs are pending."},
 	{KeyPath: "S.FailFast", Name: "fail-fast", SectionKey: "failure", DeprecatedName: "failFast", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will stop running a test suite after a failure occurs."},
 	{KeyPath: "S.FlakeAttempts", Name: "flake-attempts", SectionKey: "failure", UsageDefaultValue: "0 - failed tests are not retried", DeprecatedName: "flakeAttempts", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "Make up to this many attempts to run each spec. If any of the attempts succeed, the suite will not be failed."},
+	{KeyPath: "S.QuarantineFile", Name: "quarantine-file", SectionKey: "failure", UsageArgument: "flaky.yaml",
+		Usage: "If set, ginkgo will load a list of known-flaky specs (matched by full text or by file:line) from this YAML file.  Quarantined specs still run but their failures are reported as quarantined and do not fail the suite."},
+	{KeyPath: "S.DetectLeaks", Name: "detect-leaks", SectionKey: "failure", UsageArgument: "fail or warn",
+		Usage: "If set, ginkgo will check every spec for goroutines (and, on Linux, file descriptors) that are still around once the spec's AfterEach and DeferCleanup nodes have completed.  Leaks fail the spec when set to fail and are reported as a warning when set to warn."},
+	{KeyPath: "S.UpdateSnapshots", Name: "update-snapshots", SectionKey: "failure",
+		Usage: "If set, ginkgo will overwrite snapshots that don't match the values passed to MatchSnapshot - and remove snapshots that are no longer matched by any spec - instead of failing."},
 	{KeyPath: "S.FailOnEmpty", Name: "fail-on-empty", SectionKey: "failure",
 		Usage: "If set, ginkgo will mark the test suite as failed if no specs are run."},
 	{KeyPath: "S.SleepOnFailure", Name: "sleep-on-failure", SectionKey: "failure", UsageDefaultValue: "0 - disabled",
@@ -300,6 +358,8 @@ var SuiteConfigFlags = GinkgoFlags{
 
 	{KeyPath: "S.DryRun", Name: "dry-run", SectionKey: "debug", DeprecatedName: "dryRun", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will walk the test hierarchy without actually running anything.  Best paired with -v."},
+	{KeyPath: "S.Fuzz", Name: "fuzz", SectionKey: "debug", UsageArgument: "regexp",
+		Usage: "If set, ginkgo will use Go's fuzzing engine to fuzz the single DescribeFuzz container whose full text matches this regular expression.  Inputs that fail are saved to the container's corpus in testdata/fuzz and are then run as regular specs.  Requires a Fuzz function that calls RunFuzzSpecs in the suite.  Use --fuzztime to control how long to fuzz for."},
 	{KeyPath: "S.PollProgressAfter", Name: "poll-progress-after", SectionKey: "debug", UsageDefaultValue: "0",
 		Usage: "Emit node progress reports periodically if node hasn't completed after this duration."},
 	{KeyPath: "S.PollProgressInterval", Name: "poll-progress-interval", SectionKey: "debug", UsageDefaultValue: "10s",
@@ -325,6 +385,14 @@ var SuiteConfigFlags = GinkgoFlags{
 		Usage: "If set, ginkgo will only run specs in matching files. Can be specified multiple times, values are ORed."},
 	{KeyPath: "S.SkipFiles", Name: "skip-file", SectionKey: "filter", UsageArgument: "file (regexp) | file:line | file:lineA-lineB | file:line,line,line",
 		Usage: "If set, ginkgo will skip specs in matching files. Can be specified multiple times, values are ORed."},
+	{KeyPath: "S.FocusIDs", Name: "focus-id", SectionKey: "filter", UsageArgument: "id",
+		Usage: "If set, ginkgo will only run the spec with this ID (as reported by ginkgo list and in Ginkgo's reports) along with any specs it depends on via DependsOn. Can be specified multiple times, values are ORed."},
+	{KeyPath: "S.RerunFailed", Name: "rerun-failed", SectionKey: "filter", UsageArgument: "report.json",
+		Usage: "If set, ginkgo will only run the specs that failed, panicked, timed out, or were interrupted in the JSON report (as generated by --json-report) at the specified path.  Specs are matched by file, container hierarchy, and text so this survives edits to the spec files.  Only the failures recorded for the running suite are used.  If the report has no failures, all specs are run."},
+	{KeyPath: "S.ImpactFilter", Name: "impact-filter", SectionKey: "filter", UsageArgument: "impact-filter.json",
+		Usage: "Set by ginkgo run --changed-since.  If set, ginkgo will only run the specs matched by the impact filter at the specified path and will report the other specs as skipped because they were not impacted by the changes."},
+	{KeyPath: "S.Shard", Name: "shard", SectionKey: "filter", UsageArgument: "i/n",
+		Usage: "If set, ginkgo will split the specs that remain after filtering into n disjoint shards and only run the i-th shard (1-indexed).  Specs in Serial and Ordered containers always land in the same shard.  Use this to spread a suite across n independent CI jobs and then combine their reports with ginkgo merge-reports."},
 
 	{KeyPath: "D.RegexScansFilePath", DeprecatedName: "regexScansFilePath", DeprecatedDocLink: "removed--regexscansfilepath", DeprecatedVersion: "2.0.0"},
 	{KeyPath: "D.DebugParallel", DeprecatedName: "debug", DeprecatedDocLink: "removed--debug", DeprecatedVersion: "2.0.0"},
@@ -340,6 +408,12 @@ var ParallelConfigFlags = GinkgoFlags{
 		Usage: "The total number of worker processes.  For running specs in parallel."},
 	{KeyPath: "S.ParallelHost", Name: "parallel.host", SectionKey: "low-level-parallel", UsageDefaultValue: "set by Ginkgo CLI",
 		Usage: "The address for the server that will synchronize the processes."},
+	{KeyPath: "S.TraceSuiteSpan", Name: "trace.suite-span", SectionKey: "low-level-parallel", UsageDefaultValue: "set by Ginkgo CLI",
+		Usage: "The span (formatted as a W3C traceparent) that --trace-export will use for the suite.  Shared by all processes so that their specs end up in the same trace."},
+	{KeyPath: "S.TraceParentSpan", Name: "trace.parent-span", SectionKey: "low-level-parallel", UsageDefaultValue: "set by Ginkgo CLI",
+		Usage: "The ID of the parent of the suite's span - taken from $TRACEPARENT."},
+	{KeyPath: "R.EmitStreamEvents", Name: "parallel.emit-stream-events", SectionKey: "low-level-parallel", UsageDefaultValue: "set by Ginkgo CLI",
+		Usage: "If set, the process sends events describing the run to the server that synchronizes the processes.  For streaming events with --event-stream and --reporter."},
 }
 
 // ReporterConfigFlags provides flags for the Ginkgo test process, and CLI
@@ -372,6 +446,16 @@ var ReporterConfigFlags = GinkgoFlags{
 		Usage: "If set, Ginkgo will generate a conformant junit test report in the specified file."},
 	{KeyPath: "R.TeamcityReport", Name: "teamcity-report", UsageArgument: "filename", SectionKey: "output",
 		Usage: "If set, Ginkgo will generate a Teamcity-formatted test report at the specified location."},
+	{KeyPath: "R.HTMLReport", Name: "html-report", UsageArgument: "filename.html", SectionKey: "output",
+		Usage: "If set, Ginkgo will generate a self-contained HTML test report that can be browsed offline at the specified location."},
+	{KeyPath: "R.MarkdownReport", Name: "markdown-report", UsageArgument: "filename.md", SectionKey: "output",
+		Usage: "If set, Ginkgo will generate a compact Markdown summary of the test run at the specified location.  Ginkgo also appends this summary to $GITHUB_STEP_SUMMARY when it is set, unless --no-github-step-summary is set."},
+	{KeyPath: "R.NoGithubStepSummary", Name: "no-github-step-summary", SectionKey: "output",
+		Usage: "If set, Ginkgo will not append a Markdown summary of the test run to $GITHUB_STEP_SUMMARY."},
+	{KeyPath: "R.TraceExport", Name: "trace-export", UsageArgument: "traces.json", SectionKey: "output",
+		Usage: "If set, Ginkgo will export the suite, its specs, and their nodes and By steps as a tree of spans in OTLP JSON format at the specified location.  If $TRACEPARENT is set the suite's span is a child of the span it identifies."},
+	{KeyPath: "R.EventStream", Name: "event-stream", UsageArgument: "file or unix socket", SectionKey: "output",
+		Usage: "If set, Ginkgo will stream events describing the run as newline-delimited JSON to the specified file (or unix socket) as they happen.  Events from all the suites and all the parallel processes are combined into a single stream."},
 
 	{KeyPath: "D.SlowSpecThresholdWithFLoatUnits", DeprecatedName: "slowSpecThreshold", DeprecatedDocLink: "changed--slowspecthreshold",
 		Usage: "use --slow-spec-threshold instead and pass in a duration string (e.g. '5s', not '5.0')"},
@@ -456,6 +540,40 @@ func VetConfig(flagSet GinkgoFlagSet, suiteConfig SuiteConfig, reporterConfig Re
 		}
 	}
 
+	if suiteConfig.RerunFailed != "" {
+		_, err := LoadRerunFilter(suiteConfig.RerunFailed, "")
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.ImpactFilter != "" {
+		_, err := LoadImpactFilter(suiteConfig.ImpactFilter)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.QuarantineFile != "" {
+		_, err := LoadQuarantine(suiteConfig.QuarantineFile)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.Shard != "" {
+		_, _, err := ParseShard(suiteConfig.Shard)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.TraceSuiteSpan != "" {
+		if _, err := ParseTraceParent(suiteConfig.TraceSuiteSpan); err != nil {
+			errors = append(errors, GinkgoErrors.InvalidTraceSuiteSpan(suiteConfig.TraceSuiteSpan))
+		}
+	}
+
 	if suiteConfig.LabelFilter != "" {
 		_, err := ParseLabelFilter(suiteConfig.LabelFilter)
 		if err != nil {
@@ -470,6 +588,19 @@ func VetConfig(flagSet GinkgoFlagSet, suiteConfig SuiteConfig, reporterConfig Re
 		}
 	}
 
+	if suiteConfig.Fuzz != "" {
+		_, err := regexp.Compile(suiteConfig.Fuzz)
+		if err != nil {
+			errors = append(errors, GinkgoErrors.InvalidFuzzPattern(suiteConfig.Fuzz, err))
+		}
+	}
+
+	switch strings.ToLower(suiteConfig.DetectLeaks) {
+	case "", "fail", "warn":
+	default:
+		errors = append(errors, GinkgoErrors.InvalidDetectLeaksConfiguration(suiteConfig.DetectLeaks))
+	}
+
 	switch strings.ToLower(suiteConfig.OutputInterceptorMode) {
 	case "", "dup", "swap", "none":
 	default:
@@ -555,8 +686,30 @@ var GinkgoCLIRunFlags = GinkgoFlags{
 		Usage: "If set, ginkgo will keep rerunning test suites until a failure occurs."},
 	{KeyPath: "C.Repeat", Name: "repeat", SectionKey: "debug", UsageArgument: "n", UsageDefaultValue: "0 - i.e. no repetition, run only once",
 		Usage: "The number of times to re-run a test-suite.  Useful for debugging flaky tests.  If set to N the suite will be run N+1 times and will be required to pass each time."},
+	{KeyPath: "C.FlakeHunt", Name: "flake-hunt", SectionKey: "debug", UsageArgument: "n", UsageDefaultValue: "0 - disabled",
+		Usage: "If set, ginkgo will run the test suites n times with a different random seed each time - regardless of failures - and then summarize how often each spec passed and failed and how long it took.  Specs that failed intermittently, along with the seeds that reproduced each failure, are written to flake-hunt.json (in --output-dir, if set)."},
+	{KeyPath: "C.FuzzTime", Name: "fuzztime", SectionKey: "debug", UsageArgument: "duration", UsageDefaultValue: "0 - fuzz until interrupted or a failure is found",
+		Usage: "How long to fuzz for when running with --fuzz."},
 	{KeyPath: "C.RandomizeSuites", Name: "randomize-suites", SectionKey: "order", DeprecatedName: "randomizeSuites", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will randomize the order in which test suites run."},
+	{KeyPath: "C.LastFailed", Name: "last-failed", SectionKey: "filter",
+		Usage: "If set, ginkgo will only run the specs that failed the last time the suite was run with --last-failed.  Ginkgo keeps a JSON report of each such run in the suite's directory (or in --output-dir, if set) to track failures.  If nothing failed last time, all specs are run."},
+	{KeyPath: "C.ChangedSince", Name: "changed-since", SectionKey: "filter", UsageArgument: "git-ref",
+		Usage: "If set, ginkgo will only run the suites - and, with --impact-baseline, the specs - impacted by the files that changed since the specified git ref (as reported by the local git repository).  The specs that are not impacted are reported as skipped."},
+	{KeyPath: "C.ImpactBaseline", Name: "impact-baseline", SectionKey: "filter", UsageArgument: "file",
+		Usage: "A per-spec coverage map (as generated by --spec-coverage) or a JSON report (as generated by --json-report) of a run of the suites at the commit passed to --changed-since.  With a baseline ginkgo can narrow impacted suites down to the individual specs impacted by the changes: with a coverage map, the specs that executed code in the changed files; with a JSON report, the specs whose source code refers to the changed packages."},
+	{KeyPath: "C.SpecCoverage", Name: "spec-coverage", SectionKey: "code-and-coverage-analysis", UsageArgument: "file",
+		Usage: "If set, ginkgo will measure the code covered by each spec and write the per-spec coverage map to this file.  Each spec is rerun on its own in a separate process - along with any specs it depends on via DependsOn - so this is considerably slower than a regular run and the code covered by suite-level setup (e.g. BeforeSuite) and by prerequisites is attributed to the spec.  Use the usual filters to measure a subset of a large suite.  Implies --cover.  Query the map with 'ginkgo coverage who-covers'."},
+	{KeyPath: "C.RemoteWorkers", Name: "remote-workers", SectionKey: "parallel", UsageArgument: "n", UsageDefaultValue: "0 - run specs locally",
+		Usage: "If set, ginkgo will not run specs locally.  Instead it acts as a coordinator and waits for n remote workers (started with 'ginkgo worker --coordinator=host:port --token=token') to connect.  Each worker runs one parallel process."},
+	{KeyPath: "C.CoordinatorAddress", Name: "coordinator-address", SectionKey: "parallel", UsageArgument: "host:port", UsageDefaultValue: "127.0.0.1:7331",
+		Usage: "The address the coordinator listens on for remote workers when running with --remote-workers.  The default only accepts workers on the same machine - set this to an address on a trusted network (e.g. 10.0.0.5:7331) to accept workers on other machines.  Workers must also be able to reach the coordinator's host on automatically selected ports."},
+	{KeyPath: "C.RemoteWorkerToken", Name: "remote-worker-token", SectionKey: "parallel", UsageArgument: "token", UsageDefaultValue: "$GINKGO_REMOTE_WORKER_TOKEN, or a random token",
+		Usage: "The token remote workers must present to the coordinator when running with --remote-workers.  Pass the same token to 'ginkgo worker --token'.  If neither this nor $GINKGO_REMOTE_WORKER_TOKEN is set ginkgo generates a token and prints it."},
+	{KeyPath: "C.RemoteWorkerTimeout", Name: "remote-worker-timeout", SectionKey: "parallel", UsageDefaultValue: "1m",
+		Usage: "How long the coordinator waits for remote workers to pick up each suite's parallel processes when running with --remote-workers.  Parallel processes that no worker has picked up by then fail the suite."},
+	{KeyPath: "C.Reporters", Name: "reporter", SectionKey: "output", UsageArgument: "executable",
+		Usage: "If set, ginkgo will launch the executable and stream events describing the run to its stdin as newline-delimited JSON (in the same format as --event-stream).  Use this to plug in a custom reporter without changing your suites.  Can be specified multiple times.  A reporter that fails is reported but does not affect the outcome of the run."},
 }
 
 // GinkgoCLIRunFlags provides flags for Ginkgo CLI's watch command that aren't shared by any other commands
@@ -567,6 +720,12 @@ var GinkgoCLIWatchFlags = GinkgoFlags{
 		UsageArgument:     "Regular Expression",
 		UsageDefaultValue: `\.go$`,
 		Usage:             "Only files matching this regular expression will be watched for changes."},
+	{KeyPath: "C.TUI", Name: "tui", SectionKey: "watch",
+		Usage: "If set, ginkgo watch takes over the terminal and shows an interactive list of the watched suites and their specs instead of streaming the output of each run."},
+	{KeyPath: "C.AffectedSpecs", Name: "affected-specs", SectionKey: "watch",
+		Usage: "If set, when a change is detected ginkgo watch only reruns the specs that could be affected by the changed files - the specs defined in changed spec files and the specs whose code refers to changed packages."},
+	{KeyPath: "C.ThenRemainingSpecs", Name: "then-remaining-specs", SectionKey: "watch",
+		Usage: "If set with --affected-specs, ginkgo watch runs the remaining specs once the affected specs have passed."},
 }
 
 // GoBuildFlags provides flags for the Ginkgo CLI build, run, and watch commands that capture go's build-time flags.  These are passed to go test -c by the ginkgo CLI
@@ -664,6 +823,26 @@ func VetAndInitializeCLIAndGoConfig(cliConfig CLIConfig, goFlagsConfig GoFlagsCo
 		errors = append(errors, GinkgoErrors.BothRepeatAndUntilItFails())
 	}
 
+	if cliConfig.FlakeHunt > 0 && (cliConfig.Repeat > 0 || cliConfig.UntilItFails) {
+		errors = append(errors, GinkgoErrors.FlakeHuntWithRepeatOrUntilItFails())
+	}
+
+	if cliConfig.ImpactBaseline != "" && cliConfig.ChangedSince == "" {
+		errors = append(errors, GinkgoErrors.ImpactBaselineWithoutChangedSince())
+	}
+
+	if cliConfig.RemoteWorkers > 0 && (cliConfig.Parallel || cliConfig.Procs > 1) {
+		errors = append(errors, GinkgoErrors.RemoteWorkersWithLocalParallelism())
+	}
+
+	if cliConfig.RemoteWorkers > 0 && cliConfig.RemoteWorkerTimeout <= 0 {
+		errors = append(errors, GinkgoErrors.InvalidRemoteWorkerTimeout())
+	}
+
+	if cliConfig.RemoteWorkers > 0 && (cliConfig.SpecCoverage != "" || goFlagsConfig.Cover || goFlagsConfig.CoverMode != "" || goFlagsConfig.CoverPkg != "" || goFlagsConfig.CoverProfile != "" || goFlagsConfig.CPUProfile != "" || goFlagsConfig.MemProfile != "" || goFlagsConfig.BlockProfile != "" || goFlagsConfig.MutexProfile != "") {
+		errors = append(errors, GinkgoErrors.RemoteWorkersWithProfiling())
+	}
+
 	if strings.ContainsRune(goFlagsConfig.CoverProfile, os.PathSeparator) {
 		errors = append(errors, GinkgoErrors.ExpectFilenameNotPath("--coverprofile", goFlagsConfig.CoverProfile))
 	}
@@ -689,7 +868,7 @@ func VetAndInitializeCLIAndGoConfig(cliConfig CLIConfig, goFlagsConfig GoFlagsCo
 	}
 
 	//ensure cover mode is configured appropriately
-	if goFlagsConfig.CoverMode != "" || goFlagsConfig.CoverPkg != "" || goFlagsConfig.CoverProfile != "" {
+	if goFlagsConfig.CoverMode != "" || goFlagsConfig.CoverPkg != "" || goFlagsConfig.CoverProfile != "" || cliConfig.SpecCoverage != "" {
 		goFlagsConfig.Cover = true
 	}
 	if goFlagsConfig.Cover && goFlagsConfig.CoverProfile == "" {
@@ -842,6 +1021,67 @@ func BuildBuildCommandFlagSet(cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig
 	return NewGinkgoFlagSet(flags, bindings, flagSections)
 }
 
+// BuildMergeReportsCommandFlagSet builds the FlagSet for the `ginkgo merge-reports` command
+func BuildMergeReportsCommandFlagSet(reporterConfig *ReporterConfig) (GinkgoFlagSet, error) {
+	flags := ReporterConfigFlags.SubsetWithNames("json-report", "gojson-report", "junit-report", "teamcity-report", "html-report", "markdown-report", "trace-export", "no-github-step-summary")
+
+	bindings := map[string]any{
+		"R": reporterConfig,
+	}
+
+	flagSections := make(GinkgoFlagSections, len(FlagSections))
+	copy(flagSections, FlagSections)
+	for i := range flagSections {
+		if flagSections[i].Key == "output" {
+			flagSections[i].Heading = "Generating Merged Reports"
+		}
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, flagSections)
+}
+
+// BuildCoverageCommandFlagSet builds the FlagSet for the `ginkgo coverage` command
+func BuildCoverageCommandFlagSet(cliConfig *CLIConfig) (GinkgoFlagSet, error) {
+	flags := GinkgoFlags{
+		{KeyPath: "C.SpecCoverage", Name: "spec-coverage", SectionKey: "code-and-coverage-analysis", UsageArgument: "file", UsageDefaultValue: "spec-coverage.json",
+			Usage: "The per-spec coverage map to query, as generated by 'ginkgo run --spec-coverage'."},
+	}
+
+	bindings := map[string]any{
+		"C": cliConfig,
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
+
+// BuildListCommandFlagSet builds the FlagSet for the `ginkgo list` command
+func BuildListCommandFlagSet(suiteConfig *SuiteConfig, cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig) (GinkgoFlagSet, error) {
+	flags := GinkgoFlags{
+		{KeyPath: "C.ListFormat", Name: "format", SectionKey: "output", UsageArgument: "json or text", UsageDefaultValue: "text",
+			Usage: "The format to list specs in.  json emits a JSON array with an entry for each suite that IDEs and test selection tools can consume."},
+	}
+	flags = flags.CopyAppend(SuiteConfigFlags.SubsetWithNames("label-filter", "sem-ver-filter", "focus", "skip", "focus-file", "skip-file", "focus-id", "shard")...)
+	flags = flags.CopyAppend(GinkgoCLISharedFlags.SubsetWithNames("r", "skip-package", "compilers")...)
+	flags = flags.CopyAppend(GoBuildFlags...)
+
+	bindings := map[string]any{
+		"S":  suiteConfig,
+		"C":  cliConfig,
+		"Go": goFlagsConfig,
+		"D":  &deprecatedConfig{},
+	}
+
+	flagSections := make(GinkgoFlagSections, len(FlagSections))
+	copy(flagSections, FlagSections)
+	for i := range flagSections {
+		if flagSections[i].Key == "multiple-suites" {
+			flagSections[i].Heading = "Listing Multiple Suites"
+		}
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, flagSections)
+}
+
 func BuildLabelsCommandFlagSet(cliConfig *CLIConfig) (GinkgoFlagSet, error) {
 	flags := GinkgoCLISharedFlags.SubsetWithNames("r", "skip-package")
 
@@ -289,6 +289,51 @@ func (g ginkgoErrors) InvalidSerialNodeInNonSerialOrderedContainer(cl CodeLocati
 	}
 }
 
+func (g ginkgoErrors) InvalidEmptySpecID(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid Empty SpecID",
+		Message:      "SpecIDs passed to SpecID and DependsOn cannot be empty",
+		CodeLocation: cl,
+		DocLink:      "expressing-dependencies-between-specs",
+	}
+}
+
+func (g ginkgoErrors) DuplicateSpecID(cl CodeLocation, id string, existingCL CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Duplicate SpecID",
+		Message:      formatter.F(`The SpecID "%s" is already in use by the node at %s.  SpecIDs must be unique.`, id, existingCL),
+		CodeLocation: cl,
+		DocLink:      "expressing-dependencies-between-specs",
+	}
+}
+
+func (g ginkgoErrors) UnknownSpecDependency(cl CodeLocation, id string) error {
+	return GinkgoError{
+		Heading:      "Unknown SpecID",
+		Message:      formatter.F(`DependsOn was passed "%s" but no container or spec has that SpecID.`, id),
+		CodeLocation: cl,
+		DocLink:      "expressing-dependencies-between-specs",
+	}
+}
+
+func (g ginkgoErrors) CyclicSpecDependencies(cl CodeLocation, description string) error {
+	return GinkgoError{
+		Heading:      "Cyclic Spec Dependencies",
+		Message:      formatter.F("The specs' dependencies form a cycle and so they can't be scheduled.\n%s", description),
+		CodeLocation: cl,
+		DocLink:      "expressing-dependencies-between-specs",
+	}
+}
+
+func (g ginkgoErrors) SpecDependsOnSerialSpec(cl CodeLocation, id string) error {
+	return GinkgoError{
+		Heading:      "Non-Serial Spec Depends on Serial Spec",
+		Message:      formatter.F(`DependsOn was passed "%s" which refers to Serial specs but the specs that depend on it are not Serial.  When running in parallel Serial specs only run after all other specs have finished.  Mark the dependent specs as Serial too.`, id),
+		CodeLocation: cl,
+		DocLink:      "expressing-dependencies-between-specs",
+	}
+}
+
 func (g ginkgoErrors) SetupNodeNotInOrderedContainer(cl CodeLocation, nodeType NodeType) error {
 	return GinkgoError{
 		Heading:      "Setup Node not in Ordered Container",
@@ -541,6 +586,158 @@ func (g ginkgoErrors) ContextsCannotBeUsedInSubtreeTables(cl CodeLocation) error
 	}
 }
 
+/* Fuzz errors */
+
+func (g ginkgoErrors) InvalidFuzzBody(cl CodeLocation, reason string) error {
+	return GinkgoError{
+		Heading:      "Invalid DescribeFuzz body function",
+		Message:      fmt.Sprintf("DescribeFuzz must be passed exactly one body function.  Its parameters must all be types supported by Go's fuzzing engine ([]byte, string, bool, byte, rune, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, or uint64) and it may not return anything or accept a context.\n\n%s", reason),
+		CodeLocation: cl,
+		DocLink:      "fuzzing",
+	}
+}
+
+func (g ginkgoErrors) InvalidFuzzCorpus(cl CodeLocation, err error) error {
+	return GinkgoError{
+		Heading:      "Invalid fuzz corpus",
+		Message:      fmt.Sprintf("Ginkgo could not load the fuzz corpus for this DescribeFuzz:\n%s", err),
+		CodeLocation: cl,
+		DocLink:      "fuzzing",
+	}
+}
+
+func (g ginkgoErrors) FuzzCorpusDirectoryCollision(cl CodeLocation, dir string, text string, otherText string) error {
+	return GinkgoError{
+		Heading:      "Fuzz corpus directory collision",
+		Message:      fmt.Sprintf("The DescribeFuzz \"%s\" would store its corpus in %s but so would the DescribeFuzz \"%s\".  Give one of them a different description.", text, dir, otherText),
+		CodeLocation: cl,
+		DocLink:      "fuzzing",
+	}
+}
+
+func (g ginkgoErrors) NoMatchingFuzzTarget(pattern string) error {
+	return GinkgoError{
+		Heading: "No DescribeFuzz matches --fuzz",
+		Message: fmt.Sprintf("None of the DescribeFuzz containers in this suite match \"%s\".", pattern),
+		DocLink: "fuzzing",
+	}
+}
+
+func (g ginkgoErrors) MultipleMatchingFuzzTargets(pattern string, matches []string) error {
+	return GinkgoError{
+		Heading: "More than one DescribeFuzz matches --fuzz",
+		Message: fmt.Sprintf("Go's fuzzing engine can only fuzz one target at a time but these DescribeFuzz containers match \"%s\":\n  %s", pattern, strings.Join(matches, "\n  ")),
+		DocLink: "fuzzing",
+	}
+}
+
+func (g ginkgoErrors) InvalidFuzzPattern(pattern string, err error) error {
+	return GinkgoError{
+		Heading: "Invalid --fuzz pattern",
+		Message: fmt.Sprintf("--fuzz must be a valid regular expression.  \"%s\" is not: %s", pattern, err),
+		DocLink: "fuzzing",
+	}
+}
+
+/* Property errors */
+
+func (g ginkgoErrors) InvalidBodyTypeForProperty(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid Property",
+		Message:      formatter.F(`Specs decorated with {{bold}}Property{{/}} must be passed {{bold}}func(g Generators){{/}} or {{bold}}func(ctx SpecContext, g Generators){{/}} - and only those specs can be passed a body that accepts Generators.`),
+		CodeLocation: cl,
+		DocLink:      "property-based-testing",
+	}
+}
+
+func (g ginkgoErrors) InvalidPropertyNumRuns(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid Property",
+		Message:      "Properties must run at least once.  Pass NumRuns(n) with n > 0 to Property.",
+		CodeLocation: cl,
+		DocLink:      "property-based-testing",
+	}
+}
+
+/* Snapshot errors */
+func (g ginkgoErrors) MatchSnapshotNotDuringSpec(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Ginkgo detected an issue with your spec structure",
+		Message:      formatter.F(`It looks like you are calling {{bold}}MatchSnapshot{{/}} outside of a running spec.  Snapshots are keyed by the spec's full text so make sure you call {{bold}}MatchSnapshot{{/}} inside a node that runs as part of a spec such as It or BeforeEach - and not inside the body of a container such as Describe or Context or inside a suite-level node such as BeforeSuite.`),
+		CodeLocation: cl,
+		DocLink:      "snapshot-testing",
+	}
+}
+
+func (g ginkgoErrors) InvalidSnapshotName(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid Snapshot Name",
+		Message:      "MatchSnapshot must be passed a non-empty name that fits on a single line.",
+		CodeLocation: cl,
+		DocLink:      "snapshot-testing",
+	}
+}
+
+/* SharedFixture errors */
+func (g ginkgoErrors) DuplicateSharedFixture(cl CodeLocation, name string, existingCL CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Duplicate SharedFixture",
+		Message:      formatter.F(`A SharedFixture named "%s" has already been declared at %s.  SharedFixture names must be unique.`, name, existingCL),
+		CodeLocation: cl,
+		DocLink:      "shared-fixtures",
+	}
+}
+
+func (g ginkgoErrors) SharedFixtureDuringRunPhase(cl CodeLocation, name string) error {
+	return GinkgoError{
+		Heading:      "Ginkgo detected an issue with your spec structure",
+		Message:      formatter.F(`It looks like you are declaring the SharedFixture "%s" inside a running spec.  SharedFixtures must be declared at the top-level of your suite or in the body of a container so that every process knows about them.`, name),
+		CodeLocation: cl,
+		DocLink:      "shared-fixtures",
+	}
+}
+
+func (g ginkgoErrors) GetSharedFixtureNotDuringRunPhase(cl CodeLocation, name string) error {
+	return GinkgoError{
+		Heading:      "Ginkgo detected an issue with your spec structure",
+		Message:      formatter.F(`It looks like you are calling {{bold}}Get{{/}} on the SharedFixture "%s" outside of a running spec.  Make sure you call {{bold}}Get{{/}} inside a runnable node such as It or BeforeEach and not inside the body of a container such as Describe or Context.`, name),
+		CodeLocation: cl,
+		DocLink:      "shared-fixtures",
+	}
+}
+
+func (g ginkgoErrors) SharedFixtureSetupFailed(name string, proc int) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("SharedFixture \"%s\" failed to set up", name),
+		Message: fmt.Sprintf("The SharedFixture's setup function failed on Ginkgo process #%d when an earlier spec requested it.  Specs that use it will fail.", proc),
+		DocLink: "shared-fixtures",
+	}
+}
+
+func (g ginkgoErrors) SharedFixtureDisappeared(name string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("SharedFixture \"%s\" is unavailable", name),
+		Message: "The Ginkgo parallel process that was setting up the SharedFixture disappeared before it finished.",
+		DocLink: "shared-fixtures",
+	}
+}
+
+func (g ginkgoErrors) SharedFixtureEncodingFailed(name string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("SharedFixture \"%s\" could not be shared between processes", name),
+		Message: fmt.Sprintf("When running in parallel the values returned by SharedFixture setup functions are shared with other processes as JSON.  Encoding or decoding the value failed:\n%s", err),
+		DocLink: "shared-fixtures",
+	}
+}
+
+func (g ginkgoErrors) SharedFixtureNotDeclared(name string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("SharedFixture \"%s\" was not declared", name),
+		Message: fmt.Sprintf("Other specs declare that they use the SharedFixture \"%s\" with UsesFixture so Ginkgo tears it down as soon as they have finished.  Only those specs can call Get - decorate this spec, or one of its containers, with UsesFixture too.", name),
+		DocLink: "shared-fixtures",
+	}
+}
+
 /* Parallel Synchronization errors */
 
 func (g ginkgoErrors) AggregatedReportUnavailableDueToNodeDisappearing() error {
@@ -636,6 +833,46 @@ func (g ginkgoErrors) SleepOnFailureInParallelConfiguration() error {
 	}
 }
 
+func (g ginkgoErrors) InvalidRerunFailedReport(path string, err error) error {
+	return GinkgoError{
+		Heading: "Could not load the report passed to --rerun-failed.",
+		Message: fmt.Sprintf("Ginkgo could not read the JSON report at %s:\n%s\n\n--rerun-failed expects a report generated by --json-report.", path, err),
+		DocLink: "rerunning-failed-specs",
+	}
+}
+
+func (g ginkgoErrors) InvalidImpactFilter(path string, err error) error {
+	return GinkgoError{
+		Heading: "Could not load the file passed to --impact-filter.",
+		Message: fmt.Sprintf("Ginkgo could not load the impact filter at %s:\n%s\n\n--impact-filter is set by ginkgo run --changed-since and expects the impact filter it generates.", path, err),
+		DocLink: "running-only-impacted-specs",
+	}
+}
+
+func (g ginkgoErrors) InvalidQuarantineFile(path string, err error) error {
+	return GinkgoError{
+		Heading: "Could not load the file passed to --quarantine-file.",
+		Message: fmt.Sprintf("Ginkgo could not load the quarantine file at %s:\n%s\n\n--quarantine-file expects a YAML list of entries, each with a text and/or a location (file:line) and an optional reason.", path, err),
+		DocLink: "quarantining-flaky-specs",
+	}
+}
+
+func (g ginkgoErrors) InvalidShard(shard string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid --shard '%s'.", shard),
+		Message: "--shard must be of the form i/n where n is the total number of shards and i is the shard to run (1 <= i <= n).",
+		DocLink: "sharding-specs-across-ci-jobs",
+	}
+}
+
+func (g ginkgoErrors) InvalidTraceSuiteSpan(suiteSpan string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid --trace.suite-span '%s'.", suiteSpan),
+		Message: "--trace.suite-span is set by the Ginkgo CLI when you use --trace-export and must be a W3C traceparent.  Don't set it manually.",
+		DocLink: "exporting-traces",
+	}
+}
+
 func (g ginkgoErrors) ConflictingVerbosityConfiguration() error {
 	return GinkgoError{
 		Heading: "Conflicting reporter verbosity settings.",
@@ -650,6 +887,14 @@ func (g ginkgoErrors) InvalidOutputInterceptorModeConfiguration(value string) er
 	}
 }
 
+func (g ginkgoErrors) InvalidDetectLeaksConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --detect-leaks.", value),
+		Message: "You must choose one of 'fail' or 'warn'.",
+		DocLink: "detecting-leaked-goroutines-and-file-descriptors",
+	}
+}
+
 func (g ginkgoErrors) InvalidGoFlagCount() error {
 	return GinkgoError{
 		Heading: "Use of go test -count",
@@ -671,6 +916,46 @@ func (g ginkgoErrors) BothRepeatAndUntilItFails() error {
 	}
 }
 
+func (g ginkgoErrors) FlakeHuntWithRepeatOrUntilItFails() error {
+	return GinkgoError{
+		Heading: "--flake-hunt can't be combined with --repeat or --until-it-fails",
+		Message: "--flake-hunt directs Ginkgo to run specs a set number of times regardless of failures and then summarize the results.  --repeat and --until-it-fails stop at the first failure.  Please pick one.",
+		DocLink: "hunting-for-flaky-specs",
+	}
+}
+
+func (g ginkgoErrors) ImpactBaselineWithoutChangedSince() error {
+	return GinkgoError{
+		Heading: "--impact-baseline requires --changed-since",
+		Message: "--impact-baseline is used to narrow the suites impacted by the changes since the git ref passed to --changed-since down to individual specs.  Please also pass --changed-since.",
+		DocLink: "running-only-impacted-specs",
+	}
+}
+
+func (g ginkgoErrors) RemoteWorkersWithLocalParallelism() error {
+	return GinkgoError{
+		Heading: "--remote-workers can't be combined with -p or --procs.",
+		Message: "When running with --remote-workers each remote worker runs one parallel process.  Please remove -p and --procs.",
+		DocLink: "distributing-specs-across-machines",
+	}
+}
+
+func (g ginkgoErrors) InvalidRemoteWorkerTimeout() error {
+	return GinkgoError{
+		Heading: "--remote-worker-timeout must be positive.",
+		Message: "The coordinator fails parallel processes that no remote worker has picked up within --remote-worker-timeout.  Please pass a positive duration (e.g. 5m).",
+		DocLink: "distributing-specs-across-machines",
+	}
+}
+
+func (g ginkgoErrors) RemoteWorkersWithProfiling() error {
+	return GinkgoError{
+		Heading: "--remote-workers does not support coverage or profiling.",
+		Message: "Coverage and profiles are written on the machines that run the specs and can't be collected by the coordinator.  Please run without --remote-workers to generate them.",
+		DocLink: "distributing-specs-across-machines",
+	}
+}
+
 func (g ginkgoErrors) ExpectFilenameNotPath(flag string, path string) error {
 	return GinkgoError{
 		Heading: fmt.Sprintf("%s expects a filename but was given a path: %s", flag, path),
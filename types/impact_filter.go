@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+/*
+ImpactFilter identifies the specs in a suite that are impacted by the files that changed since a git ref.  It is computed by ginkgo run --changed-since and handed to the suite via --impact-filter.
+
+Specs that are not matched by ImpactedSpecs are skipped and reported with a "not impacted" reason so that the selection can be audited.  An ImpactFilter with no ImpactedSpecs skips every spec in the suite.
+*/
+type ImpactFilter struct {
+	// ChangedSince is the git ref the changes were computed against
+	ChangedSince string
+	// ImpactedSpecs are file filters (as accepted by --focus-file) that match the impacted specs
+	ImpactedSpecs []string
+}
+
+// LoadImpactFilter loads the ImpactFilter at path
+func LoadImpactFilter(path string) (ImpactFilter, error) {
+	filter := ImpactFilter{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return filter, GinkgoErrors.InvalidImpactFilter(path, err)
+	}
+	err = json.Unmarshal(data, &filter)
+	if err != nil {
+		return filter, GinkgoErrors.InvalidImpactFilter(path, err)
+	}
+	_, err = ParseFileFilters(filter.ImpactedSpecs)
+	if err != nil {
+		return filter, GinkgoErrors.InvalidImpactFilter(path, err)
+	}
+	return filter, nil
+}
+
+// Save writes the ImpactFilter to path
+func (f ImpactFilter) Save(path string) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// SkipReason is the reason reported for the specs the ImpactFilter skips
+func (f ImpactFilter) SkipReason() string {
+	return fmt.Sprintf("not impacted by the changes since %s", f.ChangedSince)
+}
@@ -0,0 +1,39 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImpactFilter", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "impact-filter.json")
+	})
+
+	It("round-trips through Save and LoadImpactFilter", func() {
+		filter := types.ImpactFilter{ChangedSince: "origin/main", ImpactedSpecs: []string{`^/suite/a_test\.go$`, `^/suite/b_test\.go$:3,17`}}
+		Ω(filter.Save(path)).Should(Succeed())
+		Ω(types.LoadImpactFilter(path)).Should(Equal(filter))
+		Ω(filter.SkipReason()).Should(Equal("not impacted by the changes since origin/main"))
+	})
+
+	It("errors when the filter is missing or invalid", func() {
+		_, err := types.LoadImpactFilter(path)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.(types.GinkgoError).DocLink).Should(Equal("running-only-impacted-specs"))
+
+		Ω(os.WriteFile(path, []byte("not json"), 0644)).Should(Succeed())
+		_, err = types.LoadImpactFilter(path)
+		Ω(err).Should(HaveOccurred())
+
+		Ω(types.ImpactFilter{ImpactedSpecs: []string{"a:b:c"}}.Save(path)).Should(Succeed())
+		_, err = types.LoadImpactFilter(path)
+		Ω(err).Should(HaveOccurred())
+	})
+})
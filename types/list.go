@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+// ListedSuite is a suite's entry in the output of ginkgo list --format=json
+type ListedSuite struct {
+	SuitePath        string
+	SuiteDescription string
+	// Error is set if the suite could not be compiled or listed, in which case Specs is empty
+	Error string `json:",omitempty"`
+	Specs []ListedSpec
+}
+
+// ListedSpec describes a single spec in the output of ginkgo list --format=json
+type ListedSpec struct {
+	// ID is the spec's stable ID - see SpecReport.ID
+	ID       string
+	FullText string
+
+	ContainerHierarchyTexts     []string
+	ContainerHierarchyLocations []CodeLocation
+	LeafNodeText                string
+	LeafNodeLocation            CodeLocation
+
+	// Labels and SemVerConstraints include those inherited from the spec's containers
+	Labels                     []string
+	SemVerConstraints          []string
+	ComponentSemVerConstraints map[string][]string `json:",omitempty"`
+
+	Serial             bool
+	Ordered            bool
+	FlakeAttempts      int           `json:",omitempty"`
+	MustPassRepeatedly int           `json:",omitempty"`
+	SpecTimeout        time.Duration `json:",omitempty"`
+	NodeTimeout        time.Duration `json:",omitempty"`
+	GracePeriod        time.Duration `json:",omitempty"`
+
+	// Pending is true if the spec is marked pending
+	Pending bool
+	// WillRun is true if the spec would run under the filters passed to ginkgo list
+	WillRun bool
+}
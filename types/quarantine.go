@@ -0,0 +1,93 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+/*
+QuarantinedSpec is an entry in a --quarantine-file.  It identifies a spec either by its full text (the container hierarchy texts and leaf node text joined with spaces, as returned by SpecReport.FullText()) or by the location of its It or of one of its containers.
+
+Locations are of the form file:line.  The file is matched against the end of the node's file path so widget_test.go:17 and pkg/widget/widget_test.go:17 both work.  Quarantining a container's location quarantines every spec in that container.
+*/
+type QuarantinedSpec struct {
+	Text     string `yaml:"text"`
+	Location string `yaml:"location"`
+	Reason   string `yaml:"reason"`
+
+	fileName   string
+	lineNumber int
+}
+
+// Quarantine is the list of specs loaded from a --quarantine-file
+type Quarantine []QuarantinedSpec
+
+/*
+LoadQuarantine loads the YAML quarantine file at path.  The file contains a list of QuarantinedSpecs:
+
+  - text: "Widgets when the network is slow eventually syncs"
+    reason: "https://github.com/acme/widgets/issues/17"
+  - location: widgets/widget_test.go:42
+*/
+func LoadQuarantine(path string) (Quarantine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidQuarantineFile(path, err)
+	}
+	quarantine := Quarantine{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&quarantine)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, GinkgoErrors.InvalidQuarantineFile(path, err)
+	}
+	for i := range quarantine {
+		entry := &quarantine[i]
+		if entry.Text == "" && entry.Location == "" {
+			return nil, GinkgoErrors.InvalidQuarantineFile(path, fmt.Errorf("entry #%d must have a text or a location", i+1))
+		}
+		if entry.Location == "" {
+			continue
+		}
+		idx := strings.LastIndex(entry.Location, ":")
+		if idx <= 0 {
+			return nil, GinkgoErrors.InvalidQuarantineFile(path, fmt.Errorf("location '%s' must be of the form file:line", entry.Location))
+		}
+		entry.fileName = filepath.ToSlash(entry.Location[:idx])
+		entry.lineNumber, err = strconv.Atoi(strings.TrimSpace(entry.Location[idx+1:]))
+		if err != nil {
+			return nil, GinkgoErrors.InvalidQuarantineFile(path, fmt.Errorf("location '%s' must be of the form file:line", entry.Location))
+		}
+	}
+	return quarantine, nil
+}
+
+// Match returns the entry that quarantines the spec with the passed-in full text and container and leaf node locations
+func (q Quarantine) Match(fullText string, locations []CodeLocation) (QuarantinedSpec, bool) {
+	for _, entry := range q {
+		if entry.Text != "" && entry.Text == fullText {
+			return entry, true
+		}
+		if entry.Location == "" {
+			continue
+		}
+		for _, location := range locations {
+			if location.LineNumber != entry.lineNumber {
+				continue
+			}
+			fileName := filepath.ToSlash(location.FileName)
+			if fileName == entry.fileName || strings.HasSuffix(fileName, "/"+entry.fileName) {
+				return entry, true
+			}
+		}
+	}
+	return QuarantinedSpec{}, false
+}
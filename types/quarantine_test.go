@@ -0,0 +1,97 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quarantine", func() {
+	var path string
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "flaky.yaml")
+	})
+
+	load := func(content string) (types.Quarantine, error) {
+		Ω(os.WriteFile(path, []byte(content), 0666)).Should(Succeed())
+		return types.LoadQuarantine(path)
+	}
+
+	Describe("LoadQuarantine", func() {
+		It("loads a list of quarantined specs", func() {
+			quarantine, err := load("- text: A B\n  reason: flakes\n- location: foo_test.go:17\n")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(quarantine).Should(HaveLen(2))
+			Ω(quarantine[0].Text).Should(Equal("A B"))
+			Ω(quarantine[0].Reason).Should(Equal("flakes"))
+			Ω(quarantine[1].Location).Should(Equal("foo_test.go:17"))
+		})
+
+		It("loads an empty file", func() {
+			quarantine, err := load("")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(quarantine).Should(BeEmpty())
+		})
+
+		It("errors when the file does not exist", func() {
+			_, err := types.LoadQuarantine(path)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("--quarantine-file"))
+		})
+
+		It("errors when the file is malformed", func() {
+			_, err := load("text: A")
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("errors on unknown fields", func() {
+			_, err := load("- txet: A")
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("errors when an entry has neither a text nor a location", func() {
+			_, err := load("- reason: flakes")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("entry #1"))
+		})
+
+		It("errors when a location is not of the form file:line", func() {
+			_, err := load("- location: foo_test.go")
+			Ω(err).Should(HaveOccurred())
+			_, err = load("- location: foo_test.go:seventeen")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Match", func() {
+		var quarantine types.Quarantine
+		BeforeEach(func() {
+			var err error
+			quarantine, err = load("- text: A B\n  reason: by text\n- location: pkg/foo_test.go:17\n  reason: by location\n")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("matches specs by full text", func() {
+			entry, ok := quarantine.Match("A B", nil)
+			Ω(ok).Should(BeTrue())
+			Ω(entry.Reason).Should(Equal("by text"))
+
+			_, ok = quarantine.Match("A B C", nil)
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("matches specs by the location of any of their nodes, using the end of the file path", func() {
+			entry, ok := quarantine.Match("C", []types.CodeLocation{{FileName: "/src/pkg/bar_test.go", LineNumber: 17}, {FileName: "/src/pkg/foo_test.go", LineNumber: 17}})
+			Ω(ok).Should(BeTrue())
+			Ω(entry.Reason).Should(Equal("by location"))
+
+			_, ok = quarantine.Match("C", []types.CodeLocation{{FileName: "/src/pkg/foo_test.go", LineNumber: 18}})
+			Ω(ok).Should(BeFalse())
+			_, ok = quarantine.Match("C", []types.CodeLocation{{FileName: "/src/otherpkg/foo_test.go", LineNumber: 17}})
+			Ω(ok).Should(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+RerunFilter identifies the specs that did not succeed in a prior run.  It is built from a JSON report by LoadRerunFilter and is used by --rerun-failed and --last-failed to focus on just those specs.
+
+A RerunFilter only covers the suite it was loaded for (see LoadRerunFilter) so specs in other suites that happen to share a key don't rerun.  Specs are identified by the name of the file their leaf node lives in, their container hierarchy texts, and their leaf node text (see RerunFilterKey).  Line numbers are deliberately not part of the key so that the filter continues to match after the spec file has been edited.  The line numbers of the failed specs are retained, however, to disambiguate between specs that share a key (e.g. table entries with identical descriptions).
+*/
+type RerunFilter map[string][]int
+
+// RerunFilterKey returns the key used to identify a spec in a RerunFilter
+func RerunFilterKey(leafNodeLocation CodeLocation, containerHierarchyTexts []string, leafNodeText string) string {
+	components := append([]string{filepath.Base(leafNodeLocation.FileName)}, containerHierarchyTexts...)
+	components = append(components, leafNodeText)
+	return strings.Join(components, "\x1f")
+}
+
+/*
+LoadRerunFilter loads the JSON report at path (as generated by --json-report) and returns a RerunFilter that matches the specs in the report that failed, panicked, timed out, or were interrupted or aborted.
+
+JSON reports can cover several suites (e.g. when generated by ginkgo -r) so only the reports for the suite at suitePath are used.  Reports that don't record the path of their suite - and every report, if suitePath is empty - are always used.
+*/
+func LoadRerunFilter(path string, suitePath string) (RerunFilter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidRerunFailedReport(path, err)
+	}
+	reports := []Report{}
+	err = json.Unmarshal(data, &reports)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidRerunFailedReport(path, err)
+	}
+	suiteReports := []Report{}
+	for _, report := range reports {
+		if suitePath == "" || report.SuitePath == "" || isSameSuitePath(report.SuitePath, suitePath) {
+			suiteReports = append(suiteReports, report)
+		}
+	}
+	return NewRerunFilter(suiteReports...), nil
+}
+
+func isSameSuitePath(a string, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && resolvedA == resolvedB
+}
+
+// NewRerunFilter returns a RerunFilter that matches the failed specs in the passed-in reports
+func NewRerunFilter(reports ...Report) RerunFilter {
+	filter := RerunFilter{}
+	for _, report := range reports {
+		for _, specReport := range report.SpecReports {
+			if !specReport.LeafNodeType.Is(NodeTypeIt) || !specReport.State.Is(SpecStateFailureStates) {
+				continue
+			}
+			key := RerunFilterKey(specReport.LeafNodeLocation, specReport.ContainerHierarchyTexts, specReport.LeafNodeText)
+			filter[key] = append(filter[key], specReport.LeafNodeLocation.LineNumber)
+		}
+	}
+	return filter
+}
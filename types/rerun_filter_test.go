@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RerunFilter", func() {
+	Describe("RerunFilterKey", func() {
+		It("ignores the directory and line number of the leaf node", func() {
+			a := types.RerunFilterKey(types.CodeLocation{FileName: "/a/foo_test.go", LineNumber: 3}, []string{"A", "B"}, "C")
+			b := types.RerunFilterKey(types.CodeLocation{FileName: "/b/foo_test.go", LineNumber: 7}, []string{"A", "B"}, "C")
+			Ω(a).Should(Equal(b))
+		})
+
+		It("distinguishes between container hierarchies that produce the same full text", func() {
+			a := types.RerunFilterKey(types.CodeLocation{FileName: "foo_test.go"}, []string{"A B"}, "C")
+			b := types.RerunFilterKey(types.CodeLocation{FileName: "foo_test.go"}, []string{"A", "B"}, "C")
+			Ω(a).ShouldNot(Equal(b))
+		})
+	})
+
+	Describe("NewRerunFilter", func() {
+		It("tracks the line numbers of specs that failed in any of the reports", func() {
+			spec := func(line int, text string, state types.SpecState) types.SpecReport {
+				return types.SpecReport{
+					LeafNodeType:            types.NodeTypeIt,
+					LeafNodeLocation:        types.CodeLocation{FileName: "/suite/foo_test.go", LineNumber: line},
+					ContainerHierarchyTexts: []string{"container"},
+					LeafNodeText:            text,
+					State:                   state,
+				}
+			}
+			reports := []types.Report{
+				{SpecReports: types.SpecReports{
+					spec(1, "A", types.SpecStateFailed),
+					spec(2, "A", types.SpecStatePassed),
+					spec(3, "A", types.SpecStateTimedout),
+					spec(4, "B", types.SpecStateSkipped),
+					{LeafNodeType: types.NodeTypeAfterSuite, LeafNodeLocation: types.CodeLocation{FileName: "/suite/foo_test.go", LineNumber: 5}, State: types.SpecStateFailed},
+				}},
+				{SpecReports: types.SpecReports{
+					spec(6, "C", types.SpecStatePanicked),
+					spec(7, "D", types.SpecStateInterrupted),
+				}},
+			}
+
+			key := func(text string) string {
+				return types.RerunFilterKey(types.CodeLocation{FileName: "foo_test.go"}, []string{"container"}, text)
+			}
+			Ω(types.NewRerunFilter(reports...)).Should(Equal(types.RerunFilter{
+				key("A"): {1, 3},
+				key("C"): {6},
+				key("D"): {7},
+			}))
+		})
+	})
+
+	Describe("LoadRerunFilter", func() {
+		var path string
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "report.json")
+		})
+
+		It("loads JSON reports", func() {
+			Ω(os.WriteFile(path, []byte(`[{"SpecReports":[{"LeafNodeType":"It","LeafNodeLocation":{"FileName":"foo_test.go","LineNumber":3},"LeafNodeText":"A","State":"failed"}]}]`), 0666)).Should(Succeed())
+			filter, err := types.LoadRerunFilter(path, "")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(filter).Should(Equal(types.RerunFilter{
+				types.RerunFilterKey(types.CodeLocation{FileName: "foo_test.go"}, nil, "A"): {3},
+			}))
+		})
+
+		It("only uses the reports for the passed-in suite, and reports that don't record their suite", func() {
+			Ω(os.WriteFile(path, []byte(`[
+				{"SuitePath":"/suites/a","SpecReports":[{"LeafNodeType":"It","LeafNodeLocation":{"FileName":"/suites/a/foo_test.go","LineNumber":3},"LeafNodeText":"A","State":"failed"}]},
+				{"SuitePath":"/suites/b","SpecReports":[{"LeafNodeType":"It","LeafNodeLocation":{"FileName":"/suites/b/foo_test.go","LineNumber":5},"LeafNodeText":"B","State":"failed"}]},
+				{"SpecReports":[{"LeafNodeType":"It","LeafNodeLocation":{"FileName":"foo_test.go","LineNumber":7},"LeafNodeText":"C","State":"failed"}]}
+			]`), 0666)).Should(Succeed())
+			filter, err := types.LoadRerunFilter(path, "/suites/a/")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(filter).Should(Equal(types.RerunFilter{
+				types.RerunFilterKey(types.CodeLocation{FileName: "foo_test.go"}, nil, "A"): {3},
+				types.RerunFilterKey(types.CodeLocation{FileName: "foo_test.go"}, nil, "C"): {7},
+			}))
+
+			filter, err = types.LoadRerunFilter(path, "")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(filter).Should(HaveLen(3))
+		})
+
+		It("errors when the report does not exist", func() {
+			_, err := types.LoadRerunFilter(path, "")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("--rerun-failed"))
+		})
+
+		It("errors when the report is malformed", func() {
+			Ω(os.WriteFile(path, []byte(`{"nope"`), 0666)).Should(Succeed())
+			_, err := types.LoadRerunFilter(path, "")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
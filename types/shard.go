@@ -0,0 +1,26 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseShard parses a --shard value of the form i/n and returns the (1-indexed) shard and the total number of shards
+func ParseShard(shard string) (int, int, error) {
+	components := strings.Split(shard, "/")
+	if len(components) != 2 {
+		return 0, 0, GinkgoErrors.InvalidShard(shard)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(components[0]))
+	if err != nil {
+		return 0, 0, GinkgoErrors.InvalidShard(shard)
+	}
+	total, err := strconv.Atoi(strings.TrimSpace(components[1]))
+	if err != nil {
+		return 0, 0, GinkgoErrors.InvalidShard(shard)
+	}
+	if total < 1 || index < 1 || index > total {
+		return 0, 0, GinkgoErrors.InvalidShard(shard)
+	}
+	return index, total, nil
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SnapshotFileExtension is the extension of the files MatchSnapshot stores snapshots in
+const SnapshotFileExtension = ".ginkgo.snap"
+
+/*
+Snapshots captures the snapshots stored in a single snapshot file.  It is keyed by SnapshotKey.
+
+Snapshot files live in a __snapshots__ directory next to the spec file that generated them and are named after it: the snapshots generated by specs in foo_test.go are stored in __snapshots__/foo_test.ginkgo.snap.
+
+Each snapshot is stored as a header line with the Go-quoted key and the length of the value in bytes, followed by the value and a --- line.  Keys and values can therefore contain anything - including newlines, ] and --- lines.
+*/
+type Snapshots map[string]string
+
+// ObsoleteSnapshot identifies a snapshot that is no longer matched by any spec
+type ObsoleteSnapshot struct {
+	Path string
+	Key  string
+}
+
+// SnapshotPath returns the path of the snapshot file for snapshots generated by specs in specFile
+func SnapshotPath(specFile string) string {
+	return filepath.Join(filepath.Dir(specFile), "__snapshots__", strings.TrimSuffix(filepath.Base(specFile), ".go")+SnapshotFileExtension)
+}
+
+// IsSnapshotPath returns true if path has the form of a path returned by SnapshotPath: a clean path to a .ginkgo.snap file in a __snapshots__ directory
+func IsSnapshotPath(path string) bool {
+	name := filepath.Base(path)
+	return filepath.Clean(path) == path &&
+		filepath.Base(filepath.Dir(path)) == "__snapshots__" &&
+		strings.HasSuffix(name, SnapshotFileExtension) &&
+		name != SnapshotFileExtension
+}
+
+// SnapshotKey returns the key used to identify the snapshot called name in the spec with the passed-in full text
+func SnapshotKey(fullText string, name string) string {
+	return fullText + " - " + name
+}
+
+// LoadSnapshots loads the Snapshots stored in the file at path.  A missing file is not an error - it simply results in empty Snapshots.
+func LoadSnapshots(path string) (Snapshots, error) {
+	snapshots := Snapshots{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return snapshots, err
+	}
+
+	content := string(data)
+	malformed := func(offset int, format string, args ...any) (Snapshots, error) {
+		line := strings.Count(content[:offset], "\n") + 1
+		return Snapshots{}, fmt.Errorf("Could not decode snapshots at %s:\n%s on line %d", path, fmt.Sprintf(format, args...), line)
+	}
+	for offset := 0; offset < len(content); {
+		if content[offset] == '\n' {
+			offset += 1
+			continue
+		}
+		headerEnd := strings.IndexByte(content[offset:], '\n')
+		if headerEnd == -1 {
+			headerEnd = len(content) - offset
+		}
+		key, length, ok := parseSnapshotHeader(content[offset : offset+headerEnd])
+		if !ok {
+			return malformed(offset, "expected a [\"key\"] length header")
+		}
+		valueStart := offset + headerEnd + 1
+		valueEnd := valueStart + length
+		if valueStart > len(content) || valueEnd > len(content) || !strings.HasPrefix(content[valueEnd:], "\n---\n") {
+			return malformed(offset, "the snapshot for %q is not %d bytes long followed by ---", key, length)
+		}
+		snapshots[key] = content[valueStart:valueEnd]
+		offset = valueEnd + len("\n---\n")
+	}
+	return snapshots, nil
+}
+
+// parseSnapshotHeader parses a [<quoted key>] <length of the value in bytes> header line
+func parseSnapshotHeader(header string) (string, int, bool) {
+	if !strings.HasPrefix(header, "[") {
+		return "", 0, false
+	}
+	quotedKey, err := strconv.QuotedPrefix(header[1:])
+	if err != nil {
+		return "", 0, false
+	}
+	key, err := strconv.Unquote(quotedKey)
+	if err != nil {
+		return "", 0, false
+	}
+	rest, found := strings.CutPrefix(header[1+len(quotedKey):], "] ")
+	if !found {
+		return "", 0, false
+	}
+	length, err := strconv.Atoi(rest)
+	if err != nil || length < 0 {
+		return "", 0, false
+	}
+	return key, length, true
+}
+
+// Save writes the Snapshots, sorted by key, to the file at path.  The file is replaced atomically so that concurrent readers never see a partially written file.  Saving empty Snapshots removes the file.
+func (snapshots Snapshots) Save(path string) error {
+	if len(snapshots) == 0 {
+		err := os.Remove(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(snapshots))
+	for key := range snapshots {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	out := &strings.Builder{}
+	for _, key := range keys {
+		fmt.Fprintf(out, "[%s] %d\n%s\n---\n\n", strconv.Quote(key), len(snapshots[key]), snapshots[key])
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(out.String())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// UpdateSnapshot stores value under key in the snapshot file at path, leaving the file's other snapshots untouched
+func UpdateSnapshot(path string, key string, value string) error {
+	snapshots, err := LoadSnapshots(path)
+	if err != nil {
+		return err
+	}
+	snapshots[key] = value
+	return snapshots.Save(path)
+}
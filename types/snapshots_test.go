@@ -0,0 +1,93 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshots", func() {
+	Describe("SnapshotPath and SnapshotKey", func() {
+		It("stores snapshots next to the spec file and keys them by full text and name", func() {
+			Ω(types.SnapshotPath("/path/to/suite/foo_test.go")).Should(Equal(filepath.Join("/path/to/suite", "__snapshots__", "foo_test.ginkgo.snap")))
+			Ω(types.SnapshotKey("A B C", "rendered")).Should(Equal("A B C - rendered"))
+		})
+
+		It("can tell snapshot paths apart from other paths", func() {
+			Ω(types.IsSnapshotPath(types.SnapshotPath("/path/to/suite/foo_test.go"))).Should(BeTrue())
+			Ω(types.IsSnapshotPath("/path/to/suite/foo_test.go")).Should(BeFalse())
+			Ω(types.IsSnapshotPath("/path/to/suite/foo_test.ginkgo.snap")).Should(BeFalse())
+			Ω(types.IsSnapshotPath("/path/to/suite/__snapshots__/foo_test.go")).Should(BeFalse())
+			Ω(types.IsSnapshotPath("/path/to/suite/__snapshots__/../foo_test.ginkgo.snap")).Should(BeFalse())
+		})
+	})
+
+	Describe("loading and saving", func() {
+		var path string
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "__snapshots__", "foo_test.ginkgo.snap")
+		})
+
+		It("returns empty snapshots when the file does not exist", func() {
+			snapshots, err := types.LoadSnapshots(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(snapshots).Should(BeEmpty())
+		})
+
+		It("round-trips snapshots, sorted by key", func() {
+			snapshots := types.Snapshots{
+				"B - b":      "single line",
+				"A - a":      "multiple\nlines\n",
+				"C - empty":  "",
+				"D - dashes": "---\nnot the end\n---",
+			}
+			Ω(snapshots.Save(path)).Should(Succeed())
+			Ω(os.ReadFile(path)).Should(BeEquivalentTo("[\"A - a\"] 15\nmultiple\nlines\n\n---\n\n[\"B - b\"] 11\nsingle line\n---\n\n[\"C - empty\"] 0\n\n---\n\n[\"D - dashes\"] 19\n---\nnot the end\n---\n---\n\n"))
+			loaded, err := types.LoadSnapshots(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loaded).Should(Equal(snapshots))
+		})
+
+		It("round-trips keys and values that look like the file's own syntax", func() {
+			snapshots := types.Snapshots{
+				"multi-document yaml":        "a: 1\n---\n\nb: 2",
+				"markdown":                   "# Title\n\n---\n\n[link]\n---\n",
+				"key with a\nnewline - name": "value",
+				"key with ] and [brackets]":  "[\"not a\"] 3\nkey",
+				"key with \"quotes\" and \\": "\n\n",
+			}
+			Ω(snapshots.Save(path)).Should(Succeed())
+			loaded, err := types.LoadSnapshots(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loaded).Should(Equal(snapshots))
+		})
+
+		It("removes the file when saving empty snapshots", func() {
+			Ω(types.Snapshots{"A - a": "a"}.Save(path)).Should(Succeed())
+			Ω(types.Snapshots{}.Save(path)).Should(Succeed())
+			Ω(path).ShouldNot(BeAnExistingFile())
+			Ω(types.Snapshots{}.Save(path)).Should(Succeed())
+		})
+
+		It("updates a single snapshot without touching the others", func() {
+			Ω(types.UpdateSnapshot(path, "A - a", "a")).Should(Succeed())
+			Ω(types.UpdateSnapshot(path, "B - b", "b")).Should(Succeed())
+			Ω(types.UpdateSnapshot(path, "A - a", "new a")).Should(Succeed())
+			Ω(types.LoadSnapshots(path)).Should(Equal(types.Snapshots{"A - a": "new a", "B - b": "b"}))
+		})
+
+		It("errors when the file is malformed", func() {
+			Ω(os.MkdirAll(filepath.Dir(path), 0755)).Should(Succeed())
+			Ω(os.WriteFile(path, []byte("nope\n"), 0644)).Should(Succeed())
+			_, err := types.LoadSnapshots(path)
+			Ω(err).Should(MatchError(ContainSubstring("expected a [\"key\"] length header on line 1")))
+
+			Ω(os.WriteFile(path, []byte("[\"A - a\"] 5\nvalue\n---\n\n[\"B - b\"] 10\nvalue\n---\n"), 0644)).Should(Succeed())
+			_, err = types.LoadSnapshots(path)
+			Ω(err).Should(MatchError(ContainSubstring("the snapshot for \"B - b\" is not 10 bytes long followed by --- on line 5")))
+		})
+	})
+})
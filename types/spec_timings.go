@@ -0,0 +1,79 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/*
+SpecTimings captures the observed run time of specs across test runs.  It is keyed by SpecTimingKey.
+
+When --spec-timings is set Ginkgo loads SpecTimings at the beginning of a parallel run and uses it to dispatch the specs that are expected to take the longest first.  At the end of the run the timings are updated with the run times observed during the run.
+*/
+type SpecTimings map[string]time.Duration
+
+// SpecTimingKey returns the key used to identify a spec in SpecTimings.  The key is composed of the spec's leaf node location (relative to the suite's path so that the key is stable across machines) and the spec's full text.
+func SpecTimingKey(suitePath string, location CodeLocation, fullText string) string {
+	fileName := location.FileName
+	if suitePath != "" {
+		if rel, err := filepath.Rel(suitePath, fileName); err == nil {
+			fileName = filepath.ToSlash(rel)
+		}
+	}
+	return fmt.Sprintf("%s:%d %s", fileName, location.LineNumber, fullText)
+}
+
+// LoadSpecTimings loads SpecTimings from the file at path.  A missing file is not an error - it simply results in empty SpecTimings.
+func LoadSpecTimings(path string) (SpecTimings, error) {
+	timings := SpecTimings{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return timings, nil
+	}
+	if err != nil {
+		return timings, err
+	}
+	err = json.Unmarshal(data, &timings)
+	if err != nil {
+		return SpecTimings{}, fmt.Errorf("Could not decode spec timings at %s:\n%s", path, err.Error())
+	}
+	return timings, nil
+}
+
+// Save writes the SpecTimings to the file at path
+func (timings SpecTimings) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0770); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0666)
+}
+
+/*
+WithReport returns a copy of the SpecTimings updated with the run times of the passing specs in report.
+
+Specs that appear in report but did not pass (e.g. because they were skipped or failed early) retain their prior timings.  Timings for specs that no longer appear in report are dropped.
+*/
+func (timings SpecTimings) WithReport(report Report) SpecTimings {
+	out := SpecTimings{}
+	for _, specReport := range report.SpecReports {
+		if !specReport.LeafNodeType.Is(NodeTypeIt) {
+			continue
+		}
+		key := SpecTimingKey(report.SuitePath, specReport.LeafNodeLocation, specReport.FullText())
+		if specReport.State.Is(SpecStatePassed) {
+			out[key] = specReport.RunTime
+		} else if runTime, ok := timings[key]; ok {
+			out[key] = runTime
+		}
+	}
+	return out
+}
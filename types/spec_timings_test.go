@@ -0,0 +1,74 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecTimings", func() {
+	Describe("SpecTimingKey", func() {
+		It("identifies specs by location relative to the suite and by full text", func() {
+			cl := types.CodeLocation{FileName: "/path/to/suite/sub/foo_test.go", LineNumber: 17}
+			Ω(types.SpecTimingKey("/path/to/suite", cl, "A B C")).Should(Equal("sub/foo_test.go:17 A B C"))
+			Ω(types.SpecTimingKey("", cl, "A B C")).Should(Equal("/path/to/suite/sub/foo_test.go:17 A B C"))
+		})
+	})
+
+	Describe("loading and saving", func() {
+		var path string
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "nested", "timings.json")
+		})
+
+		It("returns empty timings when the file does not exist", func() {
+			timings, err := types.LoadSpecTimings(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(timings).Should(BeEmpty())
+		})
+
+		It("round-trips timings", func() {
+			timings := types.SpecTimings{"foo_test.go:3 A": time.Second, "foo_test.go:7 B": time.Minute}
+			Ω(timings.Save(path)).Should(Succeed())
+			loaded, err := types.LoadSpecTimings(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loaded).Should(Equal(timings))
+		})
+
+		It("errors when the file is malformed", func() {
+			Ω(os.MkdirAll(filepath.Dir(path), 0770)).Should(Succeed())
+			Ω(os.WriteFile(path, []byte("{ nope"), 0666)).Should(Succeed())
+			timings, err := types.LoadSpecTimings(path)
+			Ω(err).Should(HaveOccurred())
+			Ω(timings).Should(BeEmpty())
+		})
+	})
+
+	Describe("WithReport", func() {
+		It("records passing specs, retains prior timings for specs that did not pass, and drops specs that are gone", func() {
+			report := types.Report{
+				SuitePath: "/suite",
+				SpecReports: types.SpecReports{
+					{LeafNodeType: types.NodeTypeIt, LeafNodeText: "A", LeafNodeLocation: types.CodeLocation{FileName: "/suite/a_test.go", LineNumber: 1}, State: types.SpecStatePassed, RunTime: 3 * time.Second},
+					{LeafNodeType: types.NodeTypeIt, LeafNodeText: "B", LeafNodeLocation: types.CodeLocation{FileName: "/suite/a_test.go", LineNumber: 2}, State: types.SpecStateFailed, RunTime: time.Millisecond},
+					{LeafNodeType: types.NodeTypeIt, LeafNodeText: "C", LeafNodeLocation: types.CodeLocation{FileName: "/suite/a_test.go", LineNumber: 3}, State: types.SpecStateSkipped},
+					{LeafNodeType: types.NodeTypeReportAfterSuite, LeafNodeText: "R", LeafNodeLocation: types.CodeLocation{FileName: "/suite/a_test.go", LineNumber: 4}, State: types.SpecStatePassed, RunTime: time.Second},
+				},
+			}
+			timings := types.SpecTimings{
+				"a_test.go:1 A":    time.Second,
+				"a_test.go:2 B":    2 * time.Second,
+				"a_test.go:10 Old": time.Hour,
+			}
+
+			Ω(timings.WithReport(report)).Should(Equal(types.SpecTimings{
+				"a_test.go:1 A": 3 * time.Second,
+				"a_test.go:2 B": 2 * time.Second,
+			}))
+		})
+	})
+})
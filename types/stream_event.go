@@ -0,0 +1,83 @@
+package types
+
+import "time"
+
+/*
+StreamEvent is a single event in the newline-delimited JSON stream Ginkgo emits when run with --event-stream (or feeds to the reporters passed to --reporter).
+
+Each event corresponds to one of the callbacks Ginkgo's reporters receive (see reporters.Reporter) and is emitted as it happens.  Type determines which of the optional fields are set.  When a suite runs in parallel the events emitted by each process are interleaved - use ParallelProcess to associate Failure, ProgressReport, ReportEntry, and SpecEvent events with the spec running on that process (i.e. the spec in the process' most recent WillRun event).
+*/
+type StreamEvent struct {
+	// Type identifies the event
+	Type StreamEventType
+	// Time is when the event occurred
+	Time time.Time
+
+	// SuitePath and SuiteDescription identify the suite that emitted the event
+	SuitePath        string
+	SuiteDescription string
+	// ParallelProcess is the parallel process that emitted the event.  It is 0 for SuiteDidEnd events that aggregate the reports of all the parallel processes.
+	ParallelProcess int
+
+	// Report is set for SuiteWillBegin and SuiteDidEnd events.  It never includes SpecReports - these are streamed via DidRun events instead.
+	Report *Report `json:",omitempty"`
+	// SpecReport is set for WillRun and DidRun events
+	SpecReport *SpecReport `json:",omitempty"`
+	// State and Failure are set for Failure events
+	State   SpecState `json:",omitempty"`
+	Failure *Failure  `json:",omitempty"`
+	// ProgressReport is set for ProgressReport events
+	ProgressReport *ProgressReport `json:",omitempty"`
+	// ReportEntry is set for ReportEntry events
+	ReportEntry *ReportEntry `json:",omitempty"`
+	// SpecEvent is set for SpecEvent events
+	SpecEvent *SpecEvent `json:",omitempty"`
+}
+
+type StreamEventType uint
+
+const (
+	StreamEventInvalid StreamEventType = iota
+
+	// StreamEventSuiteWillBegin is emitted once, before the suite runs any specs
+	StreamEventSuiteWillBegin
+	// StreamEventWillRun is emitted when a spec (or a suite-level node like BeforeSuite) starts running
+	StreamEventWillRun
+	// StreamEventDidRun is emitted when a spec (or a suite-level node) has finished running and includes its final report
+	StreamEventDidRun
+	// StreamEventSuiteDidEnd is emitted once, after the suite has finished running
+	StreamEventSuiteDidEnd
+
+	// StreamEventFailure is emitted when the running spec fails
+	StreamEventFailure
+	// StreamEventProgressReport is emitted when Ginkgo generates a progress report for the running spec
+	StreamEventProgressReport
+	// StreamEventReportEntry is emitted when the running spec adds a report entry
+	StreamEventReportEntry
+	// StreamEventSpecEvent is emitted when a node or By step starts or ends, and when a spec is retried or repeated
+	StreamEventSpecEvent
+)
+
+var streamEventTypeEnumSupport = NewEnumSupport(map[uint]string{
+	uint(StreamEventInvalid):        "INVALID STREAM EVENT",
+	uint(StreamEventSuiteWillBegin): "SuiteWillBegin",
+	uint(StreamEventWillRun):        "WillRun",
+	uint(StreamEventDidRun):         "DidRun",
+	uint(StreamEventSuiteDidEnd):    "SuiteDidEnd",
+	uint(StreamEventFailure):        "Failure",
+	uint(StreamEventProgressReport): "ProgressReport",
+	uint(StreamEventReportEntry):    "ReportEntry",
+	uint(StreamEventSpecEvent):      "SpecEvent",
+})
+
+func (se StreamEventType) String() string {
+	return streamEventTypeEnumSupport.String(uint(se))
+}
+func (se *StreamEventType) UnmarshalJSON(b []byte) error {
+	out, err := streamEventTypeEnumSupport.UnmarshJSON(b)
+	*se = StreamEventType(out)
+	return err
+}
+func (se StreamEventType) MarshalJSON() ([]byte, error) {
+	return streamEventTypeEnumSupport.MarshJSON(uint(se))
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+/*
+SpanContext identifies a span in a trace using W3C Trace Context identifiers - the same identifiers used by OpenTelemetry.
+
+When --trace-export is set Ginkgo assigns a span to the suite, to every spec, and to every node and By step that runs.  Pass the SpecContext of the running node to ginkgo.SpanContextFor to get its SpanContext so that code under test can attach its own spans as children of the node's span.
+*/
+type SpanContext struct {
+	// TraceID is the 32 character hex-encoded trace ID
+	TraceID string
+	// SpanID is the 16 character hex-encoded span ID
+	SpanID string
+}
+
+// IsValid returns true if the SpanContext has a valid, non-zero, TraceID and SpanID
+func (sc SpanContext) IsValid() bool {
+	return isValidTraceHex(sc.TraceID, 32) && isValidTraceHex(sc.SpanID, 16)
+}
+
+// TraceParent returns the SpanContext formatted as a W3C traceparent header (e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01)
+// You can use this to propagate the SpanContext to tracing libraries.  For example, with OpenTelemetry:
+//
+//	ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": ginkgo.SpanContextFor(specContext).TraceParent()})
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return "00-" + sc.TraceID + "-" + sc.SpanID + "-01"
+}
+
+// ParseTraceParent parses a W3C traceparent header (e.g. the $TRACEPARENT environment variable) into a SpanContext
+func ParseTraceParent(traceParent string) (SpanContext, error) {
+	components := strings.Split(strings.ToLower(strings.TrimSpace(traceParent)), "-")
+	// future versions of the format may append fields, version 00 may not
+	if len(components) < 4 || (components[0] == "00" && len(components) != 4) {
+		return SpanContext{}, fmt.Errorf("invalid traceparent %q", traceParent)
+	}
+	version, flags := components[0], components[3]
+	sc := SpanContext{TraceID: components[1], SpanID: components[2]}
+	if len(version) != 2 || version == "ff" || !isHex(version) || len(flags) != 2 || !isHex(flags) || !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("invalid traceparent %q", traceParent)
+	}
+	return sc, nil
+}
+
+// NewTraceID returns a new random trace ID
+func NewTraceID() string {
+	return randomTraceHex(16)
+}
+
+// NewSpanID returns a new random span ID
+func NewSpanID() string {
+	return randomTraceHex(8)
+}
+
+/*
+NewSuiteSpan returns the span (as a W3C traceparent) that Ginkgo will use for a suite and the ID of its parent span.
+
+If traceParent (typically the $TRACEPARENT environment variable) is a valid traceparent the suite span joins its trace as a child of its span.  Otherwise the suite span starts a new trace.
+*/
+func NewSuiteSpan(traceParent string) (string, string) {
+	parent, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return SpanContext{TraceID: NewTraceID(), SpanID: NewSpanID()}.TraceParent(), ""
+	}
+	return SpanContext{TraceID: parent.TraceID, SpanID: NewSpanID()}.TraceParent(), parent.SpanID
+}
+
+func randomTraceHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// trace and span IDs are lower-case hex and must not be all zeroes
+func isValidTraceHex(s string, length int) bool {
+	return len(s) == length && strings.Trim(s, "0") != "" && isHex(s) && strings.ToLower(s) == s
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,63 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trace", func() {
+	Describe("ParseTraceParent", func() {
+		It("parses W3C traceparents", func() {
+			sc, err := types.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(sc).Should(Equal(types.SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}))
+			Ω(sc.IsValid()).Should(BeTrue())
+			Ω(sc.TraceParent()).Should(Equal("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+		})
+
+		It("accepts future versions that append fields", func() {
+			sc, err := types.ParseTraceParent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future-holds")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(sc.SpanID).Should(Equal("00f067aa0ba902b7"))
+		})
+
+		DescribeTable("rejecting invalid traceparents",
+			func(traceParent string) {
+				sc, err := types.ParseTraceParent(traceParent)
+				Ω(err).Should(HaveOccurred())
+				Ω(sc.IsValid()).Should(BeFalse())
+				Ω(sc.TraceParent()).Should(BeEmpty())
+			},
+			Entry("empty", ""),
+			Entry("missing fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"),
+			Entry("extra fields in version 00", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-02"),
+			Entry("the forbidden version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+			Entry("an all-zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01"),
+			Entry("an all-zero span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"),
+			Entry("a short span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01"),
+			Entry("non-hex characters", "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01"),
+		)
+	})
+
+	Describe("NewSuiteSpan", func() {
+		It("joins the trace identified by the trace parent", func() {
+			suiteSpan, parentSpan := types.NewSuiteSpan("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			sc, err := types.ParseTraceParent(suiteSpan)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(sc.TraceID).Should(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Ω(sc.SpanID).ShouldNot(Equal("00f067aa0ba902b7"))
+			Ω(parentSpan).Should(Equal("00f067aa0ba902b7"))
+		})
+
+		It("starts a new trace when the trace parent is not valid", func() {
+			suiteSpan, parentSpan := types.NewSuiteSpan("")
+			otherSuiteSpan, _ := types.NewSuiteSpan("")
+			sc, err := types.ParseTraceParent(suiteSpan)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(sc.IsValid()).Should(BeTrue())
+			Ω(parentSpan).Should(BeEmpty())
+			Ω(otherSuiteSpan).ShouldNot(Equal(suiteSpan))
+		})
+	})
+})
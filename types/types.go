@@ -22,6 +22,9 @@ func init() {
 
 // ConstructionNodeReport captures information about a Ginkgo spec.
 type ConstructionNodeReport struct {
+	// ID is the stable ID of the container whose body is being invoked.  It is the SpecID the container is decorated with or, if there is none, is derived from the texts of the container and its ancestors.
+	ID string
+
 	// ContainerHierarchyTexts is a slice containing the text strings of
 	// all Describe/Context/When containers in this spec's hierarchy.
 	ContainerHierarchyTexts []string
@@ -130,6 +133,10 @@ type Report struct {
 	//SpecReports is a list of all SpecReports generated by this test run
 	//It is empty when the SuiteReport is provided to ReportBeforeSuite
 	SpecReports SpecReports
+
+	//ObsoleteSnapshots lists the snapshots that are no longer matched by any spec in the suite
+	//If the suite was run with --update-snapshots these snapshots have been removed
+	ObsoleteSnapshots []ObsoleteSnapshot
 }
 
 // PreRunStats contains a set of stats captured before the test run begins.  This is primarily used
@@ -138,6 +145,10 @@ type Report struct {
 type PreRunStats struct {
 	TotalSpecs       int
 	SpecsThatWillRun int
+
+	// Shard and TotalShards are set when the suite is run with --shard, in which case TotalSpecs and SpecsThatWillRun only count the specs in this shard
+	Shard       int
+	TotalShards int
 }
 
 // Add is used by Ginkgo's parallel aggregation mechanisms to combine test run reports form individual parallel processes
@@ -174,11 +185,20 @@ func (report Report) Add(other Report) Report {
 	}
 
 	report.SpecReports = reports
+	report.ObsoleteSnapshots = append(append([]ObsoleteSnapshot{}, report.ObsoleteSnapshots...), other.ObsoleteSnapshots...)
+	if len(report.ObsoleteSnapshots) == 0 {
+		report.ObsoleteSnapshots = nil
+	}
 	return report
 }
 
 // SpecReport captures information about a Ginkgo spec.
 type SpecReport struct {
+	// ID is the spec's stable ID.  It is derived from the texts of the spec and its containers so it doesn't change from run to run or when specs are added, removed, or reordered.  Specs that share the same texts (e.g. table entries with identical descriptions) are told apart by the order in which they are declared.
+	// The SpecID decorator pins the ID: a SpecID on the It becomes the spec's ID while a SpecID on a container stands in for the texts of the container and its ancestors.
+	// ID is empty for suite-level nodes (e.g. BeforeSuite)
+	ID string
+
 	// ContainerHierarchyTexts is a slice containing the text strings of
 	// all Describe/Context/When containers in this spec's hierarchy.
 	ContainerHierarchyTexts []string
@@ -221,6 +241,11 @@ type SpecReport struct {
 	// IsInOrderedContainer captures whether the spec appears in an Ordered container
 	IsInOrderedContainer bool
 
+	// IsQuarantined captures whether the spec is listed in the --quarantine-file.  Failures of quarantined specs are reported as SpecStateQuarantined and do not fail the suite.
+	// QuarantineReason captures the reason given for the quarantine, if any
+	IsQuarantined    bool
+	QuarantineReason string
+
 	// StartTime and EndTime capture the start and end time of the spec
 	StartTime time.Time
 	EndTime   time.Time
@@ -231,6 +256,9 @@ type SpecReport struct {
 	// ParallelProcess captures the parallel process that this spec ran on
 	ParallelProcess int
 
+	// SpanID captures the ID of the spec's span in the traces exported by --trace-export.  It is empty unless --trace-export is set.
+	SpanID string
+
 	// RunningInParallel captures whether this spec is part of a suite that ran in parallel
 	RunningInParallel bool
 
@@ -249,6 +277,11 @@ type SpecReport struct {
 	// MaxMustPassRepeatedly captures whether the spec has the MustPassRepeatedly decorator
 	MaxMustPassRepeatedly int
 
+	// SpecTimeout, NodeTimeout, and GracePeriod capture the timeouts set on the spec's It with the SpecTimeout, NodeTimeout, and GracePeriod decorators.  They are zero if the decorator was not used.
+	SpecTimeout time.Duration
+	NodeTimeout time.Duration
+	GracePeriod time.Duration
+
 	// CapturedGinkgoWriterOutput contains text printed to the GinkgoWriter
 	CapturedGinkgoWriterOutput string
 
@@ -268,11 +301,15 @@ type SpecReport struct {
 
 	// SpecEvents capture additional events that occur during the spec run
 	SpecEvents SpecEvents
+
+	// Subtests captures the subtests run via GinkgoTRunner.Run, in the order in which they ran
+	Subtests []SubtestReport
 }
 
 func (report SpecReport) MarshalJSON() ([]byte, error) {
 	//All this to avoid emitting an empty Failure struct in the JSON
 	out := struct {
+		ID                                           string `json:",omitempty"`
 		ContainerHierarchyTexts                      []string
 		ContainerHierarchyLocations                  []CodeLocation
 		ContainerHierarchyLabels                     [][]string
@@ -284,20 +321,30 @@ func (report SpecReport) MarshalJSON() ([]byte, error) {
 		LeafNodeSemVerConstraints                    []string
 		LeafNodeText                                 string
 		State                                        SpecState
+		IsSerial                                     bool   `json:",omitempty"`
+		IsInOrderedContainer                         bool   `json:",omitempty"`
+		IsQuarantined                                bool   `json:",omitempty"`
+		QuarantineReason                             string `json:",omitempty"`
 		StartTime                                    time.Time
 		EndTime                                      time.Time
 		RunTime                                      time.Duration
 		ParallelProcess                              int
+		RunningInParallel                            bool     `json:",omitempty"`
+		SpanID                                       string   `json:",omitempty"`
 		Failure                                      *Failure `json:",omitempty"`
 		NumAttempts                                  int
 		MaxFlakeAttempts                             int
 		MaxMustPassRepeatedly                        int
+		SpecTimeout                                  time.Duration       `json:",omitempty"`
+		NodeTimeout                                  time.Duration       `json:",omitempty"`
+		GracePeriod                                  time.Duration       `json:",omitempty"`
 		CapturedGinkgoWriterOutput                   string              `json:",omitempty"`
 		CapturedStdOutErr                            string              `json:",omitempty"`
 		ReportEntries                                ReportEntries       `json:",omitempty"`
 		ProgressReports                              []ProgressReport    `json:",omitempty"`
 		AdditionalFailures                           []AdditionalFailure `json:",omitempty"`
 		SpecEvents                                   SpecEvents          `json:",omitempty"`
+		Subtests                                     []SubtestReport     `json:",omitempty"`
 	}{
 		ContainerHierarchyTexts:                      report.ContainerHierarchyTexts,
 		ContainerHierarchyLocations:                  report.ContainerHierarchyLocations,
@@ -309,16 +356,26 @@ func (report SpecReport) MarshalJSON() ([]byte, error) {
 		LeafNodeLabels:                               report.LeafNodeLabels,
 		LeafNodeSemVerConstraints:                    report.LeafNodeSemVerConstraints,
 		LeafNodeText:                                 report.LeafNodeText,
+		ID:                                           report.ID,
 		State:                                        report.State,
+		IsSerial:                                     report.IsSerial,
+		IsInOrderedContainer:                         report.IsInOrderedContainer,
+		IsQuarantined:                                report.IsQuarantined,
+		QuarantineReason:                             report.QuarantineReason,
 		StartTime:                                    report.StartTime,
 		EndTime:                                      report.EndTime,
 		RunTime:                                      report.RunTime,
 		ParallelProcess:                              report.ParallelProcess,
+		RunningInParallel:                            report.RunningInParallel,
+		SpanID:                                       report.SpanID,
 		Failure:                                      nil,
 		ReportEntries:                                nil,
 		NumAttempts:                                  report.NumAttempts,
 		MaxFlakeAttempts:                             report.MaxFlakeAttempts,
 		MaxMustPassRepeatedly:                        report.MaxMustPassRepeatedly,
+		SpecTimeout:                                  report.SpecTimeout,
+		NodeTimeout:                                  report.NodeTimeout,
+		GracePeriod:                                  report.GracePeriod,
 		CapturedGinkgoWriterOutput:                   report.CapturedGinkgoWriterOutput,
 		CapturedStdOutErr:                            report.CapturedStdOutErr,
 	}
@@ -338,6 +395,9 @@ func (report SpecReport) MarshalJSON() ([]byte, error) {
 	if len(report.SpecEvents) > 0 {
 		out.SpecEvents = report.SpecEvents
 	}
+	if len(report.Subtests) > 0 {
+		out.Subtests = report.Subtests
+	}
 
 	return json.Marshal(out)
 }
@@ -736,8 +796,39 @@ func (f AdditionalFailure) GetTimelineLocation() TimelineLocation {
 	return f.Failure.TimelineLocation
 }
 
+// SubtestReport captures the outcome of a subtest run via GinkgoTRunner.Run
+type SubtestReport struct {
+	// Name is the name passed to GinkgoTRunner.Run
+	Name string
+
+	// State captures whether the subtest passed, failed, panicked, or was skipped
+	State SpecState
+
+	// Failure is populated if the subtest failed, panicked, or was skipped
+	Failure Failure
+
+	// StartTime and EndTime capture the start and end time of the subtest
+	StartTime time.Time
+	EndTime   time.Time
+
+	// RunTime captures the duration of the subtest
+	RunTime time.Duration
+
+	// CapturedGinkgoWriterOutput contains the text printed to the GinkgoWriter while the subtest ran
+	CapturedGinkgoWriterOutput string
+
+	// Subtests captures any subtests run by this subtest
+	Subtests []SubtestReport
+}
+
+// Failed returns true if report.State is one of the SpecStateFailureStates
+func (report SubtestReport) Failed() bool {
+	return report.State.Is(SpecStateFailureStates)
+}
+
 // SpecState captures the state of a spec
 // To determine if a given `state` represents a failure state, use `state.Is(SpecStateFailureStates)`
+// SpecStateQuarantined is not a failure state: it marks a spec listed in --quarantine-file that failed, panicked, or timed out
 type SpecState uint
 
 const (
@@ -751,6 +842,7 @@ const (
 	SpecStatePanicked
 	SpecStateInterrupted
 	SpecStateTimedout
+	SpecStateQuarantined
 )
 
 var ssEnumSupport = NewEnumSupport(map[uint]string{
@@ -763,6 +855,7 @@ var ssEnumSupport = NewEnumSupport(map[uint]string{
 	uint(SpecStatePanicked):    "panicked",
 	uint(SpecStateInterrupted): "interrupted",
 	uint(SpecStateTimedout):    "timedout",
+	uint(SpecStateQuarantined): "quarantined",
 })
 
 func (ss SpecState) String() string {
@@ -996,6 +1089,9 @@ type SpecEvent struct {
 	Duration time.Duration `json:",omitempty"`
 	NodeType NodeType      `json:",omitempty"`
 	Attempt  int           `json:",omitempty"`
+
+	// SpanID is the ID of the span that Node and By events correspond to in the traces exported by --trace-export.  It is empty unless --trace-export is set.
+	SpanID string `json:",omitempty"`
 }
 
 func (se SpecEvent) GetTimelineLocation() TimelineLocation {
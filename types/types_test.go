@@ -332,6 +332,7 @@ var _ = Describe("Types", func() {
 					EndTime:                    time.Date(2012, 06, 19, 05, 33, 12, 0, time.UTC),
 					RunTime:                    time.Minute,
 					ParallelProcess:            2,
+					RunningInParallel:          true,
 					NumAttempts:                3,
 					CapturedGinkgoWriterOutput: "gw",
 					CapturedStdOutErr:          "std",